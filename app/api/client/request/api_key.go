@@ -0,0 +1,46 @@
+package request
+
+import (
+	"backend/service-platform/app/database/constant/owner"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateApiKeyRequest struct {
+	Name      string     `json:"name" validate:"required,notblank,max=100"`
+	OwnerType owner.Type `json:"owner_type" validate:"required,oneof=SERVICE USER"`
+	// Required for SERVICE keys
+	ServiceName *string `json:"service_name" validate:"required_if=OwnerType SERVICE,excluded_unless=OwnerType SERVICE,omitempty,notblank,max=100"`
+	// Required for USER keys
+	UserID    *uuid.UUID `json:"user_id" validate:"required_if=OwnerType USER,excluded_unless=OwnerType USER"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required,notblank,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	// The authenticated principal creating the key
+	CreatedBy *uuid.UUID `json:"-"`
+	ActorRole string     `json:"-"`
+}
+
+type ListApiKeysRequest struct {
+	PaginationRequest
+	OwnerType   *owner.Type `query:"owner_type" validate:"omitempty,oneof=SERVICE USER"`
+	ServiceName *string     `query:"service_name"`
+	UserID      *uuid.UUID  `query:"user_id"`
+	Revoked     *bool       `query:"revoked"`
+}
+
+type UpdateApiKeyRequest struct {
+	ID        uuid.UUID  `json:"-"`
+	Name      *string    `json:"name" validate:"omitempty,notblank,max=100"`
+	Scopes    []string   `json:"scopes" validate:"omitempty,min=1,dive,required,notblank,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RotateApiKeyRequest struct {
+	ID uuid.UUID `json:"-"`
+	// Seconds the previous key keeps working, the configured default is used when omitted
+	OverlapSeconds *int `json:"overlap_seconds" validate:"omitempty,min=0"`
+	// The authenticated principal rotating the key
+	RotatedBy *uuid.UUID `json:"-"`
+	ActorRole string     `json:"-"`
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Page size of audit event listings when none is requested
+const defaultAuditEventPageSize = 50
+
+type ListAuditEventsRequest struct {
+	Action    *string    `query:"action" validate:"omitempty,notblank,max=64"`
+	ActorID   *uuid.UUID `query:"actor_id"`
+	SubjectID *uuid.UUID `query:"subject_id"`
+	RequestID *string    `query:"request_id" validate:"omitempty,notblank,max=128"`
+	From      *time.Time `query:"from"`
+	Before    *time.Time `query:"before"`
+	// next_cursor of the previous page, empty for the newest events
+	Cursor string `query:"cursor" validate:"omitempty,max=32"`
+	Size   int    `query:"size" validate:"omitempty,min=1,max=200"`
+}
+
+func (r *ListAuditEventsRequest) LoadDefaultValues() {
+	if r.Size < 1 {
+		r.Size = defaultAuditEventPageSize
+	}
+}
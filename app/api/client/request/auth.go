@@ -1,5 +1,7 @@
 package request
 
+import "github.com/google/uuid"
+
 type AuthUserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
@@ -11,9 +13,87 @@ type RefreshTokenRequest struct {
 
 type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
+	// Bearer token of the caller when present, denied until it expires
+	AccessToken string `json:"-"`
 }
 
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	IPAddress string `json:"-"`
+}
+
+type ResetPasswordRequest struct {
+	Token     string `json:"token" validate:"required"`
+	Password  string `json:"password" validate:"required"`
+	IPAddress string `json:"-"`
+}
+
+type MfaVerifyRequest struct {
+	MfaToken string `json:"mfa_token" validate:"required"`
+	// Either a TOTP code or one of the recovery codes
+	Code string `json:"code" validate:"required"`
+}
+
+// MfaEnrollRequest is authenticated either by the bearer token or by the challenge of a login that requires enrollment
+type MfaEnrollRequest struct {
+	MfaToken string     `json:"mfa_token"`
+	UserID   *uuid.UUID `json:"-"`
+}
+
+type MfaConfirmRequest struct {
+	MfaToken string     `json:"mfa_token"`
+	Code     string     `json:"code" validate:"required,len=6,numeric"`
+	UserID   *uuid.UUID `json:"-"`
+}
+
+type MfaDisableRequest struct {
+	Code   string    `json:"code" validate:"required"`
+	UserID uuid.UUID `json:"-"`
+}
+
+// SendPhoneOtpRequest sends a code to the given number, or to the number already on the account when omitted
+type SendPhoneOtpRequest struct {
+	UserID      uuid.UUID `json:"-"`
+	PhoneNumber string    `json:"phone_number" validate:"omitempty,phone"`
+}
+
+type VerifyPhoneOtpRequest struct {
+	UserID uuid.UUID `json:"-"`
+	Code   string    `json:"code" validate:"required,numeric,min=4,max=10"`
+}
+
+type OidcStartRequest struct {
+	Provider string `param:"provider" validate:"required"`
+}
+
+// OidcCallbackRequest carries the parameters the provider appends to the redirect URL
+type OidcCallbackRequest struct {
+	Provider         string `param:"provider" validate:"required"`
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+	// Value of the state cookie set when the login started
+	StateBinding string `json:"-"`
+}
+
+// SiweLoginRequest carries the EIP-4361 message exactly as the wallet signed it and the personal_sign signature
+type SiweLoginRequest struct {
+	Message   string `json:"message" validate:"required,max=4096"`
+	Signature string `json:"signature" validate:"required,startswith=0x,len=132"`
+	// Origin header of the request, compared with the message domain
+	Origin string `json:"-"`
 }
@@ -0,0 +1,26 @@
+package request
+
+import "github.com/google/uuid"
+
+// OAuthTokenRequest is the RFC 6749 token request, the client authenticates with HTTP Basic or the client_id and client_secret fields
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// IntrospectTokenRequest is the RFC 7662 introspection request, made by an authenticated service client
+type IntrospectTokenRequest struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+type CreateServiceClientRequest struct {
+	Name string `json:"name" validate:"required,notblank,max=100"`
+	// Scopes the client may request, a token request without scope gets all of them
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required,notblank,max=100,excludesall= "`
+	CreatedBy *uuid.UUID `json:"-"`
+}
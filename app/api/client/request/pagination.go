@@ -1,5 +1,7 @@
 package request
 
+import pagingUtil "backend/service-platform/app/pkg/util/paging"
+
 type SortBy string
 
 const (
@@ -35,3 +37,13 @@ func (p *PaginationRequest) LoadDefaultValues(desireSize ...int) {
 		p.OrderBy = "created_at"
 	}
 }
+
+// ToPage converts the page number and size into the limit and offset used by repositories
+func (p PaginationRequest) ToPage() pagingUtil.Page {
+	return pagingUtil.Page{
+		Limit:   p.Size,
+		Offset:  (p.Page - 1) * p.Size,
+		SortBy:  pagingUtil.SortBy(p.SortBy),
+		OrderBy: p.OrderBy,
+	}
+}
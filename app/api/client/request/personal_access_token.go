@@ -0,0 +1,15 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePersonalAccessTokenRequest struct {
+	UserID uuid.UUID `json:"-"`
+	Name   string    `json:"name" validate:"required,notblank,max=100"`
+	// Permissions of the user's role the token is narrowed to
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required,notblank,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,37 @@
+package request
+
+import "github.com/google/uuid"
+
+type ChangePasswordRequest struct {
+	UserID uuid.UUID `json:"-"`
+	// Refresh token of the caller, its session survives the change
+	RefreshToken    string `json:"-"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,nefield=CurrentPassword"`
+}
+
+type ChangeEmailRequest struct {
+	UserID          uuid.UUID `json:"-"`
+	Email           string    `json:"email" validate:"required,email,max=254"`
+	CurrentPassword string    `json:"current_password" validate:"required"`
+}
+
+type ChangeUsernameRequest struct {
+	UserID   uuid.UUID `json:"-"`
+	Username string    `json:"username" validate:"required,username"`
+}
+
+type SetPhoneNumberRequest struct {
+	UserID      uuid.UUID `json:"-"`
+	PhoneNumber string    `json:"phone_number" validate:"required,phone"`
+}
+
+type DeactivateAccountRequest struct {
+	UserID          uuid.UUID `json:"-"`
+	CurrentPassword string    `json:"current_password" validate:"required"`
+}
+
+type DeleteAccountRequest struct {
+	UserID          uuid.UUID `json:"-"`
+	CurrentPassword string    `json:"current_password" validate:"required"`
+}
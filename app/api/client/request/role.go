@@ -0,0 +1,18 @@
+package request
+
+type CreateRoleRequest struct {
+	// Stored in users.role, e.g. SUPPORT_AGENT
+	Name        string  `json:"name" validate:"required,notblank,max=64,uppercase,excludesall= "`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+	// Role whose permissions are inherited
+	Parent      *string  `json:"parent" validate:"omitempty,notblank,max=64"`
+	Permissions []string `json:"permissions" validate:"dive,required,notblank,max=128"`
+}
+
+// UpdateRoleRequest leaves omitted fields unchanged
+type UpdateRoleRequest struct {
+	Name        string   `json:"-"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Parent      *string  `json:"parent" validate:"omitempty,notblank,max=64"`
+	Permissions []string `json:"permissions" validate:"omitempty,dive,required,notblank,max=128"`
+}
@@ -0,0 +1,19 @@
+package request
+
+import "github.com/google/uuid"
+
+type ListSessionsRequest struct {
+	UserID uuid.UUID `json:"-"`
+	// Refresh token of the caller, used to flag the current session
+	RefreshToken string `json:"-"`
+}
+
+type RevokeSessionRequest struct {
+	UserID    uuid.UUID `json:"-"`
+	SessionID uuid.UUID `json:"-"`
+}
+
+type RevokeOtherSessionsRequest struct {
+	UserID       uuid.UUID `json:"-"`
+	RefreshToken string    `json:"-"`
+}
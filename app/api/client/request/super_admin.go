@@ -0,0 +1,15 @@
+package request
+
+type CreateSuperAdminRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	// Only used when the account does not exist yet, an existing account keeps its password
+	Password string `json:"password" validate:"omitempty,min=8"`
+	// Who asked for it, recorded in the audit log
+	Source string `json:"-"`
+}
+
+type BootstrapSuperAdminRequest struct {
+	CreateSuperAdminRequest
+	// Must match super_admin.bootstrap_token
+	BootstrapToken string `json:"bootstrap_token" validate:"required"`
+}
@@ -1,5 +1,13 @@
 package request
 
+import (
+	"backend/service-platform/app/database/constant/role"
+	"backend/service-platform/app/database/constant/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type FindEmailRequest struct {
 	Email string `query:"email" validate:"required,email"`
 }
@@ -8,3 +16,30 @@ type AuthRequest struct {
 	Email string `json:"email" validate:"required,email"`
 	UUID  string `json:"uuid" validate:"required,uuid4"`
 }
+
+type ListUsersRequest struct {
+	PaginationRequest
+	Status        *user.Status `query:"status" validate:"omitempty,oneof=VERIFIED DISABLED UNVERIFIED"`
+	Role          *role.Role   `query:"role" validate:"omitempty,notblank,max=50"`
+	EmailVerified *bool        `query:"email_verified"`
+	PhoneVerified *bool        `query:"phone_verified"`
+	// Part of the email or username
+	Search        *string    `query:"q" validate:"omitempty,max=100"`
+	CreatedFrom   *time.Time `query:"created_from"`
+	CreatedBefore *time.Time `query:"created_before"`
+	// List soft-deleted users instead of live ones
+	Deleted bool `query:"deleted"`
+}
+
+// AdminUserRequest targets a user on behalf of an authenticated admin
+type AdminUserRequest struct {
+	UserID uuid.UUID `json:"-"`
+	// Nil for service API keys
+	ActorID   *uuid.UUID `json:"-"`
+	ActorRole string     `json:"-"`
+}
+
+type UpdateUserRoleRequest struct {
+	AdminUserRequest
+	Role role.Role `json:"role" validate:"required,notblank,max=50"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"backend/service-platform/app/database/constant/owner"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	Prefix        string     `json:"prefix"`
+	OwnerType     owner.Type `json:"owner_type"`
+	ServiceName   *string    `json:"service_name,omitempty"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	Scopes        []string   `json:"scopes"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+	Revoked       bool       `json:"revoked"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RotatedFromID *uuid.UUID `json:"rotated_from_id,omitempty"`
+	RotatedToID   *uuid.UUID `json:"rotated_to_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreatedApiKeyResponse carries the plaintext key, which is never returned again
+type CreatedApiKeyResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
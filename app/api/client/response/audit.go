@@ -0,0 +1,32 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventResponse struct {
+	ID        uuid.UUID              `json:"id"`
+	Seq       int64                  `json:"seq"`
+	Action    string                 `json:"action"`
+	ActorType string                 `json:"actor_type"`
+	ActorID   *uuid.UUID             `json:"actor_id,omitempty"`
+	ActorName *string                `json:"actor_name,omitempty"`
+	SubjectID *uuid.UUID             `json:"subject_id,omitempty"`
+	IPAddress *string                `json:"ip_address,omitempty"`
+	UserAgent *string                `json:"user_agent,omitempty"`
+	RequestID *string                `json:"request_id,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Hash      string                 `json:"hash"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type AuditChainVerificationResponse struct {
+	Valid bool `json:"valid"`
+	// Events checked, up to and including the first broken one
+	Checked int64 `json:"checked"`
+	// First event whose hash or link to its predecessor does not match
+	BrokenAtSeq *int64     `json:"broken_at_seq,omitempty"`
+	BrokenAtID  *uuid.UUID `json:"broken_at_id,omitempty"`
+}
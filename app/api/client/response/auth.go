@@ -14,6 +14,41 @@ type AuthResponse struct {
 	RefreshToken string       `json:"refresh_token"`
 	ExpiresIn    int64        `json:"expires_in"`
 	TokenType    string       `json:"token_type,default='Bearer'"`
+	// Set instead of the tokens when the login needs a second factor
+	MfaRequired           bool   `json:"mfa_required,omitempty"`
+	MfaEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
+	MfaToken              string `json:"mfa_token,omitempty"`
+}
+
+type MfaEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MfaConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	// Present when the enrollment completed a pending login
+	Auth *AuthResponse `json:"auth,omitempty"`
+}
+
+type OidcStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	// Hash of the state, set as a cookie the callback has to present
+	StateBinding string        `json:"-"`
+	StateTTL     time.Duration `json:"-"`
+}
+
+// SiweNonceResponse gives the values the frontend puts in the message to sign
+type SiweNonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	Statement string    `json:"statement,omitempty"`
+	ChainID   int64     `json:"chain_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PhoneOtpResponse struct {
+	PhoneNumber string    `json:"phone_number"`
+	ExpiresAt   time.Time `json:"expires_at"`
 }
 
 type MeResponse struct {
@@ -25,4 +60,7 @@ type MeResponse struct {
 	EmailVerified bool       `json:"email_verified"`
 	PhoneVerified bool       `json:"phone_verified"`
 	LastLoginAt   *time.Time `json:"last_login_at,omitempty"`
+	// Set while an admin impersonates the user
+	ImpersonatorID       *uuid.UUID `json:"impersonator_id,omitempty"`
+	ImpersonatorUsername *string    `json:"impersonator_username,omitempty"`
 }
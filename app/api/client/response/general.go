@@ -59,3 +59,19 @@ func ToPaginationResponse[T any](data []T, total int64, page int, size int) Pagi
 		},
 	}
 }
+
+type CursorPaginationResponse[T any] struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+	Data    []T    `json:"data"`
+	// Cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+func ToCursorPaginationResponse[T any](data []T, nextCursor *string) CursorPaginationResponse[T] {
+	return CursorPaginationResponse[T]{
+		Message:    "success",
+		Data:       data,
+		NextCursor: nextCursor,
+	}
+}
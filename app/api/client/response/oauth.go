@@ -0,0 +1,53 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthTokenResponse is the RFC 6749 access token response, it is not wrapped in GeneralResponse
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// OAuthErrorResponse is the RFC 6749 error response of the token and introspection endpoints
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// IntrospectionResponse is the RFC 7662 introspection response, only active is set for inactive tokens
+type IntrospectionResponse struct {
+	Active    bool    `json:"active"`
+	Scope     string  `json:"scope,omitempty"`
+	ClientID  string  `json:"client_id,omitempty"`
+	Username  *string `json:"username,omitempty"`
+	TokenType string  `json:"token_type,omitempty"`
+	Exp       int64   `json:"exp,omitempty"`
+	Iat       int64   `json:"iat,omitempty"`
+	Nbf       int64   `json:"nbf,omitempty"`
+	Sub       string  `json:"sub,omitempty"`
+	Iss       string  `json:"iss,omitempty"`
+	Jti       string  `json:"jti,omitempty"`
+}
+
+type ServiceClientResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ClientID   string     `json:"client_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Revoked    bool       `json:"revoked"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedServiceClientResponse carries the plaintext secret, which is never returned again
+type CreatedServiceClientResponse struct {
+	ServiceClientResponse
+	ClientSecret string `json:"client_secret"`
+}
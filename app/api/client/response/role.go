@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type RoleResponse struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Parent      *string `json:"parent,omitempty"`
+	// Granted to the role itself
+	Permissions []string `json:"permissions"`
+	// Including the permissions inherited from the parents
+	EffectivePermissions []string  `json:"effective_permissions"`
+	BuiltIn              bool      `json:"built_in"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+type PermissionResponse struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserAgent *string    `json:"user_agent,omitempty"`
+	IPAddress *string    `json:"ip_address,omitempty"`
+	Current   bool       `json:"current"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "github.com/google/uuid"
+
+// Outcomes of a super admin creation
+const (
+	SuperAdminCreated   = "created"
+	SuperAdminPromoted  = "promoted"
+	SuperAdminUnchanged = "unchanged"
+)
+
+type SuperAdminResponse struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Email   string    `json:"email"`
+	Outcome string    `json:"outcome"`
+}
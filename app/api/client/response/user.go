@@ -1,10 +1,48 @@
 package response
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type UserResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Email *string   `json:"email"`
+	ID            uuid.UUID  `json:"id"`
+	Username      string     `json:"username"`
+	Email         *string    `json:"email"`
+	PendingEmail  *string    `json:"pending_email,omitempty"`
+	PhoneNumber   *string    `json:"phone_number"`
+	Status        string     `json:"status"`
+	Role          string     `json:"role"`
+	EmailVerified bool       `json:"email_verified"`
+	PhoneVerified bool       `json:"phone_verified"`
+	MfaEnabled    bool       `json:"mfa_enabled"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	LastLoginAt   *time.Time `json:"last_login_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+
+	DeactivatedAt       *time.Time `json:"deactivated_at,omitempty"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+}
+
+// ImpersonationResponse carries an access token for the user, impersonation never issues a refresh token
+type ImpersonationResponse struct {
+	AccessToken string       `json:"access_token"`
+	TokenType   string       `json:"token_type"`
+	ExpiresIn   int64        `json:"expires_in"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	User        UserResponse `json:"user"`
+}
+
+type AccountDeletionResponse struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+type DataExportResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
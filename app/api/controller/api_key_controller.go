@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type ApiKeyController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewApiKeyController(managers *manager.Managers, res runtime.Resource) *ApiKeyController {
+	return &ApiKeyController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// CreateApiKey godoc
+//
+//	@Summary		Create an API key
+//	@Description	Create a key for a service or a user; the plaintext key is only returned in this response
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreateApiKeyRequest	true	"API key"
+//	@Success		201		{object}	response.CreatedApiKeyResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/api-keys [post]
+func (c *ApiKeyController) CreateApiKey(ec echo.Context) error {
+	var req request.CreateApiKeyRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	if actorID, ok := middleware.CurrentUserID(ec); ok {
+		req.CreatedBy = &actorID
+	}
+	req.ActorRole = middleware.CurrentRole(ec)
+
+	res, err := c.managers.ApiKeyManager.CreateApiKey(ec.Request().Context(), req)
+	if err != nil {
+		return c.apiKeyError(ec, "Create API key failed", err)
+	}
+	return ec.JSON(http.StatusCreated, response.ToSuccessResponse(res))
+}
+
+// ListApiKeys godoc
+//
+//	@Summary		List API keys
+//	@Description	List API keys with optional owner and revocation filters
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page			query		int		false	"Page"
+//	@Param			size			query		int		false	"Page size"
+//	@Param			sort_by			query		string	false	"ASC or DESC"
+//	@Param			order_by		query		string	false	"created_at, name, expires_at or last_used_at"
+//	@Param			owner_type		query		string	false	"SERVICE or USER"
+//	@Param			service_name	query		string	false	"Service name"
+//	@Param			user_id			query		string	false	"User ID"
+//	@Param			revoked			query		bool	false	"Revoked"
+//	@Success		200				{object}	response.PaginationResponse[response.ApiKeyResponse]
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/api-keys [get]
+func (c *ApiKeyController) ListApiKeys(ec echo.Context) error {
+	var req request.ListApiKeysRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.LoadDefaultValues()
+
+	keys, total, err := c.managers.ApiKeyManager.ListApiKeys(ec.Request().Context(), req)
+	if err != nil {
+		c.res.Logger.Error("List API keys failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToPaginationResponse(keys, total, req.Page, req.Size))
+}
+
+// GetApiKey godoc
+//
+//	@Summary		Get an API key
+//	@Description	Get the metadata of an API key, the secret is never returned
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"API key ID"
+//	@Success		200	{object}	response.ApiKeyResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/api-keys/{id} [get]
+func (c *ApiKeyController) GetApiKey(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid api key id"))
+	}
+
+	res, err := c.managers.ApiKeyManager.GetApiKey(ec.Request().Context(), id)
+	if err != nil {
+		return c.apiKeyError(ec, "Get API key failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// UpdateApiKey godoc
+//
+//	@Summary		Update an API key
+//	@Description	Change the name, scopes or expiry of an API key that is not revoked
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"API key ID"
+//	@Param			request	body		request.UpdateApiKeyRequest	true	"Changes"
+//	@Success		200		{object}	response.ApiKeyResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/api-keys/{id} [patch]
+func (c *ApiKeyController) UpdateApiKey(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid api key id"))
+	}
+	var req request.UpdateApiKeyRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.ID = id
+
+	res, err := c.managers.ApiKeyManager.UpdateApiKey(ec.Request().Context(), req)
+	if err != nil {
+		return c.apiKeyError(ec, "Update API key failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// RevokeApiKey godoc
+//
+//	@Summary		Revoke an API key
+//	@Description	Revoke an API key immediately
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path	string	true	"API key ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/api-keys/{id} [delete]
+func (c *ApiKeyController) RevokeApiKey(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid api key id"))
+	}
+
+	if err := c.managers.ApiKeyManager.RevokeApiKey(ec.Request().Context(), id); err != nil {
+		return c.apiKeyError(ec, "Revoke API key failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("api key revoked"))
+}
+
+// RotateApiKey godoc
+//
+//	@Summary		Rotate an API key
+//	@Description	Issue a new key with the same owner and scopes; the previous key keeps working during the overlap window
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"API key ID"
+//	@Param			request	body		request.RotateApiKeyRequest	false	"Overlap window"
+//	@Success		201		{object}	response.CreatedApiKeyResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/api-keys/{id}/rotate [post]
+func (c *ApiKeyController) RotateApiKey(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid api key id"))
+	}
+	var req request.RotateApiKeyRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.ID = id
+	if actorID, ok := middleware.CurrentUserID(ec); ok {
+		req.RotatedBy = &actorID
+	}
+	req.ActorRole = middleware.CurrentRole(ec)
+
+	res, err := c.managers.ApiKeyManager.RotateApiKey(ec.Request().Context(), req)
+	if err != nil {
+		return c.apiKeyError(ec, "Rotate API key failed", err)
+	}
+	return ec.JSON(http.StatusCreated, response.ToSuccessResponse(res))
+}
+
+func (c *ApiKeyController) apiKeyError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrApiKeyNotFound), errors.Is(err, manager.ErrUserNotFound):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	case errors.Is(err, manager.ErrInsufficientPrivileges):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+	case errors.Is(err, manager.ErrApiKeyRevoked), errors.Is(err, manager.ErrApiKeyRotated):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrApiKeyExpiresInPast):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type AuditController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewAuditController(managers *manager.Managers, res runtime.Resource) *AuditController {
+	return &AuditController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// ListAuditEvents godoc
+//
+//	@Summary		List audit events
+//	@Description	List security audit events newest first, follow next_cursor for older events
+//	@Tags			admin
+//	@Produce		json
+//	@Param			action		query		string	false	"Action, e.g. login_failed"
+//	@Param			actor_id	query		string	false	"User who performed the action"
+//	@Param			subject_id	query		string	false	"User the action was performed on"
+//	@Param			request_id	query		string	false	"X-Request-Id of the HTTP request"
+//	@Param			from		query		string	false	"Created at or after, RFC 3339"
+//	@Param			before		query		string	false	"Created before, RFC 3339"
+//	@Param			cursor		query		string	false	"next_cursor of the previous page"
+//	@Param			size		query		int		false	"Page size"
+//	@Success		200			{object}	response.CursorPaginationResponse[response.AuditEventResponse]
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/audit-events [get]
+func (c *AuditController) ListAuditEvents(ec echo.Context) error {
+	var req request.ListAuditEventsRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.LoadDefaultValues()
+
+	events, next, err := c.managers.AuditManager.ListEvents(ec.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, manager.ErrInvalidAuditCursor) {
+			return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+		}
+		c.res.Logger.Error("List audit events failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToCursorPaginationResponse(events, next))
+}
+
+// VerifyAuditChain godoc
+//
+//	@Summary		Verify the audit log
+//	@Description	Recompute the hash chain of the audit log and report the first event that was altered or removed
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	response.AuditChainVerificationResponse
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/audit-events/verify [get]
+func (c *AuditController) VerifyAuditChain(ec echo.Context) error {
+	res, err := c.managers.AuditManager.VerifyChain(ec.Request().Context())
+	if err != nil {
+		c.res.Logger.Error("Verify audit chain failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
@@ -3,16 +3,20 @@ package controller
 import (
 	"net/http"
 
+	"backend/service-platform/app/api/client/exception"
 	"backend/service-platform/app/api/client/request"
 	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
 	"backend/service-platform/app/database/constant/role"
 	"backend/service-platform/app/internal/runtime"
 	"backend/service-platform/app/manager"
 	"backend/service-platform/app/pkg/jwt"
 	utilcookie "backend/service-platform/app/pkg/util/cookie"
+	echoutil "backend/service-platform/app/pkg/util/echo"
 
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
@@ -43,6 +47,7 @@ func NewAuthController(managers *manager.Managers, res runtime.Resource) *AuthCo
 //	@Success        200
 //	@Failure        400
 //	@Failure        409
+//	@Failure        429
 //	@Failure        500
 //	@Router         /api/v1/auth/register [post]
 func (c *AuthController) Register(ec echo.Context) error {
@@ -56,6 +61,12 @@ func (c *AuthController) Register(ec echo.Context) error {
 	}
 
 	if err := c.managers.AuthManager.Register(ctx, req); err != nil {
+		if errors.Is(err, manager.ErrTooManyRequests) {
+			return tooManyRequests(ec, err)
+		}
+		if errors.Is(err, manager.ErrPasswordPolicy) {
+			return passwordPolicyViolation(ec, "password", err)
+		}
 		if errors.Is(err, manager.ErrEmailAlreadyExists) || errors.Is(err, manager.ErrUsernameAlreadyExisted) {
 			return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
 		}
@@ -64,6 +75,145 @@ func (c *AuthController) Register(ec echo.Context) error {
 	return ec.JSON(http.StatusOK, response.ToSuccessResponse("registered"))
 }
 
+// VerifyEmail godoc
+//
+//	@Summary        Verify email
+//	@Description    Confirm the email address with the token sent by email, or complete a requested email change
+//	@Tags           auth
+//	@Accept         json
+//	@Produce        json
+//	@Param          request body        request.VerifyEmailRequest true "Verification token"
+//	@Success        200
+//	@Failure        400
+//	@Failure        409
+//	@Failure        500
+//	@Router         /api/v1/auth/verify-email [post]
+func (c *AuthController) VerifyEmail(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.VerifyEmailRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	if err := c.managers.AuthManager.VerifyEmail(ctx, req); err != nil {
+		if errors.Is(err, manager.ErrInvalidVerificationToken) || errors.Is(err, manager.ErrVerificationTokenExpired) {
+			return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+		}
+		if errors.Is(err, manager.ErrEmailAlreadyExists) {
+			return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+		}
+		c.res.Logger.Error("Email verification failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("verified"))
+}
+
+// ResendVerification godoc
+//
+//	@Summary        Resend verification email
+//	@Description    Send a new verification link if the account exists and is not verified yet
+//	@Tags           auth
+//	@Accept         json
+//	@Produce        json
+//	@Param          request body        request.ResendVerificationRequest true "Email"
+//	@Success        200
+//	@Failure        400
+//	@Failure        500
+//	@Router         /api/v1/auth/resend-verification [post]
+func (c *AuthController) ResendVerification(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.ResendVerificationRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	if err := c.managers.AuthManager.ResendVerification(ctx, req); err != nil {
+		c.res.Logger.Error("Resend verification failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("verification email sent"))
+}
+
+// ForgotPassword godoc
+//
+//	@Summary        Request password reset
+//	@Description    Email a password reset link if the account exists; the response is the same either way
+//	@Tags           auth
+//	@Accept         json
+//	@Produce        json
+//	@Param          request body        request.ForgotPasswordRequest true "Email"
+//	@Success        200
+//	@Failure        400
+//	@Failure        429
+//	@Failure        500
+//	@Router         /api/v1/auth/password/forgot [post]
+func (c *AuthController) ForgotPassword(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.ForgotPasswordRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.IPAddress = ec.RealIP()
+
+	if err := c.managers.AuthManager.ForgotPassword(ctx, req); err != nil {
+		if errors.Is(err, manager.ErrTooManyRequests) {
+			return tooManyRequests(ec, err)
+		}
+		c.res.Logger.Error("Forgot password failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("If the email is registered, a reset link has been sent"))
+}
+
+// ResetPassword godoc
+//
+//	@Summary        Reset password
+//	@Description    Set a new password with a reset token; all sessions of the user are revoked
+//	@Tags           auth
+//	@Accept         json
+//	@Produce        json
+//	@Param          request body        request.ResetPasswordRequest true "Reset token and new password"
+//	@Success        200
+//	@Failure        400
+//	@Failure        429
+//	@Failure        500
+//	@Router         /api/v1/auth/password/reset [post]
+func (c *AuthController) ResetPassword(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.ResetPasswordRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.IPAddress = ec.RealIP()
+
+	if err := c.managers.AuthManager.ResetPassword(ctx, req); err != nil {
+		if errors.Is(err, manager.ErrTooManyRequests) {
+			return tooManyRequests(ec, err)
+		}
+		if errors.Is(err, manager.ErrPasswordPolicy) {
+			return passwordPolicyViolation(ec, "password", err)
+		}
+		if errors.Is(err, manager.ErrInvalidResetToken) || errors.Is(err, manager.ErrResetTokenExpired) {
+			return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+		}
+		c.res.Logger.Error("Reset password failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("password reset"))
+}
+
 // Login godoc
 //
 //	@Summary		User login
@@ -75,6 +225,8 @@ func (c *AuthController) Register(ec echo.Context) error {
 //	@Success		200		{object}	response.AuthResponse
 //	@Failure		400
 //	@Failure		401
+//	@Failure		403
+//	@Failure		429
 //	@Failure		500
 //	@Router			/api/v1/auth/login [post]
 func (c *AuthController) Login(ec echo.Context) error {
@@ -93,18 +245,501 @@ func (c *AuthController) Login(ec echo.Context) error {
 	res, err := c.managers.AuthManager.Login(ctx, req)
 	if err != nil {
 		c.res.Logger.Error("Login failed", zap.Error(err))
+		if errors.Is(err, manager.ErrTooManyRequests) {
+			return tooManyRequests(ec, err)
+		}
 		if errors.Is(err, manager.ErrInvalidCredentials) {
 			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Invalid credentials"))
 		}
+		if errors.Is(err, manager.ErrAccountDisabled) {
+			return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, "Account is disabled"))
+		}
+		if errors.Is(err, manager.ErrAccountDeactivated) || errors.Is(err, manager.ErrAccountPendingDeletion) {
+			return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+		}
 		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
 	}
 
+	// The second factor is still pending, tokens are issued by /mfa/verify or /mfa/confirm
+	if res.MfaRequired {
+		return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+	}
+
+	ec.SetCookie(utilcookie.NewRefreshTokenCookie(ec.Request(), res.RefreshToken, c.res.Config.JwtConfig.RefreshExpiration))
+	res.RefreshToken = ""
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// MfaVerify godoc
+//
+//	@Summary		Complete login with a second factor
+//	@Description	Exchange the mfa_token returned by login and a TOTP or recovery code for tokens
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.MfaVerifyRequest	true	"MFA token and code"
+//	@Success		200		{object}	response.AuthResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/mfa/verify [post]
+func (c *AuthController) MfaVerify(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.MfaVerifyRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	res, err := c.managers.AuthManager.VerifyMfa(ctx, req)
+	if err != nil {
+		return c.mfaError(ec, "MFA verification failed", err)
+	}
+
 	ec.SetCookie(utilcookie.NewRefreshTokenCookie(ec.Request(), res.RefreshToken, c.res.Config.JwtConfig.RefreshExpiration))
 	res.RefreshToken = ""
 	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
 }
 
-// Wallet/SIWE endpoints are removed in the simplified auth flow.
+// MfaEnroll godoc
+//
+//	@Summary		Start TOTP enrollment
+//	@Description	Generate a TOTP secret for the authenticated user, or for the enrollment mfa_token returned by login
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.MfaEnrollRequest	false	"Enrollment mfa_token"
+//	@Success		200		{object}	response.MfaEnrollmentResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/auth/mfa/enroll [post]
+func (c *AuthController) MfaEnroll(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.MfaEnrollRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if req.MfaToken == "" {
+		claims, err := c.jwt.GetClaims(ec)
+		if err != nil || claims.UserID == nil {
+			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+		}
+		req.UserID = claims.UserID
+	}
+
+	res, err := c.managers.AuthManager.EnrollMfa(ctx, req)
+	if err != nil {
+		return c.mfaError(ec, "MFA enrollment failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// MfaConfirm godoc
+//
+//	@Summary		Confirm TOTP enrollment
+//	@Description	Enable MFA with a first TOTP code and return the recovery codes; completes the login when an enrollment mfa_token is used
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.MfaConfirmRequest	true	"TOTP code"
+//	@Success		200		{object}	response.MfaConfirmResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/mfa/confirm [post]
+func (c *AuthController) MfaConfirm(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	var req request.MfaConfirmRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	if req.MfaToken == "" {
+		claims, err := c.jwt.GetClaims(ec)
+		if err != nil || claims.UserID == nil {
+			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+		}
+		req.UserID = claims.UserID
+	}
+
+	res, err := c.managers.AuthManager.ConfirmMfa(ctx, req)
+	if err != nil {
+		return c.mfaError(ec, "MFA confirmation failed", err)
+	}
+
+	if res.Auth != nil {
+		ec.SetCookie(utilcookie.NewRefreshTokenCookie(ec.Request(), res.Auth.RefreshToken, c.res.Config.JwtConfig.RefreshExpiration))
+		res.Auth.RefreshToken = ""
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// MfaDisable godoc
+//
+//	@Summary		Disable MFA
+//	@Description	Turn off MFA for the authenticated user with a TOTP or recovery code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.MfaDisableRequest	true	"TOTP or recovery code"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/mfa/disable [post]
+func (c *AuthController) MfaDisable(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	claims, err := c.jwt.GetClaims(ec)
+	if err != nil || claims.UserID == nil {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	var req request.MfaDisableRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.UserID = *claims.UserID
+
+	if err := c.managers.AuthManager.DisableMfa(ctx, req); err != nil {
+		return c.mfaError(ec, "MFA disable failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("mfa disabled"))
+}
+
+func (c *AuthController) mfaError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrInvalidMfaToken), errors.Is(err, manager.ErrInvalidMfaCode):
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, err.Error()))
+	case errors.Is(err, manager.ErrMfaAttemptsExceeded), errors.Is(err, manager.ErrTooManyRequests):
+		return tooManyRequests(ec, err)
+	case errors.Is(err, manager.ErrMfaAlreadyEnabled):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrMfaRequiredForRole):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+	case errors.Is(err, manager.ErrMfaNotEnrolled), errors.Is(err, manager.ErrMfaNotEnabled), errors.Is(err, manager.ErrMfaEnrollmentRequired):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
+
+// SendPhoneOtp godoc
+//
+//	@Summary		Send a phone verification code
+//	@Description	Text a one-time code to the given number, or to the number already on the account when none is given
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.SendPhoneOtpRequest	false	"Phone number in E.164 format"
+//	@Success		200		{object}	response.PhoneOtpResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/phone/send-otp [post]
+func (c *AuthController) SendPhoneOtp(ec echo.Context) error {
+	var req request.SendPhoneOtpRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.AuthManager.SendPhoneOtp(ec.Request().Context(), req)
+	if err != nil {
+		return c.phoneError(ec, "Send phone otp failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// VerifyPhoneOtp godoc
+//
+//	@Summary		Verify a phone number
+//	@Description	Confirm the number with the code sent by SMS, it replaces the phone number of the account when it differs
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.VerifyPhoneOtpRequest	true	"Code"
+//	@Success		200		{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/phone/verify [post]
+func (c *AuthController) VerifyPhoneOtp(ec echo.Context) error {
+	var req request.VerifyPhoneOtpRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.AuthManager.VerifyPhoneOtp(ec.Request().Context(), req)
+	if err != nil {
+		return c.phoneError(ec, "Verify phone otp failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+func (c *AuthController) phoneError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, exception.ErrInvalidOtpCode):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(int(exception.ErrorCodeInvalidOtpCode), "Invalid OTP code"))
+	case errors.Is(err, manager.ErrTooManyRequests):
+		return tooManyRequests(ec, err)
+	case errors.Is(err, manager.ErrUserNotFound):
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	case errors.Is(err, manager.ErrPhoneNumberRequired):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	case errors.Is(err, manager.ErrPhoneAlreadyVerified), errors.Is(err, manager.ErrPhoneNumberExists):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
+
+// OidcStart godoc
+//
+//	@Summary		Start an OpenID Connect login
+//	@Description	Redirect to the provider with an authorization code request protected by state, nonce and PKCE, the state is bound to the browser with an HttpOnly cookie
+//	@Tags			auth
+//	@Param			provider	path	string	true	"Provider name"
+//	@Success		302
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/auth/oidc/{provider}/start [get]
+func (c *AuthController) OidcStart(ec echo.Context) error {
+	var req request.OidcStartRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	res, err := c.managers.AuthManager.StartOidcLogin(ec.Request().Context(), req)
+	if err != nil {
+		return c.oidcError(ec, "OIDC start failed", err)
+	}
+	ec.SetCookie(utilcookie.NewOidcStateCookie(ec.Request(), res.StateBinding, res.StateTTL))
+	return ec.Redirect(http.StatusFound, res.AuthorizationURL)
+}
+
+// OidcCallback godoc
+//
+//	@Summary		Complete an OpenID Connect login
+//	@Description	Exchange the authorization code, verify the ID token and sign in the linked account, creating it on first use. Requires the state cookie set by the start endpoint
+//	@Tags			auth
+//	@Produce		json
+//	@Param			provider			path		string	true	"Provider name"
+//	@Param			code				query		string	false	"Authorization code"
+//	@Param			state				query		string	true	"State returned by the provider"
+//	@Param			error				query		string	false	"Error returned by the provider"
+//	@Param			error_description	query		string	false	"Error description returned by the provider"
+//	@Success		200					{object}	response.AuthResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/oidc/{provider}/callback [get]
+func (c *AuthController) OidcCallback(ec echo.Context) error {
+	var req request.OidcCallbackRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	if stateCookie, err := ec.Cookie(utilcookie.OidcStateCookieName); err == nil && stateCookie != nil {
+		req.StateBinding = stateCookie.Value
+	}
+	// The state is single use, whatever the outcome
+	ec.SetCookie(utilcookie.ExpireCookie(utilcookie.OidcStateCookieName))
+
+	res, err := c.managers.AuthManager.CompleteOidcLogin(ec.Request().Context(), req)
+	if err != nil {
+		return c.oidcError(ec, "OIDC login failed", err)
+	}
+
+	// The second factor is still pending, tokens are issued by /mfa/verify or /mfa/confirm
+	if res.MfaRequired {
+		return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+	}
+
+	ec.SetCookie(utilcookie.NewRefreshTokenCookie(ec.Request(), res.RefreshToken, c.res.Config.JwtConfig.RefreshExpiration))
+	res.RefreshToken = ""
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+func (c *AuthController) oidcError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrTooManyRequests):
+		return tooManyRequests(ec, err)
+	case errors.Is(err, manager.ErrOidcProviderNotFound):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	case errors.Is(err, manager.ErrInvalidOidcState):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	case errors.Is(err, manager.ErrOidcLoginFailed):
+		c.res.Logger.Warn(msg, zap.Error(err))
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, manager.ErrOidcLoginFailed.Error()))
+	case errors.Is(err, manager.ErrOidcAccountConflict):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrAccountDisabled):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, "Account is disabled"))
+	case errors.Is(err, manager.ErrAccountDeactivated), errors.Is(err, manager.ErrAccountPendingDeletion):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
+
+// SiweNonce godoc
+//
+//	@Summary		Issue a Sign-In With Ethereum nonce
+//	@Description	Return a single use nonce, with the statement and chain ID the EIP-4361 message must carry
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	response.SiweNonceResponse
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/siwe/nonce [get]
+func (c *AuthController) SiweNonce(ec echo.Context) error {
+	res, err := c.managers.AuthManager.CreateSiweNonce(ec.Request().Context())
+	if err != nil {
+		return c.siweError(ec, "SIWE nonce failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// SiweLogin godoc
+//
+//	@Summary		Sign in with an Ethereum wallet
+//	@Description	Verify an EIP-4361 message signed with personal_sign and sign in the wallet's account, creating it on first use
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.SiweLoginRequest	true	"Signed message"
+//	@Success		200		{object}	response.AuthResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/siwe/verify [post]
+func (c *AuthController) SiweLogin(ec echo.Context) error {
+	var req request.SiweLoginRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.Origin = ec.Request().Header.Get(echo.HeaderOrigin)
+
+	res, err := c.managers.AuthManager.SiweLogin(ec.Request().Context(), req)
+	if err != nil {
+		return c.siweError(ec, "SIWE login failed", err)
+	}
+
+	// The second factor is still pending, tokens are issued by /mfa/verify or /mfa/confirm
+	if res.MfaRequired {
+		return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+	}
+
+	ec.SetCookie(utilcookie.NewRefreshTokenCookie(ec.Request(), res.RefreshToken, c.res.Config.JwtConfig.RefreshExpiration))
+	res.RefreshToken = ""
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+func (c *AuthController) siweError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrTooManyRequests):
+		return tooManyRequests(ec, err)
+	case errors.Is(err, manager.ErrInvalidSiweMessage):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	case errors.Is(err, manager.ErrSiweLoginFailed):
+		c.res.Logger.Warn(msg, zap.Error(err))
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, manager.ErrSiweLoginFailed.Error()))
+	case errors.Is(err, manager.ErrAccountDisabled):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, "Account is disabled"))
+	case errors.Is(err, manager.ErrAccountDeactivated), errors.Is(err, manager.ErrAccountPendingDeletion):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
+
+// ReactivateAccount godoc
+//
+//	@Summary		Reactivate my account
+//	@Description	Lift a self-service deactivation or cancel a pending deletion with the account credentials, the user logs in afterwards
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	request.AuthUserRequest	true	"Credentials"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/reactivate [post]
+func (c *AuthController) ReactivateAccount(ec echo.Context) error {
+	var req request.AuthUserRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	if err := c.managers.AuthManager.ReactivateAccount(ec.Request().Context(), req); err != nil {
+		switch {
+		case errors.Is(err, manager.ErrTooManyRequests):
+			return tooManyRequests(ec, err)
+		case errors.Is(err, manager.ErrInvalidCredentials):
+			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Invalid credentials"))
+		case errors.Is(err, manager.ErrAccountDisabled):
+			return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, "Account is disabled"))
+		case errors.Is(err, manager.ErrAccountActive):
+			return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+		}
+		c.res.Logger.Error("Reactivate account failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("account reactivated"))
+}
 
 // RefreshToken godoc
 //
@@ -115,6 +750,8 @@ func (c *AuthController) Login(ec echo.Context) error {
 //	@Produce		json
 //	@Success		200		{object}	response.AuthResponse
 //	@Failure		401
+//	@Failure		403
+//	@Failure		429
 //	@Failure		500
 //	@Router			/api/v1/auth/refresh-token [post]
 func (c *AuthController) RefreshToken(ec echo.Context) error {
@@ -125,9 +762,23 @@ func (c *AuthController) RefreshToken(ec echo.Context) error {
 	authResp, err := c.managers.AuthManager.RefreshToken(ec.Request().Context(), request.RefreshTokenRequest{RefreshToken: rtCookie.Value})
 	if err != nil {
 		c.res.Logger.Error("Token refresh failed", zap.Error(err))
+		if errors.Is(err, manager.ErrTooManyRequests) {
+			return tooManyRequests(ec, err)
+		}
+		if errors.Is(err, manager.ErrRefreshTokenReused) {
+			// The family is revoked, drop the cookie so the client starts a new login
+			ec.SetCookie(utilcookie.ExpireCookie("refresh_token"))
+			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, err.Error()))
+		}
 		if errors.Is(err, manager.ErrInvalidRefreshToken) || errors.Is(err, manager.ErrRefreshTokenRevoked) || errors.Is(err, manager.ErrRefreshTokenExpired) {
 			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, err.Error()))
 		}
+		if errors.Is(err, manager.ErrAccountDisabled) {
+			return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, "Account is disabled"))
+		}
+		if errors.Is(err, manager.ErrAccountDeactivated) || errors.Is(err, manager.ErrAccountPendingDeletion) {
+			return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+		}
 		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
 	}
 	// Rotate cookie with new refresh token
@@ -139,7 +790,7 @@ func (c *AuthController) RefreshToken(ec echo.Context) error {
 // Logout godoc
 //
 //	@Summary		User logout
-//	@Description	Revoke refresh token and logout user using refresh token from cookie
+//	@Description	Revoke refresh token from cookie and deny the bearer access token when provided
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -152,7 +803,9 @@ func (c *AuthController) Logout(ec echo.Context) error {
 	if errCookie != nil || rtCookie == nil || rtCookie.Value == "" {
 		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Missing refresh token"))
 	}
-	err := c.managers.AuthManager.Logout(ec.Request().Context(), request.LogoutRequest{RefreshToken: rtCookie.Value})
+	// The access token is optional, without it only the refresh session is revoked
+	accessToken, _ := echoutil.GetAuthToken(ec)
+	err := c.managers.AuthManager.Logout(ec.Request().Context(), request.LogoutRequest{RefreshToken: rtCookie.Value, AccessToken: accessToken})
 	if err != nil {
 		c.res.Logger.Error("Logout failed", zap.Error(err))
 		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
@@ -212,6 +865,40 @@ func (c *AuthController) Me(ec echo.Context) error {
 		PhoneVerified: phoneVerified,
 		LastLoginAt:   claims.LastLoginAt,
 	}
+	if claims.Act != nil {
+		meResponse.ImpersonatorID = claims.Act.UserID
+		meResponse.ImpersonatorUsername = claims.Act.Username
+	}
 
 	return ec.JSON(http.StatusOK, response.ToSuccessResponse(meResponse))
 }
+
+// AdminUnlockAccount godoc
+//
+//	@Summary		Unlock a user account
+//	@Description	Lift the lockout caused by failed logins and reset the login limit of the user's email
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/unlock [post]
+func (c *AuthController) AdminUnlockAccount(ec echo.Context) error {
+	userID, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	if err := c.managers.AuthManager.UnlockAccount(ec.Request().Context(), userID); err != nil {
+		if errors.Is(err, manager.ErrUserNotFound) {
+			return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+		}
+		c.res.Logger.Error("Unlock account failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("account unlocked"))
+}
@@ -1,18 +1,73 @@
 package controller
 
 import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"backend/service-platform/app/api/client/exception"
+	"backend/service-platform/app/api/client/response"
 	"backend/service-platform/app/internal/runtime"
 	"backend/service-platform/app/manager"
+
+	"github.com/labstack/echo/v4"
 )
 
 type Controllers struct {
-	AuthController   *AuthController
-	HealthController *HealthController
+	ApiKeyController              *ApiKeyController
+	AuditController               *AuditController
+	AuthController                *AuthController
+	HealthController              *HealthController
+	OAuthController               *OAuthController
+	PersonalAccessTokenController *PersonalAccessTokenController
+	ProfileController             *ProfileController
+	RoleController                *RoleController
+	SessionController             *SessionController
+	SuperAdminController          *SuperAdminController
+	UserController                *UserController
+	WellKnownController           *WellKnownController
 }
 
 func NewControllers(managers *manager.Managers, res runtime.Resource) *Controllers {
 	return &Controllers{
-		AuthController:   NewAuthController(managers, res),
-		HealthController: NewHealthController(managers, res),
+		ApiKeyController:              NewApiKeyController(managers, res),
+		AuditController:               NewAuditController(managers, res),
+		AuthController:                NewAuthController(managers, res),
+		HealthController:              NewHealthController(managers, res),
+		OAuthController:               NewOAuthController(managers, res),
+		PersonalAccessTokenController: NewPersonalAccessTokenController(managers, res),
+		ProfileController:             NewProfileController(managers, res),
+		RoleController:                NewRoleController(managers, res),
+		SessionController:             NewSessionController(managers, res),
+		SuperAdminController:          NewSuperAdminController(managers, res),
+		UserController:                NewUserController(managers, res),
+		WellKnownController:           NewWellKnownController(managers, res),
+	}
+}
+
+// tooManyRequests writes a 429 response with the rate limit error code, and a Retry-After header when the manager provided one
+func tooManyRequests(ec echo.Context, err error) error {
+	var rateLimitErr *manager.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+		seconds := int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))
+		ec.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	}
+	message := "Too many requests"
+	if errors.Is(err, manager.ErrAccountLocked) {
+		message = "Account is temporarily locked"
+	}
+	return ec.JSON(http.StatusTooManyRequests, response.ToErrorResponse(int(exception.ErrorCodeCodeRateLimitExceeded), message))
+}
+
+// passwordPolicyViolation writes a 400 response with one error detail per broken rule of the given request field
+func passwordPolicyViolation(ec echo.Context, field string, err error) error {
+	res := response.ToErrorResponse(http.StatusBadRequest, err.Error())
+	var policyErr *manager.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		for _, v := range policyErr.Violations {
+			res.ErrorDetails = append(res.ErrorDetails, response.ErrorDetail{Key: v.Rule, Field: field, Message: v.Message})
+		}
 	}
+	return ec.JSON(http.StatusBadRequest, res)
 }
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// RFC 6749 error codes
+const (
+	oauthErrInvalidRequest       = "invalid_request"
+	oauthErrInvalidClient        = "invalid_client"
+	oauthErrInvalidScope         = "invalid_scope"
+	oauthErrUnsupportedGrantType = "unsupported_grant_type"
+	oauthErrServerError          = "server_error"
+)
+
+type OAuthController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewOAuthController(managers *manager.Managers, res runtime.Resource) *OAuthController {
+	return &OAuthController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// Token godoc
+//
+//	@Summary		Issue a service access token
+//	@Description	OAuth2 client credentials grant (RFC 6749 section 4.4); the client authenticates with HTTP Basic or client_id and client_secret
+//	@Tags			oauth
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			grant_type		formData	string	true	"client_credentials"
+//	@Param			scope			formData	string	false	"Space separated scopes, every allowed scope when omitted"
+//	@Param			client_id		formData	string	false	"Client ID when not using HTTP Basic"
+//	@Param			client_secret	formData	string	false	"Client secret when not using HTTP Basic"
+//	@Success		200				{object}	response.OAuthTokenResponse
+//	@Failure		400				{object}	response.OAuthErrorResponse
+//	@Failure		401				{object}	response.OAuthErrorResponse
+//	@Failure		500				{object}	response.OAuthErrorResponse
+//	@Router			/oauth/token [post]
+func (c *OAuthController) Token(ec echo.Context) error {
+	var req request.OAuthTokenRequest
+	if err := ec.Bind(&req); err != nil {
+		return oauthError(ec, http.StatusBadRequest, oauthErrInvalidRequest, "Invalid request")
+	}
+	req.ClientID, req.ClientSecret = clientCredentials(ec, req.ClientID, req.ClientSecret)
+
+	res, err := c.managers.OAuthManager.IssueToken(ec.Request().Context(), req)
+	if err != nil {
+		return c.oauthManagerError(ec, "Issue service token failed", err)
+	}
+	noStore(ec)
+	return ec.JSON(http.StatusOK, res)
+}
+
+// IntrospectToken godoc
+//
+//	@Summary		Introspect an access token
+//	@Description	Token introspection (RFC 7662) for services validating a bearer token; the caller authenticates as a service client
+//	@Tags			oauth
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			token			formData	string	true	"Access token"
+//	@Param			token_type_hint	formData	string	false	"access_token"
+//	@Param			client_id		formData	string	false	"Client ID when not using HTTP Basic"
+//	@Param			client_secret	formData	string	false	"Client secret when not using HTTP Basic"
+//	@Success		200				{object}	response.IntrospectionResponse
+//	@Failure		400				{object}	response.OAuthErrorResponse
+//	@Failure		401				{object}	response.OAuthErrorResponse
+//	@Failure		500				{object}	response.OAuthErrorResponse
+//	@Router			/oauth/introspect [post]
+func (c *OAuthController) IntrospectToken(ec echo.Context) error {
+	var req request.IntrospectTokenRequest
+	if err := ec.Bind(&req); err != nil || req.Token == "" {
+		return oauthError(ec, http.StatusBadRequest, oauthErrInvalidRequest, "token is required")
+	}
+	req.ClientID, req.ClientSecret = clientCredentials(ec, req.ClientID, req.ClientSecret)
+
+	res, err := c.managers.OAuthManager.IntrospectToken(ec.Request().Context(), req)
+	if err != nil {
+		return c.oauthManagerError(ec, "Introspect token failed", err)
+	}
+	noStore(ec)
+	return ec.JSON(http.StatusOK, res)
+}
+
+// CreateServiceClient godoc
+//
+//	@Summary		Register a service client
+//	@Description	Register an OAuth2 client for the client credentials grant; the client secret is only returned in this response
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreateServiceClientRequest	true	"Service client"
+//	@Success		201		{object}	response.CreatedServiceClientResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/service-clients [post]
+func (c *OAuthController) CreateServiceClient(ec echo.Context) error {
+	var req request.CreateServiceClientRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	if userID, ok := middleware.CurrentUserID(ec); ok {
+		req.CreatedBy = &userID
+	}
+
+	res, err := c.managers.OAuthManager.CreateServiceClient(ec.Request().Context(), req)
+	if err != nil {
+		return c.serviceClientError(ec, "Create service client failed", err)
+	}
+	return ec.JSON(http.StatusCreated, response.ToSuccessResponse(res))
+}
+
+// ListServiceClients godoc
+//
+//	@Summary		List service clients
+//	@Description	List the OAuth2 clients of internal services newest first, the secrets are never returned
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	[]response.ServiceClientResponse
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/service-clients [get]
+func (c *OAuthController) ListServiceClients(ec echo.Context) error {
+	res, err := c.managers.OAuthManager.ListServiceClients(ec.Request().Context())
+	if err != nil {
+		return c.serviceClientError(ec, "List service clients failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// RevokeServiceClient godoc
+//
+//	@Summary		Revoke a service client
+//	@Description	Revoke a client immediately, the tokens it already obtained stop working too
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path	string	true	"Service client ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/service-clients/{id} [delete]
+func (c *OAuthController) RevokeServiceClient(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid service client id"))
+	}
+
+	if err := c.managers.OAuthManager.RevokeServiceClient(ec.Request().Context(), id); err != nil {
+		return c.serviceClientError(ec, "Revoke service client failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("service client revoked"))
+}
+
+func (c *OAuthController) oauthManagerError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrInvalidClient):
+		ec.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="oauth"`)
+		return oauthError(ec, http.StatusUnauthorized, oauthErrInvalidClient, err.Error())
+	case errors.Is(err, manager.ErrUnsupportedGrantType):
+		return oauthError(ec, http.StatusBadRequest, oauthErrUnsupportedGrantType, err.Error())
+	case errors.Is(err, manager.ErrInvalidScope):
+		return oauthError(ec, http.StatusBadRequest, oauthErrInvalidScope, err.Error())
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return oauthError(ec, http.StatusInternalServerError, oauthErrServerError, "Internal server error")
+}
+
+func (c *OAuthController) serviceClientError(ec echo.Context, msg string, err error) error {
+	if errors.Is(err, manager.ErrServiceClientNotFound) {
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
+
+// oauthError writes the RFC 6749 error body instead of the usual error response
+func oauthError(ec echo.Context, status int, code string, description string) error {
+	noStore(ec)
+	return ec.JSON(status, response.OAuthErrorResponse{Error: code, ErrorDescription: description})
+}
+
+// noStore keeps tokens out of caches as RFC 6749 section 5.1 requires
+func noStore(ec echo.Context) {
+	ec.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	ec.Response().Header().Set("Pragma", "no-cache")
+}
+
+// clientCredentials prefers HTTP Basic (client_secret_basic) over the form fields (client_secret_post)
+func clientCredentials(ec echo.Context, formClientID string, formClientSecret string) (string, string) {
+	clientID, clientSecret, ok := ec.Request().BasicAuth()
+	if !ok {
+		return formClientID, formClientSecret
+	}
+	// RFC 6749 section 2.3.1 form-encodes both values before the Basic encoding
+	if v, err := url.QueryUnescape(clientID); err == nil {
+		clientID = v
+	}
+	if v, err := url.QueryUnescape(clientSecret); err == nil {
+		clientSecret = v
+	}
+	return clientID, clientSecret
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewPersonalAccessTokenController(managers *manager.Managers, res runtime.Resource) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// CreatePersonalAccessToken godoc
+//
+//	@Summary		Create a personal access token
+//	@Description	Create a token for scripts, sent as "Authorization: Bearer pat_..."; the plaintext token is only returned in this response
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreatePersonalAccessTokenRequest	true	"Token"
+//	@Success		201		{object}	response.CreatedPersonalAccessTokenResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/auth/me/tokens [post]
+func (c *PersonalAccessTokenController) CreatePersonalAccessToken(ec echo.Context) error {
+	var req request.CreatePersonalAccessTokenRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.PersonalAccessTokenManager.CreateToken(ec.Request().Context(), req)
+	if err != nil {
+		return c.personalAccessTokenError(ec, "Create personal access token failed", err)
+	}
+	return ec.JSON(http.StatusCreated, response.ToSuccessResponse(res))
+}
+
+// ListPersonalAccessTokens godoc
+//
+//	@Summary		List my personal access tokens
+//	@Description	List the tokens of the current user newest first, the secrets are never returned
+//	@Tags			profile
+//	@Produce		json
+//	@Success		200	{object}	[]response.PersonalAccessTokenResponse
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/v1/auth/me/tokens [get]
+func (c *PersonalAccessTokenController) ListPersonalAccessTokens(ec echo.Context) error {
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+
+	res, err := c.managers.PersonalAccessTokenManager.ListTokens(ec.Request().Context(), userID)
+	if err != nil {
+		return c.personalAccessTokenError(ec, "List personal access tokens failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// RevokePersonalAccessToken godoc
+//
+//	@Summary		Revoke a personal access token
+//	@Description	Revoke one of my tokens immediately
+//	@Tags			profile
+//	@Produce		json
+//	@Param			id	path	string	true	"Token ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/auth/me/tokens/{id} [delete]
+func (c *PersonalAccessTokenController) RevokePersonalAccessToken(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid token id"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+
+	if err := c.managers.PersonalAccessTokenManager.RevokeToken(ec.Request().Context(), userID, id); err != nil {
+		return c.personalAccessTokenError(ec, "Revoke personal access token failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("personal access token revoked"))
+}
+
+func (c *PersonalAccessTokenController) personalAccessTokenError(ec echo.Context, msg string, err error) error {
+	switch {
+	// The account is gone, the token only outlived it
+	case errors.Is(err, manager.ErrUserNotFound):
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	case errors.Is(err, manager.ErrPersonalAccessTokenNotFound):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	case errors.Is(err, manager.ErrPersonalAccessTokenExpiresInPast):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	case errors.Is(err, manager.ErrPersonalAccessTokenScopeDenied):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+	case errors.Is(err, manager.ErrAccountDisabled), errors.Is(err, manager.ErrAccountDeactivated), errors.Is(err, manager.ErrAccountPendingDeletion):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
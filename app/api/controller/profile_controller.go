@@ -0,0 +1,342 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+	utilcookie "backend/service-platform/app/pkg/util/cookie"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type ProfileController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewProfileController(managers *manager.Managers, res runtime.Resource) *ProfileController {
+	return &ProfileController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change my password
+//	@Description	Change the password with the current one, every session except the one of the refresh token cookie is revoked and the access token has to be refreshed. Wrong current passwords count towards the login lockout
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	request.ChangePasswordRequest	true	"Passwords"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/me/password [put]
+func (c *ProfileController) ChangePassword(ec echo.Context) error {
+	var req request.ChangePasswordRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+	if rtCookie, err := ec.Cookie("refresh_token"); err == nil && rtCookie != nil {
+		req.RefreshToken = rtCookie.Value
+	}
+
+	if err := c.managers.ProfileManager.ChangePassword(ec.Request().Context(), req); err != nil {
+		if errors.Is(err, manager.ErrPasswordPolicy) {
+			return passwordPolicyViolation(ec, "new_password", err)
+		}
+		return c.profileError(ec, "Change password failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("password changed"))
+}
+
+// ChangeEmail godoc
+//
+//	@Summary		Change my email
+//	@Description	Send a verification link to the new address, the current email stays in use until the link is followed. Wrong current passwords count towards the login lockout
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ChangeEmailRequest	true	"New email"
+//	@Success		202		{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/me/email [put]
+func (c *ProfileController) ChangeEmail(ec echo.Context) error {
+	var req request.ChangeEmailRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.ProfileManager.ChangeEmail(ec.Request().Context(), req)
+	if err != nil {
+		return c.profileError(ec, "Change email failed", err)
+	}
+	return ec.JSON(http.StatusAccepted, response.ToSuccessResponse(res))
+}
+
+// ChangeUsername godoc
+//
+//	@Summary		Change my username
+//	@Description	Set the display username, 3 to 30 letters, digits, dots, dashes or underscores
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ChangeUsernameRequest	true	"Username"
+//	@Success		200		{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/auth/me/username [put]
+func (c *ProfileController) ChangeUsername(ec echo.Context) error {
+	var req request.ChangeUsernameRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.ProfileManager.ChangeUsername(ec.Request().Context(), req)
+	if err != nil {
+		return c.profileError(ec, "Change username failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// SetPhoneNumber godoc
+//
+//	@Summary		Set my phone number
+//	@Description	Add or replace the phone number in E.164 format, it is unverified until confirmed
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.SetPhoneNumberRequest	true	"Phone number"
+//	@Success		200		{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/auth/me/phone [put]
+func (c *ProfileController) SetPhoneNumber(ec echo.Context) error {
+	var req request.SetPhoneNumberRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.ProfileManager.SetPhoneNumber(ec.Request().Context(), req)
+	if err != nil {
+		return c.profileError(ec, "Set phone number failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// RemovePhoneNumber godoc
+//
+//	@Summary		Remove my phone number
+//	@Tags			profile
+//	@Produce		json
+//	@Success		200	{object}	response.UserResponse
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/v1/auth/me/phone [delete]
+func (c *ProfileController) RemovePhoneNumber(ec echo.Context) error {
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+
+	res, err := c.managers.ProfileManager.RemovePhoneNumber(ec.Request().Context(), userID)
+	if err != nil {
+		return c.profileError(ec, "Remove phone number failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// DeactivateAccount godoc
+//
+//	@Summary		Deactivate my account
+//	@Description	Sign out of every session and refuse logins until the account is reactivated with its credentials
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	request.DeactivateAccountRequest	true	"Current password"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/me/deactivate [post]
+func (c *ProfileController) DeactivateAccount(ec echo.Context) error {
+	var req request.DeactivateAccountRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	if err := c.managers.ProfileManager.DeactivateAccount(ec.Request().Context(), req); err != nil {
+		return c.profileError(ec, "Deactivate account failed", err)
+	}
+	ec.SetCookie(utilcookie.ExpireCookie("refresh_token"))
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("account deactivated"))
+}
+
+// DeleteAccount godoc
+//
+//	@Summary		Delete my account
+//	@Description	Sign out of every session and schedule the account to be anonymized once the grace period is over, reactivating the account before then cancels the deletion
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.DeleteAccountRequest	true	"Current password"
+//	@Success		202		{object}	response.AccountDeletionResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/me [delete]
+func (c *ProfileController) DeleteAccount(ec echo.Context) error {
+	var req request.DeleteAccountRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req.UserID = userID
+
+	res, err := c.managers.ProfileManager.DeleteAccount(ec.Request().Context(), req)
+	if err != nil {
+		return c.profileError(ec, "Delete account failed", err)
+	}
+	ec.SetCookie(utilcookie.ExpireCookie("refresh_token"))
+	return ec.JSON(http.StatusAccepted, response.ToSuccessResponse(res))
+}
+
+// RequestDataExport godoc
+//
+//	@Summary		Export my data
+//	@Description	Queue an archive of my profile, sessions, jobs and security events, a download link is emailed when it is ready
+//	@Tags			profile
+//	@Produce		json
+//	@Success		202	{object}	response.DataExportResponse
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/v1/auth/me/export [post]
+func (c *ProfileController) RequestDataExport(ec echo.Context) error {
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+
+	res, err := c.managers.ProfileManager.RequestDataExport(ec.Request().Context(), userID)
+	if err != nil {
+		return c.profileError(ec, "Request data export failed", err)
+	}
+	return ec.JSON(http.StatusAccepted, response.ToSuccessResponse(res))
+}
+
+// DownloadDataExport godoc
+//
+//	@Summary		Download my data export
+//	@Tags			profile
+//	@Produce		application/zip
+//	@Param			id	path	string	true	"Export ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/auth/me/export/{id} [get]
+func (c *ProfileController) DownloadDataExport(ec echo.Context) error {
+	exportID, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid export id"))
+	}
+	userID, ok := middleware.CurrentUserID(ec)
+	if !ok {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+
+	path, err := c.managers.ProfileManager.DataExportPath(ec.Request().Context(), userID, exportID)
+	if err != nil {
+		return c.profileError(ec, "Download data export failed", err)
+	}
+	return ec.Attachment(path, "data-export-"+exportID.String()+".zip")
+}
+
+func (c *ProfileController) profileError(ec echo.Context, msg string, err error) error {
+	switch {
+	// The account is gone, the token only outlived it
+	case errors.Is(err, manager.ErrUserNotFound):
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	// Wrong current passwords are limited and lock the account like failed logins
+	case errors.Is(err, manager.ErrTooManyRequests):
+		return tooManyRequests(ec, err)
+	case errors.Is(err, manager.ErrInvalidCurrentPassword), errors.Is(err, manager.ErrEmailUnchanged):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	case errors.Is(err, manager.ErrEmailAlreadyExists), errors.Is(err, manager.ErrUsernameAlreadyExisted), errors.Is(err, manager.ErrPhoneNumberExists):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrDataExportNotFound):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	case errors.Is(err, manager.ErrDataExportNotReady):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
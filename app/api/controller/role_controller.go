@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type RoleController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewRoleController(managers *manager.Managers, res runtime.Resource) *RoleController {
+	return &RoleController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// ListRoles godoc
+//
+//	@Summary		List roles
+//	@Description	List built-in and custom roles with their direct and inherited permissions
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	[]response.RoleResponse
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/roles [get]
+func (c *RoleController) ListRoles(ec echo.Context) error {
+	res, err := c.managers.RoleManager.ListRoles(ec.Request().Context())
+	if err != nil {
+		return c.roleError(ec, "List roles failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// GetRole godoc
+//
+//	@Summary		Get a role
+//	@Description	Get a role with its direct and inherited permissions
+//	@Tags			admin
+//	@Produce		json
+//	@Param			name	path		string	true	"Role name"
+//	@Success		200		{object}	response.RoleResponse
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/roles/{name} [get]
+func (c *RoleController) GetRole(ec echo.Context) error {
+	res, err := c.managers.RoleManager.GetRole(ec.Request().Context(), ec.Param("name"))
+	if err != nil {
+		return c.roleError(ec, "Get role failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create a custom role
+//	@Description	Create a role that can be assigned to users, it inherits every permission of its parent
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreateRoleRequest	true	"Role"
+//	@Success		201		{object}	response.RoleResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/roles [post]
+func (c *RoleController) CreateRole(ec echo.Context) error {
+	var req request.CreateRoleRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	res, err := c.managers.RoleManager.CreateRole(ec.Request().Context(), req)
+	if err != nil {
+		return c.roleError(ec, "Create role failed", err)
+	}
+	return ec.JSON(http.StatusCreated, response.ToSuccessResponse(res))
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update a custom role
+//	@Description	Change the description, parent or permissions of a custom role, built-in roles are read-only
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string						true	"Role name"
+//	@Param			request	body		request.UpdateRoleRequest	true	"Changes"
+//	@Success		200		{object}	response.RoleResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/roles/{name} [patch]
+func (c *RoleController) UpdateRole(ec echo.Context) error {
+	var req request.UpdateRoleRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.Name = ec.Param("name")
+
+	res, err := c.managers.RoleManager.UpdateRole(ec.Request().Context(), req)
+	if err != nil {
+		return c.roleError(ec, "Update role failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete a custom role
+//	@Description	Delete a custom role that no user holds and no role inherits from
+//	@Tags			admin
+//	@Produce		json
+//	@Param			name	path	string	true	"Role name"
+//	@Success		200
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/roles/{name} [delete]
+func (c *RoleController) DeleteRole(ec echo.Context) error {
+	if err := c.managers.RoleManager.DeleteRole(ec.Request().Context(), ec.Param("name")); err != nil {
+		return c.roleError(ec, "Delete role failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("role deleted"))
+}
+
+// ListPermissions godoc
+//
+//	@Summary		List permissions
+//	@Description	List the permissions that can be granted to roles
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	[]response.PermissionResponse
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/permissions [get]
+func (c *RoleController) ListPermissions(ec echo.Context) error {
+	res, err := c.managers.RoleManager.ListPermissions(ec.Request().Context())
+	if err != nil {
+		return c.roleError(ec, "List permissions failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+func (c *RoleController) roleError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrRoleNotFound):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	case errors.Is(err, manager.ErrRoleExists), errors.Is(err, manager.ErrRoleBuiltIn), errors.Is(err, manager.ErrRoleInUse):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrParentRoleNotFound), errors.Is(err, manager.ErrRoleCycle), errors.Is(err, manager.ErrUnknownPermission):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
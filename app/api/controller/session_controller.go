@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type SessionController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+	jwt      jwt.Jwt
+}
+
+func NewSessionController(managers *manager.Managers, res runtime.Resource) *SessionController {
+	return &SessionController{
+		res:      res,
+		managers: managers,
+		jwt:      jwt.NewJwt(res.Config.JwtConfig),
+	}
+}
+
+// ListSessions godoc
+//
+//	@Summary		List my sessions
+//	@Description	List the active sessions of the authenticated user; the session of the refresh token cookie is flagged as current
+//	@Tags			sessions
+//	@Produce		json
+//	@Success		200	{array}	response.SessionResponse
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/v1/auth/sessions [get]
+func (c *SessionController) ListSessions(ec echo.Context) error {
+	userID, err := c.userID(ec)
+	if err != nil {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	req := request.ListSessionsRequest{UserID: userID}
+	if rtCookie, err := ec.Cookie("refresh_token"); err == nil && rtCookie != nil {
+		req.RefreshToken = rtCookie.Value
+	}
+
+	res, err := c.managers.SessionManager.ListSessions(ec.Request().Context(), req)
+	if err != nil {
+		c.res.Logger.Error("List sessions failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke one of my sessions
+//	@Description	Revoke a session of the authenticated user
+//	@Tags			sessions
+//	@Produce		json
+//	@Param			id	path	string	true	"Session ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/auth/sessions/{id} [delete]
+func (c *SessionController) RevokeSession(ec echo.Context) error {
+	userID, err := c.userID(ec)
+	if err != nil {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	sessionID, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid session id"))
+	}
+	return c.revokeSession(ec, request.RevokeSessionRequest{UserID: userID, SessionID: sessionID})
+}
+
+// RevokeOtherSessions godoc
+//
+//	@Summary		Revoke my other sessions
+//	@Description	Revoke every session of the authenticated user except the one of the refresh token cookie
+//	@Tags			sessions
+//	@Produce		json
+//	@Success		200	{object}	response.RevokeSessionsResponse
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/v1/auth/sessions/revoke-others [post]
+func (c *SessionController) RevokeOtherSessions(ec echo.Context) error {
+	userID, err := c.userID(ec)
+	if err != nil {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Authentication required"))
+	}
+	rtCookie, errCookie := ec.Cookie("refresh_token")
+	if errCookie != nil || rtCookie == nil || rtCookie.Value == "" {
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, "Missing refresh token"))
+	}
+
+	res, err := c.managers.SessionManager.RevokeOtherSessions(ec.Request().Context(), request.RevokeOtherSessionsRequest{
+		UserID:       userID,
+		RefreshToken: rtCookie.Value,
+	})
+	if err != nil {
+		if errors.Is(err, manager.ErrInvalidRefreshToken) {
+			return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, err.Error()))
+		}
+		c.res.Logger.Error("Revoke other sessions failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// AdminListSessions godoc
+//
+//	@Summary		List sessions of a user
+//	@Description	List the active sessions of any user
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path	string	true	"User ID"
+//	@Success		200	{array}	response.SessionResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/sessions [get]
+func (c *SessionController) AdminListSessions(ec echo.Context) error {
+	userID, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.SessionManager.ListSessions(ec.Request().Context(), request.ListSessionsRequest{UserID: userID})
+	if err != nil {
+		c.res.Logger.Error("List sessions failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// AdminRevokeSession godoc
+//
+//	@Summary		Revoke a session of a user
+//	@Description	Revoke one session of any user
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id			path	string	true	"User ID"
+//	@Param			sessionId	path	string	true	"Session ID"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/sessions/{sessionId} [delete]
+func (c *SessionController) AdminRevokeSession(ec echo.Context) error {
+	userID, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+	sessionID, err := uuid.Parse(ec.Param("sessionId"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid session id"))
+	}
+	return c.revokeSession(ec, request.RevokeSessionRequest{UserID: userID, SessionID: sessionID})
+}
+
+// AdminRevokeAllSessions godoc
+//
+//	@Summary		Revoke all sessions of a user
+//	@Description	Revoke every session of any user
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.RevokeSessionsResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/sessions/revoke-all [post]
+func (c *SessionController) AdminRevokeAllSessions(ec echo.Context) error {
+	userID, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.SessionManager.RevokeAllSessions(ec.Request().Context(), userID)
+	if err != nil {
+		c.res.Logger.Error("Revoke all sessions failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+func (c *SessionController) revokeSession(ec echo.Context, req request.RevokeSessionRequest) error {
+	if err := c.managers.SessionManager.RevokeSession(ec.Request().Context(), req); err != nil {
+		if errors.Is(err, manager.ErrSessionNotFound) {
+			return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+		}
+		c.res.Logger.Error("Revoke session failed", zap.Error(err))
+		return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse("session revoked"))
+}
+
+func (c *SessionController) userID(ec echo.Context) (uuid.UUID, error) {
+	claims, err := c.jwt.GetClaims(ec)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if claims.UserID == nil {
+		return uuid.Nil, errors.New("missing user id claim")
+	}
+	return *claims.UserID, nil
+}
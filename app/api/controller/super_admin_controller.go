@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type SuperAdminController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewSuperAdminController(managers *manager.Managers, res runtime.Resource) *SuperAdminController {
+	return &SuperAdminController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// Bootstrap godoc
+//
+//	@Summary		Bootstrap the first super admin
+//	@Description	Create the first super admin of a fresh environment with the configured bootstrap token, unavailable once any admin exists. An existing account is never promoted, use the create-admin command for that
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.BootstrapSuperAdminRequest	true	"Super admin"
+//	@Success		201		{object}	response.SuperAdminResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		409
+//	@Failure		429
+//	@Failure		500
+//	@Router			/api/v1/auth/bootstrap [post]
+func (c *SuperAdminController) Bootstrap(ec echo.Context) error {
+	var req request.BootstrapSuperAdminRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+
+	res, err := c.managers.SuperAdminManager.Bootstrap(ec.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, manager.ErrPasswordPolicy) {
+			return passwordPolicyViolation(ec, "password", err)
+		}
+		return c.superAdminError(ec, err)
+	}
+	return ec.JSON(http.StatusCreated, response.ToSuccessResponse(res))
+}
+
+func (c *SuperAdminController) superAdminError(ec echo.Context, err error) error {
+	switch {
+	// Hide the endpoint entirely once it has served its purpose
+	case errors.Is(err, manager.ErrBootstrapUnavailable), errors.Is(err, manager.ErrSuperAdminCreationDisabled):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, "Not found"))
+	case errors.Is(err, manager.ErrTooManyRequests):
+		return tooManyRequests(ec, err)
+	case errors.Is(err, manager.ErrInvalidBootstrapToken):
+		return ec.JSON(http.StatusUnauthorized, response.ToErrorResponse(http.StatusUnauthorized, err.Error()))
+	case errors.Is(err, manager.ErrPasswordRequired):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	case errors.Is(err, manager.ErrEmailAlreadyExists):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	}
+	c.res.Logger.Error("Bootstrap super admin failed", zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
@@ -0,0 +1,305 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type UserController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+}
+
+func NewUserController(managers *manager.Managers, res runtime.Resource) *UserController {
+	return &UserController{
+		res:      res,
+		managers: managers,
+	}
+}
+
+// ListUsers godoc
+//
+//	@Summary		List users
+//	@Description	List users with filters, free-text search on email and username, and sorting
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page			query		int		false	"Page"
+//	@Param			size			query		int		false	"Page size"
+//	@Param			sort_by			query		string	false	"ASC or DESC"
+//	@Param			order_by		query		string	false	"created_at, last_login_at, email or username"
+//	@Param			status			query		string	false	"VERIFIED, UNVERIFIED or DISABLED"
+//	@Param			role			query		string	false	"Role"
+//	@Param			email_verified	query		bool	false	"Email verified"
+//	@Param			phone_verified	query		bool	false	"Phone verified"
+//	@Param			q				query		string	false	"Part of the email or username"
+//	@Param			created_from	query		string	false	"Created at or after, RFC 3339"
+//	@Param			created_before	query		string	false	"Created before, RFC 3339"
+//	@Param			deleted			query		bool	false	"List soft-deleted users instead"
+//	@Success		200				{object}	response.PaginationResponse[response.UserResponse]
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/v1/admin/users [get]
+func (c *UserController) ListUsers(ec echo.Context) error {
+	var req request.ListUsersRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	req.LoadDefaultValues()
+
+	users, total, err := c.managers.UserManager.ListUsers(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "List users failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToPaginationResponse(users, total, req.Page, req.Size))
+}
+
+// GetUser godoc
+//
+//	@Summary		Get a user
+//	@Description	Get a user account, soft-deleted accounts included
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id} [get]
+func (c *UserController) GetUser(ec echo.Context) error {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.UserManager.GetUser(ec.Request().Context(), id)
+	if err != nil {
+		return c.userError(ec, "Get user failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// UpdateUserRole godoc
+//
+//	@Summary		Change the role of a user
+//	@Description	Assign a role that grants no permission the caller lacks, the user's access tokens are revoked
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"User ID"
+//	@Param			request	body		request.UpdateUserRoleRequest	true	"Role"
+//	@Success		200		{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/role [put]
+func (c *UserController) UpdateUserRole(ec echo.Context) error {
+	var req request.UpdateUserRoleRequest
+	if err := ec.Bind(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid request"))
+	}
+	if err := ec.Validate(&req); err != nil {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid data"))
+	}
+	target, ok := c.adminUserRequest(ec)
+	if !ok {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+	req.AdminUserRequest = target
+
+	res, err := c.managers.UserManager.UpdateUserRole(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "Update user role failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// SuspendUser godoc
+//
+//	@Summary		Suspend a user
+//	@Description	Disable the account, end every session and revoke the API keys the user owns. Login is refused until the user is unsuspended, the keys stay revoked
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/suspend [post]
+func (c *UserController) SuspendUser(ec echo.Context) error {
+	req, ok := c.adminUserRequest(ec)
+	if !ok {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.UserManager.SuspendUser(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "Suspend user failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// UnsuspendUser godoc
+//
+//	@Summary		Unsuspend a user
+//	@Description	Enable a suspended account again
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/unsuspend [post]
+func (c *UserController) UnsuspendUser(ec echo.Context) error {
+	req, ok := c.adminUserRequest(ec)
+	if !ok {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.UserManager.UnsuspendUser(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "Unsuspend user failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// RestoreUser godoc
+//
+//	@Summary		Restore a deleted user
+//	@Description	Undo the soft delete of a user account, purged accounts cannot be restored
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.UserResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/restore [post]
+func (c *UserController) RestoreUser(ec echo.Context) error {
+	req, ok := c.adminUserRequest(ec)
+	if !ok {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.UserManager.RestoreUser(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "Restore user failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// ForceLogout godoc
+//
+//	@Summary		Force a user to log out
+//	@Description	Revoke every session and access token of the user
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.RevokeSessionsResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/logout [post]
+func (c *UserController) ForceLogout(ec echo.Context) error {
+	req, ok := c.adminUserRequest(ec)
+	if !ok {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.UserManager.ForceLogout(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "Force logout failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// ImpersonateUser godoc
+//
+//	@Summary		Impersonate a user
+//	@Description	Issue a short-lived access token to act as the user, every request made with it is audited.
+//	@Description	No refresh token is issued and the token cannot change credentials or reach admin endpoints.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.ImpersonationResponse
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/api/v1/admin/users/{id}/impersonate [post]
+func (c *UserController) ImpersonateUser(ec echo.Context) error {
+	req, ok := c.adminUserRequest(ec)
+	if !ok {
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, "Invalid user id"))
+	}
+
+	res, err := c.managers.UserManager.ImpersonateUser(ec.Request().Context(), req)
+	if err != nil {
+		return c.userError(ec, "Impersonate user failed", err)
+	}
+	return ec.JSON(http.StatusOK, response.ToSuccessResponse(res))
+}
+
+// adminUserRequest identifies the target user from the path and the admin from the authenticated principal
+func (c *UserController) adminUserRequest(ec echo.Context) (request.AdminUserRequest, bool) {
+	id, err := uuid.Parse(ec.Param("id"))
+	if err != nil {
+		return request.AdminUserRequest{}, false
+	}
+	req := request.AdminUserRequest{
+		UserID:    id,
+		ActorRole: middleware.CurrentRole(ec),
+	}
+	if actorID, ok := middleware.CurrentUserID(ec); ok {
+		req.ActorID = &actorID
+	}
+	return req, true
+}
+
+func (c *UserController) userError(ec echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, manager.ErrUserNotFound):
+		return ec.JSON(http.StatusNotFound, response.ToErrorResponse(http.StatusNotFound, err.Error()))
+	case errors.Is(err, manager.ErrCannotManageSelf), errors.Is(err, manager.ErrInsufficientPrivileges):
+		return ec.JSON(http.StatusForbidden, response.ToErrorResponse(http.StatusForbidden, err.Error()))
+	case errors.Is(err, manager.ErrUserNotSuspended), errors.Is(err, manager.ErrUserNotDeleted), errors.Is(err, manager.ErrUserRestoreConflict), errors.Is(err, manager.ErrUserPurged):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrAccountDisabled), errors.Is(err, manager.ErrAccountDeactivated), errors.Is(err, manager.ErrAccountPendingDeletion):
+		return ec.JSON(http.StatusConflict, response.ToErrorResponse(http.StatusConflict, err.Error()))
+	case errors.Is(err, manager.ErrRoleNotFound):
+		return ec.JSON(http.StatusBadRequest, response.ToErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+	c.res.Logger.Error(msg, zap.Error(err))
+	return ec.JSON(http.StatusInternalServerError, response.ToErrorResponse(http.StatusInternalServerError, "Internal server error"))
+}
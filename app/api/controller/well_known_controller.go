@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+
+	"github.com/labstack/echo/v4"
+)
+
+// jwksCacheControl lets verifiers cache the key set while still picking up rotations quickly
+const jwksCacheControl = "public, max-age=300"
+
+type WellKnownController struct {
+	res      runtime.Resource
+	managers *manager.Managers
+	jwt      jwt.Jwt
+}
+
+func NewWellKnownController(managers *manager.Managers, res runtime.Resource) *WellKnownController {
+	return &WellKnownController{
+		res:      res,
+		managers: managers,
+		jwt:      jwt.NewJwt(res.Config.JwtConfig),
+	}
+}
+
+// Jwks godoc
+//
+//	@Summary		JSON Web Key Set
+//	@Description	Public keys that verify access tokens, selected by the kid header
+//	@Tags			system
+//	@Produce		json
+//	@Success		200	{object}	jwt.JSONWebKeySet
+//	@Router			/.well-known/jwks.json [get]
+func (c *WellKnownController) Jwks(ec echo.Context) error {
+	ec.Response().Header().Set("Cache-Control", jwksCacheControl)
+	return ec.JSON(http.StatusOK, c.jwt.JWKS())
+}
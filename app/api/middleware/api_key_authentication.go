@@ -1,10 +1,15 @@
 package middleware
 
 import (
-	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/owner"
+	"backend/service-platform/app/database/repository"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/apikey"
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -12,28 +17,21 @@ import (
 )
 
 type ApiKeyAuthentication struct {
-	res runtime.Resource
-	// In production, API keys should be stored securely (database, vault, etc.)
-	// For now, we'll use a configurable map
-	validAPIKeys map[string]string // key -> service name
+	res     runtime.Resource
+	apiKeys repository.ApiKeyRepository
+	users   repository.UserRepository
 }
 
 func NewApiKeyAuthentication(res runtime.Resource) ApiKeyAuthentication {
-	// Initialize with some example API keys (in production, load from secure storage)
-	validKeys := map[string]string{
-		"service-key-1": "financial-service",
-		"service-key-2": "kyc-service",
-		"service-key-3": "notification-service",
-	}
-
 	return ApiKeyAuthentication{
-		res:          res,
-		validAPIKeys: validKeys,
+		res:     res,
+		apiKeys: repository.NewApiKeyRepository(res),
+		users:   repository.NewUserRepository(res),
 	}
 }
 
 func (ak *ApiKeyAuthentication) GetName() string {
-	return authMethodAPIKey
+	return AuthMethodAPIKey
 }
 
 func (ak *ApiKeyAuthentication) CanHandle(c echo.Context) bool {
@@ -42,17 +40,7 @@ func (ak *ApiKeyAuthentication) CanHandle(c echo.Context) bool {
 }
 
 func (ak *ApiKeyAuthentication) GetAuthenticationMethod(c echo.Context) (string, error) {
-	method := c.Get(contextAuthMethod)
-	if method == nil {
-		return "", fmt.Errorf("authentication method not found in context")
-	}
-
-	methodStr, ok := method.(string)
-	if !ok {
-		return "", fmt.Errorf("invalid authentication method type in context")
-	}
-
-	return methodStr, nil
+	return authenticationMethod(c)
 }
 
 func (ak *ApiKeyAuthentication) RequireAuth() echo.MiddlewareFunc {
@@ -82,118 +70,95 @@ func (ak *ApiKeyAuthentication) RequireAuth() echo.MiddlewareFunc {
 	}
 }
 
+// RequireRole authenticates the request and checks the role like every other mechanism
 func (ak *ApiKeyAuthentication) RequireRole(requiredRole string) echo.MiddlewareFunc {
+	authenticate := ak.RequireAuth()
+	checkRole := requireAnyRole(requiredRole)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			// Service accounts typically have specific roles
-			// For API key authentication, we might want different logic
-			// Since services often have elevated permissions, we can either:
-			// 1. Always allow service accounts (bypass role check)
-			// 2. Check against service-specific roles
-
-			// Option 1: Allow all authenticated service accounts
-			if ak.IsServiceAccount(c) {
-				ak.res.Logger.Debug("Service account authenticated, bypassing role check")
-				return next(c)
-			}
-
-			// Option 2: Check role normally (uncomment if needed)
-			/*
-			   roleInterface := c.Get(contextRole)
-			   if roleInterface == nil {
-			       return ak.CreateErrorResponse(http.StatusForbidden, "Access denied: no role found")
-			   }
-
-			   userRole, ok := roleInterface.(string)
-			   if !ok {
-			       return ak.CreateErrorResponse(http.StatusForbidden, "Access denied: invalid role")
-			   }
-
-			   if !ak.HasRequiredRole(userRole, requiredRole) {
-			       return ak.CreateErrorResponse(http.StatusForbidden, "Access denied: insufficient permissions")
-			   }
-			*/
-
-			return next(c)
-		}
+		return authenticate(checkRole(next))
 	}
 }
 
 func (ak *ApiKeyAuthentication) Authenticate(c echo.Context) (*AuthenticationResult, error) {
-	apiKey := c.Request().Header.Get(apiKeyHeader)
-	if apiKey == "" {
+	plaintext := c.Request().Header.Get(apiKeyHeader)
+	prefix, ok := apikey.ParsePrefix(plaintext)
+	if !ok {
 		return nil, fmt.Errorf(errMsgInvalidAPIKey)
 	}
 
-	serviceName, exists := ak.validAPIKeys[apiKey]
-	if !exists {
+	ctx := c.Request().Context()
+	key, err := ak.apiKeys.FindByPrefix(ctx, prefix)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			ak.res.Logger.Error("Failed to look up API key", zap.Error(err))
+		}
+		return nil, fmt.Errorf(errMsgInvalidAPIKey)
+	}
+	now := time.Now()
+	if !apikey.Verify(plaintext, key.SecretHash) || !key.IsActive(now) {
 		return nil, fmt.Errorf(errMsgInvalidAPIKey)
 	}
 
-	// Parse service UUID
-	serviceUUID, err := uuid.Parse(serviceUserID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid service UUID")
+	result := &AuthenticationResult{
+		Success:  true,
+		Method:   AuthMethodAPIKey,
+		ApiKeyID: &key.ID,
+		Scopes:   key.Scopes,
+	}
+	switch key.OwnerType {
+	case owner.Service:
+		serviceUUID := uuid.MustParse(serviceUserID)
+		username := serviceUsername
+		role := serviceRole
+		result.UserID = &serviceUUID
+		result.Username = &username
+		result.Role = &role
+		result.ServiceName = key.ServiceName
+	case owner.User:
+		if key.UserID == nil {
+			return nil, fmt.Errorf(errMsgInvalidAPIKey)
+		}
+		u, err := ak.users.FindByID(ctx, *key.UserID)
+		// A suspended, deactivated or deleting owner cannot act through their keys either
+		if err != nil || !accountActive(u) {
+			return nil, fmt.Errorf(errMsgInvalidAPIKey)
+		}
+		role := string(u.Role)
+		result.UserID = &u.ID
+		result.Username = &u.Username
+		result.Email = u.Email
+		result.PhoneNumber = u.PhoneNumber
+		result.Role = &role
+		result.EmailVerified = &u.EmailVerified
+		result.PhoneVerified = &u.PhoneVerified
+		result.LastLoginAt = u.LastLoginAt
+	default:
+		return nil, fmt.Errorf(errMsgInvalidAPIKey)
 	}
 
-	username := serviceUsername
-	serviceNamePtr := &serviceName
-
-	serviceRole := "SERVICE"
-	return &AuthenticationResult{
-		Success:     true,
-		UserID:      &serviceUUID,
-		Username:    &username,
-		Role:        &serviceRole, // Service accounts get a Service role
-		Method:      authMethodAPIKey,
-		ServiceName: serviceNamePtr,
-	}, nil
+	// Recording every request would turn reads into writes, a coarse timestamp is enough
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > lastUsedResolution {
+		if err := ak.apiKeys.UpdateLastUsedAt(ctx, key.ID, now); err != nil {
+			ak.res.Logger.Warn("Failed to record API key usage", zap.Error(err))
+		}
+	}
+	return result, nil
 }
 
 func (ak *ApiKeyAuthentication) SetUserContext(c echo.Context, result *AuthenticationResult) {
-	if result.UserID != nil {
-		c.Set(contextUserID, *result.UserID)
-		c.Set(contextUserUUID, *result.UserID)
-	}
-	if result.Username != nil {
-		c.Set(contextUsername, *result.Username)
-	}
-	if result.Email != nil {
-		c.Set(contextEmail, *result.Email)
-	}
-	if result.PhoneNumber != nil {
-		c.Set(contextPhoneNumber, *result.PhoneNumber)
-	}
-	if result.Role != nil {
-		c.Set(contextRole, *result.Role)
-	}
-	if result.EmailVerified != nil {
-		c.Set(contextEmailVerified, *result.EmailVerified)
-	}
-	if result.PhoneVerified != nil {
-		c.Set(contextPhoneVerified, *result.PhoneVerified)
-	}
-	if result.LastLoginAt != nil {
-		c.Set(contextLastLoginAt, *result.LastLoginAt)
-	}
-
-	// Set an authentication method and service name for tracking
-	c.Set(contextAuthMethod, result.Method)
-	if result.ServiceName != nil {
-		c.Set(contextServiceName, *result.ServiceName)
-	}
+	setUserContext(c, result)
 }
 
 func (ak *ApiKeyAuthentication) CreateErrorResponse(statusCode int, message string) *echo.HTTPError {
-	return echo.NewHTTPError(statusCode, response.ToErrorResponse(statusCode, message))
+	return createErrorResponse(statusCode, message)
 }
 
 func (ak *ApiKeyAuthentication) HasRequiredRole(userRole string, requiredRole string) bool {
-	return userRole == requiredRole
+	return hasRequiredRole(userRole, requiredRole)
 }
 
 // IsServiceAccount checks if the current request is from a service account
 func (ak *ApiKeyAuthentication) IsServiceAccount(c echo.Context) bool {
 	method, err := ak.GetAuthenticationMethod(c)
-	return err == nil && method == authMethodAPIKey
+	return err == nil && method == AuthMethodAPIKey
 }
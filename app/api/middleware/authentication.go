@@ -4,16 +4,28 @@
 // Supported Authentication Mechanisms:
 // - JWT Bearer tokens (primary handler for user authentication)
 // - API Key authentication (for service-to-service communication)
-// - Basic Authentication (for legacy system integration)
+// - Personal access tokens (Bearer pat_..., for scripts acting as a user)
+// - Service tokens (Bearer JWTs of the OAuth2 client credentials grant, for service-to-service communication)
+// - Basic Authentication (configured credentials bound to a user, for legacy system integration)
 //
 // Key features:
-// - Chain of responsibility pattern for trying multiple mechanisms
-// - Optimized role checking with map-based lookup for large role sets
+// - Chain of responsibility pattern for trying multiple mechanisms (see CompositeAuthentication)
+// - Routes declare the mechanisms they accept, role checks behave the same for every mechanism
+// - Role checks follow the built-in hierarchy, permissions are resolved through pkg/rbac
 // - Centralized error response creation
 // - Comprehensive helper functions for common operations
 package middleware
 
 import (
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/jwt"
+	ctxutil "backend/service-platform/app/pkg/util/context"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,11 +45,19 @@ const (
 	contextLastLoginAt   = "last_login_at"
 	contextAuthMethod    = "auth_method"
 	contextServiceName   = "service_name"
+	contextApiKeyID      = "api_key_id"
+	contextScopes        = "scopes"
 
-	// Authentication methods
-	authMethodJWT    = "jwt"
-	authMethodAPIKey = "api_key"
-	authMethodBasic  = "basic_auth"
+	contextImpersonatorID       = "impersonator_id"
+	contextImpersonatorUUID     = "impersonator_uuid"
+	contextImpersonatorUsername = "impersonator_username"
+
+	// Authentication methods, also the names used in the authentication.methods config
+	AuthMethodJWT          = "jwt"
+	AuthMethodAPIKey       = "api_key"
+	AuthMethodBasic        = "basic_auth"
+	AuthMethodPAT          = "personal_access_token"
+	AuthMethodServiceToken = "service_token"
 
 	// Token constants
 	basicPrefix    = "basic"
@@ -48,6 +68,10 @@ const (
 	// Service authentication
 	serviceUserID   = "00000000-0000-0000-0000-000000000000"
 	serviceUsername = "service"
+	serviceRole     = "SERVICE"
+
+	// API keys and personal access tokens only record their last use once per interval
+	lastUsedResolution = time.Minute
 
 	// Error messages
 	errMsgAuthRequired        = "Authentication required"
@@ -56,6 +80,10 @@ const (
 	errMsgInvalidHeaderFormat = "Invalid authorization header format"
 	errMsgInvalidAPIKey       = "Invalid API key"
 	errMsgInvalidBasicAuth    = "Invalid basic authentication"
+	errMsgInvalidPAT          = "Invalid personal access token"
+	errMsgRoleNotFound        = "User role not found"
+	errMsgInsufficientRole    = "Access denied: insufficient permissions"
+	errMsgImpersonation       = "Access denied: not allowed while impersonating a user"
 )
 
 // AuthenticationResult represents the result of an authentication attempt
@@ -70,7 +98,12 @@ type AuthenticationResult struct {
 	PhoneVerified *bool      // Phone verification status
 	LastLoginAt   *time.Time // Last login timestamp
 	Method        string     // Authentication method used
-	ServiceName   *string    // Service name (for API key auth)
+	ServiceName   *string    // Service name (for API key auth), client ID (for service token auth)
+	ApiKeyID      *uuid.UUID // Key used to authenticate (for API key auth)
+	Scopes        []string   // Granted scopes (for API key, personal access token and service token auth)
+
+	ImpersonatorID       *uuid.UUID // Admin acting as the user (for impersonation tokens)
+	ImpersonatorUsername *string    // Username of that admin
 }
 
 type Authentication interface {
@@ -94,3 +127,150 @@ type Authentication interface {
 	// HasRequiredRole checks if a user has the required role
 	HasRequiredRole(userRole string, requiredRole string) bool
 }
+
+// setUserContext stores the authenticated principal the same way for every mechanism
+func setUserContext(c echo.Context, result *AuthenticationResult) {
+	if result.UserID != nil {
+		c.Set(contextUserID, result.UserID.String())
+		c.Set(contextUserUUID, *result.UserID)
+	}
+	if result.Username != nil {
+		c.Set(contextUsername, *result.Username)
+	}
+	if result.Email != nil {
+		c.Set(contextEmail, *result.Email)
+	}
+	if result.PhoneNumber != nil {
+		c.Set(contextPhoneNumber, *result.PhoneNumber)
+	}
+	if result.Role != nil {
+		c.Set(contextRole, *result.Role)
+	}
+	if result.EmailVerified != nil {
+		c.Set(contextEmailVerified, *result.EmailVerified)
+	}
+	if result.PhoneVerified != nil {
+		c.Set(contextPhoneVerified, *result.PhoneVerified)
+	}
+	if result.LastLoginAt != nil {
+		c.Set(contextLastLoginAt, *result.LastLoginAt)
+	}
+
+	// Set an authentication method and service name for tracking
+	c.Set(contextAuthMethod, result.Method)
+	if result.ServiceName != nil {
+		c.Set(contextServiceName, *result.ServiceName)
+	}
+	if result.ApiKeyID != nil {
+		c.Set(contextApiKeyID, *result.ApiKeyID)
+	}
+	c.Set(contextScopes, result.Scopes)
+
+	// Impersonation tokens authenticate as the user, the admin behind them stays visible
+	if result.ImpersonatorID != nil {
+		c.Set(contextImpersonatorID, result.ImpersonatorID.String())
+		c.Set(contextImpersonatorUUID, *result.ImpersonatorID)
+	}
+	if result.ImpersonatorUsername != nil {
+		c.Set(contextImpersonatorUsername, *result.ImpersonatorUsername)
+	}
+
+	// Managers see the principal through the request context, e.g. to attribute audit events
+	principal := ctxutil.Principal{UserID: result.UserID, Method: result.Method, ImpersonatorID: result.ImpersonatorID}
+	if result.Role != nil {
+		principal.Role = *result.Role
+	}
+	if result.ServiceName != nil {
+		principal.ServiceName = *result.ServiceName
+	}
+	req := c.Request()
+	c.SetRequest(req.WithContext(ctxutil.PrincipalKey.Set(req.Context(), principal)))
+}
+
+// CurrentUserID returns the authenticated user, false for principals without one such as service API keys
+func CurrentUserID(c echo.Context) (uuid.UUID, bool) {
+	id, ok := c.Get(contextUserUUID).(uuid.UUID)
+	return id, ok
+}
+
+// CurrentImpersonatorID returns the admin acting as the authenticated user, false outside of impersonation
+func CurrentImpersonatorID(c echo.Context) (uuid.UUID, bool) {
+	id, ok := c.Get(contextImpersonatorUUID).(uuid.UUID)
+	return id, ok
+}
+
+// CurrentRole returns the role of the authenticated principal, empty when unauthenticated
+func CurrentRole(c echo.Context) string {
+	r, _ := c.Get(contextRole).(string)
+	return r
+}
+
+func authenticationMethod(c echo.Context) (string, error) {
+	method := c.Get(contextAuthMethod)
+	if method == nil {
+		return "", fmt.Errorf("authentication method not found in context")
+	}
+
+	methodStr, ok := method.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid authentication method type in context")
+	}
+
+	return methodStr, nil
+}
+
+// extractBearerToken reads the credentials of an "Authorization: Bearer <token>" header
+func extractBearerToken(ec echo.Context) (string, error) {
+	authHeader := ec.Request().Header.Get(authHeaderName)
+	if authHeader == "" {
+		return "", fmt.Errorf(errMsgHeaderMissing)
+	}
+
+	parts := strings.SplitN(authHeader, " ", tokenParts)
+	if len(parts) != tokenParts {
+		return "", fmt.Errorf(errMsgInvalidHeaderFormat)
+	}
+
+	if !strings.EqualFold(parts[0], jwt.TokenTypeBearer) {
+		return "", fmt.Errorf(errMsgInvalidHeaderFormat)
+	}
+
+	token := strings.TrimSpace(parts[1])
+	if token == "" {
+		return "", fmt.Errorf(errMsgInvalidHeaderFormat)
+	}
+
+	return token, nil
+}
+
+// accountActive reports whether the owner of a long-lived credential may still use it
+func accountActive(u *entity.User) bool {
+	return u.Status != userstatus.Disabled && u.DeactivatedAt == nil && u.DeletionScheduledAt == nil
+}
+
+func createErrorResponse(statusCode int, message string) *echo.HTTPError {
+	return echo.NewHTTPError(statusCode, response.ToErrorResponse(statusCode, message))
+}
+
+// hasRequiredRole follows the built-in hierarchy, a SUPER_ADMIN passes an ADMIN check
+func hasRequiredRole(userRole string, requiredRole string) bool {
+	return role.Role(userRole).Includes(role.Role(requiredRole))
+}
+
+// requireAnyRole must run after an authentication middleware, it reads the role every mechanism stores in the context
+func requireAnyRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userRole, _ := c.Get(contextRole).(string)
+			if userRole == "" {
+				return createErrorResponse(http.StatusUnauthorized, errMsgRoleNotFound)
+			}
+			for _, r := range roles {
+				if hasRequiredRole(userRole, r) {
+					return next(c)
+				}
+			}
+			return createErrorResponse(http.StatusForbidden, errMsgInsufficientRole)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"backend/service-platform/app/internal/runtime"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+)
+
+const authMethodComposite = "composite"
+
+// CompositeAuthentication tries its handlers in order and authenticates with the first one that can handle the request.
+// A request that presents credentials for a handler is not passed on to the next one when they are invalid.
+type CompositeAuthentication struct {
+	res      runtime.Resource
+	handlers []Authentication
+}
+
+func NewCompositeAuthentication(res runtime.Resource, handlers ...Authentication) CompositeAuthentication {
+	return CompositeAuthentication{
+		res:      res,
+		handlers: handlers,
+	}
+}
+
+// Only returns a chain restricted to the given methods, keeping the configured order.
+// Methods that are not configured stay disabled.
+func (ca CompositeAuthentication) Only(methods ...string) CompositeAuthentication {
+	handlers := make([]Authentication, 0, len(methods))
+	for _, h := range ca.handlers {
+		if slices.Contains(methods, h.GetName()) {
+			handlers = append(handlers, h)
+		}
+	}
+	return NewCompositeAuthentication(ca.res, handlers...)
+}
+
+// Methods returns the names of the handlers in the order they are tried
+func (ca CompositeAuthentication) Methods() []string {
+	names := make([]string, 0, len(ca.handlers))
+	for _, h := range ca.handlers {
+		names = append(names, h.GetName())
+	}
+	return names
+}
+
+func (ca CompositeAuthentication) GetName() string {
+	return authMethodComposite
+}
+
+func (ca CompositeAuthentication) CanHandle(ec echo.Context) bool {
+	return ca.handlerFor(ec) != nil
+}
+
+func (ca CompositeAuthentication) GetAuthenticationMethod(c echo.Context) (string, error) {
+	return authenticationMethod(c)
+}
+
+// RequireAuth delegates to the selected handler so each mechanism keeps its own error response
+func (ca CompositeAuthentication) RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			h := ca.handlerFor(c)
+			if h == nil {
+				return ca.CreateErrorResponse(http.StatusUnauthorized, errMsgAuthRequired)
+			}
+			return h.RequireAuth()(next)(c)
+		}
+	}
+}
+
+// RequireRole authenticates the request and checks the role like every other mechanism
+func (ca CompositeAuthentication) RequireRole(requiredRole string) echo.MiddlewareFunc {
+	authenticate := ca.RequireAuth()
+	checkRole := requireAnyRole(requiredRole)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return authenticate(checkRole(next))
+	}
+}
+
+func (ca CompositeAuthentication) Authenticate(ec echo.Context) (*AuthenticationResult, error) {
+	h := ca.handlerFor(ec)
+	if h == nil {
+		return nil, fmt.Errorf(errMsgAuthRequired)
+	}
+	return h.Authenticate(ec)
+}
+
+func (ca CompositeAuthentication) SetUserContext(c echo.Context, result *AuthenticationResult) {
+	setUserContext(c, result)
+}
+
+func (ca CompositeAuthentication) CreateErrorResponse(statusCode int, message string) *echo.HTTPError {
+	return createErrorResponse(statusCode, message)
+}
+
+func (ca CompositeAuthentication) HasRequiredRole(userRole string, requiredRole string) bool {
+	return hasRequiredRole(userRole, requiredRole)
+}
+
+func (ca CompositeAuthentication) handlerFor(ec echo.Context) Authentication {
+	for _, h := range ca.handlers {
+		if h.CanHandle(ec) {
+			return h
+		}
+	}
+	return nil
+}
@@ -1,10 +1,13 @@
 package middleware
 
 import (
-	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/config"
 	"backend/service-platform/app/internal/runtime"
-	"crypto/subtle"
+	"backend/service-platform/app/pkg/bcrypt"
+	"database/sql"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,25 +18,37 @@ import (
 )
 
 type HttpBasicAuthentication struct {
-	res              runtime.Resource
-	validCredentials map[string]string // username -> password
+	res     runtime.Resource
+	enabled bool
+	// Configured credentials by username
+	credentials map[string]config.BasicAuthCredential
+	hasher      bcrypt.Hasher
+	users       repository.UserRepository
 }
 
 func NewHttpBasicAuthentication(res runtime.Resource) HttpBasicAuthentication {
-	// Initialize with some example credentials (in production, use proper user management)
-	validCredentials := map[string]string{
-		"admin": "admin-password", // In production, use hashed passwords
-		"api":   "api-password",
+	cfg := res.Config.AuthenticationConfig.BasicAuth
+	credentials := make(map[string]config.BasicAuthCredential, len(cfg.Credentials))
+	for _, credential := range cfg.Credentials {
+		credentials[credential.Username] = credential
 	}
 
+	// Only verifies, the configured hashes may come from either algorithm
+	bcryptHasher := bcrypt.NewBcrypt(res.Config.BcryptConfig.Cost)
+	argon2Hasher := bcrypt.NewArgon2id(res.Config.Argon2Config)
+	hasher, _ := bcrypt.NewMultiHasher(bcrypt.AlgorithmBcrypt, &bcryptHasher, &argon2Hasher)
+
 	return HttpBasicAuthentication{
-		res:              res,
-		validCredentials: validCredentials,
+		res:         res,
+		enabled:     cfg.Enabled,
+		credentials: credentials,
+		hasher:      hasher,
+		users:       repository.NewUserRepository(res),
 	}
 }
 
 func (hb *HttpBasicAuthentication) GetName() string {
-	return authMethodBasic
+	return AuthMethodBasic
 }
 
 func (hb *HttpBasicAuthentication) CanHandle(c echo.Context) bool {
@@ -51,17 +66,7 @@ func (hb *HttpBasicAuthentication) CanHandle(c echo.Context) bool {
 }
 
 func (hb *HttpBasicAuthentication) GetAuthenticationMethod(c echo.Context) (string, error) {
-	method := c.Get(contextAuthMethod)
-	if method == nil {
-		return "", fmt.Errorf("authentication method not found in context")
-	}
-
-	methodStr, ok := method.(string)
-	if !ok {
-		return "", fmt.Errorf("invalid authentication method type in context")
-	}
-
-	return methodStr, nil
+	return authenticationMethod(c)
 }
 
 func (hb *HttpBasicAuthentication) RequireAuth() echo.MiddlewareFunc {
@@ -102,27 +107,12 @@ func (hb *HttpBasicAuthentication) RequireAuth() echo.MiddlewareFunc {
 	}
 }
 
+// RequireRole authenticates the request and checks the role like every other mechanism
 func (hb *HttpBasicAuthentication) RequireRole(requiredRole string) echo.MiddlewareFunc {
+	authenticate := hb.RequireAuth()
+	checkRole := requireAnyRole(requiredRole)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			// Get user role from context
-			roleInterface := c.Get(contextRole)
-			if roleInterface == nil {
-				return hb.CreateErrorResponse(http.StatusForbidden, "Access denied: no role found")
-			}
-
-			userRole, ok := roleInterface.(string)
-			if !ok {
-				return hb.CreateErrorResponse(http.StatusForbidden, "Access denied: invalid role")
-			}
-
-			// Check if a user has the required role
-			if !hb.HasRequiredRole(userRole, requiredRole) {
-				return hb.CreateErrorResponse(http.StatusForbidden, "Access denied: insufficient permissions")
-			}
-
-			return next(c)
-		}
+		return authenticate(checkRole(next))
 	}
 }
 
@@ -132,71 +122,60 @@ func (hb *HttpBasicAuthentication) Authenticate(c echo.Context) (*Authentication
 		return nil, err
 	}
 
-	validPassword, exists := hb.validCredentials[username]
+	if !hb.enabled {
+		return nil, fmt.Errorf(errMsgInvalidBasicAuth)
+	}
+	credential, exists := hb.credentials[username]
 	if !exists {
 		return nil, fmt.Errorf(errMsgInvalidBasicAuth)
 	}
-
-	// Use constant-time comparison to prevent timing attacks
-	if subtle.ConstantTimeCompare([]byte(password), []byte(validPassword)) != 1 {
+	if ok, err := hb.hasher.CheckPassword(password, credential.PasswordHash); err != nil || !ok {
 		return nil, fmt.Errorf(errMsgInvalidBasicAuth)
 	}
 
-	// Generate a UUID for a basic auth user (in production, get from a database)
-	userUUID := uuid.New()
-	usernamePtr := &username
+	// The credential acts as the bound user, suspended, deactivated or deleted users lose it with their account
+	userID, err := uuid.Parse(credential.UserID)
+	if err != nil {
+		hb.res.Logger.Error("Basic auth credential is not bound to a valid user id", zap.String("username", username))
+		return nil, fmt.Errorf(errMsgInvalidBasicAuth)
+	}
+	ctx := c.Request().Context()
+	u, err := hb.users.FindByID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			hb.res.Logger.Error("Failed to look up basic auth user", zap.Error(err))
+		}
+		return nil, fmt.Errorf(errMsgInvalidBasicAuth)
+	}
+	if !accountActive(u) {
+		return nil, fmt.Errorf(errMsgInvalidBasicAuth)
+	}
 
-	adminRole := "ADMIN"
+	role := string(u.Role)
 	return &AuthenticationResult{
-		Success:  true,
-		UserID:   &userUUID,
-		Username: usernamePtr,
-		Role:     &adminRole, // Basic auth users get admin role
-		Method:   authMethodBasic,
+		Success:       true,
+		UserID:        &u.ID,
+		Username:      &u.Username,
+		Email:         u.Email,
+		PhoneNumber:   u.PhoneNumber,
+		Role:          &role,
+		EmailVerified: &u.EmailVerified,
+		PhoneVerified: &u.PhoneVerified,
+		LastLoginAt:   u.LastLoginAt,
+		Method:        AuthMethodBasic,
 	}, nil
 }
 
 func (hb *HttpBasicAuthentication) SetUserContext(c echo.Context, result *AuthenticationResult) {
-	if result.UserID != nil {
-		c.Set(contextUserID, *result.UserID)
-		c.Set(contextUserUUID, *result.UserID)
-	}
-	if result.Username != nil {
-		c.Set(contextUsername, *result.Username)
-	}
-	if result.Email != nil {
-		c.Set(contextEmail, *result.Email)
-	}
-	if result.PhoneNumber != nil {
-		c.Set(contextPhoneNumber, *result.PhoneNumber)
-	}
-	if result.Role != nil {
-		c.Set(contextRole, *result.Role)
-	}
-	if result.EmailVerified != nil {
-		c.Set(contextEmailVerified, *result.EmailVerified)
-	}
-	if result.PhoneVerified != nil {
-		c.Set(contextPhoneVerified, *result.PhoneVerified)
-	}
-	if result.LastLoginAt != nil {
-		c.Set(contextLastLoginAt, *result.LastLoginAt)
-	}
-
-	// Set an authentication method and service name for tracking
-	c.Set(contextAuthMethod, result.Method)
-	if result.ServiceName != nil {
-		c.Set(contextServiceName, *result.ServiceName)
-	}
+	setUserContext(c, result)
 }
 
 func (hb *HttpBasicAuthentication) CreateErrorResponse(statusCode int, message string) *echo.HTTPError {
-	return echo.NewHTTPError(statusCode, response.ToErrorResponse(statusCode, message))
-
+	return createErrorResponse(statusCode, message)
 }
 
 func (hb *HttpBasicAuthentication) HasRequiredRole(userRole string, requiredRole string) bool {
-	return userRole == requiredRole
+	return hasRequiredRole(userRole, requiredRole)
 }
 
 func (hb *HttpBasicAuthentication) extractCredentials(c echo.Context) (string, string, error) {
@@ -226,5 +205,5 @@ func (hb *HttpBasicAuthentication) extractCredentials(c echo.Context) (string, s
 // IsBasicAuthenticated checks if the current request used basic authentication
 func (hb *HttpBasicAuthentication) IsBasicAuthenticated(c echo.Context) bool {
 	method, err := hb.GetAuthenticationMethod(c)
-	return err == nil && method == authMethodBasic
+	return err == nil && method == AuthMethodBasic
 }
@@ -1,61 +1,53 @@
 package middleware
 
 import (
-	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/repository"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/denylist"
 	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/pat"
+	"backend/service-platform/app/pkg/tokenversion"
+	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
 type JwtAuthentication struct {
-	jwt jwt.Jwt
-	res runtime.Resource
+	jwt      jwt.Jwt
+	denylist denylist.Denylist
+	versions tokenversion.Versions
+	// Records every request made with an impersonation token
+	auditLogger audit.AuditLogger
+	res         runtime.Resource
 }
 
 func NewJwtAuthentication(res runtime.Resource) JwtAuthentication {
 	newJwt := jwt.NewJwt(res.Config.JwtConfig)
 	return JwtAuthentication{
-		jwt: newJwt,
-		res: res,
+		jwt:         newJwt,
+		denylist:    denylist.NewRedisDenylist(res.Redis, res.Config.JwtConfig),
+		versions:    tokenversion.NewRedisVersions(res.Redis, repository.NewUserRepository(res), res.Config.JwtConfig.AccessExpiration),
+		auditLogger: audit.NewChainLogger(repository.NewAuditEventRepository(res), res.Logger),
+		res:         res,
 	}
 }
 
 func (j JwtAuthentication) GetName() string {
-	return authMethodJWT
+	return AuthMethodJWT
 }
 
 func (j JwtAuthentication) CanHandle(ec echo.Context) bool {
-	authHeader := ec.Request().Header.Get(authHeaderName)
-	if authHeader == "" {
-		return false
-	}
-
-	parts := strings.SplitN(authHeader, " ", tokenParts)
-	if len(parts) != tokenParts {
-		return false
-	}
-
-	return parts[0] == jwt.TokenTypeBearer || strings.ToLower(parts[0]) == strings.ToLower(jwt.TokenTypeBearer)
-
+	token, err := extractBearerToken(ec)
+	// Personal access tokens and service tokens share the Bearer scheme
+	return err == nil && !pat.IsToken(token) && !jwt.IsServiceToken(token)
 }
 
 func (j JwtAuthentication) GetAuthenticationMethod(c echo.Context) (string, error) {
-	method := c.Get(contextAuthMethod)
-	if method == nil {
-		return "", fmt.Errorf("authentication method not found in context")
-	}
-
-	methodStr, ok := method.(string)
-	if !ok {
-		return "", fmt.Errorf("invalid authentication method type in context")
-	}
-
-	return methodStr, nil
+	return authenticationMethod(c)
 }
 
 func (j JwtAuthentication) RequireAuth() echo.MiddlewareFunc {
@@ -80,41 +72,46 @@ func (j JwtAuthentication) RequireAuth() echo.MiddlewareFunc {
 
 			// Set user context from authentication result
 			j.SetUserContext(c, result)
+			if result.ImpersonatorID != nil {
+				return j.auditImpersonated(c, result, next)
+			}
 			return next(c)
 		}
 	}
 }
 
+// auditImpersonated runs the handler and records the request on the impersonated user, the actor is the admin
+func (j JwtAuthentication) auditImpersonated(c echo.Context, result *AuthenticationResult, next echo.HandlerFunc) error {
+	err := next(c)
+	// Errors are only written after the middleware chain returns
+	status := c.Response().Status
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		status = httpErr.Code
+	case err != nil && !c.Response().Committed:
+		status = http.StatusInternalServerError
+	}
+	j.auditLogger.Record(c.Request().Context(), audit.Event{
+		Action:    audit.ActionImpersonatedRequest,
+		ActorID:   result.ImpersonatorID,
+		SubjectID: result.UserID,
+		Metadata: map[string]interface{}{
+			"method": c.Request().Method,
+			"path":   c.Request().URL.Path,
+			"route":  c.Path(),
+			"status": status,
+		},
+	})
+	return err
+}
+
+// RequireRole authenticates the request and checks the role like every other mechanism
 func (j JwtAuthentication) RequireRole(requiredRole string) echo.MiddlewareFunc {
+	authenticate := j.RequireAuth()
+	checkRole := requireAnyRole(requiredRole)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			// Extract Authorization header
-			authHeader := c.Request().Header.Get(authHeaderName)
-			if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-				return j.CreateErrorResponse(http.StatusUnauthorized, "Missing or invalid Authorization header")
-			}
-
-			// Extract the token string
-			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-			// Parse and validate JWT
-			token, err := j.jwt.ValidateToken(tokenString)
-			if err != nil {
-				return j.CreateErrorResponse(http.StatusUnauthorized, "Invalid token")
-			}
-
-			// Check if user role is present in the token
-			if token.Role == nil || *token.Role == "" {
-				return j.CreateErrorResponse(http.StatusUnauthorized, "User role not found in token")
-			}
-
-			// Check if a user has the required role
-			if !j.HasRequiredRole(*token.Role, requiredRole) {
-				return j.CreateErrorResponse(http.StatusForbidden, "Access denied: insufficient permissions")
-			}
-
-			return next(c)
-		}
+		return authenticate(checkRole(next))
 	}
 }
 
@@ -125,11 +122,20 @@ func (j JwtAuthentication) Authenticate(ec echo.Context) (*AuthenticationResult,
 	}
 
 	claims, err := j.jwt.ValidateToken(token)
-	if err != nil {
+	// A refresh token outlives the access tokens and must not stand in for one
+	if err != nil || claims.IsServiceToken() || claims.IsRefreshToken() {
 		return nil, fmt.Errorf(errMsgInvalidCredentials)
 	}
+	// Logged out, password changed, role changed or suspended since the token was issued
+	if err := j.denylist.Check(ec.Request().Context(), claims); err != nil {
+		return nil, err
+	}
+	// The claims no longer match the user's role or status
+	if err := j.versions.Check(ec.Request().Context(), claims); err != nil {
+		return nil, err
+	}
 
-	return &AuthenticationResult{
+	result := &AuthenticationResult{
 		Success:       true,
 		UserID:        claims.UserID,
 		Username:      claims.Username,
@@ -139,77 +145,36 @@ func (j JwtAuthentication) Authenticate(ec echo.Context) (*AuthenticationResult,
 		EmailVerified: claims.EmailVerified,
 		PhoneVerified: claims.PhoneVerified,
 		LastLoginAt:   claims.LastLoginAt,
-		Method:        authMethodJWT,
-	}, nil
-}
-
-func (j JwtAuthentication) SetUserContext(c echo.Context, result *AuthenticationResult) {
-	if result.UserID != nil {
-		c.Set(contextUserID, result.UserID.String())
-		c.Set(contextUserUUID, *result.UserID)
-	}
-	if result.Username != nil {
-		c.Set(contextUsername, *result.Username)
-	}
-	if result.Email != nil {
-		c.Set(contextEmail, *result.Email)
-	}
-	if result.PhoneNumber != nil {
-		c.Set(contextPhoneNumber, *result.PhoneNumber)
-	}
-	if result.Role != nil {
-		c.Set(contextRole, *result.Role)
-	}
-	if result.EmailVerified != nil {
-		c.Set(contextEmailVerified, *result.EmailVerified)
-	}
-	if result.PhoneVerified != nil {
-		c.Set(contextPhoneVerified, *result.PhoneVerified)
+		Method:        AuthMethodJWT,
 	}
-	if result.LastLoginAt != nil {
-		c.Set(contextLastLoginAt, *result.LastLoginAt)
+	if claims.Act != nil {
+		if claims.Act.UserID == nil {
+			return nil, fmt.Errorf(errMsgInvalidCredentials)
+		}
+		result.ImpersonatorID = claims.Act.UserID
+		result.ImpersonatorUsername = claims.Act.Username
 	}
+	return result, nil
+}
 
-	// Set an authentication method and service name for tracking
-	c.Set(contextAuthMethod, result.Method)
-	if result.ServiceName != nil {
-		c.Set(contextServiceName, *result.ServiceName)
-	}
+func (j JwtAuthentication) SetUserContext(c echo.Context, result *AuthenticationResult) {
+	setUserContext(c, result)
 }
 
 func (j JwtAuthentication) CreateErrorResponse(statusCode int, message string) *echo.HTTPError {
-	return echo.NewHTTPError(statusCode, response.ToErrorResponse(statusCode, message))
+	return createErrorResponse(statusCode, message)
 }
 
 func (j JwtAuthentication) HasRequiredRole(userRole string, requiredRole string) bool {
-	return userRole == requiredRole
+	return hasRequiredRole(userRole, requiredRole)
 }
 
 func (j *JwtAuthentication) extractToken(ec echo.Context) (string, error) {
-	authHeader := ec.Request().Header.Get(authHeaderName)
-	if authHeader == "" {
-		return "", fmt.Errorf(errMsgHeaderMissing)
-	}
-
-	parts := strings.SplitN(authHeader, " ", tokenParts)
-	if len(parts) != tokenParts {
-		return "", fmt.Errorf(errMsgInvalidHeaderFormat)
-	}
-
-	if parts[0] != jwt.TokenTypeBearer && strings.ToLower(parts[0]) != strings.ToLower(jwt.TokenTypeBearer) {
-		return "", fmt.Errorf(errMsgInvalidHeaderFormat)
-	}
-
-	token := strings.TrimSpace(parts[1])
-	if token == "" {
-		return "", fmt.Errorf(errMsgInvalidHeaderFormat)
-	}
-
-	return token, nil
+	return extractBearerToken(ec)
 }
 
 // IsJWTAuthenticated checks if the current request used JWT authentication
 func (j JwtAuthentication) IsJWTAuthenticated(c echo.Context) bool {
 	method, err := j.GetAuthenticationMethod(c)
-	return err == nil && method == authMethodJWT
+	return err == nil && method == AuthMethodJWT
 }
@@ -1,29 +1,167 @@
 package middleware
 
 import (
+	"backend/service-platform/app/database/repository"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/rbac"
+	"net/http"
+	"slices"
 
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 )
 
+// Used when the authentication.methods config is empty
+var defaultAuthMethods = []string{AuthMethodJWT, AuthMethodAPIKey, AuthMethodPAT, AuthMethodServiceToken}
+
 type Middleware struct {
-	JwtAuthentication       JwtAuthentication
-	ApiKeyAuthentication    ApiKeyAuthentication
-	HttpBasicAuthentication HttpBasicAuthentication
+	JwtAuthentication          JwtAuthentication
+	ApiKeyAuthentication       ApiKeyAuthentication
+	HttpBasicAuthentication    HttpBasicAuthentication
+	PatAuthentication          PersonalAccessTokenAuthentication
+	ServiceTokenAuthentication ServiceTokenAuthentication
+	// Configured chain of the handlers above
+	Authentication CompositeAuthentication
+
+	res        runtime.Resource
+	authorizer rbac.Authorizer
 }
 
 func NewMiddleware(res runtime.Resource) *Middleware {
-	return &Middleware{
-		JwtAuthentication:       NewJwtAuthentication(res),
-		ApiKeyAuthentication:    NewApiKeyAuthentication(res),
-		HttpBasicAuthentication: NewHttpBasicAuthentication(res),
+	m := &Middleware{
+		JwtAuthentication:          NewJwtAuthentication(res),
+		ApiKeyAuthentication:       NewApiKeyAuthentication(res),
+		HttpBasicAuthentication:    NewHttpBasicAuthentication(res),
+		PatAuthentication:          NewPersonalAccessTokenAuthentication(res),
+		ServiceTokenAuthentication: NewServiceTokenAuthentication(res),
+		res:                        res,
+		authorizer:                 rbac.NewRedisAuthorizer(res.Redis, repository.NewRoleRepository(res), res.Config.RbacConfig.CacheTTL),
+	}
+
+	available := map[string]Authentication{
+		AuthMethodJWT:          &m.JwtAuthentication,
+		AuthMethodAPIKey:       &m.ApiKeyAuthentication,
+		AuthMethodBasic:        &m.HttpBasicAuthentication,
+		AuthMethodPAT:          &m.PatAuthentication,
+		AuthMethodServiceToken: &m.ServiceTokenAuthentication,
 	}
+	methods := res.Config.AuthenticationConfig.Methods
+	if len(methods) == 0 {
+		methods = defaultAuthMethods
+	}
+	handlers := make([]Authentication, 0, len(methods))
+	for _, method := range methods {
+		h, ok := available[method]
+		if !ok {
+			res.Logger.Warn("Ignoring unknown authentication method", zap.String("method", method))
+			continue
+		}
+		if !slices.Contains(handlers, h) {
+			handlers = append(handlers, h)
+		}
+	}
+	m.Authentication = NewCompositeAuthentication(res, handlers...)
+	return m
+}
+
+// RequireAuth accepts the given methods, or every configured method when none is given
+func (m *Middleware) RequireAuth(methods ...string) echo.MiddlewareFunc {
+	return m.authentication(methods).RequireAuth()
+}
+
+// RequireRole authenticates with the given methods, or every configured method, and checks the role
+func (m *Middleware) RequireRole(requiredRole string, methods ...string) echo.MiddlewareFunc {
+	return m.authentication(methods).RequireRole(requiredRole)
 }
 
-func (m *Middleware) RequireAuth() echo.MiddlewareFunc {
-	return m.JwtAuthentication.RequireAuth()
+// RequireAnyRole must run after RequireAuth, it accepts any of the given roles
+func (m *Middleware) RequireAnyRole(roles ...string) echo.MiddlewareFunc {
+	return requireAnyRole(roles...)
 }
 
-func (m *Middleware) RequireRole(requiredRole string) echo.MiddlewareFunc {
-	return m.JwtAuthentication.RequireRole(requiredRole)
+func (m *Middleware) RequireApiKey() echo.MiddlewareFunc {
+	return m.ApiKeyAuthentication.RequireAuth()
+}
+
+// DenyImpersonation must run after an authentication middleware, it refuses requests made with an impersonation token.
+// Guards endpoints that change credentials or security settings, which only the account owner may do.
+func (m *Middleware) DenyImpersonation() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := CurrentImpersonatorID(c); ok {
+				return createErrorResponse(http.StatusForbidden, errMsgImpersonation)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireScope must run after an authentication middleware, the principal needs every given scope.
+// Only API keys, personal access tokens and service tokens carry scopes, other principals are refused.
+func (m *Middleware) RequireScope(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Get(contextAuthMethod) == nil {
+				return createErrorResponse(http.StatusUnauthorized, errMsgAuthRequired)
+			}
+			granted, _ := c.Get(contextScopes).([]string)
+			for _, scope := range scopes {
+				if !slices.Contains(granted, scope) {
+					return createErrorResponse(http.StatusForbidden, "Access denied: missing scope "+scope)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission must run after an authentication middleware, the principal needs every given permission.
+// Roles hold the permissions of the roles they inherit from. API keys, personal access tokens and service tokens
+// are narrowed to their scopes through RequireScope, a service key or service token only holds its scopes.
+func (m *Middleware) RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	requireScope := m.RequireScope(permissions...)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		scoped := requireScope(next)
+		return func(c echo.Context) error {
+			method, err := authenticationMethod(c)
+			if err != nil {
+				return createErrorResponse(http.StatusUnauthorized, errMsgAuthRequired)
+			}
+			granted, err := m.permissions(c)
+			if err != nil {
+				m.res.Logger.Error("Failed to resolve permissions", zap.Error(err))
+				return createErrorResponse(http.StatusInternalServerError, "Internal server error")
+			}
+			for _, permission := range permissions {
+				if !slices.Contains(granted, permission) {
+					return createErrorResponse(http.StatusForbidden, "Access denied: missing permission "+permission)
+				}
+			}
+			if carriesScopes(method) {
+				return scoped(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (m *Middleware) permissions(c echo.Context) ([]string, error) {
+	userRole, _ := c.Get(contextRole).(string)
+	if userRole == serviceRole {
+		scopes, _ := c.Get(contextScopes).([]string)
+		return scopes, nil
+	}
+	return m.authorizer.Permissions(c.Request().Context(), userRole)
+}
+
+// carriesScopes reports whether the method authenticates a credential limited to scopes, a key without scopes grants nothing
+func carriesScopes(method string) bool {
+	return method == AuthMethodAPIKey || method == AuthMethodPAT || method == AuthMethodServiceToken
+}
+
+func (m *Middleware) authentication(methods []string) CompositeAuthentication {
+	if len(methods) == 0 {
+		return m.Authentication
+	}
+	return m.Authentication.Only(methods...)
 }
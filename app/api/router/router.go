@@ -7,9 +7,11 @@ import (
 
 	"backend/service-platform/app/api/controller"
 	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/database/constant/role"
 	"backend/service-platform/app/database/repository"
 	"backend/service-platform/app/internal/runtime"
 	"backend/service-platform/app/internal/validator"
+	"backend/service-platform/app/pkg/rbac"
 	ctxutil "backend/service-platform/app/pkg/util/context"
 	echoUtil "backend/service-platform/app/pkg/util/echo"
 	_ "backend/service-platform/docs"
@@ -20,9 +22,12 @@ const (
 	apiV1BasePath = "/api/v1"
 	swaggerPath   = "/swagger/*"
 	healthPath    = "/health"
+	jwksPath      = "/.well-known/jwks.json"
 
 	// Route prefixes
-	authPrefix = "/auth"
+	authPrefix  = "/auth"
+	adminPrefix = "/admin"
+	oauthPrefix = "/oauth"
 )
 
 type Router struct {
@@ -66,6 +71,8 @@ func NewRouter(
 	r.setupMiddlewares()
 	r.setupSwagger()
 	r.setupHealthRoutes()
+	r.setupWellKnownRoutes()
+	r.setupOAuthRoutes()
 	r.setupRoutes()
 
 	return r
@@ -79,6 +86,7 @@ func (r *Router) setupEcho() {
 
 func (r *Router) setupMiddlewares() {
 	r.Echo.Use(echoMiddleware.RequestID())
+	r.Echo.Use(echoUtil.SetupClientInfoMiddleware())
 	r.Echo.Use(echoUtil.SetupCORSMiddleware(r.res))
 	r.Echo.Use(echoUtil.SetupLoggerMiddleware(r.res))
 }
@@ -95,10 +103,22 @@ func (r *Router) setupHealthRoutes() {
 	r.Echo.GET(healthPath, r.controllers.HealthController.HealthCheck)
 }
 
+func (r *Router) setupWellKnownRoutes() {
+	r.Echo.GET(jwksPath, r.controllers.WellKnownController.Jwks)
+}
+
+// setupOAuthRoutes serves the OAuth2 endpoints of internal services, clients authenticate with their credentials
+func (r *Router) setupOAuthRoutes() {
+	oauthGroup := r.Echo.Group(oauthPrefix)
+	oauthGroup.POST("/token", r.controllers.OAuthController.Token)
+	oauthGroup.POST("/introspect", r.controllers.OAuthController.IntrospectToken)
+}
+
 func (r *Router) setupRoutes() {
 	apiGroup := r.Echo.Group(apiV1BasePath)
 
 	r.setupAuthRoutes(apiGroup)
+	r.setupAdminRoutes(apiGroup)
 }
 
 func (r *Router) setupAuthRoutes(apiGroup *echo.Group) {
@@ -107,5 +127,92 @@ func (r *Router) setupAuthRoutes(apiGroup *echo.Group) {
 	authGroup.POST("/login", r.controllers.AuthController.Login)
 	authGroup.POST("/logout", r.controllers.AuthController.Logout)
 	authGroup.POST("/refresh-token", r.controllers.AuthController.RefreshToken)
-	authGroup.GET("/me", r.controllers.AuthController.Me, r.middleware.RequireAuth())
+	authGroup.POST("/verify-email", r.controllers.AuthController.VerifyEmail)
+	authGroup.POST("/resend-verification", r.controllers.AuthController.ResendVerification)
+	authGroup.POST("/password/forgot", r.controllers.AuthController.ForgotPassword)
+	authGroup.POST("/password/reset", r.controllers.AuthController.ResetPassword)
+	authGroup.POST("/mfa/verify", r.controllers.AuthController.MfaVerify)
+	authGroup.POST("/mfa/enroll", r.controllers.AuthController.MfaEnroll)
+	authGroup.POST("/mfa/confirm", r.controllers.AuthController.MfaConfirm)
+	authGroup.POST("/mfa/disable", r.controllers.AuthController.MfaDisable, r.middleware.RequireAuth(middleware.AuthMethodJWT), r.middleware.DenyImpersonation())
+	authGroup.GET("/me", r.controllers.AuthController.Me, r.middleware.RequireAuth(middleware.AuthMethodJWT))
+	authGroup.POST("/phone/send-otp", r.controllers.AuthController.SendPhoneOtp, r.middleware.RequireAuth(middleware.AuthMethodJWT), r.middleware.DenyImpersonation())
+	authGroup.POST("/phone/verify", r.controllers.AuthController.VerifyPhoneOtp, r.middleware.RequireAuth(middleware.AuthMethodJWT), r.middleware.DenyImpersonation())
+	authGroup.GET("/oidc/:provider/start", r.controllers.AuthController.OidcStart)
+	authGroup.GET("/oidc/:provider/callback", r.controllers.AuthController.OidcCallback)
+	authGroup.GET("/siwe/nonce", r.controllers.AuthController.SiweNonce)
+	authGroup.POST("/siwe/verify", r.controllers.AuthController.SiweLogin)
+	authGroup.POST("/reactivate", r.controllers.AuthController.ReactivateAccount)
+	// Guarded by the bootstrap token and rate limited per IP, unavailable once an admin exists
+	authGroup.POST("/bootstrap", r.controllers.SuperAdminController.Bootstrap)
+
+	// Credentials, account lifecycle and personal data stay with the account owner, never an impersonating admin
+	profileGroup := authGroup.Group("/me", r.middleware.RequireAuth(middleware.AuthMethodJWT), r.middleware.DenyImpersonation())
+	profileGroup.PUT("/password", r.controllers.ProfileController.ChangePassword)
+	profileGroup.PUT("/email", r.controllers.ProfileController.ChangeEmail)
+	profileGroup.PUT("/username", r.controllers.ProfileController.ChangeUsername)
+	profileGroup.PUT("/phone", r.controllers.ProfileController.SetPhoneNumber)
+	profileGroup.DELETE("/phone", r.controllers.ProfileController.RemovePhoneNumber)
+	profileGroup.POST("/deactivate", r.controllers.ProfileController.DeactivateAccount)
+	profileGroup.DELETE("", r.controllers.ProfileController.DeleteAccount)
+	profileGroup.POST("/export", r.controllers.ProfileController.RequestDataExport)
+	profileGroup.GET("/export/:id", r.controllers.ProfileController.DownloadDataExport)
+	// Personal access tokens are managed with a session, a token cannot mint further tokens
+	profileGroup.GET("/tokens", r.controllers.PersonalAccessTokenController.ListPersonalAccessTokens)
+	profileGroup.POST("/tokens", r.controllers.PersonalAccessTokenController.CreatePersonalAccessToken)
+	profileGroup.DELETE("/tokens/:id", r.controllers.PersonalAccessTokenController.RevokePersonalAccessToken)
+
+	sessionGroup := authGroup.Group("/sessions", r.middleware.RequireAuth(middleware.AuthMethodJWT))
+	sessionGroup.GET("", r.controllers.SessionController.ListSessions)
+	sessionGroup.POST("/revoke-others", r.controllers.SessionController.RevokeOtherSessions, r.middleware.DenyImpersonation())
+	sessionGroup.DELETE("/:id", r.controllers.SessionController.RevokeSession, r.middleware.DenyImpersonation())
+}
+
+func (r *Router) setupAdminRoutes(apiGroup *echo.Group) {
+	// Every route requires its own permission, custom roles holding it get access without being an admin.
+	// Admins can also automate these endpoints with an API key or a personal access token they own
+	adminGroup := apiGroup.Group(adminPrefix,
+		r.middleware.RequireAuth(middleware.AuthMethodJWT, middleware.AuthMethodAPIKey, middleware.AuthMethodPAT),
+		r.middleware.DenyImpersonation(),
+	)
+	adminGroup.GET("/users", r.controllers.UserController.ListUsers, r.middleware.RequirePermission(rbac.UsersRead))
+	adminGroup.GET("/users/:id", r.controllers.UserController.GetUser, r.middleware.RequirePermission(rbac.UsersRead))
+	adminGroup.PUT("/users/:id/role", r.controllers.UserController.UpdateUserRole, r.middleware.RequirePermission(rbac.UsersAssignRole))
+	adminGroup.POST("/users/:id/suspend", r.controllers.UserController.SuspendUser, r.middleware.RequirePermission(rbac.UsersSuspend))
+	adminGroup.POST("/users/:id/unsuspend", r.controllers.UserController.UnsuspendUser, r.middleware.RequirePermission(rbac.UsersSuspend))
+	adminGroup.POST("/users/:id/restore", r.controllers.UserController.RestoreUser, r.middleware.RequirePermission(rbac.UsersSuspend))
+	adminGroup.POST("/users/:id/logout", r.controllers.UserController.ForceLogout, r.middleware.RequirePermission(rbac.SessionsRevoke))
+	adminGroup.GET("/users/:id/sessions", r.controllers.SessionController.AdminListSessions, r.middleware.RequirePermission(rbac.UsersRead))
+	adminGroup.POST("/users/:id/sessions/revoke-all", r.controllers.SessionController.AdminRevokeAllSessions, r.middleware.RequirePermission(rbac.SessionsRevoke))
+	adminGroup.DELETE("/users/:id/sessions/:sessionId", r.controllers.SessionController.AdminRevokeSession, r.middleware.RequirePermission(rbac.SessionsRevoke))
+	adminGroup.POST("/users/:id/unlock", r.controllers.AuthController.AdminUnlockAccount, r.middleware.RequirePermission(rbac.UsersUnlock))
+	adminGroup.POST("/users/:id/impersonate", r.controllers.UserController.ImpersonateUser,
+		r.middleware.RequireAnyRole(string(role.SuperAdmin)),
+		r.middleware.RequirePermission(rbac.UsersImpersonate),
+	)
+
+	apiKeyGroup := adminGroup.Group("/api-keys", r.middleware.RequirePermission(rbac.ApiKeysManage))
+	apiKeyGroup.POST("", r.controllers.ApiKeyController.CreateApiKey)
+	apiKeyGroup.GET("", r.controllers.ApiKeyController.ListApiKeys)
+	apiKeyGroup.GET("/:id", r.controllers.ApiKeyController.GetApiKey)
+	apiKeyGroup.PATCH("/:id", r.controllers.ApiKeyController.UpdateApiKey)
+	apiKeyGroup.DELETE("/:id", r.controllers.ApiKeyController.RevokeApiKey)
+	apiKeyGroup.POST("/:id/rotate", r.controllers.ApiKeyController.RotateApiKey)
+
+	adminGroup.GET("/permissions", r.controllers.RoleController.ListPermissions, r.middleware.RequirePermission(rbac.RolesManage))
+	roleGroup := adminGroup.Group("/roles", r.middleware.RequirePermission(rbac.RolesManage))
+	roleGroup.GET("", r.controllers.RoleController.ListRoles)
+	roleGroup.POST("", r.controllers.RoleController.CreateRole)
+	roleGroup.GET("/:name", r.controllers.RoleController.GetRole)
+	roleGroup.PATCH("/:name", r.controllers.RoleController.UpdateRole)
+	roleGroup.DELETE("/:name", r.controllers.RoleController.DeleteRole)
+
+	serviceClientGroup := adminGroup.Group("/service-clients", r.middleware.RequirePermission(rbac.ServiceClientsManage))
+	serviceClientGroup.POST("", r.controllers.OAuthController.CreateServiceClient)
+	serviceClientGroup.GET("", r.controllers.OAuthController.ListServiceClients)
+	serviceClientGroup.DELETE("/:id", r.controllers.OAuthController.RevokeServiceClient)
+
+	auditGroup := adminGroup.Group("/audit-events", r.middleware.RequirePermission(rbac.AuditRead))
+	auditGroup.GET("", r.controllers.AuditController.ListAuditEvents)
+	auditGroup.GET("/verify", r.controllers.AuditController.VerifyAuditChain)
 }
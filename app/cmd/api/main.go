@@ -3,6 +3,7 @@ package main
 import (
 	"backend/service-platform/app/pkg/aws"
 	"context"
+	"os"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
@@ -10,9 +11,11 @@ import (
 	"go.uber.org/zap"
 
 	server "backend/service-platform/app/api"
+	"backend/service-platform/app/internal/command"
 	"backend/service-platform/app/internal/config"
 	"backend/service-platform/app/internal/runtime"
 	"backend/service-platform/app/pkg/db"
+	"backend/service-platform/app/pkg/jwt"
 	"backend/service-platform/app/pkg/logging"
 	"backend/service-platform/app/pkg/redis"
 	ctxutil "backend/service-platform/app/pkg/util/context"
@@ -37,6 +40,11 @@ func main() {
 	redisClient := setupRedis(cfg, logger)
 	defer closeRedis(redisClient, logger)
 
+	if len(os.Args) > 1 && os.Args[1] == command.CreateAdmin {
+		runCreateAdmin(ctx, cfg, logger, database, redisClient)
+		return
+	}
+
 	externalClients := setupExternalClients(ctx, cfg, logger)
 
 	httpServer := createServer(cfg, logger, database, redisClient, externalClients)
@@ -58,6 +66,10 @@ func loadConfiguration(env ctxutil.AppMode, logger *zap.Logger) config.Applicati
 	if err != nil {
 		panic(err)
 	}
+	// Fail fast on unreadable signing keys instead of rejecting every token at runtime
+	if _, err := jwt.NewKeyring(cfg.JwtConfig); err != nil {
+		panic(err)
+	}
 	return cfg
 }
 
@@ -112,6 +124,18 @@ func setupExternalClients(ctx context.Context, cfg config.ApplicationConfig, log
 	}
 }
 
+func runCreateAdmin(ctx context.Context, cfg config.ApplicationConfig, logger *zap.Logger, database *db.DB, redisClient redis.Redis) {
+	res := runtime.Resource{
+		Config: cfg,
+		Logger: logger,
+		DB:     database,
+		Redis:  redisClient,
+	}
+	if err := command.RunCreateAdmin(ctx, res, os.Args[2:]); err != nil {
+		logger.Fatal("create-admin failed", zap.Error(err))
+	}
+}
+
 func createServer(cfg config.ApplicationConfig, logger *zap.Logger, database *db.DB, redisClient redis.Redis, clients ExternalClients) server.Server {
 	return server.Server{
 		Config:     cfg,
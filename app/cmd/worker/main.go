@@ -1,6 +1,7 @@
 package main
 
 import (
+	"backend/service-platform/app/internal/command"
 	"backend/service-platform/app/internal/config"
 	"backend/service-platform/app/internal/runtime"
 	"backend/service-platform/app/pkg/db"
@@ -10,6 +11,7 @@ import (
 	httpClientUtil "backend/service-platform/app/pkg/util/httpclient"
 	server "backend/service-platform/app/worker"
 	"context"
+	"os"
 	"time"
 
 	"go.uber.org/zap"
@@ -62,6 +64,15 @@ func main() {
 		}
 	}(rds)
 
+	// One-off command instead of the worker
+	if len(os.Args) > 1 && os.Args[1] == command.CreateAdmin {
+		res := runtime.Resource{Config: cfg, Logger: logger, DB: database, Redis: rds}
+		if err := command.RunCreateAdmin(ctx, res, os.Args[2:]); err != nil {
+			logger.Fatal("create-admin failed", zap.Error(err))
+		}
+		return
+	}
+
 	// Configure HttpClient
 	httpClient := httpClientUtil.NewRestyClient(30*time.Second, logger)
 
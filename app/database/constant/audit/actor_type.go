@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// ActorType represents who performed an audited action
+type ActorType string
+
+const (
+	// User indicates an authenticated user or a user signing in
+	User ActorType = "USER"
+	// Service indicates a service authenticated with an API key
+	Service ActorType = "SERVICE"
+	// System indicates a process acting on its own, such as a CLI command
+	System ActorType = "SYSTEM"
+	// Anonymous indicates an unauthenticated request, such as a failed login for an unknown email
+	Anonymous ActorType = "ANONYMOUS"
+)
+
+// Scan implements the sql.Scanner interface for database scanning
+func (t *ActorType) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan ActorType from %T", value)
+	}
+	*t = ActorType(str)
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (t ActorType) Value() (driver.Value, error) {
+	return string(t), nil
+}
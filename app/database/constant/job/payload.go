@@ -0,0 +1,8 @@
+package job
+
+// Payload keys shared by the job producers and the worker handlers
+const (
+	PayloadUserID = "user_id"
+	// Address to verify when it is not the current email, such as a requested email change
+	PayloadEmail = "email"
+)
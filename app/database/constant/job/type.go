@@ -10,6 +10,12 @@ const (
 	InitClaim       Type = "init_claim"
 	CompleteClaim   Type = "complete_claim"
 	KYCVerification Type = "kyc_verification"
+
+	SendVerificationEmail  Type = "send_verification_email"
+	SendPasswordResetEmail Type = "send_password_reset_email"
+
+	PurgeAccount   Type = "purge_account"
+	ExportUserData Type = "export_user_data"
 )
 
 func (s *Type) Scan(value interface{}) error {
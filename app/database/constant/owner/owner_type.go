@@ -0,0 +1,31 @@
+package owner
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Type represents who a credential such as an API key belongs to
+type Type string
+
+const (
+	// Service indicates a credential used by another service
+	Service Type = "SERVICE"
+	// User indicates a credential acting on behalf of a user
+	User Type = "USER"
+)
+
+// Scan implements the sql.Scanner interface for database scanning
+func (t *Type) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan OwnerType from %T", value)
+	}
+	*t = Type(str)
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (t Type) Value() (driver.Value, error) {
+	return string(t), nil
+}
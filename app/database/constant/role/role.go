@@ -19,6 +19,28 @@ const (
 	SuperAdmin Role = "SUPER_ADMIN"
 )
 
+// Built-in roles by rank, a role includes every role ranked below it
+var rank = map[Role]int{
+	User:       1,
+	Operator:   2,
+	Admin:      3,
+	SuperAdmin: 4,
+}
+
+// Includes reports whether r grants at least the access of other: SUPER_ADMIN > ADMIN > OPERATOR > USER.
+// Roles outside the built-in hierarchy only include themselves.
+func (r Role) Includes(other Role) bool {
+	if r == "" || other == "" {
+		return false
+	}
+	if r == other {
+		return true
+	}
+	rr, ok := rank[r]
+	or, otherOk := rank[other]
+	return ok && otherOk && rr > or
+}
+
 // Scan implements the sql.Scanner interface for database scanning
 func (r *Role) Scan(value interface{}) error {
 	str, ok := value.(string)
@@ -15,6 +15,8 @@ const (
 	Disabled Status = "DISABLED"
 	// Unverified indicates the user account has not been verified yet
 	Unverified Status = "UNVERIFIED"
+	// Purged indicates the user account was deleted and its personal data erased
+	Purged Status = "PURGED"
 )
 
 // Scan implements the sql.Scanner interface for database scanning
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"backend/service-platform/app/database/constant/owner"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type ApiKey struct {
+	bun.BaseModel `bun:"table:api_keys,alias:ak"`
+
+	ID            uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	Name          string     `bun:"name,notnull"`
+	Prefix        string     `bun:"prefix,notnull,unique"`
+	SecretHash    string     `bun:"secret_hash,notnull"`
+	OwnerType     owner.Type `bun:"owner_type,notnull"`
+	ServiceName   *string    `bun:"service_name"`
+	UserID        *uuid.UUID `bun:"user_id,type:uuid"`
+	Scopes        []string   `bun:"scopes,array"`
+	ExpiresAt     *time.Time `bun:"expires_at"`
+	LastUsedAt    *time.Time `bun:"last_used_at"`
+	Revoked       bool       `bun:"revoked,notnull,default:false"`
+	RevokedAt     *time.Time `bun:"revoked_at"`
+	RotatedFromID *uuid.UUID `bun:"rotated_from_id,type:uuid"`
+	RotatedToID   *uuid.UUID `bun:"rotated_to_id,type:uuid"`
+	CreatedBy     *uuid.UUID `bun:"created_by,type:uuid"`
+	CreatedAt     time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt     *time.Time `bun:"updated_at"`
+	DeletedAt     *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (k ApiKey) Alias() string {
+	return "ak"
+}
+
+// IsActive reports whether the key can authenticate at the given time
+func (k ApiKey) IsActive(now time.Time) bool {
+	return !k.Revoked && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+func (k ApiKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"backend/service-platform/app/database/constant/audit"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type AuditMetadata map[string]interface{}
+
+func (m AuditMetadata) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal AuditMetadata: %w", err)
+	}
+	return string(data), nil
+}
+
+func (m *AuditMetadata) Scan(value interface{}) error {
+	if value == nil {
+		*m = make(AuditMetadata)
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into AuditMetadata", value)
+	}
+
+	if len(bytes) == 0 {
+		*m = make(AuditMetadata)
+		return nil
+	}
+
+	return json.Unmarshal(bytes, m)
+}
+
+// AuditEvent is an entry of the append-only security audit log, see pkg/audit for the hash chain
+type AuditEvent struct {
+	bun.BaseModel `bun:"table:audit_events,alias:ae"`
+
+	ID           uuid.UUID       `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	Seq          int64           `bun:"seq,autoincrement"`
+	Action       string          `bun:"action,notnull"`
+	ActorType    audit.ActorType `bun:"actor_type,notnull"`
+	ActorID      *uuid.UUID      `bun:"actor_id,type:uuid"`
+	ActorName    *string         `bun:"actor_name"`
+	SubjectID    *uuid.UUID      `bun:"subject_id,type:uuid"`
+	IPAddress    *string         `bun:"ip_address"`
+	UserAgent    *string         `bun:"user_agent"`
+	RequestID    *string         `bun:"request_id"`
+	Metadata     AuditMetadata   `bun:"metadata,type:jsonb"`
+	PreviousHash string          `bun:"previous_hash,notnull"`
+	Hash         string          `bun:"hash,notnull"`
+	CreatedAt    time.Time       `bun:"created_at,notnull"`
+}
+
+func (e AuditEvent) Alias() string {
+	return "ae"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type MfaRecoveryCode struct {
+	bun.BaseModel `bun:"table:mfa_recovery_codes,alias:mrc"`
+
+	ID        uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `bun:"user_id,notnull"`
+	Code      string     `bun:"code,notnull"`
+	UsedAt    *time.Time `bun:"used_at"`
+	CreatedAt time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt *time.Time `bun:"updated_at"`
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (c MfaRecoveryCode) Alias() string {
+	return "mrc"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type PasswordResetToken struct {
+	bun.BaseModel `bun:"table:password_reset_tokens,alias:prt"`
+
+	ID        uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `bun:"user_id,notnull"`
+	Token     string     `bun:"token,notnull,unique"`
+	ExpiresAt time.Time  `bun:"expires_at,notnull"`
+	UsedAt    *time.Time `bun:"used_at"`
+	CreatedAt time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt *time.Time `bun:"updated_at"`
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (t PasswordResetToken) Alias() string {
+	return "prt"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Permission struct {
+	bun.BaseModel `bun:"table:permissions,alias:pe"`
+
+	ID          uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	Name        string     `bun:"name,notnull,unique"`
+	Description *string    `bun:"description"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   *time.Time `bun:"updated_at"`
+	DeletedAt   *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (p Permission) Alias() string {
+	return "pe"
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type PersonalAccessToken struct {
+	bun.BaseModel `bun:"table:personal_access_tokens,alias:pat"`
+
+	ID         uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	UserID     uuid.UUID  `bun:"user_id,notnull,type:uuid"`
+	Name       string     `bun:"name,notnull"`
+	Prefix     string     `bun:"prefix,notnull,unique"`
+	SecretHash string     `bun:"secret_hash,notnull"`
+	Scopes     []string   `bun:"scopes,array"`
+	ExpiresAt  *time.Time `bun:"expires_at"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+	RevokedAt  *time.Time `bun:"revoked_at"`
+	CreatedAt  time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt  *time.Time `bun:"updated_at"`
+	DeletedAt  *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (t PersonalAccessToken) Alias() string {
+	return "pat"
+}
+
+// IsActive reports whether the token can authenticate at the given time
+func (t PersonalAccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Role struct {
+	bun.BaseModel `bun:"table:roles,alias:ro"`
+
+	ID          uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	Name        string     `bun:"name,notnull,unique"`
+	Description *string    `bun:"description"`
+	Parent      *string    `bun:"parent"`
+	Permissions []string   `bun:"permissions,array"`
+	BuiltIn     bool       `bun:"built_in,notnull,default:false"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   *time.Time `bun:"updated_at"`
+	DeletedAt   *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (r Role) Alias() string {
+	return "ro"
+}
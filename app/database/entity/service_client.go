@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ServiceClient is an OAuth2 client using the client credentials grant
+type ServiceClient struct {
+	bun.BaseModel `bun:"table:service_clients,alias:sc"`
+
+	ID         uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	ClientID   string     `bun:"client_id,notnull,unique"`
+	Name       string     `bun:"name,notnull"`
+	SecretHash string     `bun:"secret_hash,notnull"`
+	Scopes     []string   `bun:"scopes,array"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+	RevokedAt  *time.Time `bun:"revoked_at"`
+	CreatedBy  *uuid.UUID `bun:"created_by,type:uuid"`
+	CreatedAt  time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt  *time.Time `bun:"updated_at"`
+	DeletedAt  *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (c ServiceClient) Alias() string {
+	return "sc"
+}
+
+// IsActive reports whether the client can still obtain and use tokens
+func (c ServiceClient) IsActive() bool {
+	return c.RevokedAt == nil
+}
+
+func (c ServiceClient) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
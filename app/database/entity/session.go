@@ -17,6 +17,9 @@ type Session struct {
 	IPAddress *string    `bun:"ip_address"`
 	Revoked   bool       `bun:"revoked,notnull,default:false"`
 	ExpiresAt *time.Time `bun:"expires_at"`
+	ParentID  *uuid.UUID `bun:"parent_id,type:uuid"`
+	FamilyID  *uuid.UUID `bun:"family_id,type:uuid"`
+	RotatedAt *time.Time `bun:"rotated_at"`
 	CreatedAt time.Time  `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt *time.Time `bun:"updated_at"`
 	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
@@ -25,3 +28,11 @@ type Session struct {
 func (s Session) Alias() string {
 	return "s"
 }
+
+// Family returns the ID shared by every session of the rotation chain
+func (s Session) Family() uuid.UUID {
+	if s.FamilyID != nil {
+		return *s.FamilyID
+	}
+	return s.ID
+}
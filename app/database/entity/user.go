@@ -15,6 +15,7 @@ type User struct {
 	ID            uuid.UUID   `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
 	Username      string      `bun:"username,notnull,unique"`
 	Email         *string     `bun:"email,unique"`
+	PendingEmail  *string     `bun:"pending_email"`
 	PhoneNumber   *string     `bun:"phone_number,unique"`
 	Password      string      `bun:"password,notnull"`
 	Status        user.Status `bun:"status,notnull,default:'UNVERIFIED'"`
@@ -26,6 +27,26 @@ type User struct {
 	UpdatedAt     *time.Time  `bun:"updated_at"`
 	DeletedAt     *time.Time  `bun:"deleted_at,soft_delete"`
 	DeactivatedAt *time.Time  `bun:"deactivated_at"`
+	MfaEnabled    bool        `bun:"mfa_enabled,notnull,default:false"`
+	MfaSecret     *string     `bun:"mfa_secret"`
+	MfaEnabledAt  *time.Time  `bun:"mfa_enabled_at"`
+
+	FailedLoginAttempts int        `bun:"failed_login_attempts,notnull,default:0"`
+	LockoutCount        int        `bun:"lockout_count,notnull,default:0"`
+	LockedUntil         *time.Time `bun:"locked_until"`
+
+	// Set while a requested deletion waits for its grace period, the account is purged afterwards
+	DeletionScheduledAt *time.Time `bun:"deletion_scheduled_at"`
+	// Set once the personal data of a deleted account is erased, such an account cannot be restored
+	PurgedAt *time.Time `bun:"purged_at"`
+
+	// Raised on role and status changes, tokens issued with an older version are refused
+	TokenVersion int64 `bun:"token_version,notnull,default:0"`
+}
+
+// IsLocked reports whether login is refused at the given time
+func (u User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 func (u User) Alias() string {
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// UserIdentity links a user to the subject of an external OpenID Connect provider
+type UserIdentity struct {
+	bun.BaseModel `bun:"table:user_identities,alias:ui"`
+
+	ID          uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	UserID      uuid.UUID  `bun:"user_id,notnull"`
+	Provider    string     `bun:"provider,notnull"`
+	Subject     string     `bun:"subject,notnull"`
+	Email       *string    `bun:"email"`
+	LastLoginAt *time.Time `bun:"last_login_at"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   *time.Time `bun:"updated_at"`
+	DeletedAt   *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (i UserIdentity) Alias() string {
+	return "ui"
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// UserWallet links a user to an Ethereum address proven with Sign-In With Ethereum
+type UserWallet struct {
+	bun.BaseModel `bun:"table:user_wallets,alias:uw"`
+
+	ID          uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
+	UserID      uuid.UUID  `bun:"user_id,notnull"`
+	Address     string     `bun:"address,notnull"`
+	ChainID     int64      `bun:"chain_id,notnull"`
+	LastLoginAt *time.Time `bun:"last_login_at"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   *time.Time `bun:"updated_at"`
+	DeletedAt   *time.Time `bun:"deleted_at,soft_delete"`
+}
+
+func (w UserWallet) Alias() string {
+	return "uw"
+}
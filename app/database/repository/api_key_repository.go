@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/constant/owner"
+	"backend/service-platform/app/database/entity"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
+	"backend/service-platform/app/internal/runtime"
+	pagingUtil "backend/service-platform/app/pkg/util/paging"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ApiKeyFilter selects API keys, nil fields are ignored
+type ApiKeyFilter struct {
+	OwnerType   *owner.Type `mapstructure:"owner_type,omitempty"`
+	ServiceName *string     `mapstructure:"service_name,omitempty"`
+	UserID      *uuid.UUID  `mapstructure:"user_id,omitempty"`
+	Revoked     *bool       `mapstructure:"revoked,omitempty"`
+}
+
+type ApiKeyRepository interface {
+	Insert(ctx context.Context, key *entity.ApiKey) (*entity.ApiKey, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*entity.ApiKey, error)
+	FindMany(ctx context.Context, filter ApiKeyFilter, page pagingUtil.Page) ([]entity.ApiKey, int, error)
+	Update(ctx context.Context, key entity.ApiKey) (*entity.ApiKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error)
+	RevokeByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	Rotate(ctx context.Context, id uuid.UUID, overlapUntil time.Time, next *entity.ApiKey) (*entity.ApiKey, error)
+	UpdateLastUsedAt(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type DefaultApiKeyRepository struct {
+	res runtime.Resource
+}
+
+func NewApiKeyRepository(res runtime.Resource) ApiKeyRepository {
+	return &DefaultApiKeyRepository{res: res}
+}
+
+func (r DefaultApiKeyRepository) Insert(ctx context.Context, key *entity.ApiKey) (*entity.ApiKey, error) {
+	err := r.res.DB.
+		NewInsert().
+		Model(key).
+		Returning("*").
+		Scan(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r DefaultApiKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error) {
+	k := new(entity.ApiKey)
+	err := r.res.DB.
+		NewSelect().
+		Model(k).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// FindByPrefix reads from the primary so a key works right after it is created
+func (r DefaultApiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.ApiKey, error) {
+	k := new(entity.ApiKey)
+	err := r.res.DB.
+		NewSelect().
+		Model(k).
+		Where("prefix = ?", prefix).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (r DefaultApiKeyRepository) FindMany(ctx context.Context, filter ApiKeyFilter, page pagingUtil.Page) ([]entity.ApiKey, int, error) {
+	return queryutil.FindManyEntityWithCount[entity.ApiKey](ctx, r.res.DB, filter, nil, page)
+}
+
+// Update changes the name, scopes and expiry of a key that is not revoked
+func (r DefaultApiKeyRepository) Update(ctx context.Context, key entity.ApiKey) (*entity.ApiKey, error) {
+	var k entity.ApiKey
+	err := r.res.DB.
+		NewUpdate().
+		Model(&key).
+		Column("name", "scopes", "expires_at").
+		WherePK().
+		Where("revoked = ?", false).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &k)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r DefaultApiKeyRepository) Revoke(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error) {
+	var k entity.ApiKey
+	err := r.res.DB.
+		NewUpdate().
+		Model(&k).
+		Set("revoked = ?", true).
+		Set("revoked_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("revoked = ?", false).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &k)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// RevokeByUserID revokes every active key the user owns and returns how many were revoked
+func (r DefaultApiKeyRepository) RevokeByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	res, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.ApiKey)(nil)).
+		Set("revoked = ?", true).
+		Set("revoked_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("revoked = ?", false).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// Rotate inserts the replacement key and makes the old one expire at overlapUntil, or earlier if it already expires before.
+// A key that was revoked or already rotated is left alone and sql.ErrNoRows is returned.
+func (r DefaultApiKeyRepository) Rotate(ctx context.Context, id uuid.UUID, overlapUntil time.Time, next *entity.ApiKey) (*entity.ApiKey, error) {
+	err := r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// The id is known up front so the rotated key can point to its replacement
+		next.ID = uuid.New()
+		var current entity.ApiKey
+		err := tx.NewUpdate().
+			Model(&current).
+			Set("expires_at = LEAST(COALESCE(expires_at, ?), ?)", overlapUntil, overlapUntil).
+			Set("rotated_to_id = ?", next.ID).
+			Where("id = ?", id).
+			Where("revoked = ?", false).
+			Where("rotated_to_id IS NULL").
+			Where("deleted_at IS NULL").
+			Returning("*").
+			Scan(ctx, &current)
+		if err != nil {
+			return err
+		}
+
+		next.RotatedFromID = &current.ID
+		return tx.NewInsert().
+			Model(next).
+			Returning("*").
+			Scan(ctx, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+func (r DefaultApiKeyRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.ApiKey)(nil)).
+		Set("last_used_at = ?", usedAt).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Key of the transaction-level advisory lock serializing writers of the audit hash chain
+const auditChainLockID = 7_410_021
+
+// AuditEventFilter selects audit events, nil fields are ignored
+type AuditEventFilter struct {
+	Action    *string
+	ActorID   *uuid.UUID
+	SubjectID *uuid.UUID
+	RequestID *string
+	From      *time.Time
+	Before    *time.Time
+	// Only events older than this position, newest first
+	BeforeSeq *int64
+}
+
+type AuditEventRepository interface {
+	// Append inserts the event after the newest one; seal receives the hash of that event and completes the chain fields
+	Append(ctx context.Context, event *entity.AuditEvent, seal func(previousHash string)) error
+	// FindMany returns matching events newest first
+	FindMany(ctx context.Context, filter AuditEventFilter, limit int) ([]entity.AuditEvent, error)
+	// FindAfter returns events following the given position in chain order
+	FindAfter(ctx context.Context, afterSeq int64, limit int) ([]entity.AuditEvent, error)
+	// FindByUser returns events the user performed or was the subject of, newest first
+	FindByUser(ctx context.Context, userID uuid.UUID, limit int) ([]entity.AuditEvent, error)
+}
+
+type DefaultAuditEventRepository struct {
+	res runtime.Resource
+}
+
+func NewAuditEventRepository(res runtime.Resource) AuditEventRepository {
+	return &DefaultAuditEventRepository{res: res}
+}
+
+func (r DefaultAuditEventRepository) Append(ctx context.Context, event *entity.AuditEvent, seal func(previousHash string)) error {
+	return r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewRaw("SELECT pg_advisory_xact_lock(?)", auditChainLockID).Exec(ctx); err != nil {
+			return err
+		}
+
+		var previousHash string
+		err := tx.NewSelect().
+			Model((*entity.AuditEvent)(nil)).
+			Column("hash").
+			OrderExpr("seq DESC").
+			Limit(1).
+			Scan(ctx, &previousHash)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		seal(previousHash)
+		return tx.NewInsert().Model(event).Returning("*").Scan(ctx, event)
+	})
+}
+
+func (r DefaultAuditEventRepository) FindMany(ctx context.Context, filter AuditEventFilter, limit int) ([]entity.AuditEvent, error) {
+	var events []entity.AuditEvent
+	q := r.res.DB.
+		ReplicaNewSelect().
+		Model(&events)
+	if filter.Action != nil {
+		q = q.Where("action = ?", *filter.Action)
+	}
+	if filter.ActorID != nil {
+		q = q.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.SubjectID != nil {
+		q = q.Where("subject_id = ?", *filter.SubjectID)
+	}
+	if filter.RequestID != nil {
+		q = q.Where("request_id = ?", *filter.RequestID)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.Before != nil {
+		q = q.Where("created_at < ?", *filter.Before)
+	}
+	if filter.BeforeSeq != nil {
+		q = q.Where("seq < ?", *filter.BeforeSeq)
+	}
+	err := q.OrderExpr("seq DESC").Limit(limit).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindAfter reads from the primary so a verification sees the chain up to the latest event
+func (r DefaultAuditEventRepository) FindAfter(ctx context.Context, afterSeq int64, limit int) ([]entity.AuditEvent, error) {
+	var events []entity.AuditEvent
+	err := r.res.DB.
+		NewSelect().
+		Model(&events).
+		Where("seq > ?", afterSeq).
+		OrderExpr("seq ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r DefaultAuditEventRepository) FindByUser(ctx context.Context, userID uuid.UUID, limit int) ([]entity.AuditEvent, error) {
+	var events []entity.AuditEvent
+	err := r.res.DB.
+		ReplicaNewSelect().
+		Model(&events).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("actor_id = ?", userID).WhereOr("subject_id = ?", userID)
+		}).
+		OrderExpr("seq DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -24,6 +24,7 @@ type JobRepository interface {
 	UpdateJobToRetrying(ctx context.Context, id string, errorMsg string) error
 	GetPendingJobs(ctx context.Context, limit int) ([]*entity.Job, error)
 	GetJobsByStatus(ctx context.Context, status job.Status, limit int) ([]*entity.Job, error)
+	GetJobsByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Job, error)
 	GetRetryableJobs(ctx context.Context, beforeTime time.Time, limit int) ([]*entity.Job, error)
 }
 
@@ -122,6 +123,19 @@ func (r *jobRepository) GetJobsByStatus(ctx context.Context, status job.Status,
 	return jobs, err
 }
 
+// GetJobsByUserID returns the jobs whose payload references the user, newest first
+func (r *jobRepository) GetJobsByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Job, error) {
+	var jobs []*entity.Job
+	err := r.res.DB.NewSelect().
+		Model(&jobs).
+		Where("payload ->> ? = ?", job.PayloadUserID, userID.String()).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Limit(limit).
+		Scan(ctx)
+	return jobs, err
+}
+
 func (r *jobRepository) UpdateJobToProcessing(ctx context.Context, id string, startedAt time.Time) error {
 	_, err := r.res.DB.NewUpdate().
 		Model((*entity.Job)(nil)).
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type MfaRecoveryCodeRepository interface {
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []string) error
+	Consume(ctx context.Context, userID uuid.UUID, code string) (*entity.MfaRecoveryCode, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type DefaultMfaRecoveryCodeRepository struct {
+	res runtime.Resource
+}
+
+func NewMfaRecoveryCodeRepository(res runtime.Resource) MfaRecoveryCodeRepository {
+	return &DefaultMfaRecoveryCodeRepository{res: res}
+}
+
+// ReplaceForUser discards every previous code of the user and stores the given hashed codes
+func (r DefaultMfaRecoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []string) error {
+	return r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().Model((*entity.MfaRecoveryCode)(nil)).Set("deleted_at = ?", time.Now()).Where("user_id = ?", userID).Where("deleted_at IS NULL").Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		rows := make([]entity.MfaRecoveryCode, 0, len(codes))
+		for _, code := range codes {
+			rows = append(rows, entity.MfaRecoveryCode{UserID: userID, Code: code})
+		}
+		_, err = tx.NewInsert().Model(&rows).Exec(ctx)
+		return err
+	})
+}
+
+// Consume marks an unused code as used; it returns sql.ErrNoRows when no such code is available
+func (r DefaultMfaRecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, code string) (*entity.MfaRecoveryCode, error) {
+	var c entity.MfaRecoveryCode
+	err := r.res.DB.NewUpdate().Model(&c).Set("used_at = ?", time.Now()).Where("user_id = ?", userID).Where("code = ?", code).Where("used_at IS NULL").Where("deleted_at IS NULL").Returning("*").Scan(ctx, &c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r DefaultMfaRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.res.DB.NewUpdate().Model((*entity.MfaRecoveryCode)(nil)).Set("deleted_at = ?", time.Now()).Where("user_id = ?", userID).Where("deleted_at IS NULL").Exec(ctx)
+	return err
+}
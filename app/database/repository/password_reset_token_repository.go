@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PasswordResetTokenRepository interface {
+	Insert(ctx context.Context, token *entity.PasswordResetToken) (*entity.PasswordResetToken, error)
+	FindByToken(ctx context.Context, token string) (*entity.PasswordResetToken, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) (*entity.PasswordResetToken, error)
+	InvalidateByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type DefaultPasswordResetTokenRepository struct {
+	res runtime.Resource
+}
+
+func NewPasswordResetTokenRepository(res runtime.Resource) PasswordResetTokenRepository {
+	return &DefaultPasswordResetTokenRepository{res: res}
+}
+
+func (r DefaultPasswordResetTokenRepository) Insert(
+	ctx context.Context,
+	token *entity.PasswordResetToken,
+) (*entity.PasswordResetToken, error) {
+	err := r.res.DB.NewInsert().Model(token).Returning("*").Scan(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r DefaultPasswordResetTokenRepository) FindByToken(ctx context.Context, token string) (*entity.PasswordResetToken, error) {
+	var t entity.PasswordResetToken
+	err := r.res.DB.NewSelect().Model(&t).Where("token = ?", token).Where("deleted_at IS NULL").Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// MarkUsed consumes the token; it returns sql.ErrNoRows when the token was already used
+func (r DefaultPasswordResetTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) (*entity.PasswordResetToken, error) {
+	var t entity.PasswordResetToken
+	err := r.res.DB.NewUpdate().Model(&t).Set("used_at = ?", time.Now()).Where("id = ?", id).Where("used_at IS NULL").Where("deleted_at IS NULL").Returning("*").Scan(ctx, &t)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r DefaultPasswordResetTokenRepository) InvalidateByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.res.DB.NewUpdate().Model((*entity.PasswordResetToken)(nil)).Set("deleted_at = ?", time.Now()).Where("user_id = ?", userID).Where("used_at IS NULL").Where("deleted_at IS NULL").Exec(ctx)
+	return err
+}
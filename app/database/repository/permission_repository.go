@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+)
+
+type PermissionRepository interface {
+	FindAll(ctx context.Context) ([]entity.Permission, error)
+}
+
+type DefaultPermissionRepository struct {
+	res runtime.Resource
+}
+
+func NewPermissionRepository(res runtime.Resource) PermissionRepository {
+	return &DefaultPermissionRepository{res: res}
+}
+
+func (r DefaultPermissionRepository) FindAll(ctx context.Context) ([]entity.Permission, error) {
+	var permissions []entity.Permission
+	err := r.res.DB.
+		ReplicaNewSelect().
+		Model(&permissions).
+		Where("deleted_at IS NULL").
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
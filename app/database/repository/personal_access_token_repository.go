@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenRepository interface {
+	Insert(ctx context.Context, token *entity.PersonalAccessToken) (*entity.PersonalAccessToken, error)
+	FindByPrefix(ctx context.Context, prefix string) (*entity.PersonalAccessToken, error)
+	// FindByUserID returns every token of the user newest first, revoked and expired ones included
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.PersonalAccessToken, error)
+	// Revoke only matches a token of the given user that is not revoked yet
+	Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.PersonalAccessToken, error)
+	UpdateLastUsedAt(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type DefaultPersonalAccessTokenRepository struct {
+	res runtime.Resource
+}
+
+func NewPersonalAccessTokenRepository(res runtime.Resource) PersonalAccessTokenRepository {
+	return &DefaultPersonalAccessTokenRepository{res: res}
+}
+
+func (r DefaultPersonalAccessTokenRepository) Insert(ctx context.Context, token *entity.PersonalAccessToken) (*entity.PersonalAccessToken, error) {
+	err := r.res.DB.
+		NewInsert().
+		Model(token).
+		Returning("*").
+		Scan(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// FindByPrefix reads from the primary so a token works right after it is created
+func (r DefaultPersonalAccessTokenRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.PersonalAccessToken, error) {
+	t := new(entity.PersonalAccessToken)
+	err := r.res.DB.
+		NewSelect().
+		Model(t).
+		Where("prefix = ?", prefix).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r DefaultPersonalAccessTokenRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.PersonalAccessToken, error) {
+	var tokens []entity.PersonalAccessToken
+	err := r.res.DB.
+		NewSelect().
+		Model(&tokens).
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r DefaultPersonalAccessTokenRepository) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.PersonalAccessToken, error) {
+	var t entity.PersonalAccessToken
+	err := r.res.DB.
+		NewUpdate().
+		Model(&t).
+		Set("revoked_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &t)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r DefaultPersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.PersonalAccessToken)(nil)).
+		Set("last_used_at = ?", usedAt).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
@@ -22,3 +22,11 @@ func IsUniqueViolation(err error) bool {
 	}
 	return false
 }
+
+// UniqueViolationConstraint returns the name of the constraint or unique index a unique violation hit
+func UniqueViolationConstraint(err error) (string, bool) {
+	if !IsUniqueViolation(err) {
+		return "", false
+	}
+	return err.(pgdriver.Error).Field('n'), true
+}
@@ -279,12 +279,28 @@ func FindManyEntityWithCount[E DBTable, F any](
 	selectors []string,
 	paging pagingUtil.Page,
 	relations ...string,
+) (entities []E, total int, err error) {
+	return FindManyEntityWithCountWhere[E](ctx, db, filter, selectors, paging, nil, relations...)
+}
+
+// FindManyEntityWithCountWhere is FindManyEntityWithCount with extra conditions, such as ranges or text search, that the equality filter cannot express
+func FindManyEntityWithCountWhere[E DBTable, F any](
+	ctx context.Context,
+	db bun.IDB,
+	filter F,
+	selectors []string,
+	paging pagingUtil.Page,
+	where func(*bun.SelectQuery) *bun.SelectQuery,
+	relations ...string,
 ) (entities []E, total int, err error) {
 	query := db.NewSelect().Model(&entities)
 	query, err = BuildQueryConditions[E](query, filter, selectors, paging, relations...)
 	if err != nil {
 		return nil, 0, err
 	}
+	if where != nil {
+		query = where(query)
+	}
 	total, err = query.ScanAndCount(ctx)
 	if err != nil {
 		return nil, 0, SkipNotFound(err)
@@ -5,15 +5,37 @@ import (
 )
 
 type Repositories struct {
-	UserRepository    UserRepository
-	SessionRepository SessionRepository
-	JobRepository     JobRepository
+	UserRepository                   UserRepository
+	SessionRepository                SessionRepository
+	JobRepository                    JobRepository
+	EmailVerificationTokenRepository EmailVerificationTokenRepository
+	PasswordResetTokenRepository     PasswordResetTokenRepository
+	MfaRecoveryCodeRepository        MfaRecoveryCodeRepository
+	ApiKeyRepository                 ApiKeyRepository
+	RoleRepository                   RoleRepository
+	PermissionRepository             PermissionRepository
+	UserIdentityRepository           UserIdentityRepository
+	UserWalletRepository             UserWalletRepository
+	AuditEventRepository             AuditEventRepository
+	PersonalAccessTokenRepository    PersonalAccessTokenRepository
+	ServiceClientRepository          ServiceClientRepository
 }
 
 func NewRepositories(res runtime.Resource) *Repositories {
 	return &Repositories{
-		UserRepository:    NewUserRepository(res),
-		SessionRepository: NewSessionRepository(res),
-		JobRepository:     NewJobRepository(res),
+		UserRepository:                   NewUserRepository(res),
+		SessionRepository:                NewSessionRepository(res),
+		JobRepository:                    NewJobRepository(res),
+		EmailVerificationTokenRepository: NewEmailVerificationTokenRepository(res),
+		PasswordResetTokenRepository:     NewPasswordResetTokenRepository(res),
+		MfaRecoveryCodeRepository:        NewMfaRecoveryCodeRepository(res),
+		ApiKeyRepository:                 NewApiKeyRepository(res),
+		RoleRepository:                   NewRoleRepository(res),
+		PermissionRepository:             NewPermissionRepository(res),
+		UserIdentityRepository:           NewUserIdentityRepository(res),
+		UserWalletRepository:             NewUserWalletRepository(res),
+		AuditEventRepository:             NewAuditEventRepository(res),
+		PersonalAccessTokenRepository:    NewPersonalAccessTokenRepository(res),
+		ServiceClientRepository:          NewServiceClientRepository(res),
 	}
 }
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+)
+
+type RoleRepository interface {
+	Insert(ctx context.Context, role *entity.Role) (*entity.Role, error)
+	FindAll(ctx context.Context) ([]entity.Role, error)
+	FindByName(ctx context.Context, name string) (*entity.Role, error)
+	Update(ctx context.Context, role entity.Role) (*entity.Role, error)
+	Delete(ctx context.Context, name string) (*entity.Role, error)
+	CountUsers(ctx context.Context, name string) (int, error)
+}
+
+type DefaultRoleRepository struct {
+	res runtime.Resource
+}
+
+func NewRoleRepository(res runtime.Resource) RoleRepository {
+	return &DefaultRoleRepository{res: res}
+}
+
+func (r DefaultRoleRepository) Insert(ctx context.Context, role *entity.Role) (*entity.Role, error) {
+	err := r.res.DB.
+		NewInsert().
+		Model(role).
+		Returning("*").
+		Scan(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// FindAll reads from the primary so permission changes apply as soon as the cache is dropped
+func (r DefaultRoleRepository) FindAll(ctx context.Context) ([]entity.Role, error) {
+	var roles []entity.Role
+	err := r.res.DB.
+		NewSelect().
+		Model(&roles).
+		Where("deleted_at IS NULL").
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r DefaultRoleRepository) FindByName(ctx context.Context, name string) (*entity.Role, error) {
+	role := new(entity.Role)
+	err := r.res.DB.
+		NewSelect().
+		Model(role).
+		Where("name = ?", name).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Update changes the description, parent and permissions of a custom role
+func (r DefaultRoleRepository) Update(ctx context.Context, role entity.Role) (*entity.Role, error) {
+	var updated entity.Role
+	err := r.res.DB.
+		NewUpdate().
+		Model(&role).
+		Column("description", "parent", "permissions").
+		WherePK().
+		Where("built_in = ?", false).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete soft deletes a custom role, built-in roles are never matched
+func (r DefaultRoleRepository) Delete(ctx context.Context, name string) (*entity.Role, error) {
+	var deleted entity.Role
+	err := r.res.DB.
+		NewUpdate().
+		Model(&deleted).
+		Set("deleted_at = ?", time.Now()).
+		Where("name = ?", name).
+		Where("built_in = ?", false).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &deleted)
+	if err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// CountUsers counts the accounts holding the role
+func (r DefaultRoleRepository) CountUsers(ctx context.Context, name string) (int, error) {
+	return r.res.DB.
+		NewSelect().
+		Model((*entity.User)(nil)).
+		Where("role = ?", name).
+		Where("deleted_at IS NULL").
+		Count(ctx)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceClientRepository interface {
+	Insert(ctx context.Context, client *entity.ServiceClient) (*entity.ServiceClient, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ServiceClient, error)
+	FindByClientID(ctx context.Context, clientID string) (*entity.ServiceClient, error)
+	// FindAll returns every client newest first, revoked ones included
+	FindAll(ctx context.Context) ([]entity.ServiceClient, error)
+	Revoke(ctx context.Context, id uuid.UUID) (*entity.ServiceClient, error)
+	UpdateLastUsedAt(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type DefaultServiceClientRepository struct {
+	res runtime.Resource
+}
+
+func NewServiceClientRepository(res runtime.Resource) ServiceClientRepository {
+	return &DefaultServiceClientRepository{res: res}
+}
+
+func (r DefaultServiceClientRepository) Insert(ctx context.Context, client *entity.ServiceClient) (*entity.ServiceClient, error) {
+	err := r.res.DB.
+		NewInsert().
+		Model(client).
+		Returning("*").
+		Scan(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (r DefaultServiceClientRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ServiceClient, error) {
+	c := new(entity.ServiceClient)
+	err := r.res.DB.
+		NewSelect().
+		Model(c).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// FindByClientID reads from the primary so a revoked client is refused right away
+func (r DefaultServiceClientRepository) FindByClientID(ctx context.Context, clientID string) (*entity.ServiceClient, error) {
+	c := new(entity.ServiceClient)
+	err := r.res.DB.
+		NewSelect().
+		Model(c).
+		Where("client_id = ?", clientID).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r DefaultServiceClientRepository) FindAll(ctx context.Context) ([]entity.ServiceClient, error) {
+	var clients []entity.ServiceClient
+	err := r.res.DB.
+		NewSelect().
+		Model(&clients).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+func (r DefaultServiceClientRepository) Revoke(ctx context.Context, id uuid.UUID) (*entity.ServiceClient, error) {
+	var c entity.ServiceClient
+	err := r.res.DB.
+		NewUpdate().
+		Model(&c).
+		Set("revoked_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("revoked_at IS NULL").
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r DefaultServiceClientRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.ServiceClient)(nil)).
+		Set("last_used_at = ?", usedAt).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
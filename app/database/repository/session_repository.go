@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/uptrace/bun"
 )
 
 type SessionRepository interface {
@@ -16,6 +17,13 @@ type SessionRepository interface {
 	RevokeByUserID(ctx context.Context, userID uuid.UUID) error
 	DeleteByID(ctx context.Context, id uuid.UUID) (*entity.Session, error)
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Session, error)
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]entity.Session, error)
+	ListAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Session, error)
+	RevokeByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entity.Session, error)
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID, exceptID *uuid.UUID) (int, error)
+	FindRotatedByToken(ctx context.Context, token string) (*entity.Session, error)
+	Rotate(ctx context.Context, currentID uuid.UUID, next *entity.Session) (*entity.Session, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) (int, error)
 }
 
 type DefaultSessionRepository struct {
@@ -29,8 +37,6 @@ func NewSessionRepository(res runtime.Resource) SessionRepository {
 func (r DefaultSessionRepository) Insert(ctx context.Context, session *entity.Session) (*entity.Session, error) {
 	// Revoke existing token if duplicated
 	_ = r.RevokeByToken(ctx, session.Token)
-	// Soft-revoke existing sessions for the same user
-	_ = r.RevokeByUserID(ctx, session.UserID)
 
 	err := r.res.DB.NewInsert().Model(session).Returning("*").Scan(ctx, session)
 	if err != nil {
@@ -75,3 +81,135 @@ func (r DefaultSessionRepository) DeleteByUserID(ctx context.Context, userID uui
 	}
 	return sessions, nil
 }
+
+func (r DefaultSessionRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]entity.Session, error) {
+	var sessions []entity.Session
+	err := r.res.DB.ReplicaNewSelect().
+		Model(&sessions).
+		Where("user_id = ?", userID).
+		Where("revoked = FALSE").
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// ListAllByUser also returns revoked, rotated and expired sessions, newest first
+func (r DefaultSessionRepository) ListAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Session, error) {
+	var sessions []entity.Session
+	err := r.res.DB.ReplicaNewSelect().
+		Model(&sessions).
+		WhereAllWithDeleted().
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeByID revokes one session of the user, it returns sql.ErrNoRows when no active session matches
+func (r DefaultSessionRepository) RevokeByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entity.Session, error) {
+	var session entity.Session
+	err := r.res.DB.NewUpdate().
+		Model(&session).
+		Set("revoked = ?", true).
+		Set("deleted_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx, &session)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RevokeAllForUser revokes every session of the user except exceptID when given and returns how many were revoked
+func (r DefaultSessionRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID, exceptID *uuid.UUID) (int, error) {
+	q := r.res.DB.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("revoked = ?", true).
+		Set("deleted_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL")
+	if exceptID != nil {
+		q = q.Where("id <> ?", *exceptID)
+	}
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// FindRotatedByToken finds a session whose refresh token was already exchanged, revoked sessions included
+func (r DefaultSessionRepository) FindRotatedByToken(ctx context.Context, token string) (*entity.Session, error) {
+	var session entity.Session
+	err := r.res.DB.NewSelect().Model(&session).WhereAllWithDeleted().Where("token = ?", token).Where("rotated_at IS NOT NULL").Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate retires the current session and inserts its successor in one transaction.
+// It returns sql.ErrNoRows when the current session was already rotated or revoked.
+func (r DefaultSessionRepository) Rotate(ctx context.Context, currentID uuid.UUID, next *entity.Session) (*entity.Session, error) {
+	err := r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var current entity.Session
+		now := time.Now()
+		err := tx.NewUpdate().
+			Model(&current).
+			Set("rotated_at = ?", now).
+			Set("revoked = ?", true).
+			Set("deleted_at = ?", now).
+			Where("id = ?", currentID).
+			Where("rotated_at IS NULL").
+			Where("revoked = FALSE").
+			Where("deleted_at IS NULL").
+			Returning("*").
+			Scan(ctx, &current)
+		if err != nil {
+			return err
+		}
+
+		family := current.Family()
+		next.ParentID = &current.ID
+		next.FamilyID = &family
+		return tx.NewInsert().Model(next).Returning("*").Scan(ctx, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// RevokeFamily revokes every live session of a rotation chain and returns how many were revoked
+func (r DefaultSessionRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) (int, error) {
+	res, err := r.res.DB.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("revoked = ?", true).
+		Set("deleted_at = ?", time.Now()).
+		Where("id = ? OR family_id = ?", familyID, familyID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type UserIdentityRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.UserIdentity, error)
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+	Insert(ctx context.Context, identity *entity.UserIdentity) error
+	// InsertWithUser creates the user and its first identity atomically
+	InsertWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error
+	UpdateLastLoginAt(ctx context.Context, id uuid.UUID) error
+}
+
+type DefaultUserIdentityRepository struct {
+	res runtime.Resource
+}
+
+func NewUserIdentityRepository(res runtime.Resource) UserIdentityRepository {
+	return &DefaultUserIdentityRepository{res: res}
+}
+
+func (r DefaultUserIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	identity := new(entity.UserIdentity)
+	err := r.res.DB.
+		NewSelect().
+		Model(identity).
+		Where("provider = ?", provider).
+		Where("subject = ?", subject).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (r DefaultUserIdentityRepository) Insert(ctx context.Context, identity *entity.UserIdentity) error {
+	return r.res.DB.NewInsert().Model(identity).Returning("*").Scan(ctx, identity)
+}
+
+func (r DefaultUserIdentityRepository) InsertWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error {
+	return r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewInsert().Model(user).Returning("*").Scan(ctx, user); err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.NewInsert().Model(identity).Returning("*").Scan(ctx, identity)
+	})
+}
+
+func (r DefaultUserIdentityRepository) UpdateLastLoginAt(ctx context.Context, id uuid.UUID) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.UserIdentity)(nil)).
+		Set("last_login_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r DefaultUserIdentityRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.UserIdentity, error) {
+	var identities []entity.UserIdentity
+	err := r.res.DB.
+		ReplicaNewSelect().
+		Model(&identities).
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL").
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
@@ -1,15 +1,43 @@
 package repository
 
 import (
+	"backend/service-platform/app/database/constant/role"
 	"backend/service-platform/app/database/constant/user"
 	"backend/service-platform/app/database/entity"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
 	"backend/service-platform/app/internal/runtime"
+	pagingUtil "backend/service-platform/app/pkg/util/paging"
 	"context"
+	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/uptrace/bun"
 )
 
+// Partial unique indexes on live users, see sql/001-init_schema.sql and sql/010-profile.sql
+const (
+	UsersUsernameIndex    = "unique_idx_users_by_username"
+	UsersEmailIndex       = "unique_idx_users_by_email"
+	UsersPhoneNumberIndex = "unique_idx_users_by_phone_number"
+)
+
+// UserFilter selects users, nil fields are ignored
+type UserFilter struct {
+	Status        *user.Status `mapstructure:"status,omitempty"`
+	Role          *role.Role   `mapstructure:"role,omitempty"`
+	EmailVerified *bool        `mapstructure:"email_verified,omitempty"`
+	PhoneVerified *bool        `mapstructure:"phone_verified,omitempty"`
+
+	// Case-insensitive match on part of the email or username
+	Search        *string    `mapstructure:"-"`
+	CreatedFrom   *time.Time `mapstructure:"-"`
+	CreatedBefore *time.Time `mapstructure:"-"`
+	// Only soft-deleted users instead of only live ones
+	Deleted bool `mapstructure:"-"`
+}
+
 type UserRepository interface {
 	Insert(ctx context.Context, user *entity.User) (*entity.User, error)
 	Update(ctx context.Context, user entity.User) (*entity.User, error)
@@ -22,6 +50,30 @@ type UserRepository interface {
 	UpdateLastLoginAt(ctx context.Context, userID uuid.UUID) error
 	UpdateEmailVerified(ctx context.Context, userID uuid.UUID, verified bool) error
 	UpdatePhoneVerified(ctx context.Context, userID uuid.UUID, verified bool) error
+	MarkEmailVerified(ctx context.Context, userID uuid.UUID) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error
+	UpdateMfa(ctx context.Context, userID uuid.UUID, encryptedSecret *string, enabled bool) error
+	IncrementFailedLogins(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	LockAccount(ctx context.Context, userID uuid.UUID, until time.Time) error
+	ResetLoginFailures(ctx context.Context, userID uuid.UUID) error
+	UpdateRole(ctx context.Context, userID uuid.UUID, newRole role.Role) error
+	CountByRoles(ctx context.Context, roles ...role.Role) (int, error)
+	FindMany(ctx context.Context, filter UserFilter, page pagingUtil.Page) ([]entity.User, int, error)
+	FindByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	UpdateStatus(ctx context.Context, userID uuid.UUID, status user.Status) (*entity.User, error)
+	Restore(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	UpdateUsername(ctx context.Context, userID uuid.UUID, username string) (*entity.User, error)
+	UpdatePendingEmail(ctx context.Context, userID uuid.UUID, email *string) error
+	ConfirmEmailChange(ctx context.Context, userID uuid.UUID, email string) error
+	UpdatePhoneNumber(ctx context.Context, userID uuid.UUID, phoneNumber *string) (*entity.User, error)
+	MarkPhoneVerified(ctx context.Context, userID uuid.UUID, phoneNumber string) (*entity.User, error)
+	Deactivate(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.User, error)
+	Reactivate(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	FindDueForDeletion(ctx context.Context, now time.Time, limit int) ([]entity.User, error)
+	Anonymize(ctx context.Context, userID uuid.UUID) error
+	FindTokenVersion(ctx context.Context, userID uuid.UUID) (int64, error)
+	IncrementTokenVersion(ctx context.Context, userID uuid.UUID) (int64, error)
 }
 
 type DefaultUserRepository struct {
@@ -181,3 +233,431 @@ func (r DefaultUserRepository) UpdatePhoneVerified(ctx context.Context, userID u
 		Exec(ctx)
 	return err
 }
+
+// MarkEmailVerified flags the email as verified and activates users that are still unverified
+func (r DefaultUserRepository) MarkEmailVerified(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("email_verified = ?", true).
+		Set("status = CASE WHEN status = ? THEN ? ELSE status END", user.Unverified, user.Verified).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r DefaultUserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("password = ?", hashedPassword).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+// UpdateMfa stores the encrypted TOTP secret and toggles MFA; a nil secret clears the enrollment
+func (r DefaultUserRepository) UpdateMfa(ctx context.Context, userID uuid.UUID, encryptedSecret *string, enabled bool) error {
+	var enabledAt *time.Time
+	if enabled {
+		now := time.Now()
+		enabledAt = &now
+	}
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("mfa_secret = ?", encryptedSecret).
+		Set("mfa_enabled = ?", enabled).
+		Set("mfa_enabled_at = ?", enabledAt).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+// IncrementFailedLogins counts a failed login and returns the user with the new counters
+func (r DefaultUserRepository) IncrementFailedLogins(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("failed_login_attempts = failed_login_attempts + 1").
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// LockAccount refuses logins until the given time and starts counting failures again
+func (r DefaultUserRepository) LockAccount(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("locked_until = ?", until).
+		Set("lockout_count = lockout_count + 1").
+		Set("failed_login_attempts = 0").
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+// ResetLoginFailures clears the failure counters and any lockout
+func (r DefaultUserRepository) ResetLoginFailures(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("failed_login_attempts = 0").
+		Set("lockout_count = 0").
+		Set("locked_until = NULL").
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r DefaultUserRepository) UpdateRole(ctx context.Context, userID uuid.UUID, newRole role.Role) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("role = ?", newRole).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+// CountByRoles reads from the primary, it guards one-time operations
+func (r DefaultUserRepository) CountByRoles(ctx context.Context, roles ...role.Role) (int, error) {
+	return r.res.DB.
+		NewSelect().
+		Model((*entity.User)(nil)).
+		Where("role IN (?)", bun.In(roles)).
+		Where("deleted_at IS NULL").
+		Count(ctx)
+}
+
+func (r DefaultUserRepository) FindMany(ctx context.Context, filter UserFilter, page pagingUtil.Page) ([]entity.User, int, error) {
+	return queryutil.FindManyEntityWithCountWhere[entity.User](ctx, r.res.DB.ReplicaConn(), filter, nil, page, func(q *bun.SelectQuery) *bun.SelectQuery {
+		if filter.Search != nil && *filter.Search != "" {
+			pattern := "%" + escapeLike(*filter.Search) + "%"
+			q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Where("email ILIKE ?", pattern).WhereOr("username ILIKE ?", pattern)
+			})
+		}
+		if filter.CreatedFrom != nil {
+			q = q.Where("created_at >= ?", *filter.CreatedFrom)
+		}
+		if filter.CreatedBefore != nil {
+			q = q.Where("created_at < ?", *filter.CreatedBefore)
+		}
+		if filter.Deleted {
+			q = q.WhereDeleted()
+		}
+		return q
+	})
+}
+
+// FindByIDWithDeleted also finds soft-deleted users
+func (r DefaultUserRepository) FindByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		ReplicaNewSelect().
+		Model(u).
+		WhereAllWithDeleted().
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r DefaultUserRepository) UpdateStatus(ctx context.Context, userID uuid.UUID, status user.Status) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("status = ?", status).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Restore undoes a soft delete, sql.ErrNoRows when the user is not deleted or was purged
+func (r DefaultUserRepository) Restore(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Where("id = ?", userID).
+		Where("purged_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r DefaultUserRepository) UpdateUsername(ctx context.Context, userID uuid.UUID, username string) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("username = ?", username).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// UpdatePendingEmail stores the address waiting for verification, nil cancels the change
+func (r DefaultUserRepository) UpdatePendingEmail(ctx context.Context, userID uuid.UUID, email *string) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("pending_email = ?", email).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+// ConfirmEmailChange swaps in the verified pending address, sql.ErrNoRows when it is no longer pending
+func (r DefaultUserRepository) ConfirmEmailChange(ctx context.Context, userID uuid.UUID, email string) error {
+	var id uuid.UUID
+	return r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("email = pending_email").
+		Set("pending_email = NULL").
+		Set("email_verified = ?", true).
+		Set("status = CASE WHEN status = ? THEN ? ELSE status END", user.Unverified, user.Verified).
+		Where("id = ?", userID).
+		Where("pending_email = ?", email).
+		Where("deleted_at IS NULL").
+		Returning("id").
+		Scan(ctx, &id)
+}
+
+// UpdatePhoneNumber replaces or, with nil, removes the phone number; the new number is unverified
+func (r DefaultUserRepository) UpdatePhoneNumber(ctx context.Context, userID uuid.UUID, phoneNumber *string) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("phone_number = ?", phoneNumber).
+		Set("phone_verified = ?", false).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// MarkPhoneVerified stores the phone number as verified, replacing the current one when it differs
+func (r DefaultUserRepository) MarkPhoneVerified(ctx context.Context, userID uuid.UUID, phoneNumber string) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("phone_number = ?", phoneNumber).
+		Set("phone_verified = ?", true).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// escapeLike makes LIKE wildcards in user input match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Deactivate keeps the first deactivation time when the account is already deactivated
+func (r DefaultUserRepository) Deactivate(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("deactivated_at = COALESCE(deactivated_at, ?)", time.Now()).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ScheduleDeletion keeps the earlier date when a deletion is already scheduled
+func (r DefaultUserRepository) ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("deletion_scheduled_at = COALESCE(deletion_scheduled_at, ?)", at).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Reactivate clears the deactivation and cancels a scheduled deletion
+func (r DefaultUserRepository) Reactivate(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	u := new(entity.User)
+	err := r.res.DB.
+		NewUpdate().
+		Model(u).
+		Set("deactivated_at = NULL").
+		Set("deletion_scheduled_at = NULL").
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// FindDueForDeletion reads from the primary, a reactivation must not be missed by a lagging replica
+func (r DefaultUserRepository) FindDueForDeletion(ctx context.Context, now time.Time, limit int) ([]entity.User, error) {
+	var users []entity.User
+	err := r.res.DB.
+		NewSelect().
+		Model(&users).
+		Where("deletion_scheduled_at <= ?", now).
+		Where("deleted_at IS NULL").
+		Order("deletion_scheduled_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Anonymize erases the personal data of the user, hard-deletes its sessions and credentials and marks the account
+// purged. It returns sql.ErrNoRows when the account is gone or its deletion was cancelled
+func (r DefaultUserRepository) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	return r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+		res, err := tx.NewUpdate().
+			Model((*entity.User)(nil)).
+			Set("username = ?", "deleted-"+userID.String()).
+			Set("email = NULL").
+			Set("pending_email = NULL").
+			Set("phone_number = NULL").
+			// No hash matches an empty password
+			Set("password = ''").
+			Set("status = ?", user.Purged).
+			Set("email_verified = FALSE").
+			Set("phone_verified = FALSE").
+			Set("mfa_enabled = FALSE").
+			Set("mfa_secret = NULL").
+			Set("mfa_enabled_at = NULL").
+			Set("deletion_scheduled_at = NULL").
+			Set("token_version = token_version + 1").
+			Set("purged_at = ?", now).
+			Set("deleted_at = ?", now).
+			Where("id = ?", userID).
+			Where("deletion_scheduled_at <= ?", now).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			if err != nil {
+				return err
+			}
+			return sql.ErrNoRows
+		}
+
+		// Personal data and credentials are removed for good
+		for _, model := range []any{
+			(*entity.Session)(nil),
+			(*entity.UserIdentity)(nil),
+			(*entity.UserWallet)(nil),
+			(*entity.MfaRecoveryCode)(nil),
+			(*entity.EmailVerificationToken)(nil),
+			(*entity.PasswordResetToken)(nil),
+		} {
+			if _, err := tx.NewDelete().Model(model).Where("user_id = ?", userID).ForceDelete().Exec(ctx); err != nil {
+				return err
+			}
+		}
+		// Keys stay for their audit trail but stop authenticating
+		_, err = tx.NewUpdate().
+			Model((*entity.ApiKey)(nil)).
+			Set("revoked = TRUE").
+			Set("revoked_at = COALESCE(revoked_at, ?)", now).
+			Where("user_id = ?", userID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// FindTokenVersion also reads deleted users, their tokens must stay stale
+func (r DefaultUserRepository) FindTokenVersion(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var version int64
+	err := r.res.DB.
+		NewSelect().
+		Model((*entity.User)(nil)).
+		Column("token_version").
+		WhereAllWithDeleted().
+		Where("id = ?", userID).
+		Scan(ctx, &version)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// IncrementTokenVersion raises the token version and returns the new one, sql.ErrNoRows when the user does not exist
+func (r DefaultUserRepository) IncrementTokenVersion(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var version int64
+	err := r.res.DB.
+		NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("token_version = token_version + 1").
+		WhereAllWithDeleted().
+		Where("id = ?", userID).
+		Returning("token_version").
+		Scan(ctx, &version)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
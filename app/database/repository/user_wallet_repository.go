@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/runtime"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Partial unique index on live wallets, see sql/012-user_wallets.sql
+const UserWalletsAddressIndex = "unique_idx_user_wallets_by_address"
+
+type UserWalletRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.UserWallet, error)
+	FindByAddress(ctx context.Context, address string) (*entity.UserWallet, error)
+	// InsertWithUser creates the user and its wallet atomically
+	InsertWithUser(ctx context.Context, user *entity.User, wallet *entity.UserWallet) error
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, chainID int64) error
+}
+
+type DefaultUserWalletRepository struct {
+	res runtime.Resource
+}
+
+func NewUserWalletRepository(res runtime.Resource) UserWalletRepository {
+	return &DefaultUserWalletRepository{res: res}
+}
+
+func (r DefaultUserWalletRepository) FindByAddress(ctx context.Context, address string) (*entity.UserWallet, error) {
+	wallet := new(entity.UserWallet)
+	err := r.res.DB.
+		NewSelect().
+		Model(wallet).
+		Where("address = ?", address).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (r DefaultUserWalletRepository) InsertWithUser(ctx context.Context, user *entity.User, wallet *entity.UserWallet) error {
+	return r.res.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewInsert().Model(user).Returning("*").Scan(ctx, user); err != nil {
+			return err
+		}
+		wallet.UserID = user.ID
+		return tx.NewInsert().Model(wallet).Returning("*").Scan(ctx, wallet)
+	})
+}
+
+func (r DefaultUserWalletRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, chainID int64) error {
+	_, err := r.res.DB.
+		NewUpdate().
+		Model((*entity.UserWallet)(nil)).
+		Set("last_login_at = ?", time.Now()).
+		Set("chain_id = ?", chainID).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r DefaultUserWalletRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.UserWallet, error) {
+	var wallets []entity.UserWallet
+	err := r.res.DB.
+		ReplicaNewSelect().
+		Model(&wallets).
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL").
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/internal/validator"
+	"backend/service-platform/app/manager"
+)
+
+// CreateAdmin is the name of the subcommand on the api and worker binaries
+const CreateAdmin = "create-admin"
+
+// RunCreateAdmin creates or promotes a super admin, flags take precedence over
+// SUPER_ADMIN_EMAIL and SUPER_ADMIN_PASSWORD
+func RunCreateAdmin(ctx context.Context, res runtime.Resource, args []string) error {
+	flags := flag.NewFlagSet(CreateAdmin, flag.ContinueOnError)
+	email := flags.String("email", os.Getenv("SUPER_ADMIN_EMAIL"), "email of the super admin")
+	password := flags.String("password", os.Getenv("SUPER_ADMIN_PASSWORD"), "password, only used when the account does not exist yet")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	req := request.CreateSuperAdminRequest{
+		Email:    *email,
+		Password: *password,
+		Source:   manager.SuperAdminSourceCLI,
+	}
+	if err := validator.NewValidators(res).Validate(&req); err != nil {
+		return fmt.Errorf("invalid arguments: --email must be a valid address and --password at least 8 characters")
+	}
+
+	managers := manager.NewManagers(res, nil, repository.NewRepositories(res))
+	result, err := managers.SuperAdminManager.CreateSuperAdmin(ctx, req)
+	if err != nil {
+		var policyErr *manager.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			messages := make([]string, 0, len(policyErr.Violations))
+			for _, v := range policyErr.Violations {
+				messages = append(messages, v.Message)
+			}
+			return fmt.Errorf("%w: %s", err, strings.Join(messages, ", "))
+		}
+		return err
+	}
+
+	res.Logger.Info("Super admin ready",
+		zap.String("user_id", result.UserID.String()),
+		zap.String("email", result.Email),
+		zap.String("outcome", result.Outcome),
+	)
+	return nil
+}
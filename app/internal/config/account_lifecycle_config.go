@@ -0,0 +1,27 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+)
+
+type AccountLifecycleConfig struct {
+	// Time a deletion can still be cancelled by reactivating the account
+	DeletionGracePeriod time.Duration `mapstructure:"deletion_grace_period"`
+	// How often the worker looks for accounts whose grace period ended
+	DeletionSweepInterval time.Duration `mapstructure:"deletion_sweep_interval"`
+	// Local directory the data export archives are written to, one sub directory per user
+	ExportDir string `mapstructure:"export_dir"`
+	// Page of the frontend downloading an export, the email links to it with the export ID as the "id" parameter
+	ExportURL string `mapstructure:"export_url"`
+}
+
+// UserExportDir holds every export archive of the user
+func (c AccountLifecycleConfig) UserExportDir(userID string) string {
+	return filepath.Join(c.ExportDir, userID)
+}
+
+// ExportPath is the archive written by the export job of the given ID
+func (c AccountLifecycleConfig) ExportPath(userID, exportID string) string {
+	return filepath.Join(c.UserExportDir(userID), exportID+".zip")
+}
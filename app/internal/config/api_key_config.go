@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type ApiKeyConfig struct {
+	// How long a rotated key keeps working when the request does not ask for another overlap
+	RotationOverlap time.Duration `mapstructure:"rotation_overlap"`
+	// Upper bound of the overlap an admin can request
+	MaxRotationOverlap time.Duration `mapstructure:"max_rotation_overlap"`
+}
@@ -18,17 +18,33 @@ func bindEnv(configKey, envKey string, defaultValue ...interface{}) {
 }
 
 type ApplicationConfig struct {
-	ServerConfig     ServerConfig     `mapstructure:"server"`
-	DatabaseConfig   DatabaseConfig   `mapstructure:"database"`
-	RedisConfig      RedisConfig      `mapstructure:"redis"`
-	RouterConfig     RouterConfig     `mapstructure:"router"`
-	WorkerConfig     WorkerConfig     `mapstructure:"worker"`
-	AwsConfig        AwsConfig        `mapstructure:"aws"`
-	EodhdConfig      EodhdConfig      `mapstructure:"eodhd"`
-	GoogleConfig     GoogleConfig     `mapstructure:"google"`
-	BcryptConfig     BcryptConfig     `mapstructure:"bcrypt"`
-	SuperAdminConfig SuperAdminConfig `mapstructure:"super_admin"`
-	JwtConfig        JwtConfig        `mapstructure:"jwt"`
+	ServerConfig            ServerConfig            `mapstructure:"server"`
+	DatabaseConfig          DatabaseConfig          `mapstructure:"database"`
+	RedisConfig             RedisConfig             `mapstructure:"redis"`
+	RouterConfig            RouterConfig            `mapstructure:"router"`
+	WorkerConfig            WorkerConfig            `mapstructure:"worker"`
+	AwsConfig               AwsConfig               `mapstructure:"aws"`
+	EodhdConfig             EodhdConfig             `mapstructure:"eodhd"`
+	GoogleConfig            GoogleConfig            `mapstructure:"google"`
+	BcryptConfig            BcryptConfig            `mapstructure:"bcrypt"`
+	Argon2Config            Argon2Config            `mapstructure:"argon2"`
+	PasswordHashConfig      PasswordHashConfig      `mapstructure:"password_hash"`
+	PasswordPolicyConfig    PasswordPolicyConfig    `mapstructure:"password_policy"`
+	SuperAdminConfig        SuperAdminConfig        `mapstructure:"super_admin"`
+	JwtConfig               JwtConfig               `mapstructure:"jwt"`
+	MailerConfig            MailerConfig            `mapstructure:"mailer"`
+	SmsConfig               SmsConfig               `mapstructure:"sms"`
+	EmailVerificationConfig EmailVerificationConfig `mapstructure:"email_verification"`
+	PasswordResetConfig     PasswordResetConfig     `mapstructure:"password_reset"`
+	PhoneVerificationConfig PhoneVerificationConfig `mapstructure:"phone_verification"`
+	MfaConfig               MfaConfig               `mapstructure:"mfa"`
+	OidcConfig              OidcConfig              `mapstructure:"oidc"`
+	SiweConfig              SiweConfig              `mapstructure:"siwe"`
+	LoginProtectionConfig   LoginProtectionConfig   `mapstructure:"login_protection"`
+	AccountLifecycleConfig  AccountLifecycleConfig  `mapstructure:"account_lifecycle"`
+	ApiKeyConfig            ApiKeyConfig            `mapstructure:"api_key"`
+	AuthenticationConfig    AuthenticationConfig    `mapstructure:"authentication"`
+	RbacConfig              RbacConfig              `mapstructure:"rbac"`
 }
 
 func ReadApplicationConfig(env ctxutil.AppMode, logger *zap.Logger) (cfg ApplicationConfig, err error) {
@@ -124,18 +140,122 @@ func ReadApplicationConfig(env ctxutil.AppMode, logger *zap.Logger) (cfg Applica
 	// Bcrypt
 	bindEnv("bcrypt.cost", "BCRYPT_COST")
 
+	// Argon2id
+	bindEnv("argon2.memory", "ARGON2_MEMORY", 65536)
+	bindEnv("argon2.iterations", "ARGON2_ITERATIONS", 3)
+	bindEnv("argon2.parallelism", "ARGON2_PARALLELISM", 2)
+	bindEnv("argon2.salt_length", "ARGON2_SALT_LENGTH", 16)
+	bindEnv("argon2.key_length", "ARGON2_KEY_LENGTH", 32)
+
+	// Password hashing
+	bindEnv("password_hash.algorithm", "PASSWORD_HASH_ALGORITHM", "argon2id")
+
+	// Password policy
+	bindEnv("password_policy.min_length", "PASSWORD_POLICY_MIN_LENGTH", 8)
+	bindEnv("password_policy.max_length", "PASSWORD_POLICY_MAX_LENGTH", 128)
+	bindEnv("password_policy.require_uppercase", "PASSWORD_POLICY_REQUIRE_UPPERCASE")
+	bindEnv("password_policy.require_lowercase", "PASSWORD_POLICY_REQUIRE_LOWERCASE")
+	bindEnv("password_policy.require_digit", "PASSWORD_POLICY_REQUIRE_DIGIT")
+	bindEnv("password_policy.require_symbol", "PASSWORD_POLICY_REQUIRE_SYMBOL")
+	bindEnv("password_policy.max_repeated_chars", "PASSWORD_POLICY_MAX_REPEATED_CHARS")
+	bindEnv("password_policy.forbid_personal_info", "PASSWORD_POLICY_FORBID_PERSONAL_INFO", true)
+	bindEnv("password_policy.breached_dataset_dir", "PASSWORD_POLICY_BREACHED_DATASET_DIR")
+
 	// Super Admin
 	bindEnv("super_admin.allowed_new_creation", "SUPER_ADMIN_ALLOWED_NEW_CREATION")
+	bindEnv("super_admin.bootstrap_token", "SUPER_ADMIN_BOOTSTRAP_TOKEN")
 
 	// JWT
 	bindEnv("jwt.issuer", "JWT_ISSUER")
 	bindEnv("jwt.secret_key", "JWT_SECRET_KEY")
 	bindEnv("jwt.access_expiration", "JWT_ACCESS_EXPIRATION")
 	bindEnv("jwt.refresh_expiration", "JWT_REFRESH_EXPIRATION")
+	bindEnv("jwt.impersonation_expiration", "JWT_IMPERSONATION_EXPIRATION", "15m")
+	bindEnv("jwt.service_token_expiration", "JWT_SERVICE_TOKEN_EXPIRATION", "10m")
+	bindEnv("jwt.algorithm", "JWT_ALGORITHM", "HS256")
+	bindEnv("jwt.signing_key_id", "JWT_SIGNING_KEY_ID")
+	bindEnv("jwt.accept_legacy_hs256", "JWT_ACCEPT_LEGACY_HS256", "false")
+
+	// Sign-In With Ethereum
 	bindEnv("siwe.statement", "SIWE_STATEMENT")
-	bindEnv("siwe.nonce_ttl", "SIWE_NONCE_TTL")
+	bindEnv("siwe.nonce_ttl", "SIWE_NONCE_TTL", "5m")
 	bindEnv("siwe.allowed_origins", "SIWE_ALLOWED_ORIGINS")
 	bindEnv("siwe.require_chain_id", "SIWE_REQUIRE_CHAIN_ID")
+	bindEnv("siwe.nonce_ip_limit", "SIWE_NONCE_IP_LIMIT", 30)
+
+	// Mailer
+	bindEnv("mailer.driver", "MAILER_DRIVER", "file")
+	bindEnv("mailer.from", "MAILER_FROM")
+	bindEnv("mailer.outbox_dir", "MAILER_OUTBOX_DIR", "./tmp/outbox")
+	bindEnv("mailer.smtp.host", "MAILER_SMTP_HOST")
+	bindEnv("mailer.smtp.port", "MAILER_SMTP_PORT")
+	bindEnv("mailer.smtp.username", "MAILER_SMTP_USERNAME")
+	bindEnv("mailer.smtp.password", "MAILER_SMTP_PASSWORD")
+
+	// SMS
+	bindEnv("sms.driver", "SMS_DRIVER", "file")
+	bindEnv("sms.outbox_dir", "SMS_OUTBOX_DIR", "./tmp/sms")
+
+	// Email verification
+	bindEnv("email_verification.token_ttl", "EMAIL_VERIFICATION_TOKEN_TTL", "24h")
+	bindEnv("email_verification.verify_url", "EMAIL_VERIFICATION_VERIFY_URL")
+
+	// Password reset
+	bindEnv("password_reset.token_ttl", "PASSWORD_RESET_TOKEN_TTL", "30m")
+	bindEnv("password_reset.reset_url", "PASSWORD_RESET_RESET_URL")
+	bindEnv("password_reset.email_limit", "PASSWORD_RESET_EMAIL_LIMIT", 3)
+	bindEnv("password_reset.ip_limit", "PASSWORD_RESET_IP_LIMIT", 20)
+
+	// Account lifecycle
+	bindEnv("account_lifecycle.deletion_grace_period", "ACCOUNT_DELETION_GRACE_PERIOD", "720h")
+	bindEnv("account_lifecycle.deletion_sweep_interval", "ACCOUNT_DELETION_SWEEP_INTERVAL", "1h")
+	bindEnv("account_lifecycle.export_dir", "ACCOUNT_EXPORT_DIR", "./tmp/exports")
+	bindEnv("account_lifecycle.export_url", "ACCOUNT_EXPORT_URL")
+
+	// Phone verification
+	bindEnv("phone_verification.code_length", "PHONE_VERIFICATION_CODE_LENGTH", 6)
+	bindEnv("phone_verification.code_ttl", "PHONE_VERIFICATION_CODE_TTL", "5m")
+	bindEnv("phone_verification.max_attempts", "PHONE_VERIFICATION_MAX_ATTEMPTS", 5)
+	bindEnv("phone_verification.resend_interval", "PHONE_VERIFICATION_RESEND_INTERVAL", "1m")
+	bindEnv("phone_verification.user_limit", "PHONE_VERIFICATION_USER_LIMIT", 5)
+	bindEnv("phone_verification.phone_limit", "PHONE_VERIFICATION_PHONE_LIMIT", 5)
+
+	// MFA
+	bindEnv("mfa.issuer", "MFA_ISSUER")
+	bindEnv("mfa.encryption_key", "MFA_ENCRYPTION_KEY")
+	bindEnv("mfa.challenge_ttl", "MFA_CHALLENGE_TTL", "5m")
+	bindEnv("mfa.max_attempts", "MFA_MAX_ATTEMPTS", 5)
+	bindEnv("mfa.recovery_code_count", "MFA_RECOVERY_CODE_COUNT", 10)
+	bindEnv("mfa.required_roles", "MFA_REQUIRED_ROLES")
+
+	// OpenID Connect
+	bindEnv("oidc.state_ttl", "OIDC_STATE_TTL", "10m")
+	bindEnv("oidc.jwks_cache_ttl", "OIDC_JWKS_CACHE_TTL", "1h")
+	bindEnv("oidc.providers.google.client_id", "OIDC_GOOGLE_CLIENT_ID")
+	bindEnv("oidc.providers.google.client_secret", "OIDC_GOOGLE_CLIENT_SECRET")
+	bindEnv("oidc.providers.google.redirect_url", "OIDC_GOOGLE_REDIRECT_URL")
+
+	// Login protection
+	bindEnv("login_protection.window", "LOGIN_PROTECTION_WINDOW", "15m")
+	bindEnv("login_protection.login_email_limit", "LOGIN_PROTECTION_LOGIN_EMAIL_LIMIT", 10)
+	bindEnv("login_protection.login_ip_limit", "LOGIN_PROTECTION_LOGIN_IP_LIMIT", 50)
+	bindEnv("login_protection.register_ip_limit", "LOGIN_PROTECTION_REGISTER_IP_LIMIT", 10)
+	bindEnv("login_protection.refresh_ip_limit", "LOGIN_PROTECTION_REFRESH_IP_LIMIT", 120)
+	bindEnv("login_protection.bootstrap_ip_limit", "LOGIN_PROTECTION_BOOTSTRAP_IP_LIMIT", 5)
+	bindEnv("login_protection.max_failed_attempts", "LOGIN_PROTECTION_MAX_FAILED_ATTEMPTS", 5)
+	bindEnv("login_protection.lockout_duration", "LOGIN_PROTECTION_LOCKOUT_DURATION", "1m")
+	bindEnv("login_protection.max_lockout_duration", "LOGIN_PROTECTION_MAX_LOCKOUT_DURATION", "24h")
+
+	// API keys
+	bindEnv("api_key.rotation_overlap", "API_KEY_ROTATION_OVERLAP", "24h")
+	bindEnv("api_key.max_rotation_overlap", "API_KEY_MAX_ROTATION_OVERLAP", "168h")
+
+	// Authentication
+	bindEnv("authentication.methods", "AUTHENTICATION_METHODS", []string{"jwt", "api_key", "personal_access_token", "service_token"})
+	bindEnv("authentication.basic_auth.enabled", "BASIC_AUTH_ENABLED", "false")
+
+	// Role based access control
+	bindEnv("rbac.cache_ttl", "RBAC_CACHE_TTL", "5m")
 
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return cfg, fmt.Errorf("error unmarshalling config: %s", err.Error())
@@ -0,0 +1,21 @@
+package config
+
+type AuthenticationConfig struct {
+	// Mechanisms tried in order on routes that accept several, one of jwt, api_key, personal_access_token, service_token or basic_auth
+	Methods   []string        `mapstructure:"methods"`
+	BasicAuth BasicAuthConfig `mapstructure:"basic_auth"`
+}
+
+// BasicAuthConfig holds the HTTP Basic credentials of legacy integrations, basic_auth also has to be listed in methods
+type BasicAuthConfig struct {
+	Enabled     bool                  `mapstructure:"enabled"`
+	Credentials []BasicAuthCredential `mapstructure:"credentials"`
+}
+
+type BasicAuthCredential struct {
+	Username string `mapstructure:"username"`
+	// bcrypt or argon2id hash of the password, the plaintext is never configured
+	PasswordHash string `mapstructure:"password_hash"`
+	// Existing user the credential acts as, with their role and account status
+	UserID string `mapstructure:"user_id"`
+}
@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+type EmailVerificationConfig struct {
+	TokenTTL  time.Duration `mapstructure:"token_ttl"`
+	VerifyURL string        `mapstructure:"verify_url"`
+}
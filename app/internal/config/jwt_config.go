@@ -7,4 +7,27 @@ type JwtConfig struct {
 	SecretKey         string        `mapstructure:"secret_key"`
 	AccessExpiration  time.Duration `mapstructure:"access_expiration"`
 	RefreshExpiration time.Duration `mapstructure:"refresh_expiration"`
+	// Lifetime of the access tokens issued to admins impersonating a user
+	ImpersonationExpiration time.Duration `mapstructure:"impersonation_expiration"`
+	// Lifetime of the access tokens issued to services through the client credentials grant
+	ServiceTokenExpiration time.Duration `mapstructure:"service_token_expiration"`
+	// Signing algorithm: HS256 (default, uses secret_key), RS256 or EdDSA
+	Algorithm string `mapstructure:"algorithm"`
+	// kid of the key in keys used to sign new tokens
+	SigningKeyID string `mapstructure:"signing_key_id"`
+	// Asymmetric keys; keys without a private key only verify tokens signed before a rotation
+	Keys []JwtKeyConfig `mapstructure:"keys"`
+	// While migrating from HS256 to RS256 or EdDSA, keep accepting HS256 tokens without a kid signed with
+	// secret_key until they expire. Leave off otherwise, anyone holding the secret could mint tokens
+	AcceptLegacyHS256 bool `mapstructure:"accept_legacy_hs256"`
+}
+
+type JwtKeyConfig struct {
+	ID        string `mapstructure:"id"`
+	Algorithm string `mapstructure:"algorithm"`
+	// PEM encoded, inline or read from the file path
+	PrivateKey     string `mapstructure:"private_key"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKey      string `mapstructure:"public_key"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
 }
@@ -0,0 +1,29 @@
+package config
+
+import "time"
+
+type LoginProtectionConfig struct {
+	// Sliding window shared by the request limits below
+	Window time.Duration `mapstructure:"window"`
+	// Maximum requests per window, zero disables the limit
+	LoginEmailLimit int `mapstructure:"login_email_limit"`
+	LoginIPLimit    int `mapstructure:"login_ip_limit"`
+	RegisterIPLimit int `mapstructure:"register_ip_limit"`
+	RefreshIPLimit  int `mapstructure:"refresh_ip_limit"`
+	// Super admin bootstrap attempts, the bootstrap token must not be guessable by brute force
+	BootstrapIPLimit int `mapstructure:"bootstrap_ip_limit"`
+	// Consecutive failed logins before the account is locked, zero disables the lockout
+	MaxFailedAttempts int `mapstructure:"max_failed_attempts"`
+	// First lockout duration, doubled on every following lockout up to MaxLockoutDuration
+	LockoutDuration    time.Duration `mapstructure:"lockout_duration"`
+	MaxLockoutDuration time.Duration `mapstructure:"max_lockout_duration"`
+}
+
+// LockoutFor returns how long the account is locked given the lockouts it already had
+func (c LoginProtectionConfig) LockoutFor(previousLockouts int) time.Duration {
+	d := c.LockoutDuration
+	for i := 0; i < previousLockouts && d < c.MaxLockoutDuration; i++ {
+		d *= 2
+	}
+	return min(d, max(c.MaxLockoutDuration, c.LockoutDuration))
+}
@@ -0,0 +1,16 @@
+package config
+
+type MailerConfig struct {
+	// Driver selects the mailer implementation: smtp or file
+	Driver    string     `mapstructure:"driver"`
+	From      string     `mapstructure:"from"`
+	OutboxDir string     `mapstructure:"outbox_dir"`
+	SMTP      SMTPConfig `mapstructure:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
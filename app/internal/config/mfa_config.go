@@ -0,0 +1,21 @@
+package config
+
+import (
+	"backend/service-platform/app/database/constant/role"
+	"slices"
+	"time"
+)
+
+type MfaConfig struct {
+	Issuer            string        `mapstructure:"issuer"`
+	EncryptionKey     string        `mapstructure:"encryption_key"`
+	ChallengeTTL      time.Duration `mapstructure:"challenge_ttl"`
+	MaxAttempts       int           `mapstructure:"max_attempts"`
+	RecoveryCodeCount int           `mapstructure:"recovery_code_count"`
+	// Roles that cannot sign in without a second factor
+	RequiredRoles []role.Role `mapstructure:"required_roles"`
+}
+
+func (c MfaConfig) IsRequiredFor(r role.Role) bool {
+	return slices.Contains(c.RequiredRoles, r)
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+type OidcConfig struct {
+	// Lifetime of the state, nonce and PKCE verifier kept between start and callback
+	StateTTL     time.Duration `mapstructure:"state_ttl"`
+	JwksCacheTTL time.Duration `mapstructure:"jwks_cache_ttl"`
+	// Keyed by the provider name used in the routes; providers without a client id are disabled
+	Providers map[string]OidcProviderConfig `mapstructure:"providers"`
+}
+
+type OidcProviderConfig struct {
+	Issuer       string   `mapstructure:"issuer"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"`
+	Scopes       []string `mapstructure:"scopes"`
+	// Discovered from the issuer when empty
+	AuthorizationEndpoint string `mapstructure:"authorization_endpoint"`
+	TokenEndpoint         string `mapstructure:"token_endpoint"`
+	JwksURI               string `mapstructure:"jwks_uri"`
+	// Sign in to the existing account with the same email when the provider reports it as verified
+	TrustEmail bool `mapstructure:"trust_email"`
+}
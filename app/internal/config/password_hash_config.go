@@ -0,0 +1,6 @@
+package config
+
+type PasswordHashConfig struct {
+	// Algorithm of new hashes, "argon2id" or "bcrypt"; hashes of the other one are still verified and upgraded on login
+	Algorithm string `mapstructure:"algorithm"`
+}
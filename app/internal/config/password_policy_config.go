@@ -0,0 +1,19 @@
+package config
+
+type PasswordPolicyConfig struct {
+	// Length in characters, zero disables the bound
+	MinLength int `mapstructure:"min_length"`
+	MaxLength int `mapstructure:"max_length"`
+	// Character classes the password must contain at least one of
+	RequireUppercase bool `mapstructure:"require_uppercase"`
+	RequireLowercase bool `mapstructure:"require_lowercase"`
+	RequireDigit     bool `mapstructure:"require_digit"`
+	RequireSymbol    bool `mapstructure:"require_symbol"`
+	// Longest run of the same character, zero disables the rule
+	MaxRepeatedChars int `mapstructure:"max_repeated_chars"`
+	// Refuses passwords containing the email, its local part or the username
+	ForbidPersonalInfo bool `mapstructure:"forbid_personal_info"`
+	// Directory of breached password hashes in the Pwned Passwords range format, one <first 5 SHA-1 hex>.txt file
+	// per prefix holding SUFFIX:COUNT lines; empty disables the check
+	BreachedDatasetDir string `mapstructure:"breached_dataset_dir"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type PasswordResetConfig struct {
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+	ResetURL string        `mapstructure:"reset_url"`
+	// Maximum requests per hour for a single email address and for a single client IP
+	EmailLimit int `mapstructure:"email_limit"`
+	IPLimit    int `mapstructure:"ip_limit"`
+}
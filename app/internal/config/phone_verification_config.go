@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+type PhoneVerificationConfig struct {
+	CodeLength  int           `mapstructure:"code_length"`
+	CodeTTL     time.Duration `mapstructure:"code_ttl"`
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	// Minimum delay between two codes sent to the same user
+	ResendInterval time.Duration `mapstructure:"resend_interval"`
+	// Hourly budgets of codes per user and per phone number
+	UserLimit  int `mapstructure:"user_limit"`
+	PhoneLimit int `mapstructure:"phone_limit"`
+}
@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+type RbacConfig struct {
+	// How long the resolved role to permission mapping is cached in Redis
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+type SiweConfig struct {
+	// Statement the wallet displays, messages must carry it when set
+	Statement string        `mapstructure:"statement"`
+	NonceTTL  time.Duration `mapstructure:"nonce_ttl"`
+	// Comma separated origins of the frontends requesting signatures, their hosts are the accepted message domains
+	AllowedOrigins string `mapstructure:"allowed_origins"`
+	// Chain messages must be signed for, zero accepts any chain
+	RequireChainID int64 `mapstructure:"require_chain_id"`
+	// Nonces per client IP in the login protection window
+	NonceIPLimit int `mapstructure:"nonce_ip_limit"`
+}
@@ -0,0 +1,7 @@
+package config
+
+type SmsConfig struct {
+	// Driver selects the sender implementation: log or file
+	Driver    string `mapstructure:"driver"`
+	OutboxDir string `mapstructure:"outbox_dir"`
+}
@@ -2,4 +2,6 @@ package config
 
 type SuperAdminConfig struct {
 	AllowedNewCreation bool `mapstructure:"allowed_new_creation"`
+	// Secret for the one-time HTTP bootstrap, the endpoint is disabled when empty
+	BootstrapToken string `mapstructure:"bootstrap_token"`
 }
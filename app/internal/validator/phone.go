@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// E.164: a plus sign, a country code that never starts with 0, and at most 15 digits in total
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+type PhoneValidator struct{}
+
+func NewPhoneValidator() IValidator {
+	return &PhoneValidator{}
+}
+
+func (v *PhoneValidator) Register() (validator.Func, string) {
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		if field.Kind() != reflect.String {
+			return false
+		}
+		return phonePattern.MatchString(field.String())
+	}, "phone"
+}
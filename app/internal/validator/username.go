@@ -0,0 +1,28 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Letters, digits, dots, dashes and underscores, starting with a letter or digit. No "@", so a handle
+// can never take the email that Register uses as the username of a new account.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{2,29}$`)
+
+type UsernameValidator struct{}
+
+func NewUsernameValidator() IValidator {
+	return &UsernameValidator{}
+}
+
+func (v *UsernameValidator) Register() (validator.Func, string) {
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		if field.Kind() != reflect.String {
+			return false
+		}
+		return usernamePattern.MatchString(field.String())
+	}, "username"
+}
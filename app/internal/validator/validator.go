@@ -24,6 +24,8 @@ type Validators struct {
 func NewValidators(res runtime.Resource) *Validators {
 	validators := []IValidator{
 		NewNotBlankValidator(),
+		NewUsernameValidator(),
+		NewPhoneValidator(),
 	}
 
 	v := &Validators{
@@ -0,0 +1,330 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/owner"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/apikey"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/rbac"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrApiKeyNotFound      = errors.New("api key not found")
+	ErrApiKeyRevoked       = errors.New("api key is revoked")
+	ErrApiKeyExpiresInPast = errors.New("expires_at must be in the future")
+	ErrApiKeyRotated       = errors.New("api key has already been rotated")
+)
+
+// Columns an API key listing can be ordered by
+var apiKeyOrderColumns = []string{"created_at", "name", "expires_at", "last_used_at"}
+
+type ApiKeyManager interface {
+	CreateApiKey(ctx context.Context, request request.CreateApiKeyRequest) (*response.CreatedApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, request request.ListApiKeysRequest) ([]response.ApiKeyResponse, int64, error)
+	GetApiKey(ctx context.Context, id uuid.UUID) (*response.ApiKeyResponse, error)
+	UpdateApiKey(ctx context.Context, request request.UpdateApiKeyRequest) (*response.ApiKeyResponse, error)
+	RevokeApiKey(ctx context.Context, id uuid.UUID) error
+	RotateApiKey(ctx context.Context, request request.RotateApiKeyRequest) (*response.CreatedApiKeyResponse, error)
+}
+
+type DefaultApiKeyManager struct {
+	logger       *zap.Logger
+	res          runtime.Resource
+	authorizer   rbac.Authorizer
+	auditLogger  audit.AuditLogger
+	repositories *repository.Repositories
+}
+
+func NewApiKeyManager(res runtime.Resource, authorizer rbac.Authorizer, auditLogger audit.AuditLogger, repositories *repository.Repositories) ApiKeyManager {
+	return &DefaultApiKeyManager{
+		logger:       res.Logger,
+		res:          res,
+		authorizer:   authorizer,
+		auditLogger:  auditLogger,
+		repositories: repositories,
+	}
+}
+
+func (d *DefaultApiKeyManager) CreateApiKey(ctx context.Context, request request.CreateApiKeyRequest) (*response.CreatedApiKeyResponse, error) {
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return nil, ErrApiKeyExpiresInPast
+	}
+	if request.OwnerType == owner.User {
+		if err := d.authorizeOwner(ctx, *request.UserID, request.CreatedBy, request.ActorRole); err != nil {
+			return nil, err
+		}
+	}
+
+	key := &entity.ApiKey{
+		Name:        strings.TrimSpace(request.Name),
+		OwnerType:   request.OwnerType,
+		ServiceName: request.ServiceName,
+		UserID:      request.UserID,
+		Scopes:      normalizeScopes(request.Scopes),
+		ExpiresAt:   request.ExpiresAt,
+		CreatedBy:   request.CreatedBy,
+	}
+	plaintext, err := assignSecret(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.repositories.ApiKeyRepository.Insert(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	auditOn(ctx, d.auditLogger, audit.ActionApiKeyCreated, key.UserID, apiKeyAuditMetadata(*key))
+	return &response.CreatedApiKeyResponse{ApiKeyResponse: toApiKeyResponse(*key), Key: plaintext}, nil
+}
+
+func (d *DefaultApiKeyManager) ListApiKeys(ctx context.Context, request request.ListApiKeysRequest) ([]response.ApiKeyResponse, int64, error) {
+	request.LoadDefaultValues()
+	if !slices.Contains(apiKeyOrderColumns, request.OrderBy) {
+		request.OrderBy = "created_at"
+	}
+
+	filter := repository.ApiKeyFilter{
+		OwnerType:   request.OwnerType,
+		ServiceName: request.ServiceName,
+		UserID:      request.UserID,
+		Revoked:     request.Revoked,
+	}
+	keys, total, err := d.repositories.ApiKeyRepository.FindMany(ctx, filter, request.ToPage())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	res := make([]response.ApiKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, toApiKeyResponse(k))
+	}
+	return res, int64(total), nil
+}
+
+func (d *DefaultApiKeyManager) GetApiKey(ctx context.Context, id uuid.UUID) (*response.ApiKeyResponse, error) {
+	key, err := d.findApiKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := toApiKeyResponse(*key)
+	return &res, nil
+}
+
+func (d *DefaultApiKeyManager) UpdateApiKey(ctx context.Context, request request.UpdateApiKeyRequest) (*response.ApiKeyResponse, error) {
+	key, err := d.findApiKey(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if key.Revoked {
+		return nil, ErrApiKeyRevoked
+	}
+
+	if request.Name != nil {
+		key.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Scopes != nil {
+		key.Scopes = normalizeScopes(request.Scopes)
+	}
+	if request.ExpiresAt != nil {
+		if !request.ExpiresAt.After(time.Now()) {
+			return nil, ErrApiKeyExpiresInPast
+		}
+		key.ExpiresAt = request.ExpiresAt
+	}
+
+	updated, err := d.repositories.ApiKeyRepository.Update(ctx, *key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Revoked concurrently
+			return nil, ErrApiKeyRevoked
+		}
+		return nil, fmt.Errorf("failed to update api key: %w", err)
+	}
+	auditOn(ctx, d.auditLogger, audit.ActionApiKeyUpdated, updated.UserID, apiKeyAuditMetadata(*updated))
+	res := toApiKeyResponse(*updated)
+	return &res, nil
+}
+
+func (d *DefaultApiKeyManager) RevokeApiKey(ctx context.Context, id uuid.UUID) error {
+	key, err := d.findApiKey(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key.Revoked {
+		return nil
+	}
+	if _, err := d.repositories.ApiKeyRepository.Revoke(ctx, id); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	auditOn(ctx, d.auditLogger, audit.ActionApiKeyRevoked, key.UserID, apiKeyAuditMetadata(*key))
+	return nil
+}
+
+// RotateApiKey issues a new secret with the same owner and scopes, the previous key keeps working during the overlap
+func (d *DefaultApiKeyManager) RotateApiKey(ctx context.Context, request request.RotateApiKeyRequest) (*response.CreatedApiKeyResponse, error) {
+	current, err := d.findApiKey(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !current.IsActive(time.Now()) {
+		return nil, ErrApiKeyRevoked
+	}
+	if current.RotatedToID != nil {
+		return nil, ErrApiKeyRotated
+	}
+	// The new secret is handed to the caller, who must be allowed to act as the owner
+	if current.OwnerType == owner.User && current.UserID != nil {
+		if err := d.authorizeOwner(ctx, *current.UserID, request.RotatedBy, request.ActorRole); err != nil {
+			return nil, err
+		}
+	}
+
+	next := &entity.ApiKey{
+		Name:        current.Name,
+		OwnerType:   current.OwnerType,
+		ServiceName: current.ServiceName,
+		UserID:      current.UserID,
+		Scopes:      current.Scopes,
+		ExpiresAt:   current.ExpiresAt,
+		CreatedBy:   request.RotatedBy,
+	}
+	plaintext, err := assignSecret(next)
+	if err != nil {
+		return nil, err
+	}
+
+	overlapUntil := time.Now().Add(d.rotationOverlap(request.OverlapSeconds))
+	if _, err := d.repositories.ApiKeyRepository.Rotate(ctx, current.ID, overlapUntil, next); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Revoked or rotated concurrently
+			return nil, ErrApiKeyRotated
+		}
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	metadata := apiKeyAuditMetadata(*next)
+	metadata["rotated_from_id"] = current.ID.String()
+	metadata["previous_expires_at"] = overlapUntil.UTC().Format(time.RFC3339)
+	auditOn(ctx, d.auditLogger, audit.ActionApiKeyRotated, next.UserID, metadata)
+	return &response.CreatedApiKeyResponse{ApiKeyResponse: toApiKeyResponse(*next), Key: plaintext}, nil
+}
+
+func (d *DefaultApiKeyManager) findApiKey(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error) {
+	key, err := d.repositories.ApiKeyRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrApiKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return key, nil
+}
+
+// authorizeOwner allows a user key only for the actor's own account or for a user whose role grants nothing the actor lacks,
+// the key acts with the owner's permissions
+func (d *DefaultApiKeyManager) authorizeOwner(ctx context.Context, ownerID uuid.UUID, actorID *uuid.UUID, actorRole string) error {
+	u, err := d.repositories.UserRepository.FindByID(ctx, ownerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if actorID != nil && *actorID == u.ID {
+		return nil
+	}
+	held, err := d.authorizer.Permissions(ctx, actorRole)
+	if err != nil {
+		return fmt.Errorf("failed to resolve permissions: %w", err)
+	}
+	required, err := d.authorizer.Permissions(ctx, string(u.Role))
+	if err != nil {
+		return fmt.Errorf("failed to resolve permissions: %w", err)
+	}
+	if !rbac.HasAll(held, required...) {
+		return ErrInsufficientPrivileges
+	}
+	return nil
+}
+
+// rotationOverlap applies the configured default and upper bound to the requested overlap
+func (d *DefaultApiKeyManager) rotationOverlap(requestedSeconds *int) time.Duration {
+	cfg := d.res.Config.ApiKeyConfig
+	overlap := cfg.RotationOverlap
+	if requestedSeconds != nil {
+		overlap = time.Duration(*requestedSeconds) * time.Second
+	}
+	if cfg.MaxRotationOverlap > 0 && overlap > cfg.MaxRotationOverlap {
+		overlap = cfg.MaxRotationOverlap
+	}
+	return overlap
+}
+
+// assignSecret generates the key material and returns the plaintext to hand out once
+func assignSecret(key *entity.ApiKey) (string, error) {
+	generated, err := apikey.Generate()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key.Prefix = generated.Prefix
+	key.SecretHash = generated.Hash
+	return generated.Plaintext, nil
+}
+
+func normalizeScopes(scopes []string) []string {
+	res := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		s = strings.TrimSpace(s)
+		if !slices.Contains(res, s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// apiKeyAuditMetadata identifies the key in the audit log, never the secret
+func apiKeyAuditMetadata(k entity.ApiKey) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"api_key_id": k.ID.String(),
+		"prefix":     k.Prefix,
+		"owner_type": string(k.OwnerType),
+		"scopes":     k.Scopes,
+	}
+	if k.ServiceName != nil {
+		metadata["service_name"] = *k.ServiceName
+	}
+	return metadata
+}
+
+func toApiKeyResponse(k entity.ApiKey) response.ApiKeyResponse {
+	return response.ApiKeyResponse{
+		ID:            k.ID,
+		Name:          k.Name,
+		Prefix:        k.Prefix,
+		OwnerType:     k.OwnerType,
+		ServiceName:   k.ServiceName,
+		UserID:        k.UserID,
+		Scopes:        k.Scopes,
+		ExpiresAt:     k.ExpiresAt,
+		LastUsedAt:    k.LastUsedAt,
+		Revoked:       k.Revoked,
+		RevokedAt:     k.RevokedAt,
+		RotatedFromID: k.RotatedFromID,
+		RotatedToID:   k.RotatedToID,
+		CreatedAt:     k.CreatedAt,
+	}
+}
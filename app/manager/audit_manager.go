@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+var ErrInvalidAuditCursor = errors.New("invalid cursor")
+
+// Events read per query while verifying the hash chain
+const auditVerifyBatchSize = 1000
+
+type AuditManager interface {
+	// ListEvents returns matching events newest first and the cursor of the next page, nil on the last page
+	ListEvents(ctx context.Context, request request.ListAuditEventsRequest) ([]response.AuditEventResponse, *string, error)
+	// VerifyChain recomputes the hash chain from the first event
+	VerifyChain(ctx context.Context) (*response.AuditChainVerificationResponse, error)
+}
+
+type DefaultAuditManager struct {
+	logger       *zap.Logger
+	res          runtime.Resource
+	repositories *repository.Repositories
+}
+
+func NewAuditManager(res runtime.Resource, repositories *repository.Repositories) AuditManager {
+	return &DefaultAuditManager{
+		logger:       res.Logger,
+		res:          res,
+		repositories: repositories,
+	}
+}
+
+func (d *DefaultAuditManager) ListEvents(ctx context.Context, request request.ListAuditEventsRequest) ([]response.AuditEventResponse, *string, error) {
+	request.LoadDefaultValues()
+	filter := repository.AuditEventFilter{
+		Action:    request.Action,
+		ActorID:   request.ActorID,
+		SubjectID: request.SubjectID,
+		RequestID: request.RequestID,
+		From:      request.From,
+		Before:    request.Before,
+	}
+	if request.Cursor != "" {
+		seq, err := strconv.ParseInt(request.Cursor, 10, 64)
+		if err != nil || seq < 1 {
+			return nil, nil, ErrInvalidAuditCursor
+		}
+		filter.BeforeSeq = &seq
+	}
+
+	// One extra event tells whether another page follows
+	events, err := d.repositories.AuditEventRepository.FindMany(ctx, filter, request.Size+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	var next *string
+	if len(events) > request.Size {
+		events = events[:request.Size]
+		cursor := strconv.FormatInt(events[len(events)-1].Seq, 10)
+		next = &cursor
+	}
+
+	res := make([]response.AuditEventResponse, 0, len(events))
+	for _, e := range events {
+		res = append(res, toAuditEventResponse(e))
+	}
+	return res, next, nil
+}
+
+func (d *DefaultAuditManager) VerifyChain(ctx context.Context) (*response.AuditChainVerificationResponse, error) {
+	res := &response.AuditChainVerificationResponse{Valid: true}
+	var afterSeq int64
+	previousHash := ""
+	for {
+		events, err := d.repositories.AuditEventRepository.FindAfter(ctx, afterSeq, auditVerifyBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit events: %w", err)
+		}
+		if i := audit.Verify(previousHash, events); i >= 0 {
+			broken := events[i]
+			res.Valid = false
+			res.Checked += int64(i + 1)
+			res.BrokenAtSeq = &broken.Seq
+			res.BrokenAtID = &broken.ID
+			d.logger.Error("audit chain is broken", zap.Int64("seq", broken.Seq), zap.String("audit_event_id", broken.ID.String()))
+			return res, nil
+		}
+		res.Checked += int64(len(events))
+		if len(events) < auditVerifyBatchSize {
+			return res, nil
+		}
+		last := events[len(events)-1]
+		afterSeq, previousHash = last.Seq, last.Hash
+	}
+}
+
+func toAuditEventResponse(e entity.AuditEvent) response.AuditEventResponse {
+	return response.AuditEventResponse{
+		ID:        e.ID,
+		Seq:       e.Seq,
+		Action:    e.Action,
+		ActorType: string(e.ActorType),
+		ActorID:   e.ActorID,
+		ActorName: e.ActorName,
+		SubjectID: e.SubjectID,
+		IPAddress: e.IPAddress,
+		UserAgent: e.UserAgent,
+		RequestID: e.RequestID,
+		Metadata:  e.Metadata,
+		Hash:      e.Hash,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -3,21 +3,35 @@ package manager
 import (
 	"backend/service-platform/app/api/client/request"
 	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/job"
 	"backend/service-platform/app/database/constant/role"
 	userstatus "backend/service-platform/app/database/constant/user"
 	"backend/service-platform/app/database/entity"
 	"backend/service-platform/app/database/repository"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
 	"backend/service-platform/app/pkg/bcrypt"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/encryption"
 	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/oidc"
+	"backend/service-platform/app/pkg/password"
+	"backend/service-platform/app/pkg/redis"
+	"backend/service-platform/app/pkg/sms"
+	ctxutil "backend/service-platform/app/pkg/util/context"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
 	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/spartan-truongvi/redis_rate/v10"
 	"go.uber.org/zap"
 )
 
@@ -25,38 +39,118 @@ var (
 	ErrUsernameAlreadyExisted = errors.New("username already exists")
 	ErrEmailAlreadyExists     = errors.New("email already exists")
 	ErrInvalidCredentials     = errors.New("invalid credentials")
+	ErrAccountDisabled        = errors.New("account is disabled")
 	ErrInvalidRefreshToken    = errors.New("invalid refresh token")
 	ErrRefreshTokenExpired    = errors.New("refresh token has expired")
 	ErrRefreshTokenRevoked    = errors.New("refresh token has been revoked")
+	ErrRefreshTokenReused     = errors.New("refresh token reuse detected")
+
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+	ErrVerificationTokenExpired = errors.New("verification token has expired")
+
+	ErrInvalidResetToken = errors.New("invalid reset password token")
+	ErrResetTokenExpired = errors.New("reset password token has expired")
+	ErrTooManyRequests   = errors.New("too many requests")
 )
 
+// RateLimitError is returned when a throttled action is refused; it matches ErrTooManyRequests and its Reason
+type RateLimitError struct {
+	RetryAfter time.Duration
+	// Optional cause more specific than ErrTooManyRequests, such as ErrAccountLocked
+	Reason error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reason != nil {
+		return e.Reason.Error()
+	}
+	return ErrTooManyRequests.Error()
+}
+
+func (e *RateLimitError) Unwrap() []error {
+	if e.Reason != nil {
+		return []error{ErrTooManyRequests, e.Reason}
+	}
+	return []error{ErrTooManyRequests}
+}
+
 type AuthManager interface {
 	Logout(ctx context.Context, request request.LogoutRequest) error
 	Login(ctx context.Context, request request.AuthUserRequest) (*response.AuthResponse, error)
 	RefreshToken(ctx context.Context, request request.RefreshTokenRequest) (*response.AuthResponse, error)
 	Register(ctx context.Context, request request.RegisterRequest) error
+	VerifyEmail(ctx context.Context, request request.VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, request request.ResendVerificationRequest) error
+	ForgotPassword(ctx context.Context, request request.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request request.ResetPasswordRequest) error
+	VerifyMfa(ctx context.Context, request request.MfaVerifyRequest) (*response.AuthResponse, error)
+	EnrollMfa(ctx context.Context, request request.MfaEnrollRequest) (*response.MfaEnrollmentResponse, error)
+	ConfirmMfa(ctx context.Context, request request.MfaConfirmRequest) (*response.MfaConfirmResponse, error)
+	DisableMfa(ctx context.Context, request request.MfaDisableRequest) error
+	// SendPhoneOtp texts a one-time code proving ownership of the phone number
+	SendPhoneOtp(ctx context.Context, request request.SendPhoneOtpRequest) (*response.PhoneOtpResponse, error)
+	VerifyPhoneOtp(ctx context.Context, request request.VerifyPhoneOtpRequest) (*response.UserResponse, error)
+	// StartOidcLogin returns the provider authorization URL of an authorization code flow with PKCE
+	StartOidcLogin(ctx context.Context, request request.OidcStartRequest) (*response.OidcStartResponse, error)
+	// CompleteOidcLogin exchanges the code, verifies the ID token and signs in the linked or created user
+	CompleteOidcLogin(ctx context.Context, request request.OidcCallbackRequest) (*response.AuthResponse, error)
+	// CreateSiweNonce issues the single use nonce a Sign-In With Ethereum message must carry
+	CreateSiweNonce(ctx context.Context) (*response.SiweNonceResponse, error)
+	// SiweLogin verifies the signed EIP-4361 message and signs in the wallet's user, creating it on first use
+	SiweLogin(ctx context.Context, request request.SiweLoginRequest) (*response.AuthResponse, error)
+	UnlockAccount(ctx context.Context, userID uuid.UUID) error
+	// ReactivateAccount lifts a self-service deactivation and cancels a pending deletion
+	ReactivateAccount(ctx context.Context, request request.AuthUserRequest) error
 }
 
 type DefaultAuthManager struct {
-	logger       *zap.Logger
-	res          runtime.Resource
-	hasher       bcrypt.Hasher
-	jwtManager   jwt.Jwt
-	repositories *repository.Repositories
+	logger        *zap.Logger
+	res           runtime.Resource
+	hasher        bcrypt.Hasher
+	policy        password.Policy
+	jwtManager    jwt.Jwt
+	jobManager    JobManager
+	rateLimiter   redis.RateLimiter
+	encryptor     encryption.Encryptor
+	denylist      denylist.Denylist
+	smsSender     sms.Sender
+	oidcProviders *oidc.Registry
+	auditLogger   audit.AuditLogger
+	repositories  *repository.Repositories
+
+	loginProtection loginProtection
 }
 
 func NewAuthManager(
 	res runtime.Resource,
 	hasher bcrypt.Hasher,
+	policy password.Policy,
 	jwtManager jwt.Jwt,
+	jobManager JobManager,
+	rateLimiter redis.RateLimiter,
+	encryptor encryption.Encryptor,
+	denylist denylist.Denylist,
+	smsSender sms.Sender,
+	oidcProviders *oidc.Registry,
+	auditLogger audit.AuditLogger,
 	repositories *repository.Repositories,
 ) AuthManager {
 	return &DefaultAuthManager{
-		res:          res,
-		logger:       res.Logger,
-		hasher:       hasher,
-		jwtManager:   jwtManager,
-		repositories: repositories,
+		res:           res,
+		logger:        res.Logger,
+		hasher:        hasher,
+		policy:        policy,
+		jwtManager:    jwtManager,
+		jobManager:    jobManager,
+		rateLimiter:   rateLimiter,
+		encryptor:     encryptor,
+		denylist:      denylist,
+		smsSender:     smsSender,
+		oidcProviders: oidcProviders,
+		auditLogger:   auditLogger,
+		repositories:  repositories,
+
+		loginProtection: newLoginProtection(res, rateLimiter, auditLogger, repositories.UserRepository),
 	}
 }
 
@@ -70,11 +164,27 @@ func (d *DefaultAuthManager) Logout(ctx context.Context, request request.LogoutR
 	if err := d.repositories.SessionRepository.RevokeByToken(ctx, hashed); err != nil {
 		return fmt.Errorf("failed to revoke session: %w", err)
 	}
+	if claims.UserID != nil {
+		d.auditUser(ctx, audit.ActionLoggedOut, *claims.UserID, nil)
+	}
 
+	if request.AccessToken != "" {
+		accessClaims, err := d.jwtManager.ValidateToken(request.AccessToken)
+		// Only deny an access token of the same user, an expired one needs nothing
+		if err == nil && accessClaims.UserID != nil && claims.UserID != nil && *accessClaims.UserID == *claims.UserID {
+			if err := d.denylist.RevokeToken(ctx, accessClaims); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
 func (d *DefaultAuthManager) Register(ctx context.Context, request request.RegisterRequest) error {
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.RegisterIPRateKey, d.res.Config.LoginProtectionConfig.RegisterIPLimit); err != nil {
+		return err
+	}
+
 	_, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email)
 	if err == nil {
 		return ErrEmailAlreadyExists
@@ -92,6 +202,10 @@ func (d *DefaultAuthManager) Register(ctx context.Context, request request.Regis
 		return err
 	}
 
+	// The email doubles as the username
+	if err := checkPasswordPolicy(d.policy, request.Password, request.Email); err != nil {
+		return err
+	}
 	hashed, err := d.hasher.HashPassword(request.Password)
 	if err != nil {
 		return err
@@ -109,29 +223,264 @@ func (d *DefaultAuthManager) Register(ctx context.Context, request request.Regis
 
 	_, err = d.repositories.UserRepository.Insert(ctx, user)
 	if err != nil {
+		// Lost a race with a concurrent registration
+		if conflict := uniqueUserConflict(err); conflict != nil {
+			return conflict
+		}
 		return err
 	}
+
+	// The account exists at this point, a failed email can be requested again
+	if err := d.enqueueVerificationEmail(ctx, user); err != nil {
+		d.logger.Error("failed to enqueue verification email", zap.String("user_id", user.ID.String()), zap.Error(err))
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) VerifyEmail(ctx context.Context, request request.VerifyEmailRequest) error {
+	token, err := d.repositories.EmailVerificationTokenRepository.FindByToken(ctx, securetoken.Hash(request.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to find verification token: %w", err)
+	}
+	if token.UsedAt != nil {
+		return ErrInvalidVerificationToken
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return ErrVerificationTokenExpired
+	}
+
+	u, err := d.repositories.UserRepository.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	// The token is for the current address or for a requested change, anything else changed since it was issued
+	changing := u.PendingEmail != nil && *u.PendingEmail == token.Email
+	if !changing && (u.Email == nil || *u.Email != token.Email) {
+		return ErrInvalidVerificationToken
+	}
+
+	if _, err := d.repositories.EmailVerificationTokenRepository.MarkUsed(ctx, token.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to consume verification token: %w", err)
+	}
+	if changing {
+		return d.confirmEmailChange(ctx, u, token.Email)
+	}
+	if err := d.repositories.UserRepository.MarkEmailVerified(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
 	return nil
 }
 
+func (d *DefaultAuthManager) confirmEmailChange(ctx context.Context, u *entity.User, email string) error {
+	if err := d.repositories.UserRepository.ConfirmEmailChange(ctx, u.ID, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidVerificationToken
+		}
+		// Another account took the address while the link was pending
+		if conflict := uniqueUserConflict(err); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to change email: %w", err)
+	}
+	d.auditUser(ctx, audit.ActionEmailChanged, u.ID, nil)
+	return nil
+}
+
+// ResendVerification never reveals whether the email belongs to an account
+func (d *DefaultAuthManager) ResendVerification(ctx context.Context, request request.ResendVerificationRequest) error {
+	u, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if u.EmailVerified {
+		return nil
+	}
+	return d.enqueueVerificationEmail(ctx, u)
+}
+
+// ForgotPassword never reveals whether the email belongs to an account
+func (d *DefaultAuthManager) ForgotPassword(ctx context.Context, request request.ForgotPasswordRequest) error {
+	cfg := d.res.Config.PasswordResetConfig
+	if err := d.throttle(ctx, rediskey.PasswordResetIPRateKey(request.IPAddress), cfg.IPLimit); err != nil {
+		return err
+	}
+	if err := d.throttle(ctx, rediskey.PasswordResetEmailRateKey(strings.ToLower(request.Email)), cfg.EmailLimit); err != nil {
+		return err
+	}
+
+	u, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	_, err = d.jobManager.CreateJob(ctx, CreateJobRequest{
+		Type:     string(job.SendPasswordResetEmail),
+		Priority: job.PriorityHigh,
+		Payload:  map[string]interface{}{job.PayloadUserID: u.ID.String()},
+	})
+	if err != nil {
+		d.logger.Error("failed to enqueue password reset email", zap.String("user_id", u.ID.String()), zap.Error(err))
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) ResetPassword(ctx context.Context, request request.ResetPasswordRequest) error {
+	if err := d.throttle(ctx, rediskey.PasswordResetIPRateKey(request.IPAddress), d.res.Config.PasswordResetConfig.IPLimit); err != nil {
+		return err
+	}
+
+	token, err := d.repositories.PasswordResetTokenRepository.FindByToken(ctx, securetoken.Hash(request.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to find reset token: %w", err)
+	}
+	if token.UsedAt != nil {
+		return ErrInvalidResetToken
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return ErrResetTokenExpired
+	}
+
+	u, err := d.repositories.UserRepository.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	// Checked before the token is consumed so the user can pick another password with the same link
+	if err := checkPasswordPolicy(d.policy, request.Password, userPersonalInfo(u)...); err != nil {
+		return err
+	}
+
+	if _, err := d.repositories.PasswordResetTokenRepository.MarkUsed(ctx, token.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to consume reset token: %w", err)
+	}
+
+	hashed, err := d.hasher.HashPassword(request.Password)
+	if err != nil {
+		return err
+	}
+	if err := d.repositories.UserRepository.UpdatePassword(ctx, u.ID, hashed); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := d.repositories.PasswordResetTokenRepository.InvalidateByUserID(ctx, u.ID); err != nil {
+		d.logger.Warn("failed to invalidate remaining reset tokens", zap.Error(err))
+	}
+	// Sign out everywhere, the old password may have been compromised
+	if err := d.repositories.SessionRepository.RevokeByUserID(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	if err := d.denylist.RevokeUserTokens(ctx, u.ID); err != nil {
+		return err
+	}
+	d.auditUser(ctx, audit.ActionPasswordReset, u.ID, nil)
+	return nil
+}
+
+// throttle consumes one request from an hourly budget; a non-positive limit disables the check
+func (d *DefaultAuthManager) throttle(ctx context.Context, key string, perHour int) error {
+	if perHour <= 0 {
+		return nil
+	}
+	return d.allow(ctx, key, redis_rate.PerHour(perHour))
+}
+
+func (d *DefaultAuthManager) allow(ctx context.Context, key string, limit redis_rate.Limit) error {
+	res, err := d.rateLimiter.Allow(ctx, key, limit)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if res.Allowed == 0 {
+		return &RateLimitError{RetryAfter: res.RetryAfter}
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) enqueueVerificationEmail(ctx context.Context, user *entity.User) error {
+	_, err := d.jobManager.CreateJob(ctx, CreateJobRequest{
+		Type:     string(job.SendVerificationEmail),
+		Priority: job.PriorityHigh,
+		Payload:  map[string]interface{}{job.PayloadUserID: user.ID.String()},
+	})
+	return err
+}
+
 func (d *DefaultAuthManager) Login(ctx context.Context, request request.AuthUserRequest) (*response.AuthResponse, error) {
+	cfg := d.res.Config.LoginProtectionConfig
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.LoginIPRateKey, cfg.LoginIPLimit); err != nil {
+		return nil, err
+	}
+	if err := d.loginProtection.throttleWindow(ctx, rediskey.LoginEmailRateKey(strings.ToLower(request.Email)), cfg.LoginEmailLimit); err != nil {
+		return nil, err
+	}
+
 	u, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			d.auditLogger.Record(ctx, audit.Event{
+				Action: audit.ActionLoginFailed,
+				// The email stays out of the append-only log, it could never be anonymized
+				Metadata: map[string]interface{}{"reason": loginFailureUnknownEmail},
+			})
 			return nil, ErrInvalidCredentials
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 
+	// A locked account is refused even with the right password
+	if u.IsLocked(time.Now()) {
+		d.auditLoginFailed(ctx, u.ID, loginFailureAccountLocked)
+		return nil, &RateLimitError{RetryAfter: time.Until(*u.LockedUntil), Reason: ErrAccountLocked}
+	}
+
 	// Verify password
 	valid, err := d.hasher.CheckPassword(request.Password, u.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check password: %w", err)
 	}
 	if !valid {
-		return nil, ErrInvalidCredentials
+		d.auditLoginFailed(ctx, u.ID, loginFailureInvalidPassword)
+		return nil, d.loginProtection.recordFailedLogin(ctx, u, ErrInvalidCredentials)
+	}
+	d.loginProtection.resetLoginFailures(ctx, u)
+	d.upgradePasswordHash(ctx, u, request.Password)
+
+	// Checked after the password so suspended or deactivated accounts cannot be discovered
+	if err := checkAccountActive(u); err != nil {
+		d.auditLoginFailed(ctx, u.ID, loginFailureAccountInactive)
+		return nil, err
+	}
+
+	// Hold back the tokens until the second factor is verified
+	if u.MfaEnabled || d.res.Config.MfaConfig.IsRequiredFor(u.Role) {
+		return d.createMfaChallenge(ctx, u)
 	}
+	return d.completeLogin(ctx, u, loginMethodPassword)
+}
 
+// completeLogin records the login and issues the access/refresh token pair
+func (d *DefaultAuthManager) completeLogin(ctx context.Context, u *entity.User, method string) (*response.AuthResponse, error) {
 	// Update last login timestamp
 	if err := d.repositories.UserRepository.UpdateLastLoginAt(ctx, u.ID); err != nil {
 		d.logger.Warn("failed to update last login timestamp", zap.Error(err))
@@ -150,6 +499,8 @@ func (d *DefaultAuthManager) Login(ctx context.Context, request request.AuthUser
 		return nil, err
 	}
 
+	d.auditUser(ctx, audit.ActionLoginSucceeded, u.ID, map[string]interface{}{"method": method})
+
 	// Get roles for response
 	userRoles := []role.Role{u.Role}
 	resp := d.createAuthResponse(&u.Username, &userRoles, accessToken.Token, refreshTokenString)
@@ -160,42 +511,87 @@ func (d *DefaultAuthManager) RefreshToken(
 	ctx context.Context,
 	request request.RefreshTokenRequest,
 ) (*response.AuthResponse, error) {
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.RefreshIPRateKey, d.res.Config.LoginProtectionConfig.RefreshIPLimit); err != nil {
+		return nil, err
+	}
+
 	// Validate provided refresh token
 	claims, err := d.jwtManager.ValidateToken(request.RefreshToken)
 	if err != nil || claims.RefreshTokenBase64 == nil || *claims.RefreshTokenBase64 == "" {
 		return nil, ErrInvalidRefreshToken
 	}
 	// Validate session by hashed token
-	session, err := d.validateSession(ctx, d.hash(*claims.RefreshTokenBase64))
+	hashed := d.hash(*claims.RefreshTokenBase64)
+	session, err := d.validateSession(ctx, hashed)
 	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) {
+			return nil, d.detectRefreshTokenReuse(ctx, hashed)
+		}
 		return nil, err
 	}
 
-	// Find user by session
+	// The new tokens carry the current role and token version, an account that can no longer sign in gets none
 	u, err := d.repositories.UserRepository.FindByID(ctx, session.UserID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
+	if err := checkAccountActive(u); err != nil {
+		return nil, err
+	}
 	accessToken, err := d.generateUserAccessToken(ctx, u)
 	if err != nil {
 		return nil, err
 	}
 
-	// Issue new refresh token and revoke the old one
-	newRefreshTokenString, err := d.createSession(ctx, u)
+	// Issue the next refresh token of the family, the old one is retired in the same transaction
+	newRefreshTokenString, next, err := d.newSession(ctx, u)
 	if err != nil {
 		return nil, err
 	}
-	// Revoke old session by hashed token
-	h := sha256.Sum256([]byte(*claims.RefreshTokenBase64))
-	oldHashed := hex.EncodeToString(h[:])
-	_ = d.repositories.SessionRepository.RevokeByToken(ctx, oldHashed)
+	if _, err := d.repositories.SessionRepository.Rotate(ctx, session.ID, next); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// A concurrent refresh won the race
+			return nil, ErrRefreshTokenRevoked
+		}
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
+	}
+	d.auditUser(ctx, audit.ActionTokenRefreshed, u.ID, map[string]interface{}{"session_id": next.ID.String()})
 
 	// Get roles for response
 	userRoles := []role.Role{u.Role}
 	return d.createAuthResponse(&u.Username, &userRoles, accessToken.Token, newRefreshTokenString), nil
 }
 
+// detectRefreshTokenReuse revokes the whole family when an already rotated refresh token is presented again
+func (d *DefaultAuthManager) detectRefreshTokenReuse(ctx context.Context, hashedToken string) error {
+	rotated, err := d.repositories.SessionRepository.FindRotatedByToken(ctx, hashedToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidRefreshToken
+		}
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	family := rotated.Family()
+	revoked, err := d.repositories.SessionRepository.RevokeFamily(ctx, family)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session family: %w", err)
+	}
+	d.auditLogger.Record(ctx, audit.Event{
+		Action:    audit.ActionRefreshTokenReuse,
+		SubjectID: &rotated.UserID,
+		Metadata: map[string]interface{}{
+			"session_id":       rotated.ID.String(),
+			"family_id":        family.String(),
+			"revoked_sessions": revoked,
+		},
+	})
+	return ErrRefreshTokenReused
+}
+
 func (d *DefaultAuthManager) validateSession(ctx context.Context, token string) (*entity.Session, error) {
 	session, err := d.repositories.SessionRepository.FindByToken(ctx, token)
 	if err != nil {
@@ -214,6 +610,18 @@ func (d *DefaultAuthManager) validateSession(ctx context.Context, token string)
 }
 
 func (d *DefaultAuthManager) createSession(ctx context.Context, user *entity.User) (string, error) {
+	refreshToken, session, err := d.newSession(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	if _, err = d.repositories.SessionRepository.Insert(ctx, session); err != nil {
+		return "", err
+	}
+	return refreshToken, nil
+}
+
+// newSession signs a refresh token and builds its session row without persisting it
+func (d *DefaultAuthManager) newSession(ctx context.Context, user *entity.User) (string, *entity.Session, error) {
 	roleStr := string(user.Role)
 	refreshToken, err := d.jwtManager.GenerateRefreshToken(
 		&user.ID,
@@ -224,17 +632,23 @@ func (d *DefaultAuthManager) createSession(ctx context.Context, user *entity.Use
 		&user.EmailVerified,
 		&user.PhoneVerified,
 		user.LastLoginAt,
+		&user.TokenVersion,
 	)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	exp := time.Now().Add(d.res.Config.JwtConfig.RefreshExpiration)
-	_, err = d.repositories.SessionRepository.Insert(ctx, &entity.Session{UserID: user.ID, Token: d.hash(refreshToken.TokenBase64), ExpiresAt: &exp})
-	if err != nil {
-		return "", err
+	session := &entity.Session{UserID: user.ID, Token: d.hash(refreshToken.TokenBase64), ExpiresAt: &exp}
+	if client, ok := ctxutil.ClientInfoKey.Get(ctx); ok {
+		if client.UserAgent != "" {
+			session.UserAgent = &client.UserAgent
+		}
+		if client.IPAddress != "" {
+			session.IPAddress = &client.IPAddress
+		}
 	}
-	return refreshToken.Token, nil
+	return refreshToken.Token, session, nil
 }
 
 // createAuthResponse creates a standardized auth response
@@ -266,6 +680,7 @@ func (d *DefaultAuthManager) generateUserAccessToken(_ context.Context, user *en
 		&user.EmailVerified,
 		&user.PhoneVerified,
 		user.LastLoginAt,
+		&user.TokenVersion,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
@@ -273,6 +688,23 @@ func (d *DefaultAuthManager) generateUserAccessToken(_ context.Context, user *en
 	return accessToken, nil
 }
 
+// upgradePasswordHash replaces a hash of an outdated algorithm or cost while the plain password is at hand
+func (d *DefaultAuthManager) upgradePasswordHash(ctx context.Context, u *entity.User, password string) {
+	if !d.hasher.NeedsRehash(u.Password) {
+		return
+	}
+	hashed, err := d.hasher.HashPassword(password)
+	if err != nil {
+		d.logger.Warn("failed to rehash password", zap.String("user_id", u.ID.String()), zap.Error(err))
+		return
+	}
+	if err := d.repositories.UserRepository.UpdatePassword(ctx, u.ID, hashed); err != nil {
+		d.logger.Warn("failed to store rehashed password", zap.String("user_id", u.ID.String()), zap.Error(err))
+		return
+	}
+	u.Password = hashed
+}
+
 func (d *DefaultAuthManager) hash(rawValue string) string {
 	h := sha256.Sum256([]byte(rawValue))
 	return hex.EncodeToString(h[:])
@@ -0,0 +1,82 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/audit"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAccountDeactivated     = errors.New("account is deactivated")
+	ErrAccountPendingDeletion = errors.New("account is scheduled for deletion")
+	ErrAccountActive          = errors.New("account is already active")
+)
+
+// checkAccountActive refuses a sign-in to an account that is suspended, deactivated or waiting to be deleted
+func checkAccountActive(u *entity.User) error {
+	switch {
+	case u.Status == userstatus.Disabled:
+		return ErrAccountDisabled
+	case u.DeletionScheduledAt != nil:
+		return ErrAccountPendingDeletion
+	case u.DeactivatedAt != nil:
+		return ErrAccountDeactivated
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) ReactivateAccount(ctx context.Context, request request.AuthUserRequest) error {
+	cfg := d.res.Config.LoginProtectionConfig
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.LoginIPRateKey, cfg.LoginIPLimit); err != nil {
+		return err
+	}
+	if err := d.loginProtection.throttleWindow(ctx, rediskey.LoginEmailRateKey(strings.ToLower(request.Email)), cfg.LoginEmailLimit); err != nil {
+		return err
+	}
+
+	u, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidCredentials
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if u.IsLocked(time.Now()) {
+		return &RateLimitError{RetryAfter: time.Until(*u.LockedUntil), Reason: ErrAccountLocked}
+	}
+
+	valid, err := d.hasher.CheckPassword(request.Password, u.Password)
+	if err != nil {
+		return fmt.Errorf("failed to check password: %w", err)
+	}
+	if !valid {
+		return d.loginProtection.recordFailedLogin(ctx, u, ErrInvalidCredentials)
+	}
+	d.loginProtection.resetLoginFailures(ctx, u)
+
+	// A suspension is lifted by an admin only
+	if u.Status == userstatus.Disabled {
+		return ErrAccountDisabled
+	}
+	if u.DeactivatedAt == nil && u.DeletionScheduledAt == nil {
+		return ErrAccountActive
+	}
+
+	if _, err := d.repositories.UserRepository.Reactivate(ctx, u.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidCredentials
+		}
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	d.auditUser(ctx, audit.ActionAccountReactivated, u.ID, map[string]interface{}{"deletion_cancelled": u.DeletionScheduledAt != nil})
+	return nil
+}
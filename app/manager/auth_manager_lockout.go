@@ -0,0 +1,40 @@
+package manager
+
+import (
+	"backend/service-platform/app/pkg/audit"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAccountLocked = errors.New("account is temporarily locked")
+	ErrUserNotFound  = errors.New("user not found")
+)
+
+// UnlockAccount lifts a lockout and the per-email login limit of the user
+func (d *DefaultAuthManager) UnlockAccount(ctx context.Context, userID uuid.UUID) error {
+	u, err := d.repositories.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if err := d.repositories.UserRepository.ResetLoginFailures(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+	if u.Email != nil {
+		if err := d.rateLimiter.Reset(ctx, rediskey.LoginEmailRateKey(strings.ToLower(*u.Email))); err != nil {
+			d.logger.Warn("failed to reset login rate limit", zap.String("user_id", u.ID.String()), zap.Error(err))
+		}
+	}
+	auditOn(ctx, d.auditLogger, audit.ActionAccountUnlocked, &u.ID, nil)
+	return nil
+}
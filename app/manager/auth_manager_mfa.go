@@ -0,0 +1,350 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/totp"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/spartan-truongvi/redis_rate/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	// Accept codes from the previous and next 30s step to absorb clock drift
+	mfaSkewSteps = 1
+
+	defaultMfaChallengeTTL      = 5 * time.Minute
+	defaultMfaMaxAttempts       = 5
+	defaultMfaRecoveryCodeCount = 10
+	recoveryCodeBytes           = 10
+)
+
+var (
+	ErrInvalidMfaToken       = errors.New("invalid or expired mfa token")
+	ErrInvalidMfaCode        = errors.New("invalid mfa code")
+	ErrMfaAlreadyEnabled     = errors.New("mfa is already enabled")
+	ErrMfaNotEnabled         = errors.New("mfa is not enabled")
+	ErrMfaNotEnrolled        = errors.New("mfa enrollment has not been started")
+	ErrMfaEnrollmentRequired = errors.New("mfa enrollment is required")
+	ErrMfaRequiredForRole    = errors.New("mfa is mandatory for this role")
+	ErrMfaAttemptsExceeded   = errors.New("too many invalid mfa codes")
+)
+
+// mfaChallenge is the pending login kept in Redis under the hash of the challenge token
+type mfaChallenge struct {
+	UserID uuid.UUID `json:"user_id"`
+	// The user must enroll before the login can complete
+	Enrollment bool `json:"enrollment"`
+}
+
+func (d *DefaultAuthManager) VerifyMfa(ctx context.Context, request request.MfaVerifyRequest) (*response.AuthResponse, error) {
+	challenge, tokenHash, err := d.loadMfaChallenge(ctx, request.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Enrollment {
+		return nil, ErrMfaEnrollmentRequired
+	}
+	if err := d.consumeMfaAttempt(ctx, rediskey.MfaChallengeAttemptsKey(tokenHash)); err != nil {
+		_ = d.res.Redis.Delete(ctx, rediskey.MfaChallengeKey(tokenHash))
+		return nil, err
+	}
+
+	u, err := d.repositories.UserRepository.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidMfaToken
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !u.MfaEnabled {
+		return nil, ErrInvalidMfaToken
+	}
+	if err := d.checkSecondFactor(ctx, u, request.Code); err != nil {
+		if errors.Is(err, ErrInvalidMfaCode) {
+			d.auditLoginFailed(ctx, u.ID, loginFailureInvalidMfaCode)
+		}
+		return nil, err
+	}
+
+	if err := d.res.Redis.Delete(ctx, rediskey.MfaChallengeKey(tokenHash)); err != nil {
+		d.logger.Warn("failed to delete mfa challenge", zap.Error(err))
+	}
+	return d.completeLogin(ctx, u, loginMethodMfa)
+}
+
+func (d *DefaultAuthManager) EnrollMfa(ctx context.Context, request request.MfaEnrollRequest) (*response.MfaEnrollmentResponse, error) {
+	u, _, err := d.resolveMfaUser(ctx, request.MfaToken, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u.MfaEnabled {
+		return nil, ErrMfaAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+	encrypted, err := d.encryptor.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+	// Stays disabled until a first code is confirmed
+	if err := d.repositories.UserRepository.UpdateMfa(ctx, u.ID, &encrypted, false); err != nil {
+		return nil, fmt.Errorf("failed to store totp secret: %w", err)
+	}
+
+	account := u.Username
+	if u.Email != nil {
+		account = *u.Email
+	}
+	return &response.MfaEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(d.res.Config.MfaConfig.Issuer, account, secret),
+	}, nil
+}
+
+func (d *DefaultAuthManager) ConfirmMfa(ctx context.Context, request request.MfaConfirmRequest) (*response.MfaConfirmResponse, error) {
+	u, tokenHash, err := d.resolveMfaUser(ctx, request.MfaToken, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	attemptsKey := rediskey.MfaUserAttemptsKey(u.ID.String())
+	if tokenHash != "" {
+		attemptsKey = rediskey.MfaChallengeAttemptsKey(tokenHash)
+	}
+	if err := d.consumeMfaAttempt(ctx, attemptsKey); err != nil {
+		return nil, err
+	}
+	if u.MfaEnabled {
+		return nil, ErrMfaAlreadyEnabled
+	}
+	if u.MfaSecret == nil {
+		return nil, ErrMfaNotEnrolled
+	}
+	if err := d.verifyTotp(ctx, u, request.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes(d.recoveryCodeCount())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+	if err := d.repositories.MfaRecoveryCodeRepository.ReplaceForUser(ctx, u.ID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	if err := d.repositories.UserRepository.UpdateMfa(ctx, u.ID, u.MfaSecret, true); err != nil {
+		return nil, fmt.Errorf("failed to enable mfa: %w", err)
+	}
+	u.MfaEnabled = true
+	d.auditUser(ctx, audit.ActionMfaEnabled, u.ID, nil)
+
+	resp := &response.MfaConfirmResponse{RecoveryCodes: codes}
+	if tokenHash != "" {
+		if err := d.res.Redis.Delete(ctx, rediskey.MfaChallengeKey(tokenHash)); err != nil {
+			d.logger.Warn("failed to delete mfa challenge", zap.Error(err))
+		}
+		resp.Auth, err = d.completeLogin(ctx, u, loginMethodMfa)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (d *DefaultAuthManager) DisableMfa(ctx context.Context, request request.MfaDisableRequest) error {
+	u, err := d.repositories.UserRepository.FindByID(ctx, request.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if !u.MfaEnabled {
+		return ErrMfaNotEnabled
+	}
+	if d.res.Config.MfaConfig.IsRequiredFor(u.Role) {
+		return ErrMfaRequiredForRole
+	}
+	if err := d.consumeMfaAttempt(ctx, rediskey.MfaUserAttemptsKey(u.ID.String())); err != nil {
+		return err
+	}
+	if err := d.checkSecondFactor(ctx, u, request.Code); err != nil {
+		return err
+	}
+
+	if err := d.repositories.UserRepository.UpdateMfa(ctx, u.ID, nil, false); err != nil {
+		return fmt.Errorf("failed to disable mfa: %w", err)
+	}
+	if err := d.repositories.MfaRecoveryCodeRepository.DeleteByUserID(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	d.auditUser(ctx, audit.ActionMfaDisabled, u.ID, nil)
+	return nil
+}
+
+func (d *DefaultAuthManager) createMfaChallenge(ctx context.Context, u *entity.User) (*response.AuthResponse, error) {
+	rawToken, err := securetoken.Generate(securetoken.DefaultSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+	}
+	challenge := mfaChallenge{UserID: u.ID, Enrollment: !u.MfaEnabled}
+	if err := d.res.Redis.Set(ctx, rediskey.MfaChallengeKey(securetoken.Hash(rawToken)), challenge, d.mfaChallengeTTL()); err != nil {
+		return nil, fmt.Errorf("failed to store mfa challenge: %w", err)
+	}
+
+	return &response.AuthResponse{
+		Username:              &u.Username,
+		TokenType:             jwt.TokenTypeBearer,
+		MfaRequired:           true,
+		MfaEnrollmentRequired: challenge.Enrollment,
+		MfaToken:              rawToken,
+	}, nil
+}
+
+func (d *DefaultAuthManager) loadMfaChallenge(ctx context.Context, rawToken string) (*mfaChallenge, string, error) {
+	tokenHash := securetoken.Hash(rawToken)
+	var challenge mfaChallenge
+	if err := d.res.Redis.Get(ctx, rediskey.MfaChallengeKey(tokenHash), &challenge); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, "", ErrInvalidMfaToken
+		}
+		return nil, "", fmt.Errorf("failed to load mfa challenge: %w", err)
+	}
+	return &challenge, tokenHash, nil
+}
+
+// resolveMfaUser finds the user either from an enrollment challenge or from the authenticated user id.
+// The returned token hash is empty when no challenge was used.
+func (d *DefaultAuthManager) resolveMfaUser(ctx context.Context, rawToken string, userID *uuid.UUID) (*entity.User, string, error) {
+	var tokenHash string
+	switch {
+	case rawToken != "":
+		challenge, hash, err := d.loadMfaChallenge(ctx, rawToken)
+		if err != nil {
+			return nil, "", err
+		}
+		if !challenge.Enrollment {
+			return nil, "", ErrInvalidMfaToken
+		}
+		userID, tokenHash = &challenge.UserID, hash
+	case userID == nil:
+		return nil, "", ErrInvalidMfaToken
+	}
+
+	u, err := d.repositories.UserRepository.FindByID(ctx, *userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrInvalidMfaToken
+		}
+		return nil, "", fmt.Errorf("failed to find user: %w", err)
+	}
+	return u, tokenHash, nil
+}
+
+// checkSecondFactor accepts a TOTP code or burns one of the recovery codes
+func (d *DefaultAuthManager) checkSecondFactor(ctx context.Context, u *entity.User, code string) error {
+	if len(strings.TrimSpace(code)) == totp.Digits {
+		return d.verifyTotp(ctx, u, code)
+	}
+
+	_, err := d.repositories.MfaRecoveryCodeRepository.Consume(ctx, u.ID, hashRecoveryCode(code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidMfaCode
+		}
+		return fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+	d.logger.Info("mfa recovery code used", zap.String("user_id", u.ID.String()))
+	return nil
+}
+
+func (d *DefaultAuthManager) verifyTotp(ctx context.Context, u *entity.User, code string) error {
+	if u.MfaSecret == nil {
+		return ErrMfaNotEnrolled
+	}
+	secret, err := d.encryptor.Decrypt(*u.MfaSecret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), mfaSkewSteps)
+	if !ok {
+		return ErrInvalidMfaCode
+	}
+
+	// A code can only be used once within its validity window
+	window := time.Duration(totp.Period*(2*mfaSkewSteps+1)) * time.Second
+	fresh, err := d.res.Redis.AcquireLock(ctx, rediskey.MfaUsedCodeKey(u.ID.String(), step), window)
+	if err != nil {
+		return fmt.Errorf("failed to record totp usage: %w", err)
+	}
+	if !fresh {
+		return ErrInvalidMfaCode
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) consumeMfaAttempt(ctx context.Context, key string) error {
+	maxAttempts := d.res.Config.MfaConfig.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMfaMaxAttempts
+	}
+	res, err := d.rateLimiter.Allow(ctx, key, redis_rate.Limit{Rate: maxAttempts, Burst: maxAttempts, Period: d.mfaChallengeTTL()})
+	if err != nil {
+		return fmt.Errorf("failed to check mfa attempts: %w", err)
+	}
+	if res.Allowed == 0 {
+		return ErrMfaAttemptsExceeded
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) mfaChallengeTTL() time.Duration {
+	if ttl := d.res.Config.MfaConfig.ChallengeTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultMfaChallengeTTL
+}
+
+func (d *DefaultAuthManager) recoveryCodeCount() int {
+	if count := d.res.Config.MfaConfig.RecoveryCodeCount; count > 0 {
+		return count
+	}
+	return defaultMfaRecoveryCodeCount
+}
+
+// generateRecoveryCodes returns the codes shown to the user once and the hashes to persist
+func generateRecoveryCodes(count int) ([]string, []string, error) {
+	codes := make([]string, 0, count)
+	hashes := make([]string, 0, count)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for range count {
+		bytes := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(encoding.EncodeToString(bytes))
+		code := raw[:len(raw)/2] + "-" + raw[len(raw)/2:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	return securetoken.Hash(normalized)
+}
@@ -0,0 +1,241 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/oidc"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const defaultOidcStateTTL = 10 * time.Minute
+
+var (
+	ErrOidcProviderNotFound = errors.New("oidc provider is not configured")
+	ErrInvalidOidcState     = errors.New("invalid or expired oidc state")
+	ErrOidcLoginFailed      = errors.New("oidc login failed")
+	ErrOidcAccountConflict  = errors.New("an account with this email already exists, sign in with it to link the provider")
+)
+
+// oidcLogin is the pending authorization kept in Redis under the hash of the state
+type oidcLogin struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+func (d *DefaultAuthManager) StartOidcLogin(ctx context.Context, request request.OidcStartRequest) (*response.OidcStartResponse, error) {
+	provider, err := d.oidcProvider(request.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := securetoken.Generate(securetoken.DefaultSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := securetoken.Generate(securetoken.DefaultSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build authorization url: %w", err)
+	}
+	pending := oidcLogin{Provider: provider.Name(), CodeVerifier: verifier, Nonce: nonce}
+	stateHash := securetoken.Hash(state)
+	if err := d.res.Redis.Set(ctx, rediskey.OidcStateKey(stateHash), pending, d.oidcStateTTL()); err != nil {
+		return nil, fmt.Errorf("failed to store oidc state: %w", err)
+	}
+	return &response.OidcStartResponse{AuthorizationURL: authURL, StateBinding: stateHash, StateTTL: d.oidcStateTTL()}, nil
+}
+
+func (d *DefaultAuthManager) CompleteOidcLogin(ctx context.Context, request request.OidcCallbackRequest) (*response.AuthResponse, error) {
+	// Shares the login budget of the client address with the password login
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.LoginIPRateKey, d.res.Config.LoginProtectionConfig.LoginIPLimit); err != nil {
+		return nil, err
+	}
+	provider, err := d.oidcProvider(request.Provider)
+	if err != nil {
+		return nil, err
+	}
+	// A state alone could be planted in another browser, it only counts in the one that started the login
+	if request.StateBinding == "" || subtle.ConstantTimeCompare([]byte(securetoken.Hash(request.State)), []byte(request.StateBinding)) != 1 {
+		return nil, ErrInvalidOidcState
+	}
+	pending, err := d.consumeOidcState(ctx, request.State)
+	if err != nil {
+		return nil, err
+	}
+	if pending.Provider != provider.Name() {
+		return nil, ErrInvalidOidcState
+	}
+	// The user denied the consent or the provider failed, the state is burnt either way
+	if request.Error != "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrOidcLoginFailed, request.Error, request.ErrorDescription)
+	}
+	if request.Code == "" {
+		return nil, fmt.Errorf("%w: missing authorization code", ErrOidcLoginFailed)
+	}
+
+	token, err := provider.Exchange(ctx, request.Code, pending.CodeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOidcLoginFailed, err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, pending.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOidcLoginFailed, err)
+	}
+
+	u, err := d.resolveOidcUser(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccountActive(u); err != nil {
+		return nil, err
+	}
+	if u.MfaEnabled || d.res.Config.MfaConfig.IsRequiredFor(u.Role) {
+		return d.createMfaChallenge(ctx, u)
+	}
+	return d.completeLogin(ctx, u, loginMethodOidc)
+}
+
+// resolveOidcUser returns the user linked to the identity, links it to the account with the same verified email,
+// or creates a new account
+func (d *DefaultAuthManager) resolveOidcUser(ctx context.Context, provider oidc.Provider, claims *oidc.Claims) (*entity.User, error) {
+	identity, err := d.repositories.UserIdentityRepository.FindByProviderSubject(ctx, provider.Name(), claims.Subject)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+	if identity != nil {
+		u, err := d.repositories.UserRepository.FindByID(ctx, identity.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: linked account no longer exists", ErrOidcLoginFailed)
+			}
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if err := d.repositories.UserIdentityRepository.UpdateLastLoginAt(ctx, identity.ID); err != nil {
+			d.logger.Warn("failed to update identity last login", zap.Error(err))
+		}
+		return u, nil
+	}
+
+	var email *string
+	if claims.Email != "" && claims.EmailVerified && provider.TrustEmail() {
+		email = &claims.Email
+	}
+	identity = &entity.UserIdentity{Provider: provider.Name(), Subject: claims.Subject, Email: email}
+	now := time.Now()
+	identity.LastLoginAt = &now
+
+	if email != nil {
+		u, err := d.repositories.UserRepository.FindByEmail(ctx, *email)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if u != nil {
+			return d.linkOidcIdentity(ctx, u, identity)
+		}
+	}
+	return d.createOidcUser(ctx, identity, claims)
+}
+
+func (d *DefaultAuthManager) linkOidcIdentity(ctx context.Context, u *entity.User, identity *entity.UserIdentity) (*entity.User, error) {
+	// Whoever registered an address without proving it must not receive the provider login
+	if !u.EmailVerified {
+		return nil, ErrOidcAccountConflict
+	}
+	identity.UserID = u.ID
+	if err := d.repositories.UserIdentityRepository.Insert(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+	d.auditUser(ctx, audit.ActionIdentityLinked, u.ID, map[string]interface{}{"provider": identity.Provider})
+	return u, nil
+}
+
+func (d *DefaultAuthManager) createOidcUser(ctx context.Context, identity *entity.UserIdentity, claims *oidc.Claims) (*entity.User, error) {
+	hashed, err := d.unusablePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	u := &entity.User{
+		Username: fmt.Sprintf("%s_%s", identity.Provider, claims.Subject),
+		Password: hashed,
+		Status:   userstatus.Unverified,
+	}
+	if identity.Email != nil {
+		u.Username = *identity.Email
+		u.Email = identity.Email
+		u.EmailVerified = true
+		u.Status = userstatus.Verified
+	}
+	if err := d.repositories.UserIdentityRepository.InsertWithUser(ctx, u, identity); err != nil {
+		if conflict := uniqueUserConflict(err); conflict != nil {
+			return nil, ErrOidcAccountConflict
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return u, nil
+}
+
+// unusablePassword hashes a random secret for accounts created without a password,
+// they have none usable until one is set through the reset flow
+func (d *DefaultAuthManager) unusablePassword() (string, error) {
+	secret, err := securetoken.Generate(securetoken.DefaultSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return d.hasher.HashPassword(secret)
+}
+
+// consumeOidcState loads and deletes the pending authorization in one step, so concurrent callbacks cannot both use the state
+func (d *DefaultAuthManager) consumeOidcState(ctx context.Context, state string) (*oidcLogin, error) {
+	if state == "" {
+		return nil, ErrInvalidOidcState
+	}
+	var pending oidcLogin
+	if err := d.res.Redis.GetDel(ctx, rediskey.OidcStateKey(securetoken.Hash(state)), &pending); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, ErrInvalidOidcState
+		}
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+	return &pending, nil
+}
+
+func (d *DefaultAuthManager) oidcProvider(name string) (oidc.Provider, error) {
+	provider, err := d.oidcProviders.Provider(name)
+	if err != nil {
+		if errors.Is(err, oidc.ErrUnknownProvider) {
+			return nil, ErrOidcProviderNotFound
+		}
+		return nil, err
+	}
+	return provider, nil
+}
+
+func (d *DefaultAuthManager) oidcStateTTL() time.Duration {
+	if ttl := d.res.Config.OidcConfig.StateTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultOidcStateTTL
+}
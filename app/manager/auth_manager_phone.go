@@ -0,0 +1,185 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/exception"
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/pkg/otp"
+	"backend/service-platform/app/pkg/sms"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/spartan-truongvi/redis_rate/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPhoneOtpTTL         = 5 * time.Minute
+	defaultPhoneOtpMaxAttempts = 5
+)
+
+var (
+	ErrPhoneNumberRequired      = errors.New("phone number is required")
+	ErrPhoneAlreadyVerified     = errors.New("phone number is already verified")
+	ErrPhoneOtpAttemptsExceeded = errors.New("too many invalid otp codes")
+)
+
+// phoneOtp is the pending verification kept in Redis, only the hash of the code is stored
+type phoneOtp struct {
+	PhoneNumber string `json:"phone_number"`
+	CodeHash    string `json:"code_hash"`
+}
+
+func (d *DefaultAuthManager) SendPhoneOtp(ctx context.Context, request request.SendPhoneOtpRequest) (*response.PhoneOtpResponse, error) {
+	u, err := d.repositories.UserRepository.FindByID(ctx, request.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	phoneNumber := request.PhoneNumber
+	switch {
+	case phoneNumber == "" && u.PhoneNumber == nil:
+		return nil, ErrPhoneNumberRequired
+	case phoneNumber == "":
+		phoneNumber = *u.PhoneNumber
+	}
+	isCurrent := u.PhoneNumber != nil && *u.PhoneNumber == phoneNumber
+	if isCurrent && u.PhoneVerified {
+		return nil, ErrPhoneAlreadyVerified
+	}
+	if !isCurrent {
+		if err := d.checkPhoneNumberAvailable(ctx, u.ID, phoneNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.throttlePhoneOtp(ctx, u.ID.String(), phoneNumber); err != nil {
+		return nil, err
+	}
+
+	code, err := otp.Generate(d.phoneOtpLength())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate otp: %w", err)
+	}
+	ttl := d.phoneOtpTTL()
+	pending := phoneOtp{PhoneNumber: phoneNumber, CodeHash: otp.Hash(phoneNumber, code)}
+	if err := d.res.Redis.Set(ctx, rediskey.PhoneOtpKey(u.ID.String()), pending, ttl); err != nil {
+		return nil, fmt.Errorf("failed to store otp: %w", err)
+	}
+
+	// Sent inline rather than through a job so the code never lands in the jobs table
+	message := sms.Message{
+		To:   phoneNumber,
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(math.Ceil(ttl.Minutes()))),
+	}
+	if err := d.smsSender.Send(ctx, message); err != nil {
+		_ = d.res.Redis.Delete(ctx, rediskey.PhoneOtpKey(u.ID.String()))
+		return nil, fmt.Errorf("failed to send otp: %w", err)
+	}
+
+	return &response.PhoneOtpResponse{PhoneNumber: phoneNumber, ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+func (d *DefaultAuthManager) VerifyPhoneOtp(ctx context.Context, request request.VerifyPhoneOtpRequest) (*response.UserResponse, error) {
+	userID := request.UserID.String()
+	if err := d.consumePhoneOtpAttempt(ctx, userID); err != nil {
+		// The code is burnt, a new one has to be requested
+		_ = d.res.Redis.Delete(ctx, rediskey.PhoneOtpKey(userID))
+		return nil, err
+	}
+
+	var pending phoneOtp
+	if err := d.res.Redis.Get(ctx, rediskey.PhoneOtpKey(userID), &pending); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, exception.ErrInvalidOtpCode
+		}
+		return nil, fmt.Errorf("failed to load otp: %w", err)
+	}
+	if !otp.Equal(pending.CodeHash, otp.Hash(pending.PhoneNumber, request.Code)) {
+		return nil, exception.ErrInvalidOtpCode
+	}
+	if err := d.res.Redis.Delete(ctx, rediskey.PhoneOtpKey(userID)); err != nil {
+		d.logger.Warn("failed to delete otp", zap.String("user_id", userID), zap.Error(err))
+	}
+
+	u, err := d.repositories.UserRepository.MarkPhoneVerified(ctx, request.UserID, pending.PhoneNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		// Another account took the number while the code was pending
+		if conflict := uniqueUserConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to verify phone number: %w", err)
+	}
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultAuthManager) checkPhoneNumberAvailable(ctx context.Context, userID uuid.UUID, phoneNumber string) error {
+	owner, err := d.repositories.UserRepository.FindByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to find user by phone number: %w", err)
+	}
+	if owner.ID != userID {
+		return ErrPhoneNumberExists
+	}
+	return nil
+}
+
+// throttlePhoneOtp enforces the resend interval and the hourly budgets, per user and per recipient
+func (d *DefaultAuthManager) throttlePhoneOtp(ctx context.Context, userID, phoneNumber string) error {
+	cfg := d.res.Config.PhoneVerificationConfig
+	if cfg.ResendInterval > 0 {
+		if err := d.allow(ctx, rediskey.PhoneOtpResendKey(userID), redis_rate.Limit{Rate: 1, Burst: 1, Period: cfg.ResendInterval}); err != nil {
+			return err
+		}
+	}
+	if err := d.throttle(ctx, rediskey.PhoneOtpUserRateKey(userID), cfg.UserLimit); err != nil {
+		return err
+	}
+	return d.throttle(ctx, rediskey.PhoneOtpNumberRateKey(phoneNumber), cfg.PhoneLimit)
+}
+
+func (d *DefaultAuthManager) consumePhoneOtpAttempt(ctx context.Context, userID string) error {
+	maxAttempts := d.res.Config.PhoneVerificationConfig.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultPhoneOtpMaxAttempts
+	}
+	res, err := d.rateLimiter.Allow(ctx, rediskey.PhoneOtpAttemptsKey(userID), redis_rate.Limit{Rate: maxAttempts, Burst: maxAttempts, Period: d.phoneOtpTTL()})
+	if err != nil {
+		return fmt.Errorf("failed to check otp attempts: %w", err)
+	}
+	if res.Allowed == 0 {
+		return &RateLimitError{RetryAfter: res.RetryAfter, Reason: ErrPhoneOtpAttemptsExceeded}
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) phoneOtpTTL() time.Duration {
+	if ttl := d.res.Config.PhoneVerificationConfig.CodeTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultPhoneOtpTTL
+}
+
+func (d *DefaultAuthManager) phoneOtpLength() int {
+	if length := d.res.Config.PhoneVerificationConfig.CodeLength; length > 0 {
+		return length
+	}
+	return otp.DefaultDigits
+}
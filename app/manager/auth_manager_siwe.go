@@ -0,0 +1,150 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
+	"backend/service-platform/app/pkg/siwe"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSiweNonceTTL = 5 * time.Minute
+	siweClockSkew       = time.Minute
+)
+
+var (
+	ErrInvalidSiweMessage = errors.New("invalid siwe message")
+	ErrSiweLoginFailed    = errors.New("siwe login failed")
+)
+
+func (d *DefaultAuthManager) CreateSiweNonce(ctx context.Context) (*response.SiweNonceResponse, error) {
+	cfg := d.res.Config.SiweConfig
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.SiweNonceIPRateKey, cfg.NonceIPLimit); err != nil {
+		return nil, err
+	}
+
+	// Alphanumeric as EIP-4361 requires
+	nonce := rand.Text()
+	ttl := d.siweNonceTTL()
+	if err := d.res.Redis.SetPrimitive(ctx, rediskey.SiweNonceKey(nonce), 1, ttl); err != nil {
+		return nil, fmt.Errorf("failed to store siwe nonce: %w", err)
+	}
+	return &response.SiweNonceResponse{
+		Nonce:     nonce,
+		Statement: cfg.Statement,
+		ChainID:   cfg.RequireChainID,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+func (d *DefaultAuthManager) SiweLogin(ctx context.Context, request request.SiweLoginRequest) (*response.AuthResponse, error) {
+	// Shares the login budget of the client address with the password login
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.LoginIPRateKey, d.res.Config.LoginProtectionConfig.LoginIPLimit); err != nil {
+		return nil, err
+	}
+
+	cfg := d.res.Config.SiweConfig
+	message, err := siwe.Verify(request.Message, request.Signature, siwe.VerifyOptions{
+		AllowedOrigins: strings.Split(cfg.AllowedOrigins, ","),
+		Origin:         request.Origin,
+		ChainID:        cfg.RequireChainID,
+		Statement:      cfg.Statement,
+		ClockSkew:      siweClockSkew,
+	})
+	if err != nil {
+		if errors.Is(err, siwe.ErrMalformedMessage) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSiweMessage, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSiweLoginFailed, err)
+	}
+	if err := d.consumeSiweNonce(ctx, message.Nonce); err != nil {
+		return nil, err
+	}
+
+	u, err := d.resolveWalletUser(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccountActive(u); err != nil {
+		return nil, err
+	}
+	if u.MfaEnabled || d.res.Config.MfaConfig.IsRequiredFor(u.Role) {
+		return d.createMfaChallenge(ctx, u)
+	}
+	return d.completeLogin(ctx, u, loginMethodSiwe)
+}
+
+// resolveWalletUser returns the user linked to the address, or creates an account for a wallet seen for the first time
+func (d *DefaultAuthManager) resolveWalletUser(ctx context.Context, message *siwe.Message) (*entity.User, error) {
+	wallet, err := d.repositories.UserWalletRepository.FindByAddress(ctx, message.Address)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+	if wallet != nil {
+		u, err := d.repositories.UserRepository.FindByID(ctx, wallet.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: linked account no longer exists", ErrSiweLoginFailed)
+			}
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if err := d.repositories.UserWalletRepository.UpdateLastLogin(ctx, wallet.ID, message.ChainID); err != nil {
+			d.logger.Warn("failed to update wallet last login", zap.Error(err))
+		}
+		return u, nil
+	}
+
+	hashed, err := d.unusablePassword()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	// Handles are at most 30 characters, so no user can hold the address as username beforehand
+	u := &entity.User{
+		Username: message.Address,
+		Password: hashed,
+		Status:   userstatus.Unverified,
+	}
+	wallet = &entity.UserWallet{Address: message.Address, ChainID: message.ChainID, LastLoginAt: &now}
+	if err := d.repositories.UserWalletRepository.InsertWithUser(ctx, u, wallet); err != nil {
+		// Two first logins of the same wallet raced, the loser signs in again
+		if constraint, ok := queryutil.UniqueViolationConstraint(err); ok && constraint == repository.UserWalletsAddressIndex {
+			return nil, fmt.Errorf("%w: wallet was linked concurrently", ErrSiweLoginFailed)
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return u, nil
+}
+
+// consumeSiweNonce accepts a nonce issued by CreateSiweNonce once, after the signature has been checked;
+// a single delete decides, so of two concurrent logins with the same signed message only one succeeds
+func (d *DefaultAuthManager) consumeSiweNonce(ctx context.Context, nonce string) error {
+	deleted, err := d.res.Redis.DeleteExisting(ctx, rediskey.SiweNonceKey(nonce))
+	if err != nil {
+		return fmt.Errorf("failed to consume siwe nonce: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: unknown or expired nonce", ErrSiweLoginFailed)
+	}
+	return nil
+}
+
+func (d *DefaultAuthManager) siweNonceTTL() time.Duration {
+	if ttl := d.res.Config.SiweConfig.NonceTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultSiweNonceTTL
+}
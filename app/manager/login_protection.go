@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/redis"
+	ctxutil "backend/service-platform/app/pkg/util/context"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spartan-truongvi/redis_rate/v10"
+	"go.uber.org/zap"
+)
+
+// loginProtection throttles password checks and locks the account after repeated failures, every manager
+// verifying a password shares it so the lockout cannot be bypassed through another endpoint
+type loginProtection struct {
+	res         runtime.Resource
+	logger      *zap.Logger
+	rateLimiter redis.RateLimiter
+	auditLogger audit.AuditLogger
+	users       repository.UserRepository
+}
+
+func newLoginProtection(res runtime.Resource, rateLimiter redis.RateLimiter, auditLogger audit.AuditLogger, users repository.UserRepository) loginProtection {
+	return loginProtection{
+		res:         res,
+		logger:      res.Logger,
+		rateLimiter: rateLimiter,
+		auditLogger: auditLogger,
+		users:       users,
+	}
+}
+
+// throttleWindow applies a limit of requests per login protection window, a non-positive limit disables it
+func (p loginProtection) throttleWindow(ctx context.Context, key string, limit int) error {
+	window := p.res.Config.LoginProtectionConfig.Window
+	if limit <= 0 || window <= 0 {
+		return nil
+	}
+	res, err := p.rateLimiter.Allow(ctx, key, redis_rate.Limit{Rate: limit, Burst: limit, Period: window})
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if res.Allowed == 0 {
+		return &RateLimitError{RetryAfter: res.RetryAfter}
+	}
+	return nil
+}
+
+// throttleClientIP applies the limit to the IP of the current request, requests without client info are not limited
+func (p loginProtection) throttleClientIP(ctx context.Context, key func(ip string) string, limit int) error {
+	client, ok := ctxutil.ClientInfoKey.Get(ctx)
+	if !ok || client.IPAddress == "" {
+		return nil
+	}
+	return p.throttleWindow(ctx, key(client.IPAddress), limit)
+}
+
+// recordFailedLogin counts a wrong password and locks the account once the limit is reached,
+// it returns invalid until then
+func (p loginProtection) recordFailedLogin(ctx context.Context, u *entity.User, invalid error) error {
+	cfg := p.res.Config.LoginProtectionConfig
+	if cfg.MaxFailedAttempts <= 0 {
+		return invalid
+	}
+
+	updated, err := p.users.IncrementFailedLogins(ctx, u.ID)
+	if err != nil {
+		p.logger.Error("failed to record failed login", zap.String("user_id", u.ID.String()), zap.Error(err))
+		return invalid
+	}
+	if updated.FailedLoginAttempts < cfg.MaxFailedAttempts {
+		return invalid
+	}
+
+	duration := cfg.LockoutFor(updated.LockoutCount)
+	if err := p.users.LockAccount(ctx, u.ID, time.Now().Add(duration)); err != nil {
+		p.logger.Error("failed to lock account", zap.String("user_id", u.ID.String()), zap.Error(err))
+		return invalid
+	}
+	p.auditLogger.Record(ctx, audit.Event{
+		Action:    audit.ActionAccountLocked,
+		SubjectID: &u.ID,
+		Metadata: map[string]interface{}{
+			"lockout_count":   updated.LockoutCount + 1,
+			"lockout_seconds": int(duration.Seconds()),
+		},
+	})
+	return &RateLimitError{RetryAfter: duration, Reason: ErrAccountLocked}
+}
+
+// resetLoginFailures clears the counters after a correct password
+func (p loginProtection) resetLoginFailures(ctx context.Context, u *entity.User) {
+	if u.FailedLoginAttempts == 0 && u.LockoutCount == 0 && u.LockedUntil == nil {
+		return
+	}
+	if err := p.users.ResetLoginFailures(ctx, u.ID); err != nil {
+		p.logger.Warn("failed to reset login failures", zap.String("user_id", u.ID.String()), zap.Error(err))
+	}
+}
@@ -3,14 +3,37 @@ package manager
 import (
 	"backend/service-platform/app/database/repository"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
 	"backend/service-platform/app/pkg/bcrypt"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/encryption"
 	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/oidc"
+	"backend/service-platform/app/pkg/password"
 	"backend/service-platform/app/pkg/queue"
+	"backend/service-platform/app/pkg/rbac"
+	"backend/service-platform/app/pkg/redis"
+	"backend/service-platform/app/pkg/sms"
+	"backend/service-platform/app/pkg/tokenversion"
 )
 
 type Managers struct {
-	AuthManager AuthManager
-	JobManager  JobManager
+	AuthManager    AuthManager
+	JobManager     JobManager
+	SessionManager SessionManager
+	ApiKeyManager  ApiKeyManager
+	RoleManager    RoleManager
+
+	SuperAdminManager SuperAdminManager
+	UserManager       UserManager
+	ProfileManager    ProfileManager
+	AuditManager      AuditManager
+
+	PersonalAccessTokenManager PersonalAccessTokenManager
+	OAuthManager               OAuthManager
+
+	// Appends to the security audit log
+	AuditLogger audit.AuditLogger
 }
 
 func NewManagers(
@@ -18,9 +41,19 @@ func NewManagers(
 	_ interface{},
 	repositories *repository.Repositories,
 ) *Managers {
-	// Create bcrypt hasher from configuration
+	// Hashes new passwords with the configured algorithm and verifies both bcrypt and argon2id hashes
 	bcryptHasher := bcrypt.NewBcrypt(res.Config.BcryptConfig.Cost)
-	hasher := &bcryptHasher
+	argon2Hasher := bcrypt.NewArgon2id(res.Config.Argon2Config)
+	hasher, err := bcrypt.NewMultiHasher(res.Config.PasswordHashConfig.Algorithm, &bcryptHasher, &argon2Hasher)
+	if err != nil {
+		panic(err)
+	}
+
+	// Rules and breached password check applied to every new password
+	passwordPolicy, err := password.NewRulePolicy(res.Config.PasswordPolicyConfig)
+	if err != nil {
+		panic(err)
+	}
 
 	// Create a JWT manager from configuration
 	jwtManager := jwt.NewJwt(res.Config.JwtConfig)
@@ -29,8 +62,50 @@ func NewManagers(
 	redisQueue := queue.NewRedisQueue(res.Redis.GetUniversalClient(), res.Logger)
 	jobManager := NewJobManager(repositories.JobRepository, redisQueue, res.Logger)
 
+	// Shared Redis-backed limiter for throttled endpoints
+	rateLimiter := redis.NewRedisRateLimiter(res.Redis)
+
+	// Encrypts secrets stored at rest such as TOTP seeds
+	encryptor := encryption.NewAESEncryptor(res.Config.MfaConfig.EncryptionKey)
+
+	// Revokes access tokens before they expire
+	tokenDenylist := denylist.NewRedisDenylist(res.Redis, res.Config.JwtConfig)
+
+	// Token versions cached in Redis, raised when a user's role or status changes
+	tokenVersions := tokenversion.NewRedisVersions(res.Redis, repositories.UserRepository, res.Config.JwtConfig.AccessExpiration)
+
+	// Role to permission mapping cached in Redis
+	authorizer := rbac.NewRedisAuthorizer(res.Redis, repositories.RoleRepository, res.Config.RbacConfig.CacheTTL)
+
+	// Delivers phone verification codes
+	smsSender, err := sms.NewSender(res.Config.SmsConfig, res.Logger)
+	if err != nil {
+		panic(err)
+	}
+
+	// External identity providers for the OpenID Connect login
+	oidcProviders := oidc.NewRegistry(res.Config.OidcConfig, res.HttpClient)
+
+	// Hash-chained security audit log
+	auditLogger := audit.NewChainLogger(repositories.AuditEventRepository, res.Logger)
+
+	sessionManager := NewSessionManager(res, jwtManager, tokenDenylist, auditLogger, repositories)
+
 	return &Managers{
-		AuthManager: NewAuthManager(res, hasher, jwtManager, repositories),
-		JobManager:  jobManager,
+		AuthManager:    NewAuthManager(res, hasher, passwordPolicy, jwtManager, jobManager, rateLimiter, encryptor, tokenDenylist, smsSender, oidcProviders, auditLogger, repositories),
+		JobManager:     jobManager,
+		SessionManager: sessionManager,
+		ApiKeyManager:  NewApiKeyManager(res, authorizer, auditLogger, repositories),
+		RoleManager:    NewRoleManager(res, authorizer, auditLogger, repositories),
+
+		SuperAdminManager: NewSuperAdminManager(res, hasher, passwordPolicy, tokenDenylist, tokenVersions, rateLimiter, auditLogger, repositories),
+		UserManager:       NewUserManager(res, authorizer, jwtManager, tokenDenylist, tokenVersions, auditLogger, repositories),
+		ProfileManager:    NewProfileManager(res, hasher, passwordPolicy, jobManager, sessionManager, tokenDenylist, tokenVersions, rateLimiter, auditLogger, repositories),
+		AuditManager:      NewAuditManager(res, repositories),
+
+		PersonalAccessTokenManager: NewPersonalAccessTokenManager(res, authorizer, auditLogger, repositories),
+		OAuthManager:               NewOAuthManager(res, jwtManager, tokenDenylist, tokenVersions, auditLogger, repositories),
+
+		AuditLogger: auditLogger,
 	}
 }
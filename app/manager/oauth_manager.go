@@ -0,0 +1,268 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/serviceclient"
+	"backend/service-platform/app/pkg/tokenversion"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Grant types of the token endpoint
+const grantTypeClientCredentials = "client_credentials"
+
+var (
+	ErrInvalidClient         = errors.New("invalid client credentials")
+	ErrUnsupportedGrantType  = errors.New("only the client_credentials grant is supported")
+	ErrInvalidScope          = errors.New("requested scope is not allowed for this client")
+	ErrServiceClientNotFound = errors.New("service client not found")
+)
+
+// OAuthManager implements the OAuth2 client credentials grant for internal services and manages their clients
+type OAuthManager interface {
+	IssueToken(ctx context.Context, request request.OAuthTokenRequest) (*response.OAuthTokenResponse, error)
+	IntrospectToken(ctx context.Context, request request.IntrospectTokenRequest) (*response.IntrospectionResponse, error)
+	CreateServiceClient(ctx context.Context, request request.CreateServiceClientRequest) (*response.CreatedServiceClientResponse, error)
+	ListServiceClients(ctx context.Context) ([]response.ServiceClientResponse, error)
+	RevokeServiceClient(ctx context.Context, id uuid.UUID) error
+}
+
+type DefaultOAuthManager struct {
+	logger        *zap.Logger
+	res           runtime.Resource
+	jwtManager    jwt.Jwt
+	denylist      denylist.Denylist
+	tokenVersions tokenversion.Versions
+	auditLogger   audit.AuditLogger
+	repositories  *repository.Repositories
+}
+
+func NewOAuthManager(
+	res runtime.Resource,
+	jwtManager jwt.Jwt,
+	denylist denylist.Denylist,
+	tokenVersions tokenversion.Versions,
+	auditLogger audit.AuditLogger,
+	repositories *repository.Repositories,
+) OAuthManager {
+	return &DefaultOAuthManager{
+		logger:        res.Logger,
+		res:           res,
+		jwtManager:    jwtManager,
+		denylist:      denylist,
+		tokenVersions: tokenVersions,
+		auditLogger:   auditLogger,
+		repositories:  repositories,
+	}
+}
+
+func (d *DefaultOAuthManager) IssueToken(ctx context.Context, request request.OAuthTokenRequest) (*response.OAuthTokenResponse, error) {
+	if request.GrantType != grantTypeClientCredentials {
+		return nil, ErrUnsupportedGrantType
+	}
+	client, err := d.authenticateClient(ctx, request.ClientID, request.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a scope parameter the client gets every scope it is allowed
+	scopes := serviceclient.ParseScope(request.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	for _, s := range scopes {
+		if !client.HasScope(s) {
+			return nil, ErrInvalidScope
+		}
+	}
+
+	token, err := d.jwtManager.GenerateServiceToken(client.ClientID, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate service token: %w", err)
+	}
+	if err := d.repositories.ServiceClientRepository.UpdateLastUsedAt(ctx, client.ID, time.Now()); err != nil {
+		d.logger.Warn("Failed to record service client usage", zap.Error(err))
+	}
+
+	return &response.OAuthTokenResponse{
+		AccessToken: token.Token,
+		TokenType:   jwt.TokenTypeBearer,
+		ExpiresIn:   int64(time.Until(token.ExpiredAt).Seconds()),
+		Scope:       serviceclient.FormatScope(scopes),
+	}, nil
+}
+
+// IntrospectToken reports whether an access token issued by this service is active.
+// Expired, revoked and refresh tokens, and tokens of revoked clients, are only reported as inactive.
+func (d *DefaultOAuthManager) IntrospectToken(ctx context.Context, request request.IntrospectTokenRequest) (*response.IntrospectionResponse, error) {
+	if _, err := d.authenticateClient(ctx, request.ClientID, request.ClientSecret); err != nil {
+		return nil, err
+	}
+
+	inactive := &response.IntrospectionResponse{Active: false}
+	claims, err := d.jwtManager.ValidateToken(request.Token)
+	if err != nil || claims.IsRefreshToken() {
+		return inactive, nil
+	}
+	if err := d.denylist.Check(ctx, claims); err != nil {
+		if errors.Is(err, denylist.ErrTokenRevoked) {
+			return inactive, nil
+		}
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if err := d.tokenVersions.Check(ctx, claims); err != nil {
+		if errors.Is(err, tokenversion.ErrStaleToken) {
+			return inactive, nil
+		}
+		return nil, fmt.Errorf("failed to check token version: %w", err)
+	}
+
+	res := &response.IntrospectionResponse{
+		Active:    true,
+		TokenType: jwt.TokenTypeBearer,
+		Iss:       claims.Issuer,
+		Jti:       claims.ID,
+	}
+	if claims.ExpiresAt != nil {
+		res.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		res.Iat = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		res.Nbf = claims.NotBefore.Unix()
+	}
+
+	if !claims.IsServiceToken() {
+		if claims.UserID == nil {
+			return inactive, nil
+		}
+		res.Sub = claims.UserID.String()
+		res.Username = claims.Username
+		return res, nil
+	}
+
+	// A revoked client loses the tokens it already obtained
+	client, err := d.repositories.ServiceClientRepository.FindByClientID(ctx, *claims.ClientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return inactive, nil
+		}
+		return nil, fmt.Errorf("failed to find service client: %w", err)
+	}
+	if !client.IsActive() {
+		return inactive, nil
+	}
+	res.Sub = claims.Subject
+	res.ClientID = client.ClientID
+	res.Scope = serviceclient.FormatScope(claims.Scopes())
+	return res, nil
+}
+
+func (d *DefaultOAuthManager) CreateServiceClient(ctx context.Context, request request.CreateServiceClientRequest) (*response.CreatedServiceClientResponse, error) {
+	creds, err := serviceclient.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client credentials: %w", err)
+	}
+	client := &entity.ServiceClient{
+		ClientID:   creds.ClientID,
+		Name:       strings.TrimSpace(request.Name),
+		SecretHash: creds.SecretHash,
+		Scopes:     normalizeScopes(request.Scopes),
+		CreatedBy:  request.CreatedBy,
+	}
+	if _, err := d.repositories.ServiceClientRepository.Insert(ctx, client); err != nil {
+		return nil, fmt.Errorf("failed to create service client: %w", err)
+	}
+
+	auditOn(ctx, d.auditLogger, audit.ActionServiceClientCreated, nil, serviceClientAuditMetadata(*client))
+	return &response.CreatedServiceClientResponse{
+		ServiceClientResponse: toServiceClientResponse(*client),
+		ClientSecret:          creds.Secret,
+	}, nil
+}
+
+func (d *DefaultOAuthManager) ListServiceClients(ctx context.Context) ([]response.ServiceClientResponse, error) {
+	clients, err := d.repositories.ServiceClientRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service clients: %w", err)
+	}
+	res := make([]response.ServiceClientResponse, 0, len(clients))
+	for _, c := range clients {
+		res = append(res, toServiceClientResponse(c))
+	}
+	return res, nil
+}
+
+func (d *DefaultOAuthManager) RevokeServiceClient(ctx context.Context, id uuid.UUID) error {
+	client, err := d.repositories.ServiceClientRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrServiceClientNotFound
+		}
+		return fmt.Errorf("failed to find service client: %w", err)
+	}
+	if !client.IsActive() {
+		return nil
+	}
+	if _, err := d.repositories.ServiceClientRepository.Revoke(ctx, id); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to revoke service client: %w", err)
+	}
+
+	auditOn(ctx, d.auditLogger, audit.ActionServiceClientRevoked, nil, serviceClientAuditMetadata(*client))
+	return nil
+}
+
+// authenticateClient never tells an unknown client from a wrong secret or a revoked client
+func (d *DefaultOAuthManager) authenticateClient(ctx context.Context, clientID string, secret string) (*entity.ServiceClient, error) {
+	if clientID == "" || secret == "" {
+		return nil, ErrInvalidClient
+	}
+	client, err := d.repositories.ServiceClientRepository.FindByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidClient
+		}
+		return nil, fmt.Errorf("failed to find service client: %w", err)
+	}
+	if !serviceclient.Verify(secret, client.SecretHash) || !client.IsActive() {
+		return nil, ErrInvalidClient
+	}
+	return client, nil
+}
+
+// serviceClientAuditMetadata identifies the client in the audit log, never the secret
+func serviceClientAuditMetadata(c entity.ServiceClient) map[string]interface{} {
+	return map[string]interface{}{
+		"service_client_id": c.ID.String(),
+		"client_id":         c.ClientID,
+		"scopes":            c.Scopes,
+	}
+}
+
+func toServiceClientResponse(c entity.ServiceClient) response.ServiceClientResponse {
+	return response.ServiceClientResponse{
+		ID:         c.ID,
+		ClientID:   c.ClientID,
+		Name:       c.Name,
+		Scopes:     c.Scopes,
+		LastUsedAt: c.LastUsedAt,
+		Revoked:    c.RevokedAt != nil,
+		RevokedAt:  c.RevokedAt,
+		CreatedAt:  c.CreatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/password"
+	"errors"
+	"fmt"
+)
+
+var ErrPasswordPolicy = errors.New("password does not meet the policy")
+
+// PasswordPolicyError lists the rules a new password breaks; it matches ErrPasswordPolicy
+type PasswordPolicyError struct {
+	Violations []password.Violation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrPasswordPolicy.Error()
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+// checkPasswordPolicy returns a PasswordPolicyError when the password breaks a rule, the personal values are the
+// user's email and username
+func checkPasswordPolicy(policy password.Policy, plain string, personal ...string) error {
+	violations, err := policy.Check(plain, personal...)
+	if err != nil {
+		return fmt.Errorf("failed to check password policy: %w", err)
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func userPersonalInfo(u *entity.User) []string {
+	personal := []string{u.Username}
+	if u.Email != nil {
+		personal = append(personal, *u.Email)
+	}
+	return personal
+}
@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/pat"
+	"backend/service-platform/app/pkg/rbac"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrPersonalAccessTokenNotFound      = errors.New("personal access token not found")
+	ErrPersonalAccessTokenExpiresInPast = errors.New("expires_at must be in the future")
+	ErrPersonalAccessTokenScopeDenied   = errors.New("scopes must be permissions of your role")
+)
+
+// PersonalAccessTokenManager lets users issue long-lived tokens for their own account
+type PersonalAccessTokenManager interface {
+	CreateToken(ctx context.Context, request request.CreatePersonalAccessTokenRequest) (*response.CreatedPersonalAccessTokenResponse, error)
+	ListTokens(ctx context.Context, userID uuid.UUID) ([]response.PersonalAccessTokenResponse, error)
+	RevokeToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+}
+
+type DefaultPersonalAccessTokenManager struct {
+	logger       *zap.Logger
+	res          runtime.Resource
+	authorizer   rbac.Authorizer
+	auditLogger  audit.AuditLogger
+	repositories *repository.Repositories
+}
+
+func NewPersonalAccessTokenManager(res runtime.Resource, authorizer rbac.Authorizer, auditLogger audit.AuditLogger, repositories *repository.Repositories) PersonalAccessTokenManager {
+	return &DefaultPersonalAccessTokenManager{
+		logger:       res.Logger,
+		res:          res,
+		authorizer:   authorizer,
+		auditLogger:  auditLogger,
+		repositories: repositories,
+	}
+}
+
+func (d *DefaultPersonalAccessTokenManager) CreateToken(ctx context.Context, request request.CreatePersonalAccessTokenRequest) (*response.CreatedPersonalAccessTokenResponse, error) {
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return nil, ErrPersonalAccessTokenExpiresInPast
+	}
+	u, err := d.repositories.UserRepository.FindByID(ctx, request.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if err := checkAccountActive(u); err != nil {
+		return nil, err
+	}
+
+	// A token can never do more than its owner, the role is checked again on every request
+	scopes := normalizeScopes(request.Scopes)
+	held, err := d.authorizer.Permissions(ctx, string(u.Role))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve permissions: %w", err)
+	}
+	if !rbac.HasAll(held, scopes...) {
+		return nil, ErrPersonalAccessTokenScopeDenied
+	}
+
+	generated, err := pat.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	token := &entity.PersonalAccessToken{
+		UserID:     u.ID,
+		Name:       strings.TrimSpace(request.Name),
+		Prefix:     generated.Prefix,
+		SecretHash: generated.Hash,
+		Scopes:     scopes,
+		ExpiresAt:  request.ExpiresAt,
+	}
+	if _, err := d.repositories.PersonalAccessTokenRepository.Insert(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	auditSelf(ctx, d.auditLogger, audit.ActionPersonalTokenCreated, u.ID, personalAccessTokenAuditMetadata(*token))
+	return &response.CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: toPersonalAccessTokenResponse(*token),
+		Token:                       generated.Plaintext,
+	}, nil
+}
+
+func (d *DefaultPersonalAccessTokenManager) ListTokens(ctx context.Context, userID uuid.UUID) ([]response.PersonalAccessTokenResponse, error) {
+	tokens, err := d.repositories.PersonalAccessTokenRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+	res := make([]response.PersonalAccessTokenResponse, 0, len(tokens))
+	for _, t := range tokens {
+		res = append(res, toPersonalAccessTokenResponse(t))
+	}
+	return res, nil
+}
+
+// RevokeToken is idempotent, revoking an already revoked token of the user succeeds
+func (d *DefaultPersonalAccessTokenManager) RevokeToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	revoked, err := d.repositories.PersonalAccessTokenRepository.Revoke(ctx, id, userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to revoke personal access token: %w", err)
+		}
+		// Either revoked before or not a token of this user
+		if _, err := d.findToken(ctx, userID, id); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	auditSelf(ctx, d.auditLogger, audit.ActionPersonalTokenRevoked, userID, personalAccessTokenAuditMetadata(*revoked))
+	return nil
+}
+
+func (d *DefaultPersonalAccessTokenManager) findToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entity.PersonalAccessToken, error) {
+	tokens, err := d.repositories.PersonalAccessTokenRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find personal access token: %w", err)
+	}
+	for _, t := range tokens {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+	return nil, ErrPersonalAccessTokenNotFound
+}
+
+// personalAccessTokenAuditMetadata identifies the token in the audit log, never the secret
+func personalAccessTokenAuditMetadata(t entity.PersonalAccessToken) map[string]interface{} {
+	return map[string]interface{}{
+		"personal_access_token_id": t.ID.String(),
+		"prefix":                   t.Prefix,
+		"scopes":                   t.Scopes,
+	}
+}
+
+func toPersonalAccessTokenResponse(t entity.PersonalAccessToken) response.PersonalAccessTokenResponse {
+	return response.PersonalAccessTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.Scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		Revoked:    t.RevokedAt != nil,
+		RevokedAt:  t.RevokedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
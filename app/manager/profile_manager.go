@@ -0,0 +1,280 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/bcrypt"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/password"
+	"backend/service-platform/app/pkg/redis"
+	"backend/service-platform/app/pkg/tokenversion"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+	ErrEmailUnchanged         = errors.New("email is already the current address")
+	ErrPhoneNumberExists      = errors.New("phone number already exists")
+)
+
+// ProfileManager lets authenticated users change their own account
+type ProfileManager interface {
+	// ChangePassword keeps the caller's session and signs out every other one
+	ChangePassword(ctx context.Context, request request.ChangePasswordRequest) error
+	// ChangeEmail sends a verification link to the new address, the swap happens when it is followed
+	ChangeEmail(ctx context.Context, request request.ChangeEmailRequest) (*response.UserResponse, error)
+	ChangeUsername(ctx context.Context, request request.ChangeUsernameRequest) (*response.UserResponse, error)
+	// SetPhoneNumber replaces the phone number, the new one has to be verified again
+	SetPhoneNumber(ctx context.Context, request request.SetPhoneNumberRequest) (*response.UserResponse, error)
+	RemovePhoneNumber(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error)
+	// DeactivateAccount signs the user out everywhere, the account is kept until it is reactivated
+	DeactivateAccount(ctx context.Context, request request.DeactivateAccountRequest) error
+	// DeleteAccount schedules the account to be purged once the grace period is over, reactivating cancels it
+	DeleteAccount(ctx context.Context, request request.DeleteAccountRequest) (*response.AccountDeletionResponse, error)
+	// RequestDataExport queues an archive of the user's personal data, the user is emailed when it is ready
+	RequestDataExport(ctx context.Context, userID uuid.UUID) (*response.DataExportResponse, error)
+	// DataExportPath returns the archive of a completed export of the user
+	DataExportPath(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (string, error)
+}
+
+type DefaultProfileManager struct {
+	logger         *zap.Logger
+	res            runtime.Resource
+	hasher         bcrypt.Hasher
+	policy         password.Policy
+	jobManager     JobManager
+	sessionManager SessionManager
+	denylist       denylist.Denylist
+	tokenVersions  tokenversion.Versions
+	auditLogger    audit.AuditLogger
+	repositories   *repository.Repositories
+
+	loginProtection loginProtection
+}
+
+func NewProfileManager(
+	res runtime.Resource,
+	hasher bcrypt.Hasher,
+	policy password.Policy,
+	jobManager JobManager,
+	sessionManager SessionManager,
+	denylist denylist.Denylist,
+	tokenVersions tokenversion.Versions,
+	rateLimiter redis.RateLimiter,
+	auditLogger audit.AuditLogger,
+	repositories *repository.Repositories,
+) ProfileManager {
+	return &DefaultProfileManager{
+		logger:         res.Logger,
+		res:            res,
+		hasher:         hasher,
+		policy:         policy,
+		jobManager:     jobManager,
+		sessionManager: sessionManager,
+		denylist:       denylist,
+		tokenVersions:  tokenVersions,
+		auditLogger:    auditLogger,
+		repositories:   repositories,
+
+		loginProtection: newLoginProtection(res, rateLimiter, auditLogger, repositories.UserRepository),
+	}
+}
+
+func (d *DefaultProfileManager) ChangePassword(ctx context.Context, request request.ChangePasswordRequest) error {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return err
+	}
+	if err := d.checkPassword(ctx, u, request.CurrentPassword); err != nil {
+		return err
+	}
+
+	if err := checkPasswordPolicy(d.policy, request.NewPassword, userPersonalInfo(u)...); err != nil {
+		return err
+	}
+
+	hashed, err := d.hasher.HashPassword(request.NewPassword)
+	if err != nil {
+		return err
+	}
+	if err := d.repositories.UserRepository.UpdatePassword(ctx, u.ID, hashed); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	// A pending reset link would otherwise undo the change
+	if err := d.repositories.PasswordResetTokenRepository.InvalidateByUserID(ctx, u.ID); err != nil {
+		d.logger.Warn("failed to invalidate reset tokens", zap.Error(err))
+	}
+
+	if err := d.revokeOtherSessions(ctx, u.ID, request.RefreshToken); err != nil {
+		return err
+	}
+	// Access tokens of the other sessions stay valid until they expire otherwise, the caller refreshes its own
+	if err := d.denylist.RevokeUserTokens(ctx, u.ID); err != nil {
+		return err
+	}
+
+	auditSelf(ctx, d.auditLogger, audit.ActionPasswordChanged, u.ID, nil)
+	return nil
+}
+
+func (d *DefaultProfileManager) ChangeEmail(ctx context.Context, request request.ChangeEmailRequest) (*response.UserResponse, error) {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.checkPassword(ctx, u, request.CurrentPassword); err != nil {
+		return nil, err
+	}
+	if u.Email != nil && strings.EqualFold(*u.Email, request.Email) {
+		return nil, ErrEmailUnchanged
+	}
+
+	// Checked again by the unique index when the change is confirmed
+	if _, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email); err == nil {
+		return nil, ErrEmailAlreadyExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err := d.repositories.UserRepository.UpdatePendingEmail(ctx, u.ID, &request.Email); err != nil {
+		return nil, fmt.Errorf("failed to store pending email: %w", err)
+	}
+	_, err = d.jobManager.CreateJob(ctx, CreateJobRequest{
+		Type:     string(job.SendVerificationEmail),
+		Priority: job.PriorityHigh,
+		Payload: map[string]interface{}{
+			job.PayloadUserID: u.ID.String(),
+			job.PayloadEmail:  request.Email,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue verification email: %w", err)
+	}
+
+	auditSelf(ctx, d.auditLogger, audit.ActionEmailChangeRequested, u.ID, nil)
+	u.PendingEmail = &request.Email
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultProfileManager) ChangeUsername(ctx context.Context, request request.ChangeUsernameRequest) (*response.UserResponse, error) {
+	u, err := d.repositories.UserRepository.UpdateUsername(ctx, request.UserID, request.Username)
+	if err != nil {
+		return nil, d.updateError(err)
+	}
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultProfileManager) SetPhoneNumber(ctx context.Context, request request.SetPhoneNumberRequest) (*response.UserResponse, error) {
+	u, err := d.repositories.UserRepository.UpdatePhoneNumber(ctx, request.UserID, &request.PhoneNumber)
+	if err != nil {
+		return nil, d.updateError(err)
+	}
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultProfileManager) RemovePhoneNumber(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error) {
+	u, err := d.repositories.UserRepository.UpdatePhoneNumber(ctx, userID, nil)
+	if err != nil {
+		return nil, d.updateError(err)
+	}
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultProfileManager) findUser(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	u, err := d.repositories.UserRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return u, nil
+}
+
+// checkPassword verifies the current password under the same limits and lockout as a login,
+// a stolen session must not become a way to guess the password
+func (d *DefaultProfileManager) checkPassword(ctx context.Context, u *entity.User, password string) error {
+	cfg := d.res.Config.LoginProtectionConfig
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.LoginIPRateKey, cfg.LoginIPLimit); err != nil {
+		return err
+	}
+	if err := d.loginProtection.throttleWindow(ctx, rediskey.PasswordCheckUserRateKey(u.ID.String()), cfg.LoginEmailLimit); err != nil {
+		return err
+	}
+	if u.IsLocked(time.Now()) {
+		return &RateLimitError{RetryAfter: time.Until(*u.LockedUntil), Reason: ErrAccountLocked}
+	}
+
+	valid, err := d.hasher.CheckPassword(password, u.Password)
+	if err != nil {
+		return fmt.Errorf("failed to check password: %w", err)
+	}
+	if !valid {
+		return d.loginProtection.recordFailedLogin(ctx, u, ErrInvalidCurrentPassword)
+	}
+	d.loginProtection.resetLoginFailures(ctx, u)
+	return nil
+}
+
+// revokeOtherSessions keeps the session of the refresh token, or revokes all of them when the caller has none
+func (d *DefaultProfileManager) revokeOtherSessions(ctx context.Context, userID uuid.UUID, refreshToken string) error {
+	if refreshToken != "" {
+		_, err := d.sessionManager.RevokeOtherSessions(ctx, request.RevokeOtherSessionsRequest{
+			UserID:       userID,
+			RefreshToken: refreshToken,
+		})
+		if err == nil || !errors.Is(err, ErrInvalidRefreshToken) {
+			return err
+		}
+	}
+	if _, err := d.repositories.SessionRepository.RevokeAllForUser(ctx, userID, nil); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (d *DefaultProfileManager) updateError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	if conflict := uniqueUserConflict(err); conflict != nil {
+		return conflict
+	}
+	return fmt.Errorf("failed to update user: %w", err)
+}
+
+// uniqueUserConflict maps a unique violation on the users table to the field that collided
+func uniqueUserConflict(err error) error {
+	constraint, ok := queryutil.UniqueViolationConstraint(err)
+	if !ok {
+		return nil
+	}
+	switch constraint {
+	case repository.UsersUsernameIndex:
+		return ErrUsernameAlreadyExisted
+	case repository.UsersEmailIndex:
+		return ErrEmailAlreadyExists
+	case repository.UsersPhoneNumberIndex:
+		return ErrPhoneNumberExists
+	}
+	return nil
+}
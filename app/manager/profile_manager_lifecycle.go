@@ -0,0 +1,135 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/audit"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDataExportNotFound = errors.New("data export not found")
+	ErrDataExportNotReady = errors.New("data export is not ready yet")
+)
+
+func (d *DefaultProfileManager) DeactivateAccount(ctx context.Context, request request.DeactivateAccountRequest) error {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return err
+	}
+	if err := d.checkPassword(ctx, u, request.CurrentPassword); err != nil {
+		return err
+	}
+
+	if _, err := d.repositories.UserRepository.Deactivate(ctx, u.ID); err != nil {
+		return d.updateError(err)
+	}
+	if err := d.signOutEverywhere(ctx, u.ID); err != nil {
+		return err
+	}
+	revokedKeys, err := d.repositories.ApiKeyRepository.RevokeByUserID(ctx, u.ID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api keys: %w", err)
+	}
+
+	auditSelf(ctx, d.auditLogger, audit.ActionAccountDeactivated, u.ID, map[string]interface{}{"revoked_api_keys": revokedKeys})
+	return nil
+}
+
+func (d *DefaultProfileManager) DeleteAccount(
+	ctx context.Context,
+	request request.DeleteAccountRequest,
+) (*response.AccountDeletionResponse, error) {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.checkPassword(ctx, u, request.CurrentPassword); err != nil {
+		return nil, err
+	}
+
+	// Asking twice keeps the first schedule
+	u, err = d.repositories.UserRepository.ScheduleDeletion(ctx, u.ID, time.Now().Add(d.res.Config.AccountLifecycleConfig.DeletionGracePeriod))
+	if err != nil {
+		return nil, d.updateError(err)
+	}
+	if err := d.signOutEverywhere(ctx, u.ID); err != nil {
+		return nil, err
+	}
+
+	auditSelf(ctx, d.auditLogger, audit.ActionAccountDeletionRequested, u.ID, map[string]interface{}{
+		"deletion_scheduled_at": u.DeletionScheduledAt.UTC().Format(time.RFC3339),
+	})
+	return &response.AccountDeletionResponse{DeletionScheduledAt: *u.DeletionScheduledAt}, nil
+}
+
+func (d *DefaultProfileManager) RequestDataExport(ctx context.Context, userID uuid.UUID) (*response.DataExportResponse, error) {
+	if _, err := d.findUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	j, err := d.jobManager.CreateJob(ctx, CreateJobRequest{
+		Type:     string(job.ExportUserData),
+		Priority: job.PriorityLow,
+		Payload:  map[string]interface{}{job.PayloadUserID: userID.String()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue data export: %w", err)
+	}
+	return toDataExportResponse(j), nil
+}
+
+func (d *DefaultProfileManager) DataExportPath(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (string, error) {
+	j, err := d.jobManager.GetJob(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrDataExportNotFound
+		}
+		return "", fmt.Errorf("failed to find data export: %w", err)
+	}
+	// Exports of other users are reported as missing rather than forbidden
+	if owner, _ := j.Payload[job.PayloadUserID].(string); j.Type != string(job.ExportUserData) || owner != userID.String() {
+		return "", ErrDataExportNotFound
+	}
+	if j.Status != job.Completed {
+		return "", ErrDataExportNotReady
+	}
+
+	path := d.res.Config.AccountLifecycleConfig.ExportPath(userID.String(), exportID.String())
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrDataExportNotFound
+		}
+		return "", fmt.Errorf("failed to open data export: %w", err)
+	}
+	return path, nil
+}
+
+// signOutEverywhere ends every session of the user and the access tokens issued for them
+func (d *DefaultProfileManager) signOutEverywhere(ctx context.Context, userID uuid.UUID) error {
+	if _, err := d.repositories.SessionRepository.RevokeAllForUser(ctx, userID, nil); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	if _, err := d.tokenVersions.Bump(ctx, userID); err != nil {
+		return err
+	}
+	return d.denylist.RevokeUserTokens(ctx, userID)
+}
+
+func toDataExportResponse(j *entity.Job) *response.DataExportResponse {
+	return &response.DataExportResponse{
+		ID:          j.ID,
+		Status:      string(j.Status),
+		CreatedAt:   j.CreatedAt,
+		CompletedAt: j.CompletedAt,
+	}
+}
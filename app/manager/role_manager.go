@@ -0,0 +1,257 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/rbac"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrRoleExists         = errors.New("role already exists")
+	ErrRoleBuiltIn        = errors.New("built-in roles cannot be changed")
+	ErrRoleInUse          = errors.New("role is assigned to users")
+	ErrParentRoleNotFound = errors.New("parent role not found")
+	ErrRoleCycle          = errors.New("role cannot inherit from itself")
+	ErrUnknownPermission  = errors.New("unknown permission")
+)
+
+type RoleManager interface {
+	ListRoles(ctx context.Context) ([]response.RoleResponse, error)
+	GetRole(ctx context.Context, name string) (*response.RoleResponse, error)
+	CreateRole(ctx context.Context, request request.CreateRoleRequest) (*response.RoleResponse, error)
+	UpdateRole(ctx context.Context, request request.UpdateRoleRequest) (*response.RoleResponse, error)
+	DeleteRole(ctx context.Context, name string) error
+	ListPermissions(ctx context.Context) ([]response.PermissionResponse, error)
+}
+
+type DefaultRoleManager struct {
+	logger       *zap.Logger
+	res          runtime.Resource
+	authorizer   rbac.Authorizer
+	auditLogger  audit.AuditLogger
+	repositories *repository.Repositories
+}
+
+func NewRoleManager(res runtime.Resource, authorizer rbac.Authorizer, auditLogger audit.AuditLogger, repositories *repository.Repositories) RoleManager {
+	return &DefaultRoleManager{
+		logger:       res.Logger,
+		res:          res,
+		authorizer:   authorizer,
+		auditLogger:  auditLogger,
+		repositories: repositories,
+	}
+}
+
+func (d *DefaultRoleManager) ListRoles(ctx context.Context) ([]response.RoleResponse, error) {
+	roles, err := d.repositories.RoleRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	effective := rbac.Resolve(roles)
+
+	res := make([]response.RoleResponse, 0, len(roles))
+	for _, r := range roles {
+		res = append(res, toRoleResponse(r, effective[r.Name]))
+	}
+	return res, nil
+}
+
+func (d *DefaultRoleManager) GetRole(ctx context.Context, name string) (*response.RoleResponse, error) {
+	roles, err := d.repositories.RoleRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	idx := slices.IndexFunc(roles, func(r entity.Role) bool { return r.Name == name })
+	if idx < 0 {
+		return nil, ErrRoleNotFound
+	}
+	res := toRoleResponse(roles[idx], rbac.Resolve(roles)[name])
+	return &res, nil
+}
+
+func (d *DefaultRoleManager) CreateRole(ctx context.Context, request request.CreateRoleRequest) (*response.RoleResponse, error) {
+	role := &entity.Role{
+		Name:        strings.TrimSpace(request.Name),
+		Description: request.Description,
+		Parent:      request.Parent,
+		Permissions: normalizeScopes(request.Permissions),
+	}
+	if err := d.validateRole(ctx, *role); err != nil {
+		return nil, err
+	}
+
+	if _, err := d.repositories.RoleRepository.Insert(ctx, role); err != nil {
+		if queryutil.IsUniqueViolation(err) {
+			return nil, ErrRoleExists
+		}
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+	d.invalidate(ctx)
+
+	auditOn(ctx, d.auditLogger, audit.ActionRoleCreated, nil, roleAuditMetadata(*role))
+	return d.GetRole(ctx, role.Name)
+}
+
+func (d *DefaultRoleManager) UpdateRole(ctx context.Context, request request.UpdateRoleRequest) (*response.RoleResponse, error) {
+	role, err := d.findRole(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if role.BuiltIn {
+		return nil, ErrRoleBuiltIn
+	}
+
+	if request.Description != nil {
+		role.Description = request.Description
+	}
+	if request.Parent != nil {
+		role.Parent = request.Parent
+	}
+	if request.Permissions != nil {
+		role.Permissions = normalizeScopes(request.Permissions)
+	}
+	if err := d.validateRole(ctx, *role); err != nil {
+		return nil, err
+	}
+
+	if _, err := d.repositories.RoleRepository.Update(ctx, *role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleted concurrently
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	d.invalidate(ctx)
+
+	auditOn(ctx, d.auditLogger, audit.ActionRoleUpdated, nil, roleAuditMetadata(*role))
+	return d.GetRole(ctx, role.Name)
+}
+
+func (d *DefaultRoleManager) DeleteRole(ctx context.Context, name string) error {
+	role, err := d.findRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn {
+		return ErrRoleBuiltIn
+	}
+
+	assigned, err := d.repositories.RoleRepository.CountUsers(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to count users with role: %w", err)
+	}
+	if assigned > 0 {
+		return ErrRoleInUse
+	}
+	roles, err := d.repositories.RoleRepository.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+	if slices.ContainsFunc(roles, func(r entity.Role) bool { return r.Parent != nil && *r.Parent == name }) {
+		return ErrRoleInUse
+	}
+
+	if _, err := d.repositories.RoleRepository.Delete(ctx, name); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	d.invalidate(ctx)
+
+	auditOn(ctx, d.auditLogger, audit.ActionRoleDeleted, nil, map[string]interface{}{"role": name})
+	return nil
+}
+
+func (d *DefaultRoleManager) ListPermissions(ctx context.Context) ([]response.PermissionResponse, error) {
+	permissions, err := d.repositories.PermissionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	res := make([]response.PermissionResponse, 0, len(permissions))
+	for _, p := range permissions {
+		res = append(res, response.PermissionResponse{Name: p.Name, Description: p.Description})
+	}
+	return res, nil
+}
+
+func (d *DefaultRoleManager) findRole(ctx context.Context, name string) (*entity.Role, error) {
+	role, err := d.repositories.RoleRepository.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+	return role, nil
+}
+
+// validateRole checks that the permissions exist and the parent exists without leading back to the role
+func (d *DefaultRoleManager) validateRole(ctx context.Context, role entity.Role) error {
+	permissions, err := d.repositories.PermissionRepository.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list permissions: %w", err)
+	}
+	for _, p := range role.Permissions {
+		if !slices.ContainsFunc(permissions, func(known entity.Permission) bool { return known.Name == p }) {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, p)
+		}
+	}
+
+	if role.Parent == nil {
+		return nil
+	}
+	if *role.Parent == role.Name {
+		return ErrRoleCycle
+	}
+	roles, err := d.repositories.RoleRepository.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+	if !slices.ContainsFunc(roles, func(r entity.Role) bool { return r.Name == *role.Parent }) {
+		return ErrParentRoleNotFound
+	}
+	if slices.Contains(rbac.Ancestors(roles, *role.Parent), role.Name) {
+		return ErrRoleCycle
+	}
+	return nil
+}
+
+// invalidate drops the cached permissions, they are reloaded after the cache TTL at the latest
+func (d *DefaultRoleManager) invalidate(ctx context.Context) {
+	if err := d.authorizer.Invalidate(ctx); err != nil {
+		d.logger.Warn("failed to invalidate role permissions", zap.Error(err))
+	}
+}
+
+func roleAuditMetadata(r entity.Role) map[string]interface{} {
+	metadata := map[string]interface{}{"role": r.Name, "permissions": r.Permissions}
+	if r.Parent != nil {
+		metadata["parent"] = *r.Parent
+	}
+	return metadata
+}
+
+func toRoleResponse(r entity.Role, effective []string) response.RoleResponse {
+	return response.RoleResponse{
+		Name:                 r.Name,
+		Description:          r.Description,
+		Parent:               r.Parent,
+		Permissions:          r.Permissions,
+		EffectivePermissions: effective,
+		BuiltIn:              r.BuiltIn,
+		CreatedAt:            r.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package manager
+
+import (
+	"backend/service-platform/app/pkg/audit"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Sign-in methods recorded with a successful login
+const (
+	loginMethodPassword = "password"
+	loginMethodMfa      = "mfa"
+	loginMethodOidc     = "oidc"
+	loginMethodSiwe     = "siwe"
+)
+
+// Reasons recorded with a failed login
+const (
+	loginFailureUnknownEmail    = "unknown_email"
+	loginFailureInvalidPassword = "invalid_password"
+	loginFailureInvalidMfaCode  = "invalid_mfa_code"
+	loginFailureAccountLocked   = "account_locked"
+	loginFailureAccountInactive = "account_inactive"
+)
+
+// auditSelf records an action a user performed on their own account, also before they are authenticated
+func auditSelf(ctx context.Context, auditLogger audit.AuditLogger, action string, userID uuid.UUID, metadata map[string]interface{}) {
+	auditLogger.Record(ctx, audit.Event{Action: action, ActorID: &userID, SubjectID: &userID, Metadata: metadata})
+}
+
+// auditOn records an action the authenticated principal performed on the given user
+func auditOn(ctx context.Context, auditLogger audit.AuditLogger, action string, subjectID *uuid.UUID, metadata map[string]interface{}) {
+	auditLogger.Record(ctx, audit.Event{Action: action, SubjectID: subjectID, Metadata: metadata})
+}
+
+func (d *DefaultAuthManager) auditUser(ctx context.Context, action string, userID uuid.UUID, metadata map[string]interface{}) {
+	auditSelf(ctx, d.auditLogger, action, userID, metadata)
+}
+
+func (d *DefaultAuthManager) auditLoginFailed(ctx context.Context, userID uuid.UUID, reason string) {
+	d.auditUser(ctx, audit.ActionLoginFailed, userID, map[string]interface{}{"reason": reason})
+}
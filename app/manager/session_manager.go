@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/jwt"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionManager interface {
+	ListSessions(ctx context.Context, request request.ListSessionsRequest) ([]response.SessionResponse, error)
+	RevokeSession(ctx context.Context, request request.RevokeSessionRequest) error
+	RevokeOtherSessions(ctx context.Context, request request.RevokeOtherSessionsRequest) (*response.RevokeSessionsResponse, error)
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) (*response.RevokeSessionsResponse, error)
+}
+
+type DefaultSessionManager struct {
+	logger       *zap.Logger
+	res          runtime.Resource
+	jwtManager   jwt.Jwt
+	denylist     denylist.Denylist
+	auditLogger  audit.AuditLogger
+	repositories *repository.Repositories
+}
+
+func NewSessionManager(
+	res runtime.Resource,
+	jwtManager jwt.Jwt,
+	denylist denylist.Denylist,
+	auditLogger audit.AuditLogger,
+	repositories *repository.Repositories,
+) SessionManager {
+	return &DefaultSessionManager{
+		logger:       res.Logger,
+		res:          res,
+		jwtManager:   jwtManager,
+		denylist:     denylist,
+		auditLogger:  auditLogger,
+		repositories: repositories,
+	}
+}
+
+func (d *DefaultSessionManager) ListSessions(ctx context.Context, request request.ListSessionsRequest) ([]response.SessionResponse, error) {
+	sessions, err := d.repositories.SessionRepository.ListActiveByUser(ctx, request.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	var currentID *uuid.UUID
+	if request.RefreshToken != "" {
+		if current, err := d.currentSession(ctx, request.UserID, request.RefreshToken); err == nil {
+			currentID = &current.ID
+		}
+	}
+
+	res := make([]response.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, toSessionResponse(s, currentID))
+	}
+	return res, nil
+}
+
+func (d *DefaultSessionManager) RevokeSession(ctx context.Context, request request.RevokeSessionRequest) error {
+	if _, err := d.repositories.SessionRepository.RevokeByID(ctx, request.UserID, request.SessionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	auditOn(ctx, d.auditLogger, audit.ActionSessionRevoked, &request.UserID, map[string]interface{}{"session_id": request.SessionID.String()})
+	return nil
+}
+
+// RevokeOtherSessions keeps the session of the presented refresh token and revokes the rest
+func (d *DefaultSessionManager) RevokeOtherSessions(ctx context.Context, request request.RevokeOtherSessionsRequest) (*response.RevokeSessionsResponse, error) {
+	current, err := d.currentSession(ctx, request.UserID, request.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := d.repositories.SessionRepository.RevokeAllForUser(ctx, request.UserID, &current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	auditOn(ctx, d.auditLogger, audit.ActionSessionsRevoked, &request.UserID, map[string]interface{}{
+		"revoked_sessions": revoked,
+		"kept_session_id":  current.ID.String(),
+	})
+	return &response.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+func (d *DefaultSessionManager) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (*response.RevokeSessionsResponse, error) {
+	revoked, err := d.repositories.SessionRepository.RevokeAllForUser(ctx, userID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	// Access tokens already handed out would otherwise outlive their sessions
+	if err := d.denylist.RevokeUserTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+	auditOn(ctx, d.auditLogger, audit.ActionSessionsRevoked, &userID, map[string]interface{}{"revoked_sessions": revoked})
+	return &response.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+// currentSession resolves the active session of the refresh token, it must belong to userID
+func (d *DefaultSessionManager) currentSession(ctx context.Context, userID uuid.UUID, refreshToken string) (*entity.Session, error) {
+	claims, err := d.jwtManager.ValidateToken(refreshToken)
+	if err != nil || claims.RefreshTokenBase64 == nil || *claims.RefreshTokenBase64 == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+	h := sha256.Sum256([]byte(*claims.RefreshTokenBase64))
+	session, err := d.repositories.SessionRepository.FindByToken(ctx, hex.EncodeToString(h[:]))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+	if session.UserID != userID || session.Revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+	return session, nil
+}
+
+func toSessionResponse(s entity.Session, currentID *uuid.UUID) response.SessionResponse {
+	return response.SessionResponse{
+		ID:        s.ID,
+		UserAgent: s.UserAgent,
+		IPAddress: s.IPAddress,
+		Current:   currentID != nil && *currentID == s.ID,
+		CreatedAt: s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+	}
+}
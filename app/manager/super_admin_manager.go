@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/bcrypt"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/password"
+	"backend/service-platform/app/pkg/redis"
+	"backend/service-platform/app/pkg/tokenversion"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSuperAdminCreationDisabled = errors.New("super admin creation is disabled")
+	ErrBootstrapUnavailable       = errors.New("bootstrap is not available")
+	ErrInvalidBootstrapToken      = errors.New("invalid bootstrap token")
+	ErrPasswordRequired           = errors.New("password is required to create the account")
+)
+
+// Sources recorded with a super admin creation
+const (
+	SuperAdminSourceCLI       = "cli"
+	SuperAdminSourceBootstrap = "bootstrap"
+)
+
+// Long enough for the bootstrap to finish, short enough to retry after a crash
+const superAdminBootstrapLockTTL = 30 * time.Second
+
+type SuperAdminManager interface {
+	// CreateSuperAdmin creates the account or promotes an existing one, running it again changes nothing
+	CreateSuperAdmin(ctx context.Context, request request.CreateSuperAdminRequest) (*response.SuperAdminResponse, error)
+	// Bootstrap creates the first super admin of a fresh environment, it refuses once any admin exists and never promotes an existing account
+	Bootstrap(ctx context.Context, request request.BootstrapSuperAdminRequest) (*response.SuperAdminResponse, error)
+}
+
+type DefaultSuperAdminManager struct {
+	logger        *zap.Logger
+	res           runtime.Resource
+	hasher        bcrypt.Hasher
+	policy        password.Policy
+	denylist      denylist.Denylist
+	tokenVersions tokenversion.Versions
+	auditLogger   audit.AuditLogger
+	repositories  *repository.Repositories
+
+	loginProtection loginProtection
+}
+
+func NewSuperAdminManager(
+	res runtime.Resource,
+	hasher bcrypt.Hasher,
+	policy password.Policy,
+	denylist denylist.Denylist,
+	tokenVersions tokenversion.Versions,
+	rateLimiter redis.RateLimiter,
+	auditLogger audit.AuditLogger,
+	repositories *repository.Repositories,
+) SuperAdminManager {
+	return &DefaultSuperAdminManager{
+		logger:        res.Logger,
+		res:           res,
+		hasher:        hasher,
+		policy:        policy,
+		denylist:      denylist,
+		tokenVersions: tokenVersions,
+		auditLogger:   auditLogger,
+		repositories:  repositories,
+
+		loginProtection: newLoginProtection(res, rateLimiter, auditLogger, repositories.UserRepository),
+	}
+}
+
+func (d *DefaultSuperAdminManager) CreateSuperAdmin(ctx context.Context, request request.CreateSuperAdminRequest) (*response.SuperAdminResponse, error) {
+	if !d.res.Config.SuperAdminConfig.AllowedNewCreation {
+		return nil, ErrSuperAdminCreationDisabled
+	}
+
+	u, err := d.repositories.UserRepository.FindByEmail(ctx, request.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if u == nil {
+		return d.createSuperAdmin(ctx, request)
+	}
+
+	res := &response.SuperAdminResponse{UserID: u.ID, Email: request.Email, Outcome: response.SuperAdminUnchanged}
+	if u.Role == role.SuperAdmin {
+		return res, nil
+	}
+	if err := d.repositories.UserRepository.UpdateRole(ctx, u.ID, role.SuperAdmin); err != nil {
+		return nil, fmt.Errorf("failed to promote user: %w", err)
+	}
+	// Tokens issued with the previous role must be refreshed
+	if _, err := d.tokenVersions.Bump(ctx, u.ID); err != nil {
+		d.logger.Warn("failed to raise token version after promotion", zap.String("user_id", u.ID.String()), zap.Error(err))
+	}
+	if err := d.denylist.RevokeUserTokens(ctx, u.ID); err != nil {
+		d.logger.Warn("failed to revoke tokens after promotion", zap.String("user_id", u.ID.String()), zap.Error(err))
+	}
+
+	d.auditLogger.Record(ctx, audit.Event{
+		Action:    audit.ActionSuperAdminPromoted,
+		System:    request.Source,
+		SubjectID: &u.ID,
+		Metadata:  map[string]interface{}{"previous_role": string(u.Role)},
+	})
+	res.Outcome = response.SuperAdminPromoted
+	return res, nil
+}
+
+func (d *DefaultSuperAdminManager) Bootstrap(ctx context.Context, request request.BootstrapSuperAdminRequest) (*response.SuperAdminResponse, error) {
+	expected := d.res.Config.SuperAdminConfig.BootstrapToken
+	if expected == "" {
+		return nil, ErrBootstrapUnavailable
+	}
+	if !d.res.Config.SuperAdminConfig.AllowedNewCreation {
+		return nil, ErrSuperAdminCreationDisabled
+	}
+	if err := d.loginProtection.throttleClientIP(ctx, rediskey.SuperAdminBootstrapIPRateKey, d.res.Config.LoginProtectionConfig.BootstrapIPLimit); err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(request.BootstrapToken), []byte(expected)) != 1 {
+		return nil, ErrInvalidBootstrapToken
+	}
+
+	// Two concurrent requests could both see an empty admin list
+	locked, err := d.res.Redis.AcquireLock(ctx, rediskey.SuperAdminBootstrapLockKey(), superAdminBootstrapLockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock bootstrap: %w", err)
+	}
+	if !locked {
+		return nil, ErrBootstrapUnavailable
+	}
+	defer func() {
+		if err := d.res.Redis.ReleaseLock(ctx, rediskey.SuperAdminBootstrapLockKey()); err != nil {
+			d.logger.Warn("failed to release bootstrap lock", zap.Error(err))
+		}
+	}()
+
+	admins, err := d.repositories.UserRepository.CountByRoles(ctx, role.Admin, role.SuperAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count admins: %w", err)
+	}
+	if admins > 0 {
+		return nil, ErrBootstrapUnavailable
+	}
+
+	// Unlike the CLI, an existing account is never promoted, the token only proves access to the configuration
+	request.Source = SuperAdminSourceBootstrap
+	return d.createSuperAdmin(ctx, request.CreateSuperAdminRequest)
+}
+
+func (d *DefaultSuperAdminManager) createSuperAdmin(ctx context.Context, request request.CreateSuperAdminRequest) (*response.SuperAdminResponse, error) {
+	if request.Password == "" {
+		return nil, ErrPasswordRequired
+	}
+	// The most privileged account gets no exception from the policy every other account follows
+	if err := checkPasswordPolicy(d.policy, request.Password, request.Email); err != nil {
+		return nil, err
+	}
+	hashed, err := d.hasher.HashPassword(request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &entity.User{
+		Username:      request.Email,
+		Email:         &request.Email,
+		Password:      hashed,
+		Status:        userstatus.Verified,
+		Role:          role.SuperAdmin,
+		EmailVerified: true,
+	}
+	if _, err := d.repositories.UserRepository.Insert(ctx, u); err != nil {
+		if queryutil.IsUniqueViolation(err) {
+			return nil, ErrEmailAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create super admin: %w", err)
+	}
+
+	d.auditLogger.Record(ctx, audit.Event{
+		Action:    audit.ActionSuperAdminCreated,
+		System:    request.Source,
+		SubjectID: &u.ID,
+	})
+	return &response.SuperAdminResponse{UserID: u.ID, Email: request.Email, Outcome: response.SuperAdminCreated}, nil
+}
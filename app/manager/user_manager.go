@@ -0,0 +1,403 @@
+package manager
+
+import (
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/rbac"
+	"backend/service-platform/app/pkg/tokenversion"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCannotManageSelf       = errors.New("admins cannot change their own account")
+	ErrInsufficientPrivileges = errors.New("the user holds permissions you do not have")
+	ErrUserNotSuspended       = errors.New("user is not suspended")
+	ErrUserNotDeleted         = errors.New("user is not deleted")
+	ErrUserRestoreConflict    = errors.New("another account already uses the email or username")
+	ErrUserPurged             = errors.New("user was purged and cannot be restored")
+)
+
+var userOrderColumns = []string{"created_at", "last_login_at", "email", "username"}
+
+type UserManager interface {
+	ListUsers(ctx context.Context, request request.ListUsersRequest) ([]response.UserResponse, int64, error)
+	// GetUser also returns soft-deleted users
+	GetUser(ctx context.Context, id uuid.UUID) (*response.UserResponse, error)
+	UpdateUserRole(ctx context.Context, request request.UpdateUserRoleRequest) (*response.UserResponse, error)
+	// SuspendUser disables the account, ends every session and revokes the user's API keys
+	SuspendUser(ctx context.Context, request request.AdminUserRequest) (*response.UserResponse, error)
+	UnsuspendUser(ctx context.Context, request request.AdminUserRequest) (*response.UserResponse, error)
+	RestoreUser(ctx context.Context, request request.AdminUserRequest) (*response.UserResponse, error)
+	// ForceLogout revokes every session and access token of the user
+	ForceLogout(ctx context.Context, request request.AdminUserRequest) (*response.RevokeSessionsResponse, error)
+	// ImpersonateUser issues a short-lived access token for the user that names the admin, without a refresh token or session
+	ImpersonateUser(ctx context.Context, request request.AdminUserRequest) (*response.ImpersonationResponse, error)
+}
+
+type DefaultUserManager struct {
+	logger        *zap.Logger
+	res           runtime.Resource
+	authorizer    rbac.Authorizer
+	jwtManager    jwt.Jwt
+	denylist      denylist.Denylist
+	tokenVersions tokenversion.Versions
+	auditLogger   audit.AuditLogger
+	repositories  *repository.Repositories
+}
+
+func NewUserManager(
+	res runtime.Resource,
+	authorizer rbac.Authorizer,
+	jwtManager jwt.Jwt,
+	denylist denylist.Denylist,
+	tokenVersions tokenversion.Versions,
+	auditLogger audit.AuditLogger,
+	repositories *repository.Repositories,
+) UserManager {
+	return &DefaultUserManager{
+		logger:        res.Logger,
+		res:           res,
+		authorizer:    authorizer,
+		jwtManager:    jwtManager,
+		denylist:      denylist,
+		tokenVersions: tokenVersions,
+		auditLogger:   auditLogger,
+		repositories:  repositories,
+	}
+}
+
+func (d *DefaultUserManager) ListUsers(ctx context.Context, request request.ListUsersRequest) ([]response.UserResponse, int64, error) {
+	request.LoadDefaultValues()
+	if !slices.Contains(userOrderColumns, request.OrderBy) {
+		request.OrderBy = "created_at"
+	}
+
+	filter := repository.UserFilter{
+		Status:        request.Status,
+		Role:          request.Role,
+		EmailVerified: request.EmailVerified,
+		PhoneVerified: request.PhoneVerified,
+		CreatedFrom:   request.CreatedFrom,
+		CreatedBefore: request.CreatedBefore,
+		Deleted:       request.Deleted,
+	}
+	if request.Search != nil {
+		search := strings.TrimSpace(*request.Search)
+		filter.Search = &search
+	}
+	users, total, err := d.repositories.UserRepository.FindMany(ctx, filter, request.ToPage())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	res := make([]response.UserResponse, 0, len(users))
+	for _, u := range users {
+		res = append(res, toUserResponse(u))
+	}
+	return res, int64(total), nil
+}
+
+func (d *DefaultUserManager) GetUser(ctx context.Context, id uuid.UUID) (*response.UserResponse, error) {
+	u, err := d.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultUserManager) UpdateUserRole(ctx context.Context, request request.UpdateUserRoleRequest) (*response.UserResponse, error) {
+	if _, err := d.repositories.RoleRepository.FindByName(ctx, string(request.Role)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// Nobody hands out more than they hold
+	if err := d.authorizeActor(ctx, request.AdminUserRequest, u.Role, request.Role); err != nil {
+		return nil, err
+	}
+	if u.Role == request.Role {
+		res := toUserResponse(*u)
+		return &res, nil
+	}
+
+	if err := d.repositories.UserRepository.UpdateRole(ctx, u.ID, request.Role); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	// Access tokens carry the role, the user has to refresh them
+	if _, err := d.tokenVersions.Bump(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	// Also ends the impersonations the user started
+	if err := d.denylist.RevokeUserTokens(ctx, u.ID); err != nil {
+		return nil, err
+	}
+
+	d.auditEvent(ctx, audit.ActionUserRoleChanged, request.AdminUserRequest, map[string]interface{}{
+		"previous_role": string(u.Role),
+		"new_role":      string(request.Role),
+	})
+	u.Role = request.Role
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultUserManager) SuspendUser(ctx context.Context, request request.AdminUserRequest) (*response.UserResponse, error) {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.authorizeActor(ctx, request, u.Role); err != nil {
+		return nil, err
+	}
+
+	u, err = d.repositories.UserRepository.UpdateStatus(ctx, u.ID, userstatus.Disabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to suspend user: %w", err)
+	}
+	if _, err := d.revokeAccess(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	if _, err := d.tokenVersions.Bump(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	// Keys are not restored on unsuspend, the user creates new ones
+	revokedKeys, err := d.repositories.ApiKeyRepository.RevokeByUserID(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api keys: %w", err)
+	}
+
+	d.auditEvent(ctx, audit.ActionUserSuspended, request, map[string]interface{}{"revoked_api_keys": revokedKeys})
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultUserManager) UnsuspendUser(ctx context.Context, request request.AdminUserRequest) (*response.UserResponse, error) {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.authorizeActor(ctx, request, u.Role); err != nil {
+		return nil, err
+	}
+	if u.Status != userstatus.Disabled {
+		return nil, ErrUserNotSuspended
+	}
+
+	// Back to where email verification left the account
+	status := userstatus.Unverified
+	if u.EmailVerified {
+		status = userstatus.Verified
+	}
+	u, err = d.repositories.UserRepository.UpdateStatus(ctx, u.ID, status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to unsuspend user: %w", err)
+	}
+
+	d.auditEvent(ctx, audit.ActionUserUnsuspended, request, nil)
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultUserManager) RestoreUser(ctx context.Context, request request.AdminUserRequest) (*response.UserResponse, error) {
+	u, err := d.repositories.UserRepository.FindByIDWithDeleted(ctx, request.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if err := d.authorizeActor(ctx, request, u.Role); err != nil {
+		return nil, err
+	}
+	// Nothing is left to restore once the personal data is erased
+	if u.PurgedAt != nil {
+		return nil, ErrUserPurged
+	}
+
+	u, err = d.repositories.UserRepository.Restore(ctx, request.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrUserNotDeleted
+		case queryutil.IsUniqueViolation(err):
+			return nil, ErrUserRestoreConflict
+		}
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	d.auditEvent(ctx, audit.ActionUserRestored, request, nil)
+	res := toUserResponse(*u)
+	return &res, nil
+}
+
+func (d *DefaultUserManager) ForceLogout(ctx context.Context, request request.AdminUserRequest) (*response.RevokeSessionsResponse, error) {
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.authorizeActor(ctx, request, u.Role); err != nil {
+		return nil, err
+	}
+
+	revoked, err := d.revokeAccess(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.auditEvent(ctx, audit.ActionUserLoggedOut, request, map[string]interface{}{"revoked_sessions": revoked})
+	return &response.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+func (d *DefaultUserManager) ImpersonateUser(ctx context.Context, request request.AdminUserRequest) (*response.ImpersonationResponse, error) {
+	// The token names the admin, a principal without a user cannot impersonate
+	if request.ActorID == nil || *request.ActorID == uuid.Nil {
+		return nil, ErrInsufficientPrivileges
+	}
+	u, err := d.findUser(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u.DeletedAt != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := d.authorizeActor(ctx, request, u.Role); err != nil {
+		return nil, err
+	}
+	if err := checkAccountActive(u); err != nil {
+		return nil, err
+	}
+	actor, err := d.findUser(ctx, *request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRole := string(u.Role)
+	token, err := d.jwtManager.GenerateImpersonationToken(
+		&u.ID,
+		&u.Username,
+		u.Email,
+		u.PhoneNumber,
+		&userRole,
+		&u.EmailVerified,
+		&u.PhoneVerified,
+		u.LastLoginAt,
+		&u.TokenVersion,
+		&jwt.Actor{UserID: &actor.ID, Username: &actor.Username},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	d.auditEvent(ctx, audit.ActionImpersonationStarted, request, map[string]interface{}{
+		"expires_at": token.ExpiredAt.UTC().Format(time.RFC3339),
+	})
+	return &response.ImpersonationResponse{
+		AccessToken: token.Token,
+		TokenType:   jwt.TokenTypeBearer,
+		ExpiresIn:   int64(time.Until(token.ExpiredAt).Seconds()),
+		ExpiresAt:   token.ExpiredAt,
+		User:        toUserResponse(*u),
+	}, nil
+}
+
+func (d *DefaultUserManager) findUser(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	u, err := d.repositories.UserRepository.FindByIDWithDeleted(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return u, nil
+}
+
+// authorizeActor refuses changes to the actor's own account and to users whose roles grant permissions the actor lacks
+func (d *DefaultUserManager) authorizeActor(ctx context.Context, request request.AdminUserRequest, roles ...role.Role) error {
+	if request.ActorID != nil && *request.ActorID == request.UserID {
+		return ErrCannotManageSelf
+	}
+	held, err := d.authorizer.Permissions(ctx, request.ActorRole)
+	if err != nil {
+		return fmt.Errorf("failed to resolve permissions: %w", err)
+	}
+	for _, r := range roles {
+		required, err := d.authorizer.Permissions(ctx, string(r))
+		if err != nil {
+			return fmt.Errorf("failed to resolve permissions: %w", err)
+		}
+		if !rbac.HasAll(held, required...) {
+			return ErrInsufficientPrivileges
+		}
+	}
+	return nil
+}
+
+// revokeAccess ends every session and denies the access tokens already issued
+func (d *DefaultUserManager) revokeAccess(ctx context.Context, userID uuid.UUID) (int, error) {
+	revoked, err := d.repositories.SessionRepository.RevokeAllForUser(ctx, userID, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	if err := d.denylist.RevokeUserTokens(ctx, userID); err != nil {
+		return 0, err
+	}
+	return revoked, nil
+}
+
+// auditEvent records the action on the target user, the actor is the authenticated principal
+func (d *DefaultUserManager) auditEvent(ctx context.Context, action string, request request.AdminUserRequest, metadata map[string]interface{}) {
+	auditOn(ctx, d.auditLogger, action, &request.UserID, metadata)
+}
+
+func toUserResponse(u entity.User) response.UserResponse {
+	return response.UserResponse{
+		ID:            u.ID,
+		Username:      u.Username,
+		Email:         u.Email,
+		PendingEmail:  u.PendingEmail,
+		PhoneNumber:   u.PhoneNumber,
+		Status:        string(u.Status),
+		Role:          string(u.Role),
+		EmailVerified: u.EmailVerified,
+		PhoneVerified: u.PhoneVerified,
+		MfaEnabled:    u.MfaEnabled,
+		LockedUntil:   u.LockedUntil,
+		LastLoginAt:   u.LastLoginAt,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+		DeletedAt:     u.DeletedAt,
+
+		DeactivatedAt:       u.DeactivatedAt,
+		DeletionScheduledAt: u.DeletionScheduledAt,
+	}
+}
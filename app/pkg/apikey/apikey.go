@@ -0,0 +1,63 @@
+// Package apikey generates and parses API keys of the form sk_<prefix>_<secret>.
+// The prefix is stored in clear for lookup, the full key is only kept as a hash.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+)
+
+const (
+	keyPrefix   = "sk_"
+	prefixBytes = 6
+	secretBytes = 32
+)
+
+// Key is a freshly generated API key; Plaintext must only be shown once
+type Key struct {
+	Plaintext string
+	Prefix    string
+	Hash      string
+}
+
+// Generate returns a new random key
+func Generate() (Key, error) {
+	lookup := make([]byte, prefixBytes)
+	if _, err := rand.Read(lookup); err != nil {
+		return Key{}, err
+	}
+	secret, err := securetoken.Generate(secretBytes)
+	if err != nil {
+		return Key{}, err
+	}
+	prefix := hex.EncodeToString(lookup)
+	plaintext := keyPrefix + prefix + "_" + secret
+	return Key{Plaintext: plaintext, Prefix: prefix, Hash: Hash(plaintext)}, nil
+}
+
+// ParsePrefix extracts the lookup prefix, ok is false when the key is not in the expected format
+func ParsePrefix(key string) (prefix string, ok bool) {
+	rest, found := strings.CutPrefix(key, keyPrefix)
+	if !found {
+		return "", false
+	}
+	prefix, secret, found := strings.Cut(rest, "_")
+	if !found || len(prefix) != 2*prefixBytes || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// Hash returns the value persisted for a key
+func Hash(key string) string {
+	return securetoken.Hash(key)
+}
+
+// Verify compares a presented key with a stored hash in constant time
+func Verify(key string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
@@ -0,0 +1,221 @@
+// Package audit records security relevant actions in an append-only log.
+// Every event stores the hash of the event before it, so editing or removing a row breaks the chain.
+package audit
+
+import (
+	"backend/service-platform/app/database/constant/audit"
+	"backend/service-platform/app/database/entity"
+	ctxutil "backend/service-platform/app/pkg/util/context"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Audited actions, also logged with a stable "security_event" field so they can be alerted on
+const (
+	ActionLoginSucceeded           = "login_succeeded"
+	ActionLoginFailed              = "login_failed"
+	ActionTokenRefreshed           = "token_refreshed"
+	ActionRefreshTokenReuse        = "refresh_token_reuse"
+	ActionLoggedOut                = "logged_out"
+	ActionPasswordReset            = "password_reset"
+	ActionPasswordChanged          = "password_changed"
+	ActionEmailChangeRequested     = "email_change_requested"
+	ActionEmailChanged             = "email_changed"
+	ActionIdentityLinked           = "identity_linked"
+	ActionAccountLocked            = "account_locked"
+	ActionAccountUnlocked          = "account_unlocked"
+	ActionAccountDeactivated       = "account_deactivated"
+	ActionAccountReactivated       = "account_reactivated"
+	ActionAccountDeletionRequested = "account_deletion_requested"
+	ActionMfaEnabled               = "mfa_enabled"
+	ActionMfaDisabled              = "mfa_disabled"
+	ActionSessionRevoked           = "session_revoked"
+	ActionSessionsRevoked          = "sessions_revoked"
+	ActionPersonalTokenCreated     = "personal_access_token_created"
+	ActionPersonalTokenRevoked     = "personal_access_token_revoked"
+
+	ActionUserRoleChanged    = "user_role_changed"
+	ActionUserSuspended      = "user_suspended"
+	ActionUserUnsuspended    = "user_unsuspended"
+	ActionUserRestored       = "user_restored"
+	ActionUserLoggedOut      = "user_force_logged_out"
+	ActionApiKeyCreated      = "api_key_created"
+	ActionApiKeyUpdated      = "api_key_updated"
+	ActionApiKeyRevoked      = "api_key_revoked"
+	ActionApiKeyRotated      = "api_key_rotated"
+	ActionRoleCreated        = "role_created"
+	ActionRoleUpdated        = "role_updated"
+	ActionRoleDeleted        = "role_deleted"
+	ActionSuperAdminCreated  = "super_admin_created"
+	ActionSuperAdminPromoted = "super_admin_promoted"
+
+	ActionImpersonationStarted = "impersonation_started"
+	ActionImpersonatedRequest  = "impersonated_request"
+
+	ActionServiceClientCreated = "service_client_created"
+	ActionServiceClientRevoked = "service_client_revoked"
+)
+
+// Metadata key naming the admin behind an action taken with an impersonation token
+const MetadataImpersonatorID = "impersonator_id"
+
+// Event is an action to record, the client and the authenticated principal are taken from the context
+type Event struct {
+	Action string
+	// Overrides the principal, e.g. the user signing in
+	ActorID *uuid.UUID
+	// Names the process acting without a principal, e.g. a CLI command
+	System string
+	// User the action was performed on
+	SubjectID *uuid.UUID
+	Metadata  map[string]interface{}
+}
+
+// Store appends events to the chain
+type Store interface {
+	// Append inserts the event after the newest one; seal receives the hash of that event and completes the chain fields
+	Append(ctx context.Context, event *entity.AuditEvent, seal func(previousHash string)) error
+}
+
+type AuditLogger interface {
+	// Record appends the event and logs it; a failure is logged and never fails the audited action
+	Record(ctx context.Context, event Event)
+}
+
+type ChainLogger struct {
+	store  Store
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+func NewChainLogger(store Store, logger *zap.Logger) *ChainLogger {
+	return &ChainLogger{
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (l *ChainLogger) Record(ctx context.Context, event Event) {
+	e, err := l.newEvent(ctx, event)
+	if err == nil {
+		// The action already happened, a client disconnecting must not lose its record
+		err = l.store.Append(context.WithoutCancel(ctx), e, func(previousHash string) {
+			e.PreviousHash = previousHash
+			// Postgres keeps microseconds, the hash must match the stored timestamp
+			e.CreatedAt = l.now().UTC().Truncate(time.Microsecond)
+			e.Hash = Hash(previousHash, *e)
+		})
+	}
+	if err != nil {
+		fields := []zap.Field{zap.String("action", event.Action), zap.Error(err)}
+		if event.SubjectID != nil {
+			fields = append(fields, zap.String("subject_id", event.SubjectID.String()))
+		}
+		l.logger.Error("failed to record audit event", fields...)
+		return
+	}
+	l.logger.Info("security event", logFields(e)...)
+}
+
+func logFields(e *entity.AuditEvent) []zap.Field {
+	fields := []zap.Field{
+		zap.String("security_event", e.Action),
+		zap.String("actor_type", string(e.ActorType)),
+	}
+	if e.ActorID != nil {
+		fields = append(fields, zap.String("actor_id", e.ActorID.String()))
+	}
+	if e.ActorName != nil {
+		fields = append(fields, zap.String("actor_name", *e.ActorName))
+	}
+	if e.SubjectID != nil {
+		fields = append(fields, zap.String("user_id", e.SubjectID.String()))
+	}
+	if e.IPAddress != nil {
+		fields = append(fields, zap.String("ip_address", *e.IPAddress))
+	}
+	if e.UserAgent != nil {
+		fields = append(fields, zap.String("user_agent", *e.UserAgent))
+	}
+	if e.RequestID != nil {
+		fields = append(fields, zap.String("request_id", *e.RequestID))
+	}
+	if len(e.Metadata) > 0 {
+		fields = append(fields, zap.Any("metadata", map[string]interface{}(e.Metadata)))
+	}
+	return fields
+}
+
+func (l *ChainLogger) newEvent(ctx context.Context, event Event) (*entity.AuditEvent, error) {
+	principal, authenticated := ctxutil.PrincipalKey.Get(ctx)
+	if authenticated && principal.ImpersonatorID != nil {
+		event.Metadata = maps.Clone(event.Metadata)
+		if event.Metadata == nil {
+			event.Metadata = map[string]interface{}{}
+		}
+		event.Metadata[MetadataImpersonatorID] = principal.ImpersonatorID.String()
+	}
+	metadata, err := normalizeMetadata(event.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	e := &entity.AuditEvent{
+		Action:    event.Action,
+		ActorType: audit.Anonymous,
+		SubjectID: event.SubjectID,
+		Metadata:  metadata,
+	}
+
+	switch {
+	case event.ActorID != nil:
+		e.ActorType = audit.User
+		e.ActorID = event.ActorID
+	case event.System != "":
+		e.ActorType = audit.System
+		e.ActorName = &event.System
+	// Service API keys carry a placeholder user ID, the service name identifies them
+	case authenticated && principal.ServiceName != "":
+		e.ActorType = audit.Service
+		e.ActorName = &principal.ServiceName
+	case authenticated && principal.UserID != nil:
+		e.ActorType = audit.User
+		e.ActorID = principal.UserID
+	}
+
+	if client, ok := ctxutil.ClientInfoKey.Get(ctx); ok {
+		e.IPAddress = optional(client.IPAddress)
+		e.UserAgent = optional(client.UserAgent)
+		e.RequestID = optional(client.RequestID)
+	}
+	return e, nil
+}
+
+// normalizeMetadata round-trips the metadata through JSON so it hashes the same before and after it is stored
+func normalizeMetadata(metadata map[string]interface{}) (entity.AuditMetadata, error) {
+	normalized := entity.AuditMetadata{}
+	if len(metadata) == 0 {
+		return normalized, nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit metadata: %w", err)
+	}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit metadata: %w", err)
+	}
+	return normalized, nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
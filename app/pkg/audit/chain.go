@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"backend/service-platform/app/database/constant/audit"
+	"backend/service-platform/app/database/entity"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// chainLink is the hashed form of an event, json.Marshal keeps the field order and sorts the metadata keys
+type chainLink struct {
+	PreviousHash string               `json:"previous_hash"`
+	Action       string               `json:"action"`
+	ActorType    audit.ActorType      `json:"actor_type"`
+	ActorID      *uuid.UUID           `json:"actor_id"`
+	ActorName    *string              `json:"actor_name"`
+	SubjectID    *uuid.UUID           `json:"subject_id"`
+	IPAddress    *string              `json:"ip_address"`
+	UserAgent    *string              `json:"user_agent"`
+	RequestID    *string              `json:"request_id"`
+	Metadata     entity.AuditMetadata `json:"metadata"`
+	CreatedAt    string               `json:"created_at"`
+}
+
+// Hash links the event to the previous one, the event's own Seq, ID and Hash are not part of it
+func Hash(previousHash string, e entity.AuditEvent) string {
+	metadata := e.Metadata
+	if metadata == nil {
+		metadata = entity.AuditMetadata{}
+	}
+	link := chainLink{
+		PreviousHash: previousHash,
+		Action:       e.Action,
+		ActorType:    e.ActorType,
+		ActorID:      e.ActorID,
+		ActorName:    e.ActorName,
+		SubjectID:    e.SubjectID,
+		IPAddress:    e.IPAddress,
+		UserAgent:    e.UserAgent,
+		RequestID:    e.RequestID,
+		Metadata:     metadata,
+		CreatedAt:    e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	// Only fails for metadata that cannot be stored either
+	data, _ := json.Marshal(link)
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// Verify checks consecutive events in chain order, starting after an event with the given hash.
+// It returns the index of the first event that does not link to its predecessor or whose content changed, -1 when all hold.
+func Verify(previousHash string, events []entity.AuditEvent) int {
+	for i, e := range events {
+		if e.PreviousHash != previousHash || Hash(previousHash, e) != e.Hash {
+			return i
+		}
+		previousHash = e.Hash
+	}
+	return -1
+}
@@ -0,0 +1,133 @@
+package bcrypt
+
+import (
+	"backend/service-platform/app/internal/config"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// Defaults from the second recommended option of RFC 9106
+const (
+	DefaultArgon2Memory      = 64 * 1024
+	DefaultArgon2Iterations  = 3
+	DefaultArgon2Parallelism = 2
+	DefaultArgon2SaltLength  = 16
+	DefaultArgon2KeyLength   = 32
+)
+
+var ErrInvalidHash = errors.New("invalid password hash")
+
+// Argon2id hashes passwords into the PHC string format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+type Argon2id struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+func NewArgon2id(cfg config.Argon2Config) Argon2id {
+	a := Argon2id{
+		memory:      cfg.Memory,
+		iterations:  cfg.Iterations,
+		parallelism: cfg.Parallelism,
+		saltLength:  cfg.SaltLength,
+		keyLength:   cfg.KeyLength,
+	}
+	if a.memory == 0 {
+		a.memory = DefaultArgon2Memory
+	}
+	if a.iterations == 0 {
+		a.iterations = DefaultArgon2Iterations
+	}
+	if a.parallelism == 0 {
+		a.parallelism = DefaultArgon2Parallelism
+	}
+	// Below 8 bytes the salt does not prevent precomputation, below 16 the key is too short to resist brute force
+	if a.saltLength < 8 {
+		a.saltLength = DefaultArgon2SaltLength
+	}
+	if a.keyLength < 16 {
+		a.keyLength = DefaultArgon2KeyLength
+	}
+	return a
+}
+
+func (a *Argon2id) HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("password cannot be empty")
+	}
+
+	salt := make([]byte, a.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, a.iterations, a.memory, a.parallelism, a.keyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, a.memory, a.iterations, a.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *Argon2id) CheckPassword(password, hash string) (bool, error) {
+	if password == "" {
+		return false, fmt.Errorf("password cannot be empty")
+	}
+	if hash == "" {
+		return false, fmt.Errorf("hash cannot be empty")
+	}
+
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	// Verified with the parameters of the hash, not the configured ones
+	computed := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(computed, key) == 1, nil
+}
+
+// NeedsRehash reports whether the hash is not an argon2id hash with the configured parameters
+func (a *Argon2id) NeedsRehash(hash string) bool {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.memory != a.memory ||
+		params.iterations != a.iterations ||
+		params.parallelism != a.parallelism ||
+		uint32(len(salt)) != a.saltLength ||
+		uint32(len(key)) != a.keyLength
+}
+
+func decodeArgon2id(hash string) (params Argon2id, salt, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported argon2 version", ErrInvalidHash)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil ||
+		params.memory == 0 || params.iterations == 0 || params.parallelism == 0 {
+		return params, nil, nil, fmt.Errorf("%w: invalid argon2 parameters", ErrInvalidHash)
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: invalid salt", ErrInvalidHash)
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return params, nil, nil, fmt.Errorf("%w: invalid key", ErrInvalidHash)
+	}
+	return params, salt, key, nil
+}
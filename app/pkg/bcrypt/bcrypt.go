@@ -59,3 +59,9 @@ func (b *Bcrypt) CheckPassword(password, hash string) (bool, error) {
 
 	return true, nil
 }
+
+// NeedsRehash reports whether the hash is not a bcrypt hash of the configured cost
+func (b *Bcrypt) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.cost
+}
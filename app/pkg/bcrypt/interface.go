@@ -3,4 +3,6 @@ package bcrypt
 type Hasher interface {
 	HashPassword(password string) (string, error)
 	CheckPassword(password, hash string) (bool, error)
+	// NeedsRehash reports whether the hash should be replaced by a fresh one on the next successful check
+	NeedsRehash(hash string) bool
 }
@@ -0,0 +1,69 @@
+package bcrypt
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// bcrypt hashes carry the variant of the implementation that produced them
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// MultiHasher hashes with the configured algorithm and verifies hashes of every supported algorithm,
+// detected from their format, so stored hashes can be upgraded one login at a time
+type MultiHasher struct {
+	algorithm string
+	bcrypt    *Bcrypt
+	argon2id  *Argon2id
+}
+
+func NewMultiHasher(algorithm string, bcrypt *Bcrypt, argon2id *Argon2id) (*MultiHasher, error) {
+	switch algorithm {
+	case AlgorithmBcrypt, AlgorithmArgon2id:
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", algorithm)
+	}
+	return &MultiHasher{algorithm: algorithm, bcrypt: bcrypt, argon2id: argon2id}, nil
+}
+
+func (m *MultiHasher) HashPassword(password string) (string, error) {
+	return m.hasher(m.algorithm).HashPassword(password)
+}
+
+func (m *MultiHasher) CheckPassword(password, hash string) (bool, error) {
+	algorithm, ok := DetectAlgorithm(hash)
+	if !ok {
+		return false, fmt.Errorf("%w: unknown hash format", ErrInvalidHash)
+	}
+	return m.hasher(algorithm).CheckPassword(password, hash)
+}
+
+// NeedsRehash reports hashes of another algorithm than the configured one, or with outdated parameters
+func (m *MultiHasher) NeedsRehash(hash string) bool {
+	algorithm, ok := DetectAlgorithm(hash)
+	return !ok || algorithm != m.algorithm || m.hasher(algorithm).NeedsRehash(hash)
+}
+
+func (m *MultiHasher) hasher(algorithm string) Hasher {
+	if algorithm == AlgorithmArgon2id {
+		return m.argon2id
+	}
+	return m.bcrypt
+}
+
+// DetectAlgorithm returns the algorithm that produced the hash
+func DetectAlgorithm(hash string) (string, bool) {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return AlgorithmArgon2id, true
+	}
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(hash, prefix) {
+			return AlgorithmBcrypt, true
+		}
+	}
+	return "", false
+}
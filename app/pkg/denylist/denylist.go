@@ -0,0 +1,105 @@
+package denylist
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/redis"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// Denylist invalidates signed tokens before they expire
+type Denylist interface {
+	// RevokeToken denies a single token until it would have expired anyway
+	RevokeToken(ctx context.Context, claims *jwt.Claims) error
+	// RevokeUserTokens denies every token of the user issued before now
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID) error
+	// Check returns ErrTokenRevoked when the token was revoked by either mechanism
+	Check(ctx context.Context, claims *jwt.Claims) error
+}
+
+type RedisDenylist struct {
+	redis redis.Redis
+	// Longest token lifetime, after which a user cutoff is no longer needed
+	maxLifetime time.Duration
+}
+
+func NewRedisDenylist(rds redis.Redis, cfg config.JwtConfig) *RedisDenylist {
+	return &RedisDenylist{
+		redis:       rds,
+		maxLifetime: max(cfg.AccessExpiration, cfg.RefreshExpiration),
+	}
+}
+
+func (d *RedisDenylist) RevokeToken(ctx context.Context, claims *jwt.Claims) error {
+	// Tokens issued before jti existed can only be revoked per user
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := d.redis.Set(ctx, rediskey.AccessTokenDenylistKey(claims.ID), true, ttl); err != nil {
+		return fmt.Errorf("failed to deny token: %w", err)
+	}
+	return nil
+}
+
+func (d *RedisDenylist) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	// Fractional seconds, cutoffs stored in whole seconds still read back
+	validAfter := float64(time.Now().UnixMilli()) / 1000
+	if err := d.redis.Set(ctx, rediskey.TokensValidAfterKey(userID.String()), validAfter, d.maxLifetime); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	return nil
+}
+
+func (d *RedisDenylist) Check(ctx context.Context, claims *jwt.Claims) error {
+	if claims.ID != "" {
+		denied, err := d.redis.Exists(ctx, rediskey.AccessTokenDenylistKey(claims.ID))
+		if err != nil {
+			return fmt.Errorf("failed to check token denylist: %w", err)
+		}
+		if denied {
+			return ErrTokenRevoked
+		}
+	}
+
+	if claims.UserID != nil {
+		if err := d.checkUser(ctx, *claims.UserID, claims); err != nil {
+			return err
+		}
+	}
+	// Revoking the admin's tokens also ends the impersonations they started
+	if claims.Act != nil && claims.Act.UserID != nil {
+		return d.checkUser(ctx, *claims.Act.UserID, claims)
+	}
+	return nil
+}
+
+// checkUser denies tokens issued before the user's cutoff
+func (d *RedisDenylist) checkUser(ctx context.Context, userID uuid.UUID, claims *jwt.Claims) error {
+	var validAfter float64
+	if err := d.redis.Get(ctx, rediskey.TokensValidAfterKey(userID.String()), &validAfter); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil
+		}
+		return fmt.Errorf("failed to check tokens valid after: %w", err)
+	}
+	// iat has millisecond precision, a token issued in the revocation millisecond is denied as well
+	cutoff := time.UnixMilli(int64(math.Round(validAfter * 1000)))
+	if claims.IssuedAt == nil || !claims.IssuedAt.After(cutoff) {
+		return ErrTokenRevoked
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+var ErrMissingKey = errors.New("encryption key is not configured")
+
+type Encryptor interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// AESEncryptor seals values with AES-256-GCM; the key is derived from the configured secret with sha256
+type AESEncryptor struct {
+	key []byte
+}
+
+func NewAESEncryptor(secret string) *AESEncryptor {
+	if secret == "" {
+		return &AESEncryptor{}
+	}
+	key := sha256.Sum256([]byte(secret))
+	return &AESEncryptor{key: key[:]}
+}
+
+func (e *AESEncryptor) Encrypt(plaintext string) (string, error) {
+	gcm, err := e.gcm()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (e *AESEncryptor) Decrypt(ciphertext string) (string, error) {
+	gcm, err := e.gcm()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func (e *AESEncryptor) gcm() (cipher.AEAD, error) {
+	if len(e.key) == 0 {
+		return nil, ErrMissingKey
+	}
+	block, err := aes.NewCipher(e.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,66 @@
+// Package ethereum implements the few Ethereum primitives needed to authenticate wallets:
+// Keccak-256, EIP-55 addresses and recovery of EIP-191 personal signatures on secp256k1.
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const AddressLength = 20
+
+var ErrInvalidAddress = errors.New("invalid ethereum address")
+
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// ChecksumAddress returns the EIP-55 form of a 0x-prefixed hex address of any case
+func ChecksumAddress(address string) (string, error) {
+	raw, err := decodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return checksum(raw), nil
+}
+
+// IsChecksumAddress reports whether the address is written in its EIP-55 mixed case form
+func IsChecksumAddress(address string) bool {
+	raw, err := decodeAddress(address)
+	return err == nil && checksum(raw) == address
+}
+
+func decodeAddress(address string) ([]byte, error) {
+	if !strings.HasPrefix(address, "0x") || len(address) != 2+2*AddressLength {
+		return nil, ErrInvalidAddress
+	}
+	raw, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return nil, ErrInvalidAddress
+	}
+	return raw, nil
+}
+
+// checksum upper-cases each hex letter whose nibble in the hash of the lowercase address is 8 or more
+func checksum(raw []byte) string {
+	lower := hex.EncodeToString(raw)
+	hash := Keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0x0f >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
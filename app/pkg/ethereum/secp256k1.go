@@ -0,0 +1,157 @@
+package ethereum
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+// secp256k1 domain parameters (SEC 2, section 2.4.1)
+var (
+	curveP, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	curveN, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	curveGx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	curveGy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+	curveB     = big.NewInt(7)
+
+	halfN = new(big.Int).Rsh(curveN, 1)
+	// (p+1)/4, p = 3 mod 4 so a square root of a is a^((p+1)/4)
+	sqrtExp = new(big.Int).Rsh(new(big.Int).Add(curveP, big.NewInt(1)), 2)
+)
+
+// point is an affine point of the curve, nil being the point at infinity.
+// Only public values go through this code, so the arithmetic does not need to be constant time.
+type point struct {
+	x, y *big.Int
+}
+
+var generator = &point{x: curveGx, y: curveGy}
+
+func (a *point) add(b *point) *point {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.x.Cmp(b.x) == 0:
+		if a.y.Cmp(b.y) == 0 {
+			return a.double()
+		}
+		return nil
+	}
+	// lambda = (y2 - y1) / (x2 - x1)
+	num := new(big.Int).Sub(b.y, a.y)
+	den := new(big.Int).Sub(b.x, a.x)
+	den.Mod(den, curveP).ModInverse(den, curveP)
+	lambda := num.Mul(num, den)
+	lambda.Mod(lambda, curveP)
+	return a.complete(lambda, b.x)
+}
+
+func (a *point) double() *point {
+	if a == nil || a.y.Sign() == 0 {
+		return nil
+	}
+	// lambda = 3x^2 / 2y
+	num := new(big.Int).Mul(a.x, a.x)
+	num.Mul(num, big.NewInt(3))
+	den := new(big.Int).Lsh(a.y, 1)
+	den.Mod(den, curveP).ModInverse(den, curveP)
+	lambda := num.Mul(num, den)
+	lambda.Mod(lambda, curveP)
+	return a.complete(lambda, a.x)
+}
+
+// complete finishes an addition of a and a point with abscissa bx given the slope of the line through them
+func (a *point) complete(lambda, bx *big.Int) *point {
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, a.x).Sub(x, bx).Mod(x, curveP)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, lambda).Sub(y, a.y).Mod(y, curveP)
+	return &point{x: x, y: y}
+}
+
+func (a *point) mul(k *big.Int) *point {
+	var r *point
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = r.double()
+		if k.Bit(i) == 1 {
+			r = r.add(a)
+		}
+	}
+	return r
+}
+
+// decompress returns the point with abscissa x and the given parity of the ordinate
+func decompress(x *big.Int, odd bool) (*point, error) {
+	if x.Sign() <= 0 || x.Cmp(curveP) >= 0 {
+		return nil, errors.New("abscissa out of range")
+	}
+	// y^2 = x^3 + 7
+	alpha := new(big.Int).Mul(x, x)
+	alpha.Mul(alpha, x).Add(alpha, curveB).Mod(alpha, curveP)
+	y := new(big.Int).Exp(alpha, sqrtExp, curveP)
+	if check := new(big.Int).Mul(y, y); check.Mod(check, curveP).Cmp(alpha) != 0 {
+		return nil, errors.New("abscissa is not on the curve")
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(curveP, y)
+	}
+	return &point{x: x, y: y}, nil
+}
+
+// recoverPublicKey returns the key that produced the signature (r, s) of hash, recID selecting the parity of R
+func recoverPublicKey(hash []byte, r, s *big.Int, recID byte) (*point, error) {
+	if r.Sign() <= 0 || r.Cmp(curveN) >= 0 || s.Sign() <= 0 || s.Cmp(curveN) >= 0 {
+		return nil, errors.New("signature values out of range")
+	}
+	R, err := decompress(r, recID&1 == 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Q = r^-1 (sR - eG)
+	e := new(big.Int).SetBytes(hash)
+	rInv := new(big.Int).ModInverse(r, curveN)
+	u1 := new(big.Int).Mul(e, rInv)
+	u1.Neg(u1).Mod(u1, curveN)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, curveN)
+	q := generator.mul(u1).add(R.mul(u2))
+	if q == nil {
+		return nil, errors.New("recovered the point at infinity")
+	}
+	return q, nil
+}
+
+// sign produces a low-s signature of hash with the recovery id of R
+func sign(hash []byte, d *big.Int) (r, s *big.Int, recID byte, err error) {
+	e := new(big.Int).SetBytes(hash)
+	for {
+		k, err := rand.Int(rand.Reader, curveN)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if k.Sign() == 0 {
+			continue
+		}
+		R := generator.mul(k)
+		// R.x >= n would need the overflow bit of the recovery id, simply draw another nonce
+		if R.x.Cmp(curveN) >= 0 {
+			continue
+		}
+		r = new(big.Int).Set(R.x)
+		// s = k^-1 (e + r d)
+		s = new(big.Int).Mul(r, d)
+		s.Add(s, e).Mul(s, new(big.Int).ModInverse(k, curveN)).Mod(s, curveN)
+		if s.Sign() == 0 {
+			continue
+		}
+		recID = byte(R.y.Bit(0))
+		if s.Cmp(halfN) > 0 {
+			s.Sub(curveN, s)
+			recID ^= 1
+		}
+		return r, s, recID, nil
+	}
+}
@@ -0,0 +1,93 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const SignatureLength = 65
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// PersonalMessageHash is the EIP-191 hash wallets sign for personal_sign
+func PersonalMessageHash(message []byte) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return Keccak256([]byte(prefix), message)
+}
+
+// DecodeSignature parses a 0x-prefixed r || s || v signature
+func DecodeSignature(signature string) ([]byte, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(raw) != SignatureLength {
+		return nil, ErrInvalidSignature
+	}
+	return raw, nil
+}
+
+// RecoverPersonal returns the checksummed address of the account that signed the message with personal_sign
+func RecoverPersonal(message []byte, signature []byte) (string, error) {
+	if len(signature) != SignatureLength {
+		return "", ErrInvalidSignature
+	}
+	// Wallets use 27/28 for v, some hardware wallets and libraries 0/1
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return "", ErrInvalidSignature
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	q, err := recoverPublicKey(PersonalMessageHash(message), r, s, v)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return publicKeyAddress(q), nil
+}
+
+// SignPersonal signs the message like personal_sign with a raw 32 bytes private key.
+// The service never holds wallet keys, this exists for tests and tooling.
+func SignPersonal(message []byte, privateKey []byte) ([]byte, error) {
+	d, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	r, s, recID, err := sign(PersonalMessageHash(message), d)
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, SignatureLength)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:64])
+	signature[64] = 27 + recID
+	return signature, nil
+}
+
+// PrivateKeyAddress returns the checksummed address of a raw 32 bytes private key
+func PrivateKeyAddress(privateKey []byte) (string, error) {
+	d, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return publicKeyAddress(generator.mul(d)), nil
+}
+
+func parsePrivateKey(privateKey []byte) (*big.Int, error) {
+	d := new(big.Int).SetBytes(privateKey)
+	if len(privateKey) != 32 || d.Sign() == 0 || d.Cmp(curveN) >= 0 {
+		return nil, errors.New("invalid private key")
+	}
+	return d, nil
+}
+
+// publicKeyAddress is the last 20 bytes of the hash of the uncompressed key without its 0x04 prefix
+func publicKeyAddress(q *point) string {
+	var key [64]byte
+	q.x.FillBytes(key[:32])
+	q.y.FillBytes(key[32:])
+	return checksum(Keccak256(key[:])[32-AddressLength:])
+}
@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -18,9 +19,49 @@ type Claims struct {
 	PhoneVerified      *bool      `json:"phone_verified,omitempty"`
 	LastLoginAt        *time.Time `json:"last_login_at,omitempty"`
 	RefreshTokenBase64 *string    `json:"refresh_token"`
+	// The user's token version at issue, the token is stale once the version moves on
+	TokenVersion *int64 `json:"token_version,omitempty"`
+	// Set on impersonation tokens, the admin acting as the user
+	Act *Actor `json:"act,omitempty"`
+	// Set on service tokens of the client credentials grant, sub holds the same client ID
+	ClientID *string `json:"client_id,omitempty"`
+	// Space separated scopes of a service token
+	Scope *string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsServiceToken reports whether the claims belong to a service rather than a user
+func (c *Claims) IsServiceToken() bool {
+	return c.ClientID != nil
+}
+
+// IsRefreshToken reports whether the claims belong to a refresh token, which only the refresh endpoint accepts
+func (c *Claims) IsRefreshToken() bool {
+	return c.RefreshTokenBase64 != nil
+}
+
+// Scopes returns the scopes of a service token, nil for user tokens
+func (c *Claims) Scopes() []string {
+	if c.Scope == nil {
+		return nil
+	}
+	return strings.Fields(*c.Scope)
+}
+
+// Version returns the token version the token was issued with, tokens issued before versioning are at 0
+func (c *Claims) Version() int64 {
+	if c.TokenVersion == nil {
+		return 0
+	}
+	return *c.TokenVersion
+}
+
+// Actor is the RFC 8693 act claim of an impersonation token
+type Actor struct {
+	UserID   *uuid.UUID `json:"user_id"`
+	Username *string    `json:"username,omitempty"`
+}
+
 type Jwt interface {
 	GetExpirationTime() int64
 	ParseToken(token string) (*jwt.Token, error)
@@ -34,6 +75,7 @@ type Jwt interface {
 		emailVerified *bool,
 		phoneVerified *bool,
 		lastLoginAt *time.Time,
+		tokenVersion *int64,
 	) (*AccessToken, error)
 	GenerateRefreshToken(
 		userID *uuid.UUID,
@@ -44,7 +86,24 @@ type Jwt interface {
 		emailVerified *bool,
 		phoneVerified *bool,
 		lastLoginAt *time.Time,
+		tokenVersion *int64,
 	) (*RefreshToken, error)
+	// GenerateImpersonationToken issues a short-lived access token for the user that names the acting admin
+	GenerateImpersonationToken(
+		userID *uuid.UUID,
+		username *string,
+		email *string,
+		phoneNumber *string,
+		role *string,
+		emailVerified *bool,
+		phoneVerified *bool,
+		lastLoginAt *time.Time,
+		tokenVersion *int64,
+		actor *Actor,
+	) (*AccessToken, error)
+	// GenerateServiceToken issues a short-lived access token for an OAuth2 client with sub=clientID
+	GenerateServiceToken(clientID string, scopes []string) (*AccessToken, error)
 	GenerateAccessTokenWithExpiration(claims *Claims) (string, error)
 	GetClaims(c echo.Context) (*Claims, error)
+	JWKS() JSONWebKeySet
 }
@@ -0,0 +1,21 @@
+package jwt
+
+// JSONWebKeySet is the RFC 7517 document served at /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP and EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	// EC
+	Y string `json:"y,omitempty"`
+}
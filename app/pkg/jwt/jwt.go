@@ -17,13 +17,24 @@ const (
 	TokenTypeBearer = "Bearer"
 )
 
+func init() {
+	// iat with millisecond precision lets a revocation tell apart tokens issued in the same second
+	jwt.TimePrecision = time.Millisecond
+}
+
 type DefaultJwt struct {
-	config config.JwtConfig
+	config  config.JwtConfig
+	keyring *Keyring
+	// Set when the configured keys cannot be loaded; every sign and parse call fails with it
+	keyringErr error
 }
 
 func NewJwt(jwtConfig config.JwtConfig) Jwt {
+	keyring, err := NewKeyring(jwtConfig)
 	return &DefaultJwt{
-		config: jwtConfig,
+		config:     jwtConfig,
+		keyring:    keyring,
+		keyringErr: err,
 	}
 }
 
@@ -32,12 +43,10 @@ func (m *DefaultJwt) GetExpirationTime() int64 {
 }
 
 func (m *DefaultJwt) ParseToken(token string) (*jwt.Token, error) {
-	return jwt.ParseWithClaims(token, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(m.config.SecretKey), nil
-	})
+	if m.keyringErr != nil {
+		return nil, m.keyringErr
+	}
+	return jwt.ParseWithClaims(token, &Claims{}, m.keyring.Keyfunc)
 }
 
 func (m *DefaultJwt) ValidateToken(token string) (*Claims, error) {
@@ -67,6 +76,7 @@ func (m *DefaultJwt) GenerateAccessToken(
 	emailVerified *bool,
 	phoneVerified *bool,
 	lastLoginAt *time.Time,
+	tokenVersion *int64,
 ) (*AccessToken, error) {
 	now := time.Now()
 	claims := &Claims{
@@ -78,8 +88,10 @@ func (m *DefaultJwt) GenerateAccessToken(
 		EmailVerified:      emailVerified,
 		PhoneVerified:      phoneVerified,
 		LastLoginAt:        lastLoginAt,
+		TokenVersion:       tokenVersion,
 		RefreshTokenBase64: nil,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    m.config.Issuer,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(m.config.AccessExpiration)),
@@ -105,6 +117,7 @@ func (m *DefaultJwt) GenerateRefreshToken(
 	emailVerified *bool,
 	phoneVerified *bool,
 	lastLoginAt *time.Time,
+	tokenVersion *int64,
 ) (*RefreshToken, error) {
 	tokenBase64, err := GenerateRandomBase64(32)
 	if err != nil {
@@ -120,8 +133,10 @@ func (m *DefaultJwt) GenerateRefreshToken(
 		EmailVerified:      emailVerified,
 		PhoneVerified:      phoneVerified,
 		LastLoginAt:        lastLoginAt,
+		TokenVersion:       tokenVersion,
 		RefreshTokenBase64: &tokenBase64,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    m.config.Issuer,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(m.config.RefreshExpiration)),
@@ -139,9 +154,96 @@ func (m *DefaultJwt) GenerateRefreshToken(
 	}, nil
 }
 
+func (m *DefaultJwt) GenerateImpersonationToken(
+	userID *uuid.UUID,
+	username *string,
+	email *string,
+	phoneNumber *string,
+	role *string,
+	emailVerified *bool,
+	phoneVerified *bool,
+	lastLoginAt *time.Time,
+	tokenVersion *int64,
+	actor *Actor,
+) (*AccessToken, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID:             userID,
+		Username:           username,
+		Email:              email,
+		PhoneNumber:        phoneNumber,
+		Role:               role,
+		EmailVerified:      emailVerified,
+		PhoneVerified:      phoneVerified,
+		LastLoginAt:        lastLoginAt,
+		TokenVersion:       tokenVersion,
+		RefreshTokenBase64: nil,
+		Act:                actor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    m.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.config.ImpersonationExpiration)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+	token, err := m.GenerateAccessTokenWithExpiration(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessToken{
+		Token:     token,
+		ExpiredAt: claims.RegisteredClaims.ExpiresAt.Time,
+	}, nil
+}
+
+func (m *DefaultJwt) GenerateServiceToken(clientID string, scopes []string) (*AccessToken, error) {
+	now := time.Now()
+	scope := strings.Join(scopes, " ")
+	claims := &Claims{
+		ClientID: &clientID,
+		Scope:    &scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   clientID,
+			Issuer:    m.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.config.ServiceTokenExpiration)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+	token, err := m.GenerateAccessTokenWithExpiration(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessToken{
+		Token:     token,
+		ExpiredAt: claims.RegisteredClaims.ExpiresAt.Time,
+	}, nil
+}
+
+// IsServiceToken tells service tokens apart from user tokens without verifying the signature,
+// callers still have to validate the token
+func IsServiceToken(token string) bool {
+	claims := &Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return false
+	}
+	return claims.IsServiceToken()
+}
+
 func (m *DefaultJwt) GenerateAccessTokenWithExpiration(claims *Claims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.config.SecretKey))
+	if m.keyringErr != nil {
+		return "", m.keyringErr
+	}
+	return m.keyring.Sign(claims)
+}
+
+func (m *DefaultJwt) JWKS() JSONWebKeySet {
+	if m.keyringErr != nil {
+		return JSONWebKeySet{Keys: []JSONWebKey{}}
+	}
+	return m.keyring.JWKS()
 }
 
 func GenerateRandomBase64(length int) (string, error) {
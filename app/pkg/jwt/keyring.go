@@ -0,0 +1,231 @@
+package jwt
+
+import (
+	"backend/service-platform/app/internal/config"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	ErrUnknownKeyID       = errors.New("unknown signing key id")
+	ErrUnsupportedKeyAlgo = errors.New("unsupported signing algorithm")
+)
+
+// Key is one entry of the keyring; Private is nil for verification-only keys
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.PrivateKey
+	Public  crypto.PublicKey
+}
+
+// Keyring holds the key used to sign new tokens and every key still accepted for verification
+type Keyring struct {
+	signing *Key
+	keys    map[string]*Key
+	// Tokens without a kid are verified with the HMAC secret, in asymmetric mode only during a migration
+	legacy *Key
+}
+
+func NewKeyring(cfg config.JwtConfig) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]*Key)}
+	var secret *Key
+	if cfg.SecretKey != "" {
+		secret = &Key{Method: jwt.SigningMethodHS256, Private: []byte(cfg.SecretKey), Public: []byte(cfg.SecretKey)}
+	}
+
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", keyCfg.ID, err)
+		}
+		if _, ok := k.keys[key.ID]; ok {
+			return nil, fmt.Errorf("jwt key %q: duplicated id", key.ID)
+		}
+		k.keys[key.ID] = key
+	}
+
+	switch cfg.Algorithm {
+	case "", AlgorithmHS256:
+		if secret == nil {
+			return nil, errors.New("jwt secret_key is required for HS256")
+		}
+		k.legacy = secret
+		k.signing = &Key{ID: cfg.SigningKeyID, Method: secret.Method, Private: secret.Private, Public: secret.Public}
+		if cfg.SigningKeyID != "" {
+			k.keys[cfg.SigningKeyID] = k.signing
+		}
+	case AlgorithmRS256, AlgorithmEdDSA:
+		key, ok := k.keys[cfg.SigningKeyID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, cfg.SigningKeyID)
+		}
+		if key.Private == nil {
+			return nil, fmt.Errorf("jwt key %q has no private key", key.ID)
+		}
+		if key.Method.Alg() != cfg.Algorithm {
+			return nil, fmt.Errorf("jwt key %q is %s, expected %s", key.ID, key.Method.Alg(), cfg.Algorithm)
+		}
+		k.signing = key
+		if cfg.AcceptLegacyHS256 {
+			if secret == nil {
+				return nil, errors.New("jwt secret_key is required to accept legacy HS256 tokens")
+			}
+			k.legacy = secret
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlgo, cfg.Algorithm)
+	}
+	return k, nil
+}
+
+// Sign signs the claims with the active key and sets its kid header
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signing.Method, claims)
+	if k.signing.ID != "" {
+		token.Header["kid"] = k.signing.ID
+	}
+	return token.SignedString(k.signing.Private)
+}
+
+// Keyfunc resolves the verification key from the kid header and refuses algorithm substitution
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := k.legacy
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		key, ok = k.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, kid)
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: missing kid", ErrUnknownKeyID)
+	}
+	// The legacy secret only ever verifies HS256 tokens
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public, nil
+}
+
+// JWKS returns the public verification keys; HMAC secrets are never published
+func (k *Keyring) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(k.keys))}
+	for _, key := range k.keys {
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Kid: key.ID,
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				Kty: "OKP",
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Kid: key.ID,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+func loadKey(cfg config.JwtKeyConfig) (*Key, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("id is required")
+	}
+	key := &Key{ID: cfg.ID}
+	switch cfg.Algorithm {
+	case AlgorithmRS256:
+		key.Method = jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlgo, cfg.Algorithm)
+	}
+
+	privatePEM, err := readPEM(cfg.PrivateKey, cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	publicPEM, err := readPEM(cfg.PublicKey, cfg.PublicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case privatePEM != nil:
+		key.Private, key.Public, err = parsePrivateKey(key.Method, privatePEM)
+	case publicPEM != nil:
+		key.Public, err = parsePublicKey(key.Method, publicPEM)
+	default:
+		err = errors.New("a private or public key is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func readPEM(inline, path string) ([]byte, error) {
+	if strings.TrimSpace(inline) != "" {
+		return []byte(inline), nil
+	}
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return data, nil
+}
+
+func parsePrivateKey(method jwt.SigningMethod, data []byte) (crypto.PrivateKey, crypto.PublicKey, error) {
+	if method == jwt.SigningMethodRS256 {
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return private, &private.PublicKey, nil
+	}
+
+	parsed, err := jwt.ParseEdPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	private, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("not an Ed25519 private key")
+	}
+	return private, private.Public(), nil
+}
+
+func parsePublicKey(method jwt.SigningMethod, data []byte) (crypto.PublicKey, error) {
+	if method == jwt.SigningMethodRS256 {
+		return jwt.ParseRSAPublicKeyFromPEM(data)
+	}
+	return jwt.ParseEdPublicKeyFromPEM(data)
+}
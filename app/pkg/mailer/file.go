@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"backend/service-platform/app/internal/config"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FileMailer writes every message as an .eml file into a local outbox directory
+type FileMailer struct {
+	config config.MailerConfig
+	logger *zap.Logger
+}
+
+func NewFileMailer(cfg config.MailerConfig, logger *zap.Logger) *FileMailer {
+	return &FileMailer{
+		config: cfg,
+		logger: logger.With(zap.String("component", "file_mailer")),
+	}
+}
+
+func (m *FileMailer) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if message.From == "" {
+		message.From = m.config.From
+	}
+	if len(message.To) == 0 {
+		return fmt.Errorf("message has no recipients")
+	}
+
+	body, err := buildMIME(message)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %w", err)
+	}
+
+	if err := os.MkdirAll(m.config.OutboxDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create outbox: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(m.config.OutboxDir, name)
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	m.logger.Info("Mail written to outbox", zap.String("path", path), zap.Strings("to", message.To))
+	return nil
+}
@@ -0,0 +1,15 @@
+package mailer
+
+import "context"
+
+type Message struct {
+	From     string
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
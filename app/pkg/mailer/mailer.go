@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"backend/service-platform/app/internal/config"
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+)
+
+// NewMailer builds the mailer selected by the configured driver
+func NewMailer(cfg config.MailerConfig, logger *zap.Logger) (Mailer, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg), nil
+	case DriverFile, "":
+		return NewFileMailer(cfg, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported mailer driver: %s", cfg.Driver)
+	}
+}
+
+// buildMIME renders a message as an RFC 5322 document with text and html alternatives
+func buildMIME(message Message) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.TextBody},
+		{"text/html; charset=UTF-8", message.HTMLBody},
+	}
+	for _, p := range parts {
+		if p.content == "" {
+			continue
+		}
+		part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {p.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", message.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(message.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
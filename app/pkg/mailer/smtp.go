@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"backend/service-platform/app/internal/config"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+type SMTPMailer struct {
+	config config.MailerConfig
+}
+
+func NewSMTPMailer(cfg config.MailerConfig) *SMTPMailer {
+	return &SMTPMailer{config: cfg}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if message.From == "" {
+		message.From = m.config.From
+	}
+	if len(message.To) == 0 {
+		return fmt.Errorf("message has no recipients")
+	}
+
+	body, err := buildMIME(message)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.config.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", m.config.SMTP.Username, m.config.SMTP.Password, m.config.SMTP.Host)
+	}
+	addr := net.JoinHostPort(m.config.SMTP.Host, strconv.Itoa(m.config.SMTP.Port))
+	if err := smtp.SendMail(addr, auth, message.From, message.To, body); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// Template pairs the subject and bodies of one kind of email
+type Template struct {
+	Subject string
+	Text    *texttemplate.Template
+	HTML    *htmltemplate.Template
+}
+
+var VerifyEmailTemplate = Template{
+	Subject: "Verify your email address",
+	Text: texttemplate.Must(texttemplate.New("verify_email_text").Parse(
+		`Hello {{.Username}},
+
+Please confirm your email address by opening the link below:
+
+{{.Link}}
+
+This link expires at {{.ExpiresAt}}. If you did not create an account, you can ignore this email.
+`)),
+	HTML: htmltemplate.Must(htmltemplate.New("verify_email_html").Parse(
+		`<p>Hello {{.Username}},</p>
+<p>Please confirm your email address by opening the link below:</p>
+<p><a href="{{.Link}}">Verify email</a></p>
+<p>This link expires at {{.ExpiresAt}}. If you did not create an account, you can ignore this email.</p>
+`)),
+}
+
+var PasswordResetTemplate = Template{
+	Subject: "Reset your password",
+	Text: texttemplate.Must(texttemplate.New("password_reset_text").Parse(
+		`Hello {{.Username}},
+
+We received a request to reset your password. Open the link below to choose a new one:
+
+{{.Link}}
+
+This link expires at {{.ExpiresAt}} and can only be used once. If you did not request a reset, you can ignore this email.
+`)),
+	HTML: htmltemplate.Must(htmltemplate.New("password_reset_html").Parse(
+		`<p>Hello {{.Username}},</p>
+<p>We received a request to reset your password. Open the link below to choose a new one:</p>
+<p><a href="{{.Link}}">Reset password</a></p>
+<p>This link expires at {{.ExpiresAt}} and can only be used once. If you did not request a reset, you can ignore this email.</p>
+`)),
+}
+
+var DataExportReadyTemplate = Template{
+	Subject: "Your data export is ready",
+	Text: texttemplate.Must(texttemplate.New("data_export_ready_text").Parse(
+		`Hello {{.Username}},
+
+The copy of your personal data you requested is ready. Sign in and open the link below to download it:
+
+{{.Link}}
+
+If you did not request an export, please change your password.
+`)),
+	HTML: htmltemplate.Must(htmltemplate.New("data_export_ready_html").Parse(
+		`<p>Hello {{.Username}},</p>
+<p>The copy of your personal data you requested is ready. Sign in and open the link below to download it:</p>
+<p><a href="{{.Link}}">Download my data</a></p>
+<p>If you did not request an export, please change your password.</p>
+`)),
+}
+
+// Render executes the template with data and returns a message addressed to recipients
+func (t Template) Render(data any, to ...string) (Message, error) {
+	var text, html bytes.Buffer
+	if t.Text != nil {
+		if err := t.Text.Execute(&text, data); err != nil {
+			return Message{}, err
+		}
+	}
+	if t.HTML != nil {
+		if err := t.HTML.Execute(&html, data); err != nil {
+			return Message{}, err
+		}
+	}
+	return Message{
+		To:       to,
+		Subject:  t.Subject,
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
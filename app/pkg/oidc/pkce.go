@@ -0,0 +1,19 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+)
+
+// NewCodeVerifier returns a RFC 7636 code verifier, 43 characters from 32 random bytes
+func NewCodeVerifier() (string, error) {
+	return securetoken.Generate(securetoken.DefaultSize)
+}
+
+// CodeChallengeS256 derives the S256 code challenge sent with the authorization request
+func CodeChallengeS256(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
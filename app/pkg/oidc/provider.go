@@ -0,0 +1,316 @@
+// Package oidc implements the relying party side of the OpenID Connect authorization code flow with PKCE.
+package oidc
+
+import (
+	"backend/service-platform/app/internal/config"
+	pkgjwt "backend/service-platform/app/pkg/jwt"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	defaultJwksCacheTTL = time.Hour
+	// An unknown kid triggers a refresh at most this often, providers rotate keys ahead of use
+	jwksRefreshInterval = time.Minute
+	clockSkew           = time.Minute
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown oidc provider")
+	ErrExchangeFailed  = errors.New("oidc code exchange failed")
+	ErrInvalidIDToken  = errors.New("invalid id token")
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+type DefaultProvider struct {
+	name     string
+	config   config.OidcProviderConfig
+	client   *resty.Client
+	jwksTTL  time.Duration
+	mu       sync.Mutex
+	metadata *Metadata
+	keys     map[string]crypto.PublicKey
+	// When the keys were last fetched
+	keysAt time.Time
+}
+
+func NewProvider(name string, cfg config.OidcProviderConfig, client *resty.Client, jwksTTL time.Duration) *DefaultProvider {
+	if jwksTTL <= 0 {
+		jwksTTL = defaultJwksCacheTTL
+	}
+	return &DefaultProvider{
+		name:    name,
+		config:  cfg,
+		client:  client,
+		jwksTTL: jwksTTL,
+	}
+}
+
+func (p *DefaultProvider) Name() string {
+	return p.name
+}
+
+func (p *DefaultProvider) TrustEmail() bool {
+	return p.config.TrustEmail
+}
+
+func (p *DefaultProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	endpoint, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+	query := endpoint.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String(), nil
+}
+
+func (p *DefaultProvider) Exchange(ctx context.Context, code, codeVerifier string) (*TokenResponse, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"redirect_uri":  p.config.RedirectURL,
+		"client_id":     p.config.ClientID,
+		"code_verifier": codeVerifier,
+	}
+	if p.config.ClientSecret != "" {
+		form["client_secret"] = p.config.ClientSecret
+	}
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json").
+		SetFormData(form).
+		Post(metadata.TokenEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		var body struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(resp.Body(), &body)
+		return nil, fmt.Errorf("%w: status %d %s %s", ErrExchangeFailed, resp.StatusCode(), body.Error, body.Description)
+	}
+
+	var token TokenResponse
+	if err := json.Unmarshal(resp.Body(), &token); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in response", ErrExchangeFailed)
+	}
+	return &token, nil
+}
+
+// VerifyIDToken checks the signature against the provider keys, then the issuer, audience, expiry and nonce
+func (p *DefaultProvider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := new(Claims)
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return p.verificationKey(ctx, token)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	// With several audiences the token must have been issued to us
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected authorized party", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// discover returns the configured endpoints, or fetches and caches the discovery document of the issuer
+func (p *DefaultProvider) discover(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	if p.config.AuthorizationEndpoint != "" && p.config.TokenEndpoint != "" && p.config.JwksURI != "" {
+		p.metadata = &Metadata{
+			Issuer:                p.config.Issuer,
+			AuthorizationEndpoint: p.config.AuthorizationEndpoint,
+			TokenEndpoint:         p.config.TokenEndpoint,
+			JwksURI:               p.config.JwksURI,
+		}
+		return p.metadata, nil
+	}
+
+	resp, err := p.client.R().SetContext(ctx).Get(strings.TrimSuffix(p.config.Issuer, "/") + discoveryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("discovery document returned status %d", resp.StatusCode())
+	}
+	var metadata Metadata
+	if err := json.Unmarshal(resp.Body(), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+	// A document served for another issuer must not be trusted
+	if metadata.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("discovery issuer %q does not match %q", metadata.Issuer, p.config.Issuer)
+	}
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+func (p *DefaultProvider) verificationKey(ctx context.Context, token *jwt.Token) (crypto.PublicKey, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key, ok := p.keys[kid]
+	stale := time.Since(p.keysAt) > p.jwksTTL
+	if stale || (!ok && time.Since(p.keysAt) > jwksRefreshInterval) {
+		if err := p.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		key, ok = p.keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, isRSA := token.Method.(*jwt.SigningMethodRSA); isRSA {
+			return key, nil
+		}
+	case *ecdsa.PublicKey:
+		if _, isEC := token.Method.(*jwt.SigningMethodECDSA); isEC {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("signing method %s does not match key %q", token.Method.Alg(), kid)
+}
+
+// fetchKeys replaces the cached keys; the caller holds the lock
+func (p *DefaultProvider) fetchKeys(ctx context.Context) error {
+	if p.metadata == nil {
+		return errors.New("provider metadata is not loaded")
+	}
+	resp, err := p.client.R().SetContext(ctx).Get(p.metadata.JwksURI)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("jwks returned status %d", resp.StatusCode())
+	}
+	var set pkgjwt.JSONWebKeySet
+	if err := json.Unmarshal(resp.Body(), &set); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			// Keys of unsupported types are skipped rather than failing the whole set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	p.keysAt = time.Now()
+	return nil
+}
+
+func parseJWK(jwk pkgjwt.JSONWebKey) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"backend/service-platform/app/internal/config"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Registry holds the enabled providers by name
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry builds a provider for every configured entry with a client id
+func NewRegistry(cfg config.OidcConfig, client *resty.Client) *Registry {
+	if client == nil {
+		client = resty.New()
+	}
+	providers := make(map[string]Provider, len(cfg.Providers))
+	for name, providerConfig := range cfg.Providers {
+		if providerConfig.ClientID == "" || providerConfig.Issuer == "" {
+			continue
+		}
+		name = strings.ToLower(name)
+		providers[name] = NewProvider(name, providerConfig, client, cfg.JwksCacheTTL)
+	}
+	return &Registry{providers: providers}
+}
+
+// Register adds or replaces a provider, mostly useful to plug a test double
+func (r *Registry) Register(provider Provider) {
+	r.providers[strings.ToLower(provider.Name())] = provider
+}
+
+func (r *Registry) Provider(name string) (Provider, error) {
+	provider, ok := r.providers[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return provider, nil
+}
+
+// Names lists the enabled providers in a stable order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,67 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Length of the SHA-1 hex prefix naming a range file
+const rangePrefixLength = 5
+
+// BreachChecker tells whether a password is known from a data breach
+type BreachChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// DatasetBreachChecker looks passwords up in an offline copy of the Pwned Passwords ranges. Like the k-anonymity
+// API only the range file of the hash prefix is read, the password itself never leaves memory
+type DatasetBreachChecker struct {
+	dir string
+}
+
+func NewDatasetBreachChecker(dir string) (*DatasetBreachChecker, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("breached password dataset: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("breached password dataset: %s is not a directory", dir)
+	}
+	return &DatasetBreachChecker{dir: dir}, nil
+}
+
+func (c *DatasetBreachChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:rangePrefixLength], hash[rangePrefixLength:]
+
+	f, err := os.Open(filepath.Join(c.dir, prefix+".txt"))
+	if err != nil {
+		// A partial dataset has no file for ranges without known hashes
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to open breached password range: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// SUFFIX:COUNT, padding entries of the API have a zero count
+		entry, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(entry, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read breached password range: %w", err)
+	}
+	return false, nil
+}
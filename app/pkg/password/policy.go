@@ -0,0 +1,143 @@
+package password
+
+import (
+	"backend/service-platform/app/internal/config"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rules reported in violations, stable identifiers clients can translate
+const (
+	RuleMinLength     = "min_length"
+	RuleMaxLength     = "max_length"
+	RuleUppercase     = "uppercase"
+	RuleLowercase     = "lowercase"
+	RuleDigit         = "digit"
+	RuleSymbol        = "symbol"
+	RuleRepeatedChars = "repeated_chars"
+	RulePersonalInfo  = "personal_info"
+	RuleBreached      = "breached"
+)
+
+// Personal values shorter than this are too common to be refused in a password
+const minPersonalInfoLength = 3
+
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// Policy decides whether a new password is acceptable
+type Policy interface {
+	// Check returns every rule the password breaks, personal values such as the email must not appear in it
+	Check(password string, personal ...string) ([]Violation, error)
+}
+
+type RulePolicy struct {
+	cfg    config.PasswordPolicyConfig
+	breach BreachChecker
+}
+
+// NewRulePolicy fails when the breached password dataset is configured but cannot be read
+func NewRulePolicy(cfg config.PasswordPolicyConfig) (*RulePolicy, error) {
+	p := &RulePolicy{cfg: cfg}
+	if cfg.BreachedDatasetDir != "" {
+		breach, err := NewDatasetBreachChecker(cfg.BreachedDatasetDir)
+		if err != nil {
+			return nil, err
+		}
+		p.breach = breach
+	}
+	return p, nil
+}
+
+func (p *RulePolicy) Check(password string, personal ...string) ([]Violation, error) {
+	var violations []Violation
+	length := utf8.RuneCountInString(password)
+	if p.cfg.MinLength > 0 && length < p.cfg.MinLength {
+		violations = append(violations, Violation{RuleMinLength, fmt.Sprintf("must be at least %d characters", p.cfg.MinLength)})
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		violations = append(violations, Violation{RuleMaxLength, fmt.Sprintf("must be at most %d characters", p.cfg.MaxLength)})
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.cfg.RequireUppercase && !upper {
+		violations = append(violations, Violation{RuleUppercase, "must contain an uppercase letter"})
+	}
+	if p.cfg.RequireLowercase && !lower {
+		violations = append(violations, Violation{RuleLowercase, "must contain a lowercase letter"})
+	}
+	if p.cfg.RequireDigit && !digit {
+		violations = append(violations, Violation{RuleDigit, "must contain a digit"})
+	}
+	if p.cfg.RequireSymbol && !symbol {
+		violations = append(violations, Violation{RuleSymbol, "must contain a symbol"})
+	}
+
+	if p.cfg.MaxRepeatedChars > 0 && longestRun(password) > p.cfg.MaxRepeatedChars {
+		violations = append(violations, Violation{RuleRepeatedChars, fmt.Sprintf("must not repeat a character more than %d times in a row", p.cfg.MaxRepeatedChars)})
+	}
+
+	if p.cfg.ForbidPersonalInfo && containsPersonalInfo(password, personal) {
+		violations = append(violations, Violation{RulePersonalInfo, "must not contain your email or username"})
+	}
+
+	if p.breach != nil {
+		breached, err := p.breach.IsBreached(password)
+		if err != nil {
+			return nil, err
+		}
+		if breached {
+			violations = append(violations, Violation{RuleBreached, "appears in a known data breach"})
+		}
+	}
+	return violations, nil
+}
+
+func longestRun(s string) int {
+	longest, run := 0, 0
+	var previous rune
+	for i, r := range []rune(s) {
+		if i > 0 && r == previous {
+			run++
+		} else {
+			run = 1
+		}
+		previous = r
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// containsPersonalInfo compares case-insensitively, an email is also matched by its local part
+func containsPersonalInfo(password string, personal []string) bool {
+	lowered := strings.ToLower(password)
+	for _, value := range personal {
+		value = strings.ToLower(strings.TrimSpace(value))
+		candidates := []string{value}
+		if local, _, ok := strings.Cut(value, "@"); ok {
+			candidates = append(candidates, local)
+		}
+		for _, c := range candidates {
+			if utf8.RuneCountInString(c) >= minPersonalInfoLength && strings.Contains(lowered, c) {
+				return true
+			}
+		}
+	}
+	return false
+}
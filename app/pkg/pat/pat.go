@@ -0,0 +1,69 @@
+// Package pat generates and parses personal access tokens of the form pat_<prefix>_<secret>.
+// The prefix is stored in clear for lookup, the full token is only kept as a hash.
+package pat
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+)
+
+const (
+	// TokenPrefix tells personal access tokens apart from JWTs in the Authorization header
+	TokenPrefix = "pat_"
+	prefixBytes = 6
+	secretBytes = 32
+)
+
+// Token is a freshly generated personal access token; Plaintext must only be shown once
+type Token struct {
+	Plaintext string
+	Prefix    string
+	Hash      string
+}
+
+// Generate returns a new random token
+func Generate() (Token, error) {
+	lookup := make([]byte, prefixBytes)
+	if _, err := rand.Read(lookup); err != nil {
+		return Token{}, err
+	}
+	secret, err := securetoken.Generate(secretBytes)
+	if err != nil {
+		return Token{}, err
+	}
+	prefix := hex.EncodeToString(lookup)
+	plaintext := TokenPrefix + prefix + "_" + secret
+	return Token{Plaintext: plaintext, Prefix: prefix, Hash: Hash(plaintext)}, nil
+}
+
+// IsToken reports whether the value looks like a personal access token, without validating it
+func IsToken(value string) bool {
+	return strings.HasPrefix(value, TokenPrefix)
+}
+
+// ParsePrefix extracts the lookup prefix, ok is false when the token is not in the expected format
+func ParsePrefix(token string) (prefix string, ok bool) {
+	rest, found := strings.CutPrefix(token, TokenPrefix)
+	if !found {
+		return "", false
+	}
+	prefix, secret, found := strings.Cut(rest, "_")
+	if !found || len(prefix) != 2*prefixBytes || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// Hash returns the value persisted for a token
+func Hash(token string) string {
+	return securetoken.Hash(token)
+}
+
+// Verify compares a presented token with a stored hash in constant time
+func Verify(token string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(token)), []byte(hash)) == 1
+}
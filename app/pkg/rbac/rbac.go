@@ -0,0 +1,160 @@
+package rbac
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/redis"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Permissions checked by the API, the catalogue lives in the permissions table
+const (
+	JobsRead             = "jobs:read"
+	JobsRetry            = "jobs:retry"
+	UsersRead            = "users:read"
+	UsersSuspend         = "users:suspend"
+	UsersUnlock          = "users:unlock"
+	UsersAssignRole      = "users:assign_role"
+	SessionsRevoke       = "sessions:revoke"
+	ApiKeysManage        = "api_keys:manage"
+	RolesManage          = "roles:manage"
+	AuditRead            = "audit:read"
+	UsersImpersonate     = "users:impersonate"
+	ServiceClientsManage = "service_clients:manage"
+)
+
+// RoleStore loads every role with its direct permissions
+type RoleStore interface {
+	FindAll(ctx context.Context) ([]entity.Role, error)
+}
+
+// Authorizer answers permission checks for roles
+type Authorizer interface {
+	// Permissions returns the direct and inherited permissions of a role, an unknown role has none
+	Permissions(ctx context.Context, role string) ([]string, error)
+	// HasPermissions reports whether the role holds every given permission
+	HasPermissions(ctx context.Context, role string, permissions ...string) (bool, error)
+	// Invalidate drops the cached mapping after a role changes
+	Invalidate(ctx context.Context) error
+}
+
+type RedisAuthorizer struct {
+	redis redis.Redis
+	roles RoleStore
+	ttl   time.Duration
+}
+
+func NewRedisAuthorizer(rds redis.Redis, roles RoleStore, ttl time.Duration) *RedisAuthorizer {
+	return &RedisAuthorizer{
+		redis: rds,
+		roles: roles,
+		ttl:   ttl,
+	}
+}
+
+func (a *RedisAuthorizer) Permissions(ctx context.Context, role string) ([]string, error) {
+	mapping, err := a.mapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapping[role], nil
+}
+
+func (a *RedisAuthorizer) HasPermissions(ctx context.Context, role string, permissions ...string) (bool, error) {
+	granted, err := a.Permissions(ctx, role)
+	if err != nil {
+		return false, err
+	}
+	return HasAll(granted, permissions...), nil
+}
+
+func (a *RedisAuthorizer) Invalidate(ctx context.Context) error {
+	if err := a.redis.Delete(ctx, rediskey.RolePermissionsKey()); err != nil {
+		return fmt.Errorf("failed to invalidate role permissions: %w", err)
+	}
+	return nil
+}
+
+// mapping returns the effective permissions of every role, from the cache when possible
+func (a *RedisAuthorizer) mapping(ctx context.Context) (map[string][]string, error) {
+	var cached map[string][]string
+	err := a.redis.Get(ctx, rediskey.RolePermissionsKey(), &cached)
+	if err == nil {
+		return cached, nil
+	}
+	if !errors.Is(err, goredis.Nil) {
+		return nil, fmt.Errorf("failed to read role permissions: %w", err)
+	}
+
+	roles, err := a.roles.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roles: %w", err)
+	}
+	mapping := Resolve(roles)
+	if err := a.redis.Set(ctx, rediskey.RolePermissionsKey(), mapping, a.ttl); err != nil {
+		return nil, fmt.Errorf("failed to cache role permissions: %w", err)
+	}
+	return mapping, nil
+}
+
+// Resolve expands inheritance, each role holds its own permissions and those of its ancestors.
+// A parent that does not exist ends the chain and a cycle is only walked once.
+func Resolve(roles []entity.Role) map[string][]string {
+	byName := make(map[string]entity.Role, len(roles))
+	for _, r := range roles {
+		byName[r.Name] = r
+	}
+
+	res := make(map[string][]string, len(roles))
+	for _, r := range roles {
+		permissions := make([]string, 0)
+		visited := map[string]bool{}
+		for current, ok := r, true; ok && !visited[current.Name]; {
+			visited[current.Name] = true
+			for _, p := range current.Permissions {
+				if !slices.Contains(permissions, p) {
+					permissions = append(permissions, p)
+				}
+			}
+			if current.Parent == nil {
+				break
+			}
+			current, ok = byName[*current.Parent]
+		}
+		slices.Sort(permissions)
+		res[r.Name] = permissions
+	}
+	return res
+}
+
+// Ancestors returns the chain of parents of a role, nearest first
+func Ancestors(roles []entity.Role, name string) []string {
+	byName := make(map[string]entity.Role, len(roles))
+	for _, r := range roles {
+		byName[r.Name] = r
+	}
+
+	var res []string
+	visited := map[string]bool{name: true}
+	for current, ok := byName[name]; ok && current.Parent != nil && !visited[*current.Parent]; current, ok = byName[*current.Parent] {
+		visited[*current.Parent] = true
+		res = append(res, *current.Parent)
+	}
+	return res
+}
+
+// HasAll reports whether granted contains every required permission
+func HasAll(granted []string, required ...string) bool {
+	for _, p := range required {
+		if !slices.Contains(granted, p) {
+			return false
+		}
+	}
+	return true
+}
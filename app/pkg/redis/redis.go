@@ -31,7 +31,13 @@ type Redis interface {
 
 	// Default method to use to get a value into redis. It supports any type of value including structs, maps, slices, etc.
 	Get(ctx context.Context, key string, outPtr any) error
+
+	// Gets a value stored with Set and deletes it atomically, so only one caller can ever receive it
+	GetDel(ctx context.Context, key string, outPtr any) error
 	Delete(ctx context.Context, key string) error
+
+	// Deletes the key and reports whether it existed, of concurrent callers only one sees true
+	DeleteExisting(ctx context.Context, key string) (bool, error)
 	Increment(ctx context.Context, key string, val int64) error
 	Decrement(ctx context.Context, key string, val int64) error
 	SetExpire(ctx context.Context, key string, ttl_sec time.Duration) error
@@ -131,6 +137,14 @@ func (r *Client) Get(c context.Context, key string, outPtr any) error {
 	return json.Unmarshal(b, outPtr)
 }
 
+func (r *Client) GetDel(c context.Context, key string, outPtr any) error {
+	b, err := r.client.GetDel(c, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, outPtr)
+}
+
 func (r *Client) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
 	result := r.client.Scan(ctx, cursor, match, count)
 	if err := result.Err(); err != nil {
@@ -199,6 +213,14 @@ func (r *Client) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+func (r *Client) DeleteExisting(ctx context.Context, key string) (bool, error) {
+	deleted, err := r.client.Del(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
 func (r *Client) Exists(ctx context.Context, key string) (bool, error) {
 	result, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
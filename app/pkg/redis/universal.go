@@ -154,6 +154,14 @@ func (r *UniversalClient) Get(c context.Context, key string, outPtr any) error {
 	return json.Unmarshal(b, outPtr)
 }
 
+func (r *UniversalClient) GetDel(c context.Context, key string, outPtr any) error {
+	b, err := r.client.GetDel(c, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, outPtr)
+}
+
 func (r *UniversalClient) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
 	result := r.client.Scan(ctx, cursor, match, count)
 	if err := result.Err(); err != nil {
@@ -203,6 +211,14 @@ func (r *UniversalClient) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+func (r *UniversalClient) DeleteExisting(ctx context.Context, key string) (bool, error) {
+	deleted, err := r.client.Del(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
 func (r *UniversalClient) Exists(ctx context.Context, key string) (bool, error) {
 	result, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
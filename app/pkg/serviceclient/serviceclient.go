@@ -0,0 +1,69 @@
+// Package serviceclient generates the credentials of OAuth2 clients using the client credentials grant.
+// The client ID is public, the secret is only kept as a hash.
+package serviceclient
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+)
+
+const (
+	clientIDPrefix = "svc_"
+	clientIDBytes  = 12
+	secretBytes    = 32
+)
+
+// Credentials of a freshly registered client; Secret must only be shown once
+type Credentials struct {
+	ClientID   string
+	Secret     string
+	SecretHash string
+}
+
+// Generate returns a new random client ID and secret
+func Generate() (Credentials, error) {
+	id := make([]byte, clientIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return Credentials{}, err
+	}
+	secret, err := securetoken.Generate(secretBytes)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{
+		ClientID:   clientIDPrefix + hex.EncodeToString(id),
+		Secret:     secret,
+		SecretHash: Hash(secret),
+	}, nil
+}
+
+// Hash returns the value persisted for a secret
+func Hash(secret string) string {
+	return securetoken.Hash(secret)
+}
+
+// Verify compares a presented secret with a stored hash in constant time
+func Verify(secret string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(secret)), []byte(hash)) == 1
+}
+
+// ParseScope splits an RFC 6749 scope parameter into its distinct scopes
+func ParseScope(scope string) []string {
+	res := make([]string, 0)
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(res, s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// FormatScope joins scopes into an RFC 6749 scope parameter
+func FormatScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
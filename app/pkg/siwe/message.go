@@ -0,0 +1,212 @@
+// Package siwe parses and verifies Sign-In With Ethereum (EIP-4361) messages.
+package siwe
+
+import (
+	"backend/service-platform/app/pkg/ethereum"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Version = "1"
+
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+	minNonceSize = 8
+)
+
+var ErrMalformedMessage = errors.New("malformed siwe message")
+
+var nonceRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// String renders the message in the EIP-4361 format, the exact text the wallet signs
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n\n")
+	}
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + strconv.FormatInt(m.ChainID, 10) + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.Format(time.RFC3339))
+	if m.ExpirationTime != nil {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime.Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\nNot Before: " + m.NotBefore.Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+	return b.String()
+}
+
+// ParseMessage reads a message following the EIP-4361 grammar, fields in their mandated order
+func ParseMessage(raw string) (*Message, error) {
+	p := &parser{lines: strings.Split(raw, "\n")}
+	m := new(Message)
+
+	header := p.next()
+	if !strings.HasSuffix(header, headerSuffix) {
+		return nil, malformed("missing header")
+	}
+	m.Domain = strings.TrimSuffix(header, headerSuffix)
+	if m.Domain == "" {
+		return nil, malformed("missing domain")
+	}
+
+	m.Address = p.next()
+	if !ethereum.IsChecksumAddress(m.Address) {
+		return nil, malformed("address is not in EIP-55 format")
+	}
+	if p.next() != "" {
+		return nil, malformed("expected an empty line after the address")
+	}
+	// The statement is optional and takes a single line followed by an empty one
+	if !p.peekTag("URI") {
+		m.Statement = p.next()
+		if p.next() != "" {
+			return nil, malformed("expected an empty line after the statement")
+		}
+	}
+
+	var err error
+	if m.URI, err = p.tag("URI", true); err != nil {
+		return nil, err
+	}
+	if _, err := url.Parse(m.URI); err != nil {
+		return nil, malformed("invalid URI")
+	}
+	if m.Version, err = p.tag("Version", true); err != nil {
+		return nil, err
+	}
+	if m.Version != Version {
+		return nil, malformed("unsupported version")
+	}
+	chainID, err := p.tag("Chain ID", true)
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil || m.ChainID <= 0 {
+		return nil, malformed("invalid chain ID")
+	}
+	if m.Nonce, err = p.tag("Nonce", true); err != nil {
+		return nil, err
+	}
+	if len(m.Nonce) < minNonceSize || !nonceRegex.MatchString(m.Nonce) {
+		return nil, malformed("nonce must be at least 8 alphanumeric characters")
+	}
+	issuedAt, err := p.timeTag("Issued At", true)
+	if err != nil {
+		return nil, err
+	}
+	m.IssuedAt = *issuedAt
+	if m.ExpirationTime, err = p.timeTag("Expiration Time", false); err != nil {
+		return nil, err
+	}
+	if m.NotBefore, err = p.timeTag("Not Before", false); err != nil {
+		return nil, err
+	}
+	if m.RequestID, err = p.tag("Request ID", false); err != nil {
+		return nil, err
+	}
+	if p.peek() == "Resources:" {
+		p.next()
+		for p.more() {
+			resource, ok := strings.CutPrefix(p.next(), "- ")
+			if !ok || resource == "" {
+				return nil, malformed("invalid resource")
+			}
+			m.Resources = append(m.Resources, resource)
+		}
+	}
+	if p.more() {
+		return nil, malformed("unexpected content")
+	}
+	return m, nil
+}
+
+type parser struct {
+	lines []string
+	pos   int
+}
+
+func (p *parser) more() bool {
+	return p.pos < len(p.lines)
+}
+
+func (p *parser) peek() string {
+	if !p.more() {
+		return ""
+	}
+	return p.lines[p.pos]
+}
+
+func (p *parser) next() string {
+	line := p.peek()
+	p.pos++
+	return line
+}
+
+func (p *parser) peekTag(name string) bool {
+	return strings.HasPrefix(p.peek(), name+": ")
+}
+
+// tag reads the "Name: value" line, an optional tag that is absent yields an empty value
+func (p *parser) tag(name string, required bool) (string, error) {
+	if !p.peekTag(name) {
+		if required {
+			return "", malformed(fmt.Sprintf("missing %s", name))
+		}
+		return "", nil
+	}
+	value := strings.TrimPrefix(p.next(), name+": ")
+	if value == "" {
+		return "", malformed(fmt.Sprintf("empty %s", name))
+	}
+	return value, nil
+}
+
+func (p *parser) timeTag(name string, required bool) (*time.Time, error) {
+	value, err := p.tag(name, required)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, malformed(fmt.Sprintf("invalid %s", name))
+	}
+	return &t, nil
+}
+
+func malformed(reason string) error {
+	return fmt.Errorf("%w: %s", ErrMalformedMessage, reason)
+}
@@ -0,0 +1,105 @@
+package siwe
+
+import (
+	"backend/service-platform/app/pkg/ethereum"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMessage   = errors.New("siwe message rejected")
+	ErrInvalidSignature = errors.New("siwe signature does not match the address")
+)
+
+type VerifyOptions struct {
+	// Origins allowed to request signatures, the message domain must be the host of one of them
+	AllowedOrigins []string
+	// Origin header of the request, checked against the message domain when present
+	Origin string
+	// Chain the message must be signed for, zero accepts any chain
+	ChainID int64
+	// Statement the message must carry, empty skips the check
+	Statement string
+	Now       time.Time
+	// Tolerated clock difference with the wallet on the issued at, expiration and not before times
+	ClockSkew time.Duration
+}
+
+// Verify parses the message, validates its fields and checks it was signed by the address it contains
+func Verify(raw, signature string, opts VerifyOptions) (*Message, error) {
+	m, err := ParseMessage(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Validate(opts); err != nil {
+		return nil, err
+	}
+	sig, err := ethereum.DecodeSignature(signature)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	address, err := ethereum.RecoverPersonal([]byte(raw), sig)
+	if err != nil || address != m.Address {
+		return nil, ErrInvalidSignature
+	}
+	return m, nil
+}
+
+// Validate checks the domain, URI, origin, chain, statement and validity window; the nonce is left to the caller
+func (m *Message) Validate(opts VerifyOptions) error {
+	origin, ok := matchOrigin(m.Domain, opts.AllowedOrigins)
+	if !ok {
+		return rejected("domain %q is not allowed", m.Domain)
+	}
+	uri, err := url.Parse(m.URI)
+	if err != nil || uri.Scheme+"://"+uri.Host != origin {
+		return rejected("URI %q is outside of %s", m.URI, origin)
+	}
+	// Browsers send the origin of the page, it must be the one the wallet displayed
+	if opts.Origin != "" && strings.TrimSuffix(opts.Origin, "/") != origin {
+		return rejected("request origin %q does not match the domain", opts.Origin)
+	}
+	if opts.ChainID != 0 && m.ChainID != opts.ChainID {
+		return rejected("chain ID %d is not supported", m.ChainID)
+	}
+	if opts.Statement != "" && m.Statement != opts.Statement {
+		return rejected("unexpected statement")
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if m.IssuedAt.After(now.Add(opts.ClockSkew)) {
+		return rejected("issued in the future")
+	}
+	if m.ExpirationTime != nil && now.After(m.ExpirationTime.Add(opts.ClockSkew)) {
+		return rejected("expired")
+	}
+	if m.NotBefore != nil && now.Add(opts.ClockSkew).Before(*m.NotBefore) {
+		return rejected("not yet valid")
+	}
+	return nil
+}
+
+// matchOrigin returns the allowed origin whose host is the domain; a domain with a scheme must match it as well
+func matchOrigin(domain string, allowedOrigins []string) (string, bool) {
+	for _, allowed := range allowedOrigins {
+		u, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(allowed), "/"))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			continue
+		}
+		origin := u.Scheme + "://" + u.Host
+		if domain == u.Host || domain == origin {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+func rejected(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMessage, fmt.Sprintf(format, args...))
+}
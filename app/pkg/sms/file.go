@@ -0,0 +1,48 @@
+package sms
+
+import (
+	"backend/service-platform/app/internal/config"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FileSender writes every message as a .txt file into a local outbox directory
+type FileSender struct {
+	config config.SmsConfig
+	logger *zap.Logger
+}
+
+func NewFileSender(cfg config.SmsConfig, logger *zap.Logger) *FileSender {
+	return &FileSender{
+		config: cfg,
+		logger: logger.With(zap.String("component", "file_sms_sender")),
+	}
+}
+
+func (s *FileSender) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if message.To == "" {
+		return fmt.Errorf("message has no recipient")
+	}
+
+	if err := os.MkdirAll(s.config.OutboxDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create outbox: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.txt", time.Now().UTC().Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(s.config.OutboxDir, name)
+	content := fmt.Sprintf("To: %s\n\n%s\n", message.To, message.Body)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	s.logger.Info("SMS written to outbox", zap.String("path", path), zap.String("to", message.To))
+	return nil
+}
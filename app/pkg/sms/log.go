@@ -0,0 +1,28 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// LogSender writes every message to the application log, the body included, so it is meant for local use only
+type LogSender struct {
+	logger *zap.Logger
+}
+
+func NewLogSender(logger *zap.Logger) *LogSender {
+	return &LogSender{logger: logger.With(zap.String("component", "log_sms_sender"))}
+}
+
+func (s *LogSender) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if message.To == "" {
+		return fmt.Errorf("message has no recipient")
+	}
+	s.logger.Info("SMS sent", zap.String("to", message.To), zap.String("body", message.Body))
+	return nil
+}
@@ -0,0 +1,26 @@
+package sms
+
+import (
+	"backend/service-platform/app/internal/config"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+// NewSender builds the SMS sender selected by the configured driver
+func NewSender(cfg config.SmsConfig, logger *zap.Logger) (Sender, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case DriverLog:
+		return NewLogSender(logger), nil
+	case DriverFile, "":
+		return NewFileSender(cfg, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported sms driver: %s", cfg.Driver)
+	}
+}
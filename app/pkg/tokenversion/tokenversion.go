@@ -0,0 +1,93 @@
+package tokenversion
+
+import (
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/redis"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var ErrStaleToken = errors.New("token was issued before the user's last role or status change")
+
+// UserStore reads and raises the token version of users
+type UserStore interface {
+	FindTokenVersion(ctx context.Context, userID uuid.UUID) (int64, error)
+	IncrementTokenVersion(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// Versions tracks the token version of every user, tokens issued with an older version are stale
+type Versions interface {
+	// Current returns the user's token version, an unknown user is at version 0
+	Current(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Bump makes every token issued to the user so far stale and returns the new version
+	Bump(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Check returns ErrStaleToken when the token carries an older version than its user
+	Check(ctx context.Context, claims *jwt.Claims) error
+}
+
+type RedisVersions struct {
+	redis redis.Redis
+	users UserStore
+	// How long a cached version is trusted, the database holds the source of truth
+	ttl time.Duration
+}
+
+func NewRedisVersions(rds redis.Redis, users UserStore, ttl time.Duration) *RedisVersions {
+	return &RedisVersions{
+		redis: rds,
+		users: users,
+		ttl:   ttl,
+	}
+}
+
+func (v *RedisVersions) Current(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var version int64
+	err := v.redis.Get(ctx, rediskey.TokenVersionKey(userID.String()), &version)
+	if err == nil {
+		return version, nil
+	}
+	if !errors.Is(err, goredis.Nil) {
+		return 0, fmt.Errorf("failed to read token version: %w", err)
+	}
+
+	version, err = v.users.FindTokenVersion(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to load token version: %w", err)
+	}
+	if err := v.redis.Set(ctx, rediskey.TokenVersionKey(userID.String()), version, v.ttl); err != nil {
+		return 0, fmt.Errorf("failed to cache token version: %w", err)
+	}
+	return version, nil
+}
+
+func (v *RedisVersions) Bump(ctx context.Context, userID uuid.UUID) (int64, error) {
+	version, err := v.users.IncrementTokenVersion(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to raise token version: %w", err)
+	}
+	if err := v.redis.Set(ctx, rediskey.TokenVersionKey(userID.String()), version, v.ttl); err != nil {
+		return 0, fmt.Errorf("failed to cache token version: %w", err)
+	}
+	return version, nil
+}
+
+func (v *RedisVersions) Check(ctx context.Context, claims *jwt.Claims) error {
+	if claims.UserID == nil {
+		return nil
+	}
+	current, err := v.Current(ctx, *claims.UserID)
+	if err != nil {
+		return err
+	}
+	if claims.Version() < current {
+		return ErrStaleToken
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package ctxutil
+
+// ClientInfo describes the HTTP client behind the current request
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
+
+const ClientInfoKey ContextKey[ClientInfo] = "client_info"
@@ -0,0 +1,17 @@
+package ctxutil
+
+import "github.com/google/uuid"
+
+// Principal describes the authenticated caller of the current request
+type Principal struct {
+	// Nil for service API keys
+	UserID *uuid.UUID
+	Role   string
+	Method string
+	// Set for service API keys
+	ServiceName string
+	// Set when an admin acts as the user through an impersonation token
+	ImpersonatorID *uuid.UUID
+}
+
+const PrincipalKey ContextKey[Principal] = "principal"
@@ -47,6 +47,17 @@ func ExpireCookie(name string) *http.Cookie {
 	}
 }
 
+// OidcStateCookieName holds the hash of the state of a pending OpenID Connect login
+const OidcStateCookieName = "oidc_state"
+
+// NewOidcStateCookie binds an OpenID Connect login to the browser that started it,
+// Lax because the provider sends the browser back with a cross-site redirect
+func NewOidcStateCookie(req *http.Request, value string, expiry time.Duration) *http.Cookie {
+	c := NewCookie(OidcStateCookieName, value, expiry, req)
+	c.SameSite = http.SameSiteLaxMode
+	return c
+}
+
 func NewRefreshTokenCookie(req *http.Request, token string, expiry time.Duration) *http.Cookie {
 	return NewCookie("refresh_token", token, expiry, req)
 }
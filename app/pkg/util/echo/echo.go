@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"backend/service-platform/app/internal/runtime"
+	ctxutil "backend/service-platform/app/pkg/util/context"
 )
 
 func SetupCORSMiddleware(res runtime.Resource) echo.MiddlewareFunc {
@@ -80,6 +81,23 @@ func SetupLoggerMiddleware(res runtime.Resource) echo.MiddlewareFunc {
 	})
 }
 
+// SetupClientInfoMiddleware exposes the client IP, user agent and request ID to managers through the request context.
+// It must run after the RequestID middleware.
+func SetupClientInfoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			info := ctxutil.ClientInfo{
+				IPAddress: c.RealIP(),
+				UserAgent: req.UserAgent(),
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+			}
+			c.SetRequest(req.WithContext(ctxutil.ClientInfoKey.Set(req.Context(), info)))
+			return next(c)
+		}
+	}
+}
+
 func BindAndValidate(c echo.Context, payload any) error {
 	if err := c.Bind(payload); err != nil {
 		return err
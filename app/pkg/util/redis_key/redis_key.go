@@ -5,3 +5,103 @@ import "fmt"
 func LoginTokenKey(token string) string {
 	return fmt.Sprintf("login::{%s}", token)
 }
+
+func PasswordResetEmailRateKey(email string) string {
+	return fmt.Sprintf("password_reset::email::{%s}", email)
+}
+
+func PasswordResetIPRateKey(ip string) string {
+	return fmt.Sprintf("password_reset::ip::{%s}", ip)
+}
+
+func LoginEmailRateKey(email string) string {
+	return fmt.Sprintf("login::email::{%s}", email)
+}
+
+func LoginIPRateKey(ip string) string {
+	return fmt.Sprintf("login::ip::{%s}", ip)
+}
+
+func PasswordCheckUserRateKey(userID string) string {
+	return fmt.Sprintf("password_check::user::{%s}", userID)
+}
+
+func RegisterIPRateKey(ip string) string {
+	return fmt.Sprintf("register::ip::{%s}", ip)
+}
+
+func RefreshIPRateKey(ip string) string {
+	return fmt.Sprintf("refresh_token::ip::{%s}", ip)
+}
+
+func MfaChallengeKey(tokenHash string) string {
+	return fmt.Sprintf("mfa::challenge::{%s}", tokenHash)
+}
+
+func MfaChallengeAttemptsKey(tokenHash string) string {
+	return fmt.Sprintf("mfa::challenge_attempts::{%s}", tokenHash)
+}
+
+func MfaUsedCodeKey(userID string, step int64) string {
+	return fmt.Sprintf("mfa::used_code::{%s}::%d", userID, step)
+}
+
+func MfaUserAttemptsKey(userID string) string {
+	return fmt.Sprintf("mfa::user_attempts::{%s}", userID)
+}
+
+func PhoneOtpKey(userID string) string {
+	return fmt.Sprintf("phone_otp::{%s}", userID)
+}
+
+func PhoneOtpAttemptsKey(userID string) string {
+	return fmt.Sprintf("phone_otp::attempts::{%s}", userID)
+}
+
+func PhoneOtpResendKey(userID string) string {
+	return fmt.Sprintf("phone_otp::resend::{%s}", userID)
+}
+
+func PhoneOtpUserRateKey(userID string) string {
+	return fmt.Sprintf("phone_otp::user::{%s}", userID)
+}
+
+func PhoneOtpNumberRateKey(phoneNumber string) string {
+	return fmt.Sprintf("phone_otp::number::{%s}", phoneNumber)
+}
+
+func OidcStateKey(stateHash string) string {
+	return fmt.Sprintf("oidc::state::{%s}", stateHash)
+}
+
+func SiweNonceKey(nonce string) string {
+	return fmt.Sprintf("siwe::nonce::{%s}", nonce)
+}
+
+func SiweNonceIPRateKey(ip string) string {
+	return fmt.Sprintf("siwe::nonce::ip::{%s}", ip)
+}
+
+func AccessTokenDenylistKey(jti string) string {
+	return fmt.Sprintf("token_denylist::{%s}", jti)
+}
+
+func TokensValidAfterKey(userID string) string {
+	return fmt.Sprintf("tokens_valid_after::{%s}", userID)
+}
+
+func TokenVersionKey(userID string) string {
+	return fmt.Sprintf("token_version::{%s}", userID)
+}
+
+func RolePermissionsKey() string {
+	return "rbac::role_permissions"
+}
+
+func SuperAdminBootstrapLockKey() string {
+	return "super_admin::bootstrap_lock"
+}
+
+func SuperAdminBootstrapIPRateKey(ip string) string {
+	return fmt.Sprintf("super_admin::bootstrap::ip::{%s}", ip)
+}
@@ -0,0 +1,26 @@
+package securetoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// DefaultSize is the number of random bytes used for one-time tokens
+const DefaultSize = 32
+
+// Generate returns a URL-safe random token built from size random bytes
+func Generate(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// Hash returns the hex encoded sha256 of a token, which is what gets persisted
+func Hash(rawValue string) string {
+	h := sha256.Sum256([]byte(rawValue))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"archive/zip"
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/mailer"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// Most recent jobs included in an export
+	exportJobLimit = 1000
+	// Most recent audit events included in an export
+	exportAuditEventLimit = 1000
+)
+
+type exportProfile struct {
+	ID                  uuid.UUID  `json:"id"`
+	Username            string     `json:"username"`
+	Email               *string    `json:"email"`
+	PendingEmail        *string    `json:"pending_email,omitempty"`
+	PhoneNumber         *string    `json:"phone_number"`
+	Status              string     `json:"status"`
+	Role                string     `json:"role"`
+	EmailVerified       bool       `json:"email_verified"`
+	PhoneVerified       bool       `json:"phone_verified"`
+	MfaEnabled          bool       `json:"mfa_enabled"`
+	LastLoginAt         *time.Time `json:"last_login_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           *time.Time `json:"updated_at"`
+	DeactivatedAt       *time.Time `json:"deactivated_at,omitempty"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+}
+
+type exportSession struct {
+	ID        uuid.UUID  `json:"id"`
+	UserAgent *string    `json:"user_agent,omitempty"`
+	IPAddress *string    `json:"ip_address,omitempty"`
+	Revoked   bool       `json:"revoked"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+type exportJob struct {
+	ID          uuid.UUID         `json:"id"`
+	Type        string            `json:"type"`
+	Status      string            `json:"status"`
+	Payload     entity.JobPayload `json:"payload"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+}
+
+type exportIdentity struct {
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"subject"`
+	Email       *string    `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type exportWallet struct {
+	Address     string     `json:"address"`
+	ChainID     int64      `json:"chain_id"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// exportAuditEvent leaves out who else acted on the account, the request details are those of the user only
+type exportAuditEvent struct {
+	Action    string         `json:"action"`
+	ActorType string         `json:"actor_type"`
+	BySelf    bool           `json:"by_self"`
+	IPAddress *string        `json:"ip_address,omitempty"`
+	UserAgent *string        `json:"user_agent,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// ExportUserDataHandler writes the personal data of a user into a ZIP archive of JSON files in local storage and
+// emails the user a link to download it
+type ExportUserDataHandler struct {
+	logger       *zap.Logger
+	repositories *repository.Repositories
+	mailer       mailer.Mailer
+	config       config.AccountLifecycleConfig
+}
+
+func NewExportUserDataHandler(
+	repositories *repository.Repositories,
+	mail mailer.Mailer,
+	cfg config.AccountLifecycleConfig,
+	logger *zap.Logger,
+) *ExportUserDataHandler {
+	return &ExportUserDataHandler{
+		logger:       logger.With(zap.String("handler", "export_user_data")),
+		repositories: repositories,
+		mailer:       mail,
+		config:       cfg,
+	}
+}
+
+func (h *ExportUserDataHandler) Handle(ctx context.Context, j *entity.Job) error {
+	rawUserID, _ := j.Payload[job.PayloadUserID].(string)
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id in payload: %w", err)
+	}
+
+	u, err := h.repositories.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			h.logger.Warn("User not found, skipping data export", zap.String("user_id", rawUserID))
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	files, err := h.collect(ctx, u)
+	if err != nil {
+		return err
+	}
+	if err := h.writeArchive(h.config.ExportPath(rawUserID, j.ID.String()), files); err != nil {
+		return err
+	}
+
+	if u.Email == nil {
+		h.logger.Info("User has no email, export ready without notification", zap.String("user_id", rawUserID))
+		return nil
+	}
+	message, err := mailer.DataExportReadyTemplate.Render(map[string]any{
+		"Username": u.Username,
+		"Link":     linkWithParam(h.config.ExportURL, "id", j.ID.String()),
+	}, *u.Email)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	if err := h.mailer.Send(ctx, message); err != nil {
+		return err
+	}
+
+	h.logger.Info("Data export ready",
+		zap.String("job_id", j.ID.String()),
+		zap.String("user_id", rawUserID))
+	return nil
+}
+
+// collect returns the content of the archive by file name
+func (h *ExportUserDataHandler) collect(ctx context.Context, u *entity.User) (map[string]any, error) {
+	sessions, err := h.repositories.SessionRepository.ListAllByUser(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	jobs, err := h.repositories.JobRepository.GetJobsByUserID(ctx, u.ID, exportJobLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	identities, err := h.repositories.UserIdentityRepository.FindByUserID(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+	wallets, err := h.repositories.UserWalletRepository.FindByUserID(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+	events, err := h.repositories.AuditEventRepository.FindByUser(ctx, u.ID, exportAuditEventLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	profile := exportProfile{
+		ID:                  u.ID,
+		Username:            u.Username,
+		Email:               u.Email,
+		PendingEmail:        u.PendingEmail,
+		PhoneNumber:         u.PhoneNumber,
+		Status:              string(u.Status),
+		Role:                string(u.Role),
+		EmailVerified:       u.EmailVerified,
+		PhoneVerified:       u.PhoneVerified,
+		MfaEnabled:          u.MfaEnabled,
+		LastLoginAt:         u.LastLoginAt,
+		CreatedAt:           u.CreatedAt,
+		UpdatedAt:           u.UpdatedAt,
+		DeactivatedAt:       u.DeactivatedAt,
+		DeletionScheduledAt: u.DeletionScheduledAt,
+	}
+	exportedSessions := make([]exportSession, 0, len(sessions))
+	for _, s := range sessions {
+		exportedSessions = append(exportedSessions, exportSession{
+			ID:        s.ID,
+			UserAgent: s.UserAgent,
+			IPAddress: s.IPAddress,
+			Revoked:   s.Revoked,
+			CreatedAt: s.CreatedAt,
+			ExpiresAt: s.ExpiresAt,
+			RotatedAt: s.RotatedAt,
+			EndedAt:   s.DeletedAt,
+		})
+	}
+	exportedJobs := make([]exportJob, 0, len(jobs))
+	for _, jb := range jobs {
+		exportedJobs = append(exportedJobs, exportJob{
+			ID:          jb.ID,
+			Type:        jb.Type,
+			Status:      string(jb.Status),
+			Payload:     jb.Payload,
+			CreatedAt:   jb.CreatedAt,
+			CompletedAt: jb.CompletedAt,
+		})
+	}
+	exportedIdentities := make([]exportIdentity, 0, len(identities))
+	for _, i := range identities {
+		exportedIdentities = append(exportedIdentities, exportIdentity{
+			Provider:    i.Provider,
+			Subject:     i.Subject,
+			Email:       i.Email,
+			LastLoginAt: i.LastLoginAt,
+			CreatedAt:   i.CreatedAt,
+		})
+	}
+	exportedWallets := make([]exportWallet, 0, len(wallets))
+	for _, w := range wallets {
+		exportedWallets = append(exportedWallets, exportWallet{
+			Address:     w.Address,
+			ChainID:     w.ChainID,
+			LastLoginAt: w.LastLoginAt,
+			CreatedAt:   w.CreatedAt,
+		})
+	}
+
+	exportedEvents := make([]exportAuditEvent, 0, len(events))
+	for _, e := range events {
+		event := exportAuditEvent{
+			Action:    e.Action,
+			ActorType: string(e.ActorType),
+			BySelf:    e.ActorID != nil && *e.ActorID == u.ID,
+			CreatedAt: e.CreatedAt,
+		}
+		if event.BySelf {
+			event.IPAddress = e.IPAddress
+			event.UserAgent = e.UserAgent
+			event.Metadata = e.Metadata
+		}
+		exportedEvents = append(exportedEvents, event)
+	}
+
+	return map[string]any{
+		"profile.json":      profile,
+		"sessions.json":     exportedSessions,
+		"jobs.json":         exportedJobs,
+		"identities.json":   exportedIdentities,
+		"wallets.json":      exportedWallets,
+		"audit_events.json": exportedEvents,
+	}, nil
+}
+
+// writeArchive writes next to the destination first, a download never sees a partial archive
+func (h *ExportUserDataHandler) writeArchive(path string, files map[string]any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create export archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	archive := zip.NewWriter(tmp)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to add %s to the export: %w", name, err)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(content); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to finish export archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to finish export archive: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (h *ExportUserDataHandler) CanHandle(jobType string) bool {
+	return jobType == string(job.ExportUserData)
+}
+
+func (h *ExportUserDataHandler) GetType() string {
+	return string(job.ExportUserData)
+}
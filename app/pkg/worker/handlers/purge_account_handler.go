@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/denylist"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PurgeAccountHandler anonymizes an account whose deletion grace period is over, revokes its tokens and removes
+// its data exports
+type PurgeAccountHandler struct {
+	logger       *zap.Logger
+	repositories *repository.Repositories
+	denylist     denylist.Denylist
+	config       config.AccountLifecycleConfig
+}
+
+func NewPurgeAccountHandler(
+	repositories *repository.Repositories,
+	denylist denylist.Denylist,
+	cfg config.AccountLifecycleConfig,
+	logger *zap.Logger,
+) *PurgeAccountHandler {
+	return &PurgeAccountHandler{
+		logger:       logger.With(zap.String("handler", "purge_account")),
+		repositories: repositories,
+		denylist:     denylist,
+		config:       cfg,
+	}
+}
+
+func (h *PurgeAccountHandler) Handle(ctx context.Context, j *entity.Job) error {
+	rawUserID, _ := j.Payload[job.PayloadUserID].(string)
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id in payload: %w", err)
+	}
+
+	// The repository checks the schedule again, the user may have reactivated since the job was created
+	if err := h.repositories.UserRepository.Anonymize(ctx, userID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+		// A retry after a failure below finds the account purged already and finishes the job
+		purged, err := h.alreadyPurged(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			h.logger.Info("Account not due for deletion, skipping", zap.String("user_id", rawUserID))
+			return nil
+		}
+	}
+	// Access tokens are stateless, they stop working only once the user is denied
+	if err := h.denylist.RevokeUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	if err := os.RemoveAll(h.config.UserExportDir(rawUserID)); err != nil {
+		return fmt.Errorf("failed to remove data exports: %w", err)
+	}
+
+	h.logger.Info("Account purged",
+		zap.String("job_id", j.ID.String()),
+		zap.String("user_id", rawUserID))
+	return nil
+}
+
+func (h *PurgeAccountHandler) alreadyPurged(ctx context.Context, userID uuid.UUID) (bool, error) {
+	u, err := h.repositories.UserRepository.FindByIDWithDeleted(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to find user: %w", err)
+	}
+	return u.PurgedAt != nil, nil
+}
+
+func (h *PurgeAccountHandler) CanHandle(jobType string) bool {
+	return jobType == string(job.PurgeAccount)
+}
+
+func (h *PurgeAccountHandler) GetType() string {
+	return string(job.PurgeAccount)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/mailer"
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultPasswordResetTTL = 30 * time.Minute
+
+// SendPasswordResetEmailHandler issues a single-use reset token and mails the link to the user
+type SendPasswordResetEmailHandler struct {
+	logger       *zap.Logger
+	repositories *repository.Repositories
+	mailer       mailer.Mailer
+	config       config.PasswordResetConfig
+}
+
+func NewSendPasswordResetEmailHandler(
+	repositories *repository.Repositories,
+	mail mailer.Mailer,
+	cfg config.PasswordResetConfig,
+	logger *zap.Logger,
+) *SendPasswordResetEmailHandler {
+	return &SendPasswordResetEmailHandler{
+		logger:       logger.With(zap.String("handler", "send_password_reset_email")),
+		repositories: repositories,
+		mailer:       mail,
+		config:       cfg,
+	}
+}
+
+func (h *SendPasswordResetEmailHandler) Handle(ctx context.Context, j *entity.Job) error {
+	rawUserID, _ := j.Payload[job.PayloadUserID].(string)
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id in payload: %w", err)
+	}
+
+	u, err := h.repositories.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			h.logger.Warn("User not found, skipping password reset email", zap.String("user_id", rawUserID))
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if u.Email == nil {
+		h.logger.Info("User has no email, skipping", zap.String("user_id", rawUserID))
+		return nil
+	}
+
+	// Only the most recent link stays valid
+	if err := h.repositories.PasswordResetTokenRepository.InvalidateByUserID(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	rawToken, err := securetoken.Generate(securetoken.DefaultSize)
+	if err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+	ttl := h.config.TokenTTL
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+	_, err = h.repositories.PasswordResetTokenRepository.Insert(ctx, &entity.PasswordResetToken{
+		UserID:    u.ID,
+		Token:     securetoken.Hash(rawToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
+	message, err := mailer.PasswordResetTemplate.Render(map[string]any{
+		"Username":  u.Username,
+		"Link":      linkWithToken(h.config.ResetURL, rawToken),
+		"ExpiresAt": expiresAt.UTC().Format(time.RFC1123),
+	}, *u.Email)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	if err := h.mailer.Send(ctx, message); err != nil {
+		return err
+	}
+
+	h.logger.Info("Password reset email sent",
+		zap.String("job_id", j.ID.String()),
+		zap.String("user_id", rawUserID))
+	return nil
+}
+
+func (h *SendPasswordResetEmailHandler) CanHandle(jobType string) bool {
+	return jobType == string(job.SendPasswordResetEmail)
+}
+
+func (h *SendPasswordResetEmailHandler) GetType() string {
+	return string(job.SendPasswordResetEmail)
+}
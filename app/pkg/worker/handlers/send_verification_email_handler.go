@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/mailer"
+	securetoken "backend/service-platform/app/pkg/util/secure_token"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultEmailVerificationTTL = 24 * time.Hour
+
+// SendVerificationEmailHandler issues a fresh verification token and mails the link to the user.
+// The plaintext token only lives in the email, the database keeps its hash.
+type SendVerificationEmailHandler struct {
+	logger       *zap.Logger
+	repositories *repository.Repositories
+	mailer       mailer.Mailer
+	config       config.EmailVerificationConfig
+}
+
+func NewSendVerificationEmailHandler(
+	repositories *repository.Repositories,
+	mail mailer.Mailer,
+	cfg config.EmailVerificationConfig,
+	logger *zap.Logger,
+) *SendVerificationEmailHandler {
+	return &SendVerificationEmailHandler{
+		logger:       logger.With(zap.String("handler", "send_verification_email")),
+		repositories: repositories,
+		mailer:       mail,
+		config:       cfg,
+	}
+}
+
+func (h *SendVerificationEmailHandler) Handle(ctx context.Context, j *entity.Job) error {
+	rawUserID, _ := j.Payload[job.PayloadUserID].(string)
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id in payload: %w", err)
+	}
+
+	u, err := h.repositories.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			h.logger.Warn("User not found, skipping verification email", zap.String("user_id", rawUserID))
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	email, ok := h.addressToVerify(u, j)
+	if !ok {
+		h.logger.Info("Email already verified or missing, skipping", zap.String("user_id", rawUserID))
+		return nil
+	}
+
+	// Only the most recent link stays valid
+	if err := h.repositories.EmailVerificationTokenRepository.InvalidateByUserID(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	rawToken, err := securetoken.Generate(securetoken.DefaultSize)
+	if err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+	ttl := h.config.TokenTTL
+	if ttl <= 0 {
+		ttl = defaultEmailVerificationTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+	_, err = h.repositories.EmailVerificationTokenRepository.Insert(ctx, &entity.EmailVerificationToken{
+		UserID:    u.ID,
+		Email:     email,
+		Token:     securetoken.Hash(rawToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
+	message, err := mailer.VerifyEmailTemplate.Render(map[string]any{
+		"Username":  u.Username,
+		"Link":      linkWithToken(h.config.VerifyURL, rawToken),
+		"ExpiresAt": expiresAt.UTC().Format(time.RFC1123),
+	}, email)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	if err := h.mailer.Send(ctx, message); err != nil {
+		return err
+	}
+
+	h.logger.Info("Verification email sent",
+		zap.String("job_id", j.ID.String()),
+		zap.String("user_id", rawUserID))
+	return nil
+}
+
+// addressToVerify picks the pending address of an email change, or the current one while it is unverified
+func (h *SendVerificationEmailHandler) addressToVerify(u *entity.User, j *entity.Job) (string, bool) {
+	if pending, _ := j.Payload[job.PayloadEmail].(string); pending != "" {
+		// A newer change request or a completed one supersedes this job
+		if u.PendingEmail == nil || *u.PendingEmail != pending {
+			return "", false
+		}
+		return pending, true
+	}
+	if u.Email == nil || u.EmailVerified {
+		return "", false
+	}
+	return *u.Email, true
+}
+
+// linkWithToken appends the token as a query parameter, or returns it alone when no base URL is configured
+func linkWithToken(baseURL, token string) string {
+	return linkWithParam(baseURL, "token", token)
+}
+
+func linkWithParam(baseURL, name, value string) string {
+	if baseURL == "" {
+		return value
+	}
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return value
+	}
+	q := link.Query()
+	q.Set(name, value)
+	link.RawQuery = q.Encode()
+	return link.String()
+}
+
+func (h *SendVerificationEmailHandler) CanHandle(jobType string) bool {
+	return jobType == string(job.SendVerificationEmail)
+}
+
+func (h *SendVerificationEmailHandler) GetType() string {
+	return string(job.SendVerificationEmail)
+}
@@ -1,9 +1,14 @@
 package service
 
 import (
+	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/entity"
 	"backend/service-platform/app/database/repository"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
 	"backend/service-platform/app/internal/config"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/mailer"
 	"backend/service-platform/app/pkg/queue"
 	"backend/service-platform/app/pkg/worker"
 	"backend/service-platform/app/pkg/worker/handlers"
@@ -12,16 +17,19 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 // WorkerService manages worker pools and background processes
 type WorkerService struct {
-	workerPool   worker.Pool
-	jobRepo      repository.JobRepository
-	queue        queue.Queue
-	logger       *zap.Logger
-	workerConfig config.WorkerConfig
+	workerPool      worker.Pool
+	jobRepo         repository.JobRepository
+	userRepo        repository.UserRepository
+	queue           queue.Queue
+	logger          *zap.Logger
+	workerConfig    config.WorkerConfig
+	lifecycleConfig config.AccountLifecycleConfig
 }
 
 // NewWorkerService creates a new worker service with all necessary components
@@ -31,6 +39,12 @@ func NewWorkerService(res runtime.Resource, workerConfig config.WorkerConfig) *W
 	// Create a job repository
 	jobRepo := repository.NewJobRepository(res)
 
+	// Create mailer used by notification handlers
+	mail, err := mailer.NewMailer(res.Config.MailerConfig, logger)
+	if err != nil {
+		panic(err)
+	}
+
 	// Create Redis queue
 	redisQueue := queue.NewRedisQueue(res.Redis.GetUniversalClient(), logger)
 
@@ -39,6 +53,31 @@ func NewWorkerService(res runtime.Resource, workerConfig config.WorkerConfig) *W
 	handlerRegistry.Register(handlers.NewInitClaimHandler(logger))
 	handlerRegistry.Register(handlers.NewCompleteClaimHandler(logger))
 	handlerRegistry.Register(handlers.NewKYCVerificationHandler(logger))
+	repositories := repository.NewRepositories(res)
+	handlerRegistry.Register(handlers.NewSendVerificationEmailHandler(
+		repositories,
+		mail,
+		res.Config.EmailVerificationConfig,
+		logger,
+	))
+	handlerRegistry.Register(handlers.NewSendPasswordResetEmailHandler(
+		repositories,
+		mail,
+		res.Config.PasswordResetConfig,
+		logger,
+	))
+	handlerRegistry.Register(handlers.NewPurgeAccountHandler(
+		repositories,
+		denylist.NewRedisDenylist(res.Redis, res.Config.JwtConfig),
+		res.Config.AccountLifecycleConfig,
+		logger,
+	))
+	handlerRegistry.Register(handlers.NewExportUserDataHandler(
+		repositories,
+		mail,
+		res.Config.AccountLifecycleConfig,
+		logger,
+	))
 
 	// Create a worker pool
 	workerPool := worker.NewWorkerPool(
@@ -50,11 +89,13 @@ func NewWorkerService(res runtime.Resource, workerConfig config.WorkerConfig) *W
 	)
 
 	return &WorkerService{
-		workerPool:   workerPool,
-		jobRepo:      jobRepo,
-		queue:        redisQueue,
-		logger:       logger,
-		workerConfig: workerConfig,
+		workerPool:      workerPool,
+		jobRepo:         jobRepo,
+		userRepo:        repositories.UserRepository,
+		queue:           redisQueue,
+		logger:          logger,
+		workerConfig:    workerConfig,
+		lifecycleConfig: res.Config.AccountLifecycleConfig,
 	}
 }
 
@@ -80,6 +121,13 @@ func (ws *WorkerService) Start(ctx context.Context) error {
 		ws.runRetryScheduler(ctx)
 	}()
 
+	// Start account deletion scheduler
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ws.runDeletionScheduler(ctx)
+	}()
+
 	// Start health monitor
 	wg.Add(1)
 	go func() {
@@ -173,6 +221,79 @@ func (ws *WorkerService) processRetryableJobs(ctx context.Context, logger *zap.L
 	}
 }
 
+// runDeletionScheduler queues a purge job for every account whose deletion grace period is over. The queue does not
+// delay scheduled jobs, so due accounts are found by polling instead
+func (ws *WorkerService) runDeletionScheduler(ctx context.Context) {
+	interval := ws.lifecycleConfig.DeletionSweepInterval
+	if interval <= 0 {
+		interval = 1 * time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	deletionLogger := ws.logger.With(zap.String("component", "deletion_scheduler"))
+	deletionLogger.Info("Starting deletion scheduler", zap.Duration("interval", interval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			deletionLogger.Info("Deletion scheduler stopping")
+			return
+		case <-ticker.C:
+			ws.processDueDeletions(ctx, deletionLogger)
+		}
+	}
+}
+
+func (ws *WorkerService) processDueDeletions(ctx context.Context, logger *zap.Logger) {
+	users, err := ws.userRepo.FindDueForDeletion(ctx, time.Now(), 100)
+	if err != nil {
+		logger.Error("Failed to get accounts due for deletion", zap.Error(err))
+		return
+	}
+
+	if len(users) == 0 {
+		return
+	}
+
+	logger.Info("Found accounts due for deletion", zap.Int("count", len(users)))
+
+	for _, u := range users {
+		purge := &entity.Job{
+			ID:          uuid.New(),
+			Type:        string(job.PurgeAccount),
+			Priority:    job.PriorityLow,
+			Payload:     entity.JobPayload{job.PayloadUserID: u.ID.String()},
+			MaxAttempts: 3,
+			CreatedAt:   time.Now(),
+			Status:      job.Pending,
+		}
+		if err := ws.jobRepo.Create(ctx, purge); err != nil {
+			// Another worker or an earlier tick queued it, the open job purges the account
+			if queryutil.IsUniqueViolation(err) {
+				logger.Debug("Account already queued for purge", zap.String("user_id", u.ID.String()))
+				continue
+			}
+			logger.Error("Failed to create purge job",
+				zap.String("user_id", u.ID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		if err := ws.queue.Enqueue(ctx, purge); err != nil {
+			logger.Error("Failed to enqueue purge job",
+				zap.String("job_id", purge.ID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		logger.Info("Account queued for purge",
+			zap.String("job_id", purge.ID.String()),
+			zap.String("user_id", u.ID.String()))
+	}
+}
+
 func (ws *WorkerService) runHealthMonitor(ctx context.Context) {
 	interval := ws.workerConfig.HealthMonitorInterval
 	ws.logger.Info(fmt.Sprintf("Health Monitor interval: %s", interval))
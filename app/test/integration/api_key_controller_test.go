@@ -0,0 +1,393 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/owner"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/rbac"
+	pagingUtil "backend/service-platform/app/pkg/util/paging"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	ApiKeysEndpoint = "/api/v1/admin/api-keys"
+	ApiKeyEndpoint  = "/api/v1/admin/api-keys/%s"
+)
+
+type ApiKeyControllerSuite struct {
+	RouterSuite
+}
+
+func TestApiKeyControllerSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyControllerSuite))
+}
+
+func (s *ApiKeyControllerSuite) accessToken(userID uuid.UUID, userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	username := "admin@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *ApiKeyControllerSuite) TestCreateApiKey_Success() {
+	// Arrange
+	m := mocks.NewMockApiKeyManager(s.T())
+	s.managers.ApiKeyManager = m
+
+	adminID := uuid.New()
+	token := s.accessToken(adminID, role.Admin)
+	serviceName := "kyc-service"
+	req := request.CreateApiKeyRequest{
+		Name:        "kyc",
+		OwnerType:   owner.Service,
+		ServiceName: &serviceName,
+		Scopes:      []string{"jobs:read"},
+	}
+	m.EXPECT().CreateApiKey(mock.Anything, mock.MatchedBy(func(r request.CreateApiKeyRequest) bool {
+		return r.Name == "kyc" && r.CreatedBy != nil && *r.CreatedBy == adminID
+	})).Return(&response.CreatedApiKeyResponse{
+		ApiKeyResponse: response.ApiKeyResponse{ID: uuid.New(), Name: "kyc", Prefix: "0123456789ab", OwnerType: owner.Service},
+		Key:            "sk_0123456789ab_secret",
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.CreatedApiKeyResponse]](
+		s.e,
+		http.MethodPost,
+		ApiKeysEndpoint,
+		&token,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusCreated, code)
+	s.r.Equal("sk_0123456789ab_secret", resp.Data.Key)
+	s.r.Equal("0123456789ab", resp.Data.Prefix)
+}
+
+func (s *ApiKeyControllerSuite) TestCreateApiKey_ServiceNameRequired() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.Admin)
+	req := request.CreateApiKeyRequest{
+		Name:      "kyc",
+		OwnerType: owner.Service,
+		Scopes:    []string{"jobs:read"},
+	}
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ApiKeysEndpoint,
+		&token,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *ApiKeyControllerSuite) TestCreateApiKey_ForbiddenForUser() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.User)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ApiKeysEndpoint,
+		&token,
+		request.CreateApiKeyRequest{},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *ApiKeyControllerSuite) TestListApiKeys_Success() {
+	// Arrange
+	m := mocks.NewMockApiKeyManager(s.T())
+	s.managers.ApiKeyManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	keys := []response.ApiKeyResponse{{ID: uuid.New(), Name: "kyc", OwnerType: owner.Service}}
+	m.EXPECT().ListApiKeys(mock.Anything, mock.MatchedBy(func(r request.ListApiKeysRequest) bool {
+		return r.OwnerType != nil && *r.OwnerType == owner.Service && r.Page == 1
+	})).Return(keys, int64(1), nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.PaginationResponse[response.ApiKeyResponse]](
+		s.e,
+		http.MethodGet,
+		ApiKeysEndpoint+"?owner_type=SERVICE",
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 1)
+	s.r.Equal(int64(1), resp.Paging.Total)
+}
+
+func (s *ApiKeyControllerSuite) TestGetApiKey_NotFound() {
+	// Arrange
+	m := mocks.NewMockApiKeyManager(s.T())
+	s.managers.ApiKeyManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	id := uuid.New()
+	m.EXPECT().GetApiKey(mock.Anything, id).Return(nil, manager.ErrApiKeyNotFound)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		fmt.Sprintf(ApiKeyEndpoint, id),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
+
+func (s *ApiKeyControllerSuite) TestRevokeApiKey_Success() {
+	// Arrange
+	m := mocks.NewMockApiKeyManager(s.T())
+	s.managers.ApiKeyManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	id := uuid.New()
+	m.EXPECT().RevokeApiKey(mock.Anything, id).Return(nil)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodDelete,
+		fmt.Sprintf(ApiKeyEndpoint, id),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+}
+
+func (s *ApiKeyControllerSuite) TestRotateApiKey_Success() {
+	// Arrange
+	m := mocks.NewMockApiKeyManager(s.T())
+	s.managers.ApiKeyManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	id := uuid.New()
+	overlap := 3600
+	m.EXPECT().RotateApiKey(mock.Anything, mock.MatchedBy(func(r request.RotateApiKeyRequest) bool {
+		return r.ID == id && r.OverlapSeconds != nil && *r.OverlapSeconds == overlap
+	})).Return(&response.CreatedApiKeyResponse{
+		ApiKeyResponse: response.ApiKeyResponse{ID: uuid.New(), RotatedFromID: &id},
+		Key:            "sk_ba9876543210_secret",
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.CreatedApiKeyResponse]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(ApiKeyEndpoint, id)+"/rotate",
+		&token,
+		request.RotateApiKeyRequest{OverlapSeconds: &overlap},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusCreated, code)
+	s.r.Equal("sk_ba9876543210_secret", resp.Data.Key)
+	s.r.Equal(id, *resp.Data.RotatedFromID)
+}
+
+func (s *ApiKeyControllerSuite) TestRotateApiKey_Revoked() {
+	// Arrange
+	m := mocks.NewMockApiKeyManager(s.T())
+	s.managers.ApiKeyManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	id := uuid.New()
+	m.EXPECT().RotateApiKey(mock.Anything, mock.Anything).Return(nil, manager.ErrApiKeyRevoked)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(ApiKeyEndpoint, id)+"/rotate",
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *ApiKeyControllerSuite) createUser(username string, userRole role.Role) *entity.User {
+	email := username + "@example.com"
+	u, err := s.repositories.UserRepository.Insert(s.ctx, &entity.User{
+		Username:      username,
+		Email:         &email,
+		Password:      "not-a-hash",
+		Status:        userstatus.Verified,
+		Role:          userRole,
+		EmailVerified: true,
+	})
+	s.r.NoError(err)
+	return u
+}
+
+// useRealApiKeyManager undoes a mock installed by an earlier test
+func (s *ApiKeyControllerSuite) useRealApiKeyManager() {
+	authorizer := rbac.NewRedisAuthorizer(s.resource.Redis, s.repositories.RoleRepository, s.resource.Config.RbacConfig.CacheTTL)
+	s.managers.ApiKeyManager = manager.NewApiKeyManager(s.resource, authorizer, s.managers.AuditLogger, s.repositories)
+}
+
+func (s *ApiKeyControllerSuite) createUserKey(token string, userID uuid.UUID, scopes ...string) (response.GeneralResponse[response.CreatedApiKeyResponse], int) {
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.CreatedApiKeyResponse]](
+		s.e,
+		http.MethodPost,
+		ApiKeysEndpoint,
+		&token,
+		request.CreateApiKeyRequest{Name: "automation", OwnerType: owner.User, UserID: &userID, Scopes: scopes},
+	)
+	s.r.NoError(err)
+	return resp, code
+}
+
+// withApiKey sends a request authenticated with the key instead of a bearer token
+func (s *ApiKeyControllerSuite) withApiKey(key string, method string, target string) int {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("X-API-Key", key)
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func (s *ApiKeyControllerSuite) TestCreateApiKey_OwnerWithMorePermissions() {
+	// Arrange - an ADMIN asks for a key that would act as a SUPER_ADMIN
+	s.useRealApiKeyManager()
+	admin := s.createUser("api-key-escalating-admin", role.Admin)
+	superAdmin := s.createUser("api-key-super-admin", role.SuperAdmin)
+
+	// Act
+	_, code := s.createUserKey(s.accessToken(admin.ID, admin.Role), superAdmin.ID, rbac.UsersRead)
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, code)
+	keys, _, err := s.repositories.ApiKeyRepository.FindMany(s.ctx, repository.ApiKeyFilter{UserID: &superAdmin.ID}, pagingUtil.Page{Limit: 10, OrderBy: "created_at", SortBy: pagingUtil.DESC})
+	s.r.NoError(err)
+	s.r.Empty(keys)
+}
+
+func (s *ApiKeyControllerSuite) TestCreateApiKey_OwnKey() {
+	// Arrange
+	s.useRealApiKeyManager()
+	admin := s.createUser("api-key-own-admin", role.Admin)
+
+	// Act
+	created, code := s.createUserKey(s.accessToken(admin.ID, admin.Role), admin.ID, rbac.UsersRead)
+
+	// Assert - attributed to the caller and usable within its scopes
+	s.r.Equal(http.StatusCreated, code)
+	stored, err := s.repositories.ApiKeyRepository.FindByID(s.ctx, created.Data.ID)
+	s.r.NoError(err)
+	s.r.Equal(admin.ID, *stored.CreatedBy)
+	s.r.Equal(http.StatusOK, s.withApiKey(created.Data.Key, http.MethodGet, AdminUsersEndpoint))
+	s.r.Equal(http.StatusForbidden, s.withApiKey(created.Data.Key, http.MethodGet, AuditEventsEndpoint))
+}
+
+func (s *ApiKeyControllerSuite) TestApiKey_RefusedForInactiveOwner() {
+	// Arrange
+	s.useRealApiKeyManager()
+	admin := s.createUser("api-key-inactive-admin", role.Admin)
+	created, code := s.createUserKey(s.accessToken(admin.ID, admin.Role), admin.ID, rbac.UsersRead)
+	s.r.Equal(http.StatusCreated, code)
+
+	// Act - the owner is disabled without going through the suspension endpoint
+	_, err := s.repositories.UserRepository.UpdateStatus(s.ctx, admin.ID, userstatus.Disabled)
+	s.r.NoError(err)
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, s.withApiKey(created.Data.Key, http.MethodGet, AdminUsersEndpoint))
+}
+
+func (s *ApiKeyControllerSuite) TestApiKey_RevokedOnSuspension() {
+	// Arrange
+	s.useRealApiKeyManager()
+	admin := s.createUser("api-key-suspended-admin", role.Admin)
+	superAdmin := s.createUser("api-key-suspending-super-admin", role.SuperAdmin)
+	created, code := s.createUserKey(s.accessToken(admin.ID, admin.Role), admin.ID, rbac.UsersRead)
+	s.r.Equal(http.StatusCreated, code)
+
+	// Act
+	token := s.accessToken(superAdmin.ID, superAdmin.Role)
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, fmt.Sprintf(AdminUsersEndpoint+"/%s/suspend", admin.ID), &token, nil)
+
+	// Assert - the key stays revoked after the user is unsuspended
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	stored, err := s.repositories.ApiKeyRepository.FindByID(s.ctx, created.Data.ID)
+	s.r.NoError(err)
+	s.r.True(stored.Revoked)
+}
+
+func (s *ApiKeyControllerSuite) TestRotateApiKey_OnlyOnce() {
+	// Arrange
+	s.useRealApiKeyManager()
+	admin := s.createUser("api-key-rotating-admin", role.Admin)
+	token := s.accessToken(admin.ID, admin.Role)
+	created, code := s.createUserKey(token, admin.ID, rbac.UsersRead)
+	s.r.Equal(http.StatusCreated, code)
+	rotate := func() int {
+		_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, fmt.Sprintf(ApiKeyEndpoint, created.Data.ID)+"/rotate", &token, nil)
+		s.r.NoError(err)
+		return code
+	}
+
+	// Act
+	first := rotate()
+	second := rotate()
+
+	// Assert - the rotated key points to its single replacement
+	s.r.Equal(http.StatusCreated, first)
+	s.r.Equal(http.StatusConflict, second)
+	stored, err := s.repositories.ApiKeyRepository.FindByID(s.ctx, created.Data.ID)
+	s.r.NoError(err)
+	s.r.NotNil(stored.RotatedToID)
+	replacement, err := s.repositories.ApiKeyRepository.FindByID(s.ctx, *stored.RotatedToID)
+	s.r.NoError(err)
+	s.r.Equal(created.Data.ID, *replacement.RotatedFromID)
+}
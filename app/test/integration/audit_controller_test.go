@@ -0,0 +1,296 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	auditactor "backend/service-platform/app/database/constant/audit"
+	"backend/service-platform/app/database/constant/role"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/jwt"
+	ctxutil "backend/service-platform/app/pkg/util/context"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	AuditEventsEndpoint       = "/api/v1/admin/audit-events"
+	AuditEventsVerifyEndpoint = "/api/v1/admin/audit-events/verify"
+)
+
+type AuditControllerSuite struct {
+	RouterSuite
+}
+
+func TestAuditControllerSuite(t *testing.T) {
+	suite.Run(t, new(AuditControllerSuite))
+}
+
+func (s *AuditControllerSuite) accessToken(userID uuid.UUID, userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	username := "admin@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+// useRealAuditManager undoes a mock installed by an earlier test
+func (s *AuditControllerSuite) useRealAuditManager() {
+	s.managers.AuditManager = manager.NewAuditManager(s.resource, s.repositories)
+}
+
+func (s *AuditControllerSuite) listEvents(token string, query string) (response.CursorPaginationResponse[response.AuditEventResponse], int) {
+	resp, code, err := httputil.RequestHTTP[response.CursorPaginationResponse[response.AuditEventResponse]](
+		s.e,
+		http.MethodGet,
+		AuditEventsEndpoint+"?"+query,
+		&token,
+		nil,
+	)
+	s.r.NoError(err)
+	return resp, code
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_Success() {
+	// Arrange
+	m := mocks.NewMockAuditManager(s.T())
+	s.managers.AuditManager = m
+
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+	next := "41"
+	m.EXPECT().ListEvents(mock.Anything, mock.MatchedBy(func(r request.ListAuditEventsRequest) bool {
+		return r.Action != nil && *r.Action == audit.ActionLoginFailed && r.Size == 1
+	})).Return([]response.AuditEventResponse{
+		{Seq: 42, Action: audit.ActionLoginFailed, ActorType: string(auditactor.Anonymous)},
+	}, &next, nil)
+
+	// Act
+	resp, code := s.listEvents(token, "action=login_failed&size=1")
+
+	// Assert
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 1)
+	s.r.Equal(int64(42), resp.Data[0].Seq)
+	s.r.NotNil(resp.NextCursor)
+	s.r.Equal("41", *resp.NextCursor)
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_ForbiddenWithoutPermission() {
+	// Arrange - ADMIN holds other admin permissions but not audit:read
+	token := s.accessToken(uuid.New(), role.Admin)
+
+	// Act
+	_, code := s.listEvents(token, "")
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_Unauthenticated() {
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		AuditEventsEndpoint,
+		nil,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_InvalidCursor() {
+	// Arrange
+	s.useRealAuditManager()
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+
+	for _, cursor := range []string{"abc", "0", "-5"} {
+		// Act
+		_, code := s.listEvents(token, "cursor="+cursor)
+
+		// Assert
+		s.r.Equal(http.StatusBadRequest, code, "cursor %q", cursor)
+	}
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_InvalidSize() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+
+	// Act
+	_, code := s.listEvents(token, "size=500")
+
+	// Assert
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_Pagination() {
+	// Arrange - three events about a subject no other test touches
+	s.useRealAuditManager()
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+	subjectID := uuid.New()
+	for _, action := range []string{audit.ActionUserSuspended, audit.ActionUserUnsuspended, audit.ActionUserSuspended} {
+		s.managers.AuditLogger.Record(s.ctx, audit.Event{Action: action, SubjectID: &subjectID})
+	}
+	query := fmt.Sprintf("subject_id=%s&size=2", subjectID)
+
+	// Act - first page
+	first, code := s.listEvents(token, query)
+
+	// Assert - newest first with a cursor to the rest
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(first.Data, 2)
+	s.r.Greater(first.Data[0].Seq, first.Data[1].Seq)
+	s.r.Equal(audit.ActionUserSuspended, first.Data[0].Action)
+	s.r.NotNil(first.NextCursor)
+
+	// Act - last page
+	last, code := s.listEvents(token, query+"&cursor="+*first.NextCursor)
+
+	// Assert - the oldest event and no further cursor
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(last.Data, 1)
+	s.r.Less(last.Data[0].Seq, first.Data[1].Seq)
+	s.r.Equal(audit.ActionUserSuspended, last.Data[0].Action)
+	s.r.Nil(last.NextCursor)
+
+	// Act - a page that ends exactly at the last event
+	exact, code := s.listEvents(token, fmt.Sprintf("subject_id=%s&size=3", subjectID))
+
+	// Assert
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(exact.Data, 3)
+	s.r.Nil(exact.NextCursor)
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_EmptyPage() {
+	// Arrange
+	s.useRealAuditManager()
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+
+	// Act
+	resp, code := s.listEvents(token, fmt.Sprintf("subject_id=%s", uuid.New()))
+
+	// Assert
+	s.r.Equal(http.StatusOK, code)
+	s.r.NotNil(resp.Data)
+	s.r.Empty(resp.Data)
+	s.r.Nil(resp.NextCursor)
+}
+
+func (s *AuditControllerSuite) TestListAuditEvents_ResolvesActor() {
+	// Arrange - events recorded on behalf of a user and of a service API key
+	s.useRealAuditManager()
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+	subjectID := uuid.New()
+	adminID := uuid.New()
+	serviceUserID := uuid.Nil
+
+	userCtx := ctxutil.PrincipalKey.Set(s.ctx, ctxutil.Principal{UserID: &adminID, Role: string(role.Admin), Method: "jwt"})
+	s.managers.AuditLogger.Record(userCtx, audit.Event{Action: audit.ActionUserSuspended, SubjectID: &subjectID})
+	serviceCtx := ctxutil.PrincipalKey.Set(s.ctx, ctxutil.Principal{UserID: &serviceUserID, Role: "SERVICE", Method: "api_key", ServiceName: "billing"})
+	s.managers.AuditLogger.Record(serviceCtx, audit.Event{Action: audit.ActionUserUnsuspended, SubjectID: &subjectID})
+
+	// Act
+	resp, code := s.listEvents(token, fmt.Sprintf("subject_id=%s", subjectID))
+
+	// Assert
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 2)
+	service, user := resp.Data[0], resp.Data[1]
+	s.r.Equal(string(auditactor.Service), service.ActorType)
+	s.r.Nil(service.ActorID)
+	s.r.NotNil(service.ActorName)
+	s.r.Equal("billing", *service.ActorName)
+	s.r.Equal(string(auditactor.User), user.ActorType)
+	s.r.NotNil(user.ActorID)
+	s.r.Equal(adminID, *user.ActorID)
+
+	// Act - filter by actor
+	byActor, code := s.listEvents(token, fmt.Sprintf("actor_id=%s", adminID))
+
+	// Assert
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(byActor.Data, 1)
+	s.r.Equal(audit.ActionUserSuspended, byActor.Data[0].Action)
+}
+
+func (s *AuditControllerSuite) TestAuditEvents_AppendOnly() {
+	// Arrange
+	subjectID := uuid.New()
+	s.managers.AuditLogger.Record(s.ctx, audit.Event{Action: audit.ActionUserSuspended, SubjectID: &subjectID})
+
+	// Act
+	_, updateErr := s.resource.DB.PrimaryDb.ExecContext(s.ctx, "UPDATE audit_events SET action = 'user_restored' WHERE subject_id = $1", subjectID)
+	_, deleteErr := s.resource.DB.PrimaryDb.ExecContext(s.ctx, "DELETE FROM audit_events WHERE subject_id = $1", subjectID)
+
+	// Assert
+	s.r.Error(updateErr)
+	s.r.Error(deleteErr)
+	events, err := s.repositories.AuditEventRepository.FindAfter(s.ctx, 0, 1)
+	s.r.NoError(err)
+	s.r.NotEmpty(events)
+}
+
+func (s *AuditControllerSuite) TestVerifyAuditChain_Valid() {
+	// Arrange
+	s.useRealAuditManager()
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+	subjectID := uuid.New()
+	s.managers.AuditLogger.Record(s.ctx, audit.Event{Action: audit.ActionUserSuspended, SubjectID: &subjectID})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuditChainVerificationResponse]](
+		s.e,
+		http.MethodGet,
+		AuditEventsVerifyEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.True(resp.Data.Valid)
+	s.r.Positive(resp.Data.Checked)
+	s.r.Nil(resp.Data.BrokenAtSeq)
+}
+
+func (s *AuditControllerSuite) TestVerifyAuditChain_Broken() {
+	// Arrange
+	m := mocks.NewMockAuditManager(s.T())
+	s.managers.AuditManager = m
+
+	token := s.accessToken(uuid.New(), role.SuperAdmin)
+	seq := int64(7)
+	m.EXPECT().VerifyChain(mock.Anything).Return(&response.AuditChainVerificationResponse{Valid: false, Checked: 7, BrokenAtSeq: &seq}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuditChainVerificationResponse]](
+		s.e,
+		http.MethodGet,
+		AuditEventsVerifyEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.False(resp.Data.Valid)
+	s.r.Equal(int64(7), *resp.Data.BrokenAtSeq)
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"backend/service-platform/app/api/client/exception"
 	"backend/service-platform/app/api/client/request"
 	"backend/service-platform/app/api/client/response"
 	"backend/service-platform/app/database/constant/role"
@@ -17,6 +19,7 @@ import (
 	httputil "backend/service-platform/app/test/util"
 
 	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/password"
 
 	"github.com/google/uuid"
 )
@@ -27,6 +30,20 @@ const (
 	LogoutEndpoint       = "/api/v1/auth/logout"
 	RefreshTokenEndpoint = "/api/v1/auth/refresh-token"
 	MeEndpoint           = "/api/v1/auth/me"
+
+	VerifyEmailEndpoint        = "/api/v1/auth/verify-email"
+	ResendVerificationEndpoint = "/api/v1/auth/resend-verification"
+	ForgotPasswordEndpoint     = "/api/v1/auth/password/forgot"
+	ResetPasswordEndpoint      = "/api/v1/auth/password/reset"
+
+	MfaVerifyEndpoint  = "/api/v1/auth/mfa/verify"
+	MfaEnrollEndpoint  = "/api/v1/auth/mfa/enroll"
+	MfaConfirmEndpoint = "/api/v1/auth/mfa/confirm"
+
+	SendPhoneOtpEndpoint   = "/api/v1/auth/phone/send-otp"
+	VerifyPhoneOtpEndpoint = "/api/v1/auth/phone/verify"
+
+	AdminUnlockAccountEndpoint = "/api/v1/admin/users/%s/unlock"
 )
 
 type AuthControllerSuite struct {
@@ -118,12 +135,19 @@ func (s *AuthControllerSuite) TestRegister_InvalidEmail() {
 	s.r.Equal("Invalid data", resp.Message)
 }
 
-func (s *AuthControllerSuite) TestRegister_ShortPassword() {
+func (s *AuthControllerSuite) TestRegister_PasswordPolicy() {
 	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
 	req := request.RegisterRequest{
 		Email:    "user@example.com",
 		Password: "short",
 	}
+	m.EXPECT().Register(mock.Anything, req).Return(&manager.PasswordPolicyError{Violations: []password.Violation{
+		{Rule: password.RuleMinLength, Message: "must be at least 8 characters"},
+		{Rule: password.RuleBreached, Message: "appears in a known data breach"},
+	}})
 
 	// Act
 	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
@@ -137,8 +161,11 @@ func (s *AuthControllerSuite) TestRegister_ShortPassword() {
 	// Assert
 	s.r.NoError(err)
 	s.r.Equal(http.StatusBadRequest, code)
-	s.r.Equal(http.StatusBadRequest, resp.Code)
-	s.r.Equal("Invalid data", resp.Message)
+	s.r.Equal(manager.ErrPasswordPolicy.Error(), resp.Message)
+	s.r.Equal([]response.ErrorDetail{
+		{Key: password.RuleMinLength, Field: "password", Message: "must be at least 8 characters"},
+		{Key: password.RuleBreached, Field: "password", Message: "appears in a known data breach"},
+	}, resp.ErrorDetails)
 }
 
 // Login Tests
@@ -219,6 +246,32 @@ func (s *AuthControllerSuite) TestLogin_InvalidCredentials() {
 	s.r.Equal("Invalid credentials", resp.Message)
 }
 
+func (s *AuthControllerSuite) TestLogin_AccountDisabled() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.AuthUserRequest{
+		Email:    "suspended@example.com",
+		Password: "password123",
+	}
+	m.EXPECT().Login(mock.Anything, req).Return(nil, manager.ErrAccountDisabled)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		LoginEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+	s.r.Equal("Account is disabled", resp.Message)
+}
+
 func (s *AuthControllerSuite) TestLogin_InvalidRequestBody() {
 	// Arrange
 	invalidReq := map[string]interface{}{
@@ -638,7 +691,7 @@ func (s *AuthControllerSuite) TestMe_Success() {
 	phoneVerified := false
 	lastLoginAt := time.Now()
 
-	accessToken, err := j.GenerateAccessToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt)
+	accessToken, err := j.GenerateAccessToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt, nil)
 	s.r.NoError(err)
 
 	// Act
@@ -774,3 +827,725 @@ func (s *AuthControllerSuite) TestRefreshToken_ValidRequestWithLongToken() {
 	s.r.Equal(http.StatusOK, code)
 	s.r.Equal("success", resp.Message)
 }
+
+// Email Verification Tests
+
+func (s *AuthControllerSuite) TestVerifyEmail_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.VerifyEmailRequest{Token: "verification-token"}
+	m.EXPECT().VerifyEmail(mock.Anything, req).Return(nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		VerifyEmailEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("success", resp.Message)
+	s.r.Equal("verified", resp.Data)
+}
+
+func (s *AuthControllerSuite) TestVerifyEmail_ExpiredToken() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.VerifyEmailRequest{Token: "expired-token"}
+	m.EXPECT().VerifyEmail(mock.Anything, req).Return(manager.ErrVerificationTokenExpired)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		VerifyEmailEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal("verification token has expired", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestVerifyEmail_MissingToken() {
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		VerifyEmailEndpoint,
+		nil,
+		request.VerifyEmailRequest{},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal("Invalid data", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestResendVerification_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.ResendVerificationRequest{Email: "user@example.com"}
+	m.EXPECT().ResendVerification(mock.Anything, req).Return(nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		ResendVerificationEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("verification email sent", resp.Data)
+}
+
+// Password Reset Tests
+
+func (s *AuthControllerSuite) TestForgotPassword_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.ForgotPasswordRequest{Email: "user@example.com"}
+	m.EXPECT().
+		ForgotPassword(mock.Anything, mock.MatchedBy(func(r request.ForgotPasswordRequest) bool {
+			return r.Email == req.Email && r.IPAddress != ""
+		})).
+		Return(nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		ForgotPasswordEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("success", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestForgotPassword_RateLimited() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.ForgotPasswordRequest{Email: "user@example.com"}
+	m.EXPECT().ForgotPassword(mock.Anything, mock.Anything).Return(&manager.RateLimitError{RetryAfter: 30 * time.Second})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ForgotPasswordEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+	s.r.Equal("Too many requests", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestResetPassword_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.ResetPasswordRequest{Token: "reset-token", Password: "newpassword123"}
+	m.EXPECT().ResetPassword(mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		ResetPasswordEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("password reset", resp.Data)
+}
+
+func (s *AuthControllerSuite) TestResetPassword_InvalidToken() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.ResetPasswordRequest{Token: "used-token", Password: "newpassword123"}
+	m.EXPECT().ResetPassword(mock.Anything, mock.Anything).Return(manager.ErrInvalidResetToken)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ResetPasswordEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal("invalid reset password token", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestResetPassword_PasswordPolicy() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+	m.EXPECT().ResetPassword(mock.Anything, mock.Anything).Return(&manager.PasswordPolicyError{Violations: []password.Violation{
+		{Rule: password.RulePersonalInfo, Message: "must not contain your email or username"},
+	}})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ResetPasswordEndpoint,
+		nil,
+		request.ResetPasswordRequest{Token: "reset-token", Password: "user-example-password"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Len(resp.ErrorDetails, 1)
+	s.r.Equal(password.RulePersonalInfo, resp.ErrorDetails[0].Key)
+	s.r.Equal("password", resp.ErrorDetails[0].Field)
+}
+
+// MFA Tests
+
+func (s *AuthControllerSuite) TestLogin_MfaRequired() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	username := "admin@example.com"
+	req := request.AuthUserRequest{Email: username, Password: "password123"}
+	m.EXPECT().Login(mock.Anything, req).Return(&response.AuthResponse{
+		Username:    &username,
+		TokenType:   jwt.TokenTypeBearer,
+		MfaRequired: true,
+		MfaToken:    "challenge-token",
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		LoginEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.True(resp.Data.MfaRequired)
+	s.r.Equal("challenge-token", resp.Data.MfaToken)
+	s.r.Empty(resp.Data.AccessToken)
+}
+
+func (s *AuthControllerSuite) TestMfaVerify_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.MfaVerifyRequest{MfaToken: "challenge-token", Code: "123456"}
+	m.EXPECT().VerifyMfa(mock.Anything, req).Return(&response.AuthResponse{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		TokenType:    jwt.TokenTypeBearer,
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		MfaVerifyEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("access-token", resp.Data.AccessToken)
+	s.r.Empty(resp.Data.RefreshToken)
+}
+
+func (s *AuthControllerSuite) TestMfaVerify_InvalidCode() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.MfaVerifyRequest{MfaToken: "challenge-token", Code: "000000"}
+	m.EXPECT().VerifyMfa(mock.Anything, req).Return(nil, manager.ErrInvalidMfaCode)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		MfaVerifyEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+	s.r.Equal("invalid mfa code", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestMfaVerify_TooManyAttempts() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.MfaVerifyRequest{MfaToken: "challenge-token", Code: "000000"}
+	m.EXPECT().VerifyMfa(mock.Anything, req).Return(nil, manager.ErrMfaAttemptsExceeded)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		MfaVerifyEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+}
+
+func (s *AuthControllerSuite) TestMfaEnroll_Unauthenticated() {
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		MfaEnrollEndpoint,
+		nil,
+		request.MfaEnrollRequest{},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *AuthControllerSuite) TestMfaConfirm_WithChallengeCompletesLogin() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.MfaConfirmRequest{MfaToken: "enrollment-token", Code: "123456"}
+	m.EXPECT().ConfirmMfa(mock.Anything, req).Return(&response.MfaConfirmResponse{
+		RecoveryCodes: []string{"abcde-fghij"},
+		Auth:          &response.AuthResponse{AccessToken: "access-token", RefreshToken: "refresh-token"},
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.MfaConfirmResponse]](
+		s.e,
+		http.MethodPost,
+		MfaConfirmEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal([]string{"abcde-fghij"}, resp.Data.RecoveryCodes)
+	s.r.NotNil(resp.Data.Auth)
+	s.r.Empty(resp.Data.Auth.RefreshToken)
+}
+
+func (s *AuthControllerSuite) TestRefreshToken_ReusedToken() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.RefreshTokenRequest{
+		RefreshToken: "rotated_refresh_token",
+	}
+	m.EXPECT().RefreshToken(mock.Anything, req).Return(nil, manager.ErrRefreshTokenReused)
+
+	// Seed cookie
+	httputil.SetCookie("refresh_token", req.RefreshToken, 3600)
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		RefreshTokenEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+	s.r.Equal(manager.ErrRefreshTokenReused.Error(), resp.Message)
+	c := httputil.GetCookie("refresh_token")
+	s.r.True(c == nil || c.Value == "")
+}
+
+// Brute-force protection Tests
+
+func (s *AuthControllerSuite) TestLogin_RateLimited() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.AuthUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+	m.EXPECT().Login(mock.Anything, req).Return(nil, &manager.RateLimitError{RetryAfter: time.Minute})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		LoginEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+	s.r.Equal(int(exception.ErrorCodeCodeRateLimitExceeded), resp.Code)
+	s.r.Equal("Too many requests", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestLogin_AccountLocked() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.AuthUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+	m.EXPECT().Login(mock.Anything, req).Return(nil, &manager.RateLimitError{RetryAfter: time.Minute, Reason: manager.ErrAccountLocked})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		LoginEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+	s.r.Equal(int(exception.ErrorCodeCodeRateLimitExceeded), resp.Code)
+	s.r.Equal("Account is temporarily locked", resp.Message)
+}
+
+func (s *AuthControllerSuite) TestRegister_RateLimited() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	req := request.RegisterRequest{
+		Email:    "newuser@example.com",
+		Password: "password123",
+	}
+	m.EXPECT().Register(mock.Anything, req).Return(&manager.RateLimitError{RetryAfter: time.Minute})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		RegisterEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+	s.r.Equal(int(exception.ErrorCodeCodeRateLimitExceeded), resp.Code)
+}
+
+func (s *AuthControllerSuite) TestAdminUnlockAccount_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	targetID := uuid.New()
+	token := s.accessToken(role.Admin)
+	m.EXPECT().UnlockAccount(mock.Anything, targetID).Return(nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUnlockAccountEndpoint, targetID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("account unlocked", resp.Data)
+}
+
+func (s *AuthControllerSuite) TestAdminUnlockAccount_NotFound() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	targetID := uuid.New()
+	token := s.accessToken(role.Admin)
+	m.EXPECT().UnlockAccount(mock.Anything, targetID).Return(manager.ErrUserNotFound)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUnlockAccountEndpoint, targetID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
+
+func (s *AuthControllerSuite) TestAdminUnlockAccount_ForbiddenForUser() {
+	// Arrange
+	token := s.accessToken(role.User)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUnlockAccountEndpoint, uuid.NewString()),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *AuthControllerSuite) TestSendPhoneOtp_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	token := s.accessToken(role.User)
+	expiresAt := time.Now().Add(5 * time.Minute)
+	m.EXPECT().SendPhoneOtp(mock.Anything, mock.MatchedBy(func(r request.SendPhoneOtpRequest) bool {
+		return r.PhoneNumber == "+14155550100" && r.UserID != uuid.Nil
+	})).Return(&response.PhoneOtpResponse{PhoneNumber: "+14155550100", ExpiresAt: expiresAt}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.PhoneOtpResponse]](
+		s.e,
+		http.MethodPost,
+		SendPhoneOtpEndpoint,
+		&token,
+		request.SendPhoneOtpRequest{PhoneNumber: "+14155550100"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("+14155550100", resp.Data.PhoneNumber)
+}
+
+func (s *AuthControllerSuite) TestSendPhoneOtp_InvalidNumber() {
+	// Arrange
+	token := s.accessToken(role.User)
+
+	// Act - missing the leading plus and country code
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		SendPhoneOtpEndpoint,
+		&token,
+		request.SendPhoneOtpRequest{PhoneNumber: "0415555010"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *AuthControllerSuite) TestSendPhoneOtp_Unauthenticated() {
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		SendPhoneOtpEndpoint,
+		nil,
+		request.SendPhoneOtpRequest{PhoneNumber: "+14155550100"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *AuthControllerSuite) TestSendPhoneOtp_RateLimited() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	token := s.accessToken(role.User)
+	m.EXPECT().SendPhoneOtp(mock.Anything, mock.Anything).Return(nil, &manager.RateLimitError{RetryAfter: 45 * time.Second})
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		SendPhoneOtpEndpoint,
+		&token,
+		request.SendPhoneOtpRequest{},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+}
+
+func (s *AuthControllerSuite) TestSendPhoneOtp_NumberTaken() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	token := s.accessToken(role.User)
+	m.EXPECT().SendPhoneOtp(mock.Anything, mock.Anything).Return(nil, manager.ErrPhoneNumberExists)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		SendPhoneOtpEndpoint,
+		&token,
+		request.SendPhoneOtpRequest{PhoneNumber: "+14155550100"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *AuthControllerSuite) TestVerifyPhoneOtp_Success() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	token := s.accessToken(role.User)
+	phoneNumber := "+14155550100"
+	m.EXPECT().VerifyPhoneOtp(mock.Anything, mock.MatchedBy(func(r request.VerifyPhoneOtpRequest) bool {
+		return r.Code == "123456"
+	})).Return(&response.UserResponse{PhoneNumber: &phoneNumber, PhoneVerified: true}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.UserResponse]](
+		s.e,
+		http.MethodPost,
+		VerifyPhoneOtpEndpoint,
+		&token,
+		request.VerifyPhoneOtpRequest{Code: "123456"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.True(resp.Data.PhoneVerified)
+}
+
+func (s *AuthControllerSuite) TestVerifyPhoneOtp_InvalidCode() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	token := s.accessToken(role.User)
+	m.EXPECT().VerifyPhoneOtp(mock.Anything, mock.Anything).Return(nil, exception.ErrInvalidOtpCode)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		VerifyPhoneOtpEndpoint,
+		&token,
+		request.VerifyPhoneOtpRequest{Code: "000000"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal(int(exception.ErrorCodeInvalidOtpCode), resp.Code)
+}
+
+func (s *AuthControllerSuite) TestVerifyPhoneOtp_TooManyAttempts() {
+	// Arrange
+	m := mocks.NewMockAuthManager(s.T())
+	s.managers.AuthManager = m
+
+	token := s.accessToken(role.User)
+	m.EXPECT().VerifyPhoneOtp(mock.Anything, mock.Anything).Return(nil, &manager.RateLimitError{Reason: manager.ErrPhoneOtpAttemptsExceeded})
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		VerifyPhoneOtpEndpoint,
+		&token,
+		request.VerifyPhoneOtpRequest{Code: "000000"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+}
+
+func (s *AuthControllerSuite) accessToken(userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	userID := uuid.New()
+	username := "admin@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
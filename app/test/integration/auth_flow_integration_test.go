@@ -1,14 +1,23 @@
 package integration
 
 import (
+	"database/sql"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 
 	"backend/service-platform/app/api/client/request"
 	"backend/service-platform/app/api/client/response"
 	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/bcrypt"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/password"
 	httputil "backend/service-platform/app/test/util"
 )
 
@@ -447,3 +456,622 @@ func (s *AuthFlowIntegrationSuite) TestAuthFlow_EdgeCases() {
 	s.r.Equal(http.StatusOK, loginCode)
 	s.r.Equal("success", loginResp.Message)
 }
+
+// TestAuthFlow_RefreshTokenReuse replays a rotated refresh token and expects the whole family to be revoked
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_RefreshTokenReuse() {
+	registerReq := request.RegisterRequest{
+		Email:    "reuse@example.com",
+		Password: "password123",
+	}
+	_, registerCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, registerCode)
+
+	_, loginCode, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, loginCode)
+	first := httputil.GetCookie("refresh_token")
+	s.Require().NotNil(first)
+	firstToken := first.Value
+
+	// Rotate once
+	_, refreshCode, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/refresh-token",
+		nil,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, refreshCode)
+	second := httputil.GetCookie("refresh_token")
+	s.Require().NotNil(second)
+	secondToken := second.Value
+	s.r.NotEqual(firstToken, secondToken)
+
+	// Replay the rotated token
+	httputil.SetCookie("refresh_token", firstToken, 3600)
+	reuseResp, reuseCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/refresh-token",
+		nil,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, reuseCode)
+	s.r.Equal("refresh token reuse detected", reuseResp.Message)
+
+	// The legitimate successor is revoked with the family
+	httputil.SetCookie("refresh_token", secondToken, 3600)
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/refresh-token",
+		nil,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+// TestAuthFlow_LogoutDeniesAccessToken checks the bearer token stops working right after logout
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_LogoutDeniesAccessToken() {
+	registerReq := request.RegisterRequest{
+		Email:    "logout@example.com",
+		Password: "password123",
+	}
+	_, registerCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, registerCode)
+
+	loginResp, loginCode, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, loginCode)
+	accessToken := loginResp.Data.AccessToken
+
+	_, logoutCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/logout",
+		&accessToken,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, logoutCode)
+
+	_, meCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		"/api/v1/auth/me",
+		&accessToken,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, meCode)
+}
+
+// TestAuthFlow_LockoutAfterFailedLogins locks the account after consecutive wrong passwords
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_LockoutAfterFailedLogins() {
+	registerReq := request.RegisterRequest{
+		Email:    "lockout@example.com",
+		Password: "password123",
+	}
+	_, registerCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, registerCode)
+
+	maxAttempts := s.resource.Config.LoginProtectionConfig.MaxFailedAttempts
+	wrongReq := request.AuthUserRequest{Email: registerReq.Email, Password: "wrong-password"}
+	for i := 1; i < maxAttempts; i++ {
+		_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+			s.e,
+			http.MethodPost,
+			"/api/v1/auth/login",
+			nil,
+			wrongReq,
+		)
+		s.r.NoError(err)
+		s.r.Equal(http.StatusUnauthorized, code)
+	}
+
+	// The last allowed failure locks the account
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		wrongReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+
+	// Even the right password is refused while locked
+	lockedResp, lockedCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, lockedCode)
+	s.r.Equal("Account is temporarily locked", lockedResp.Message)
+}
+
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_LoginUpgradesLegacyHash() {
+	registerReq := request.RegisterRequest{
+		Email:    "legacy-hash@example.com",
+		Password: "password123",
+	}
+	_, registerCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, registerCode)
+
+	// Store the password the way it was hashed before argon2id
+	u, err := s.repositories.UserRepository.FindByEmail(s.ctx, registerReq.Email)
+	s.r.NoError(err)
+	legacy := bcrypt.NewBcrypt(s.resource.Config.BcryptConfig.Cost)
+	legacyHash, err := legacy.HashPassword(registerReq.Password)
+	s.r.NoError(err)
+	s.r.NoError(s.repositories.UserRepository.UpdatePassword(s.ctx, u.ID, legacyHash))
+
+	loginReq := request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password}
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	u, err = s.repositories.UserRepository.FindByEmail(s.ctx, registerReq.Email)
+	s.r.NoError(err)
+	algorithm, ok := bcrypt.DetectAlgorithm(u.Password)
+	s.r.True(ok)
+	s.r.Equal(bcrypt.AlgorithmArgon2id, algorithm)
+
+	// The upgraded hash keeps accepting the same password
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+}
+
+// TestAuthFlow_RegisterPasswordPolicy registers with passwords the policy of config-test.yaml refuses
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_RegisterPasswordPolicy() {
+	tests := []struct {
+		name     string
+		password string
+		rules    []string
+	}{
+		// Listed in the dataset of app/test/testdata/pwned_passwords
+		{name: "breached", password: "Tr0ub4dor&3", rules: []string{password.RuleBreached}},
+		{name: "contains the email", password: "policy-user-2024", rules: []string{password.RulePersonalInfo}},
+		{name: "repeated characters", password: "passssword", rules: []string{password.RuleRepeatedChars}},
+		{name: "too short", password: "pass", rules: []string{password.RuleMinLength}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+				s.e,
+				http.MethodPost,
+				"/api/v1/auth/register",
+				nil,
+				request.RegisterRequest{Email: "policy-user@example.com", Password: tt.password},
+			)
+			s.r.NoError(err)
+			s.r.Equal(http.StatusBadRequest, code)
+
+			var rules []string
+			for _, detail := range resp.ErrorDetails {
+				s.r.Equal("password", detail.Field)
+				rules = append(rules, detail.Key)
+			}
+			s.r.Equal(tt.rules, rules)
+		})
+	}
+
+	_, err := s.repositories.UserRepository.FindByEmail(s.ctx, "policy-user@example.com")
+	s.r.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_DeactivateAndReactivate() {
+	registerReq := request.RegisterRequest{
+		Email:    "deactivate@example.com",
+		Password: "password123",
+	}
+	_, registerCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, registerCode)
+
+	loginReq := request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password}
+	loginResp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/me/deactivate",
+		&loginResp.Data.AccessToken,
+		request.DeactivateAccountRequest{CurrentPassword: registerReq.Password},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	// The access token is revoked along with the sessions
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		"/api/v1/auth/me",
+		&loginResp.Data.AccessToken,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+
+	deactivatedResp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+	s.r.Equal("account is deactivated", deactivatedResp.Message)
+
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/reactivate",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+}
+
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_DeleteAccount() {
+	registerReq := request.RegisterRequest{
+		Email:    "delete-me@example.com",
+		Password: "password123",
+	}
+	_, registerCode, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, registerCode)
+
+	loginReq := request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password}
+	loginResp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	deleteResp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AccountDeletionResponse]](
+		s.e,
+		http.MethodDelete,
+		"/api/v1/auth/me",
+		&loginResp.Data.AccessToken,
+		request.DeleteAccountRequest{CurrentPassword: registerReq.Password},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusAccepted, code)
+	gracePeriod := s.resource.Config.AccountLifecycleConfig.DeletionGracePeriod
+	s.r.WithinDuration(time.Now().Add(gracePeriod), deleteResp.Data.DeletionScheduledAt, time.Minute)
+
+	pendingResp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+	s.r.Equal("account is scheduled for deletion", pendingResp.Message)
+
+	// Not due yet, the purge leaves the account alone
+	u, err := s.repositories.UserRepository.FindByEmail(s.ctx, registerReq.Email)
+	s.r.NoError(err)
+	s.r.ErrorIs(s.repositories.UserRepository.Anonymize(s.ctx, u.ID), sql.ErrNoRows)
+
+	// Once the grace period is over the personal data is erased
+	_, err = s.repositories.UserRepository.Reactivate(s.ctx, u.ID)
+	s.r.NoError(err)
+	_, err = s.repositories.UserRepository.ScheduleDeletion(s.ctx, u.ID, time.Now().Add(-time.Minute))
+	s.r.NoError(err)
+	s.r.NoError(s.repositories.UserRepository.Anonymize(s.ctx, u.ID))
+
+	purged, err := s.repositories.UserRepository.FindByIDWithDeleted(s.ctx, u.ID)
+	s.r.NoError(err)
+	s.r.Nil(purged.Email)
+	s.r.Equal("deleted-"+u.ID.String(), purged.Username)
+	s.r.NotNil(purged.DeletedAt)
+	s.r.NotNil(purged.PurgedAt)
+	s.r.Equal(userstatus.Purged, purged.Status)
+	sessions, err := s.repositories.SessionRepository.ListAllByUser(s.ctx, u.ID)
+	s.r.NoError(err)
+	s.r.Empty(sessions)
+
+	// A purged account is not restored like a soft-deleted one
+	adminID := uuid.New()
+	adminName := "admin@example.com"
+	adminRole := string(role.SuperAdmin)
+	verified := true
+	now := time.Now()
+	adminToken, err := jwt.NewJwt(s.resource.Config.JwtConfig).GenerateAccessToken(&adminID, &adminName, &adminName, nil, &adminRole, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf("/api/v1/admin/users/%s/restore", u.ID),
+		&adminToken.Token,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+	_, err = s.repositories.UserRepository.Restore(s.ctx, u.ID)
+	s.r.ErrorIs(err, sql.ErrNoRows)
+
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		loginReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+// login registers the account and signs in, the refresh token is left in the cookie jar
+func (s *AuthFlowIntegrationSuite) login(email string) (*entity.User, string) {
+	registerReq := request.RegisterRequest{Email: email, Password: "password123"}
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/register",
+		nil,
+		registerReq,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	loginResp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		request.AuthUserRequest{Email: registerReq.Email, Password: registerReq.Password},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	u, err := s.repositories.UserRepository.FindByEmail(s.ctx, email)
+	s.r.NoError(err)
+	return u, loginResp.Data.AccessToken
+}
+
+func (s *AuthFlowIntegrationSuite) refresh() (response.GeneralResponse[response.AuthResponse], int) {
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/refresh-token",
+		nil,
+		nil,
+	)
+	s.r.NoError(err)
+	return resp, code
+}
+
+// TestAuthFlow_RoleChangeTakesEffect checks a demoted or promoted user cannot keep acting with the old role
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_RoleChangeTakesEffect() {
+	u, accessToken := s.login("role-change@example.com")
+	s.r.Zero(u.TokenVersion)
+
+	adminID := uuid.New()
+	adminName := "admin@example.com"
+	adminRole := string(role.SuperAdmin)
+	verified := true
+	now := time.Now()
+	adminToken, err := jwt.NewJwt(s.resource.Config.JwtConfig).GenerateAccessToken(&adminID, &adminName, &adminName, nil, &adminRole, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[response.UserResponse]](
+		s.e,
+		http.MethodPut,
+		fmt.Sprintf("/api/v1/admin/users/%s/role", u.ID),
+		&adminToken.Token,
+		request.UpdateUserRoleRequest{Role: role.Admin},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	// The access token still names the old role and is refused right away
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		"/api/v1/auth/me",
+		&accessToken,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+
+	// A refresh issues tokens with the new role and version
+	refreshResp, code := s.refresh()
+	s.r.Equal(http.StatusOK, code)
+	claims, err := jwt.NewJwt(s.resource.Config.JwtConfig).ValidateToken(refreshResp.Data.AccessToken)
+	s.r.NoError(err)
+	s.r.Equal(int64(1), claims.Version())
+
+	me, code, err := httputil.RequestHTTP[response.GeneralResponse[response.MeResponse]](
+		s.e,
+		http.MethodGet,
+		"/api/v1/auth/me",
+		&refreshResp.Data.AccessToken,
+		nil,
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(role.Admin, me.Data.Role)
+}
+
+// TestAuthFlow_RefreshRefusedForDisabledAccount checks a refresh re-reads the account instead of trusting the session
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_RefreshRefusedForDisabledAccount() {
+	u, _ := s.login("refresh-disabled@example.com")
+	_, err := s.repositories.UserRepository.UpdateStatus(s.ctx, u.ID, userstatus.Disabled)
+	s.r.NoError(err)
+
+	resp, code := s.refresh()
+
+	s.r.Equal(http.StatusForbidden, code)
+	s.r.Equal("Account is disabled", resp.Message)
+}
+
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_RefreshRefusedForDeactivatedAccount() {
+	u, _ := s.login("refresh-deactivated@example.com")
+	_, err := s.repositories.UserRepository.Deactivate(s.ctx, u.ID)
+	s.r.NoError(err)
+
+	_, code := s.refresh()
+
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_RefreshRefusedForDeletedAccount() {
+	u, _ := s.login("refresh-deleted@example.com")
+	_, err := s.repositories.UserRepository.DeleteByID(s.ctx, u.ID)
+	s.r.NoError(err)
+
+	_, code := s.refresh()
+
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+// TestAuthFlow_WrongCurrentPasswordLocksAccount checks a stolen session cannot be used to guess the password
+func (s *AuthFlowIntegrationSuite) TestAuthFlow_WrongCurrentPasswordLocksAccount() {
+	_, accessToken := s.login("reauth-lockout@example.com")
+
+	maxAttempts := s.resource.Config.LoginProtectionConfig.MaxFailedAttempts
+	for i := 1; i < maxAttempts; i++ {
+		_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+			s.e,
+			http.MethodPut,
+			"/api/v1/auth/me/email",
+			&accessToken,
+			request.ChangeEmailRequest{Email: "reauth-lockout-new@example.com", CurrentPassword: "wrong-password"},
+		)
+		s.r.NoError(err)
+		s.r.Equal(http.StatusBadRequest, code)
+	}
+
+	// The last allowed failure locks the account, whichever endpoint checked the password
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		"/api/v1/auth/me/password",
+		&accessToken,
+		request.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "another-password-123"},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+
+	// Neither the right password on the profile nor a login gets through while locked
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		"/api/v1/auth/me/password",
+		&accessToken,
+		request.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "another-password-123"},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		"/api/v1/auth/login",
+		nil,
+		request.AuthUserRequest{Email: "reauth-lockout@example.com", Password: "password123"},
+	)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+}
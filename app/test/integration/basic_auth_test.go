@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	gobcrypt "golang.org/x/crypto/bcrypt"
+
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/bcrypt"
+	"backend/service-platform/app/pkg/rbac"
+)
+
+// BasicAuthSuite checks configured basic credentials against the users they are bound to
+type BasicAuthSuite struct {
+	RouterSuite
+}
+
+func TestBasicAuthSuite(t *testing.T) {
+	suite.Run(t, new(BasicAuthSuite))
+}
+
+func (s *BasicAuthSuite) createUser(username string, userRole role.Role) *entity.User {
+	email := username + "@example.com"
+	u, err := s.repositories.UserRepository.Insert(s.ctx, &entity.User{
+		Username:      username,
+		Email:         &email,
+		Password:      "not-a-hash",
+		Status:        userstatus.Verified,
+		Role:          userRole,
+		EmailVerified: true,
+	})
+	s.r.NoError(err)
+	return u
+}
+
+// middlewareFor enables basic auth with a single credential bound to the user
+func (s *BasicAuthSuite) middlewareFor(u *entity.User, username string, password string) *middleware.Middleware {
+	hasher := bcrypt.NewBcrypt(gobcrypt.MinCost)
+	hash, err := hasher.HashPassword(password)
+	s.r.NoError(err)
+
+	res := s.resource
+	res.Config.AuthenticationConfig = config.AuthenticationConfig{
+		Methods: []string{middleware.AuthMethodBasic},
+		BasicAuth: config.BasicAuthConfig{
+			Enabled:     true,
+			Credentials: []config.BasicAuthCredential{{Username: username, PasswordHash: hash, UserID: u.ID.String()}},
+		},
+	}
+	return middleware.NewMiddleware(res)
+}
+
+// run sends the credentials through authentication and the permission check, it returns the context the handler saw
+func (s *BasicAuthSuite) run(m *middleware.Middleware, username string, password string, permission string) (echo.Context, int) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	ctx := s.e.NewContext(req, httptest.NewRecorder())
+
+	var seen echo.Context
+	err := m.RequireAuth()(m.RequirePermission(permission)(func(c echo.Context) error {
+		seen = c
+		return nil
+	}))(ctx)
+	if err == nil {
+		return seen, http.StatusOK
+	}
+	var httpErr *echo.HTTPError
+	s.r.True(errors.As(err, &httpErr))
+	return nil, httpErr.Code
+}
+
+func (s *BasicAuthSuite) TestBasicAuth_ActsAsBoundUser() {
+	// Arrange
+	admin := s.createUser("basic-auth-admin", role.Admin)
+	m := s.middlewareFor(admin, "reporting", "reporting-password")
+
+	// Act
+	ctx, code := s.run(m, "reporting", "reporting-password", rbac.UsersRead)
+
+	// Assert - the user's identity and role, not a made up one
+	s.r.Equal(http.StatusOK, code)
+	userID, ok := middleware.CurrentUserID(ctx)
+	s.r.True(ok)
+	s.r.Equal(admin.ID, userID)
+	s.r.Equal(string(role.Admin), middleware.CurrentRole(ctx))
+}
+
+func (s *BasicAuthSuite) TestBasicAuth_RoleLimitsPermissions() {
+	// Arrange
+	user := s.createUser("basic-auth-user", role.User)
+	m := s.middlewareFor(user, "reporting", "reporting-password")
+
+	// Act
+	_, code := s.run(m, "reporting", "reporting-password", rbac.UsersRead)
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *BasicAuthSuite) TestBasicAuth_Refused() {
+	// Arrange
+	admin := s.createUser("basic-auth-refused-admin", role.Admin)
+	m := s.middlewareFor(admin, "reporting", "reporting-password")
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{name: "wrong password", username: "reporting", password: "admin-password"},
+		{name: "unknown username", username: "admin", password: "reporting-password"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			_, code := s.run(m, tt.username, tt.password, rbac.UsersRead)
+
+			// Assert
+			s.r.Equal(http.StatusUnauthorized, code)
+		})
+	}
+}
+
+func (s *BasicAuthSuite) TestBasicAuth_SuspendedUser() {
+	// Arrange
+	admin := s.createUser("basic-auth-suspended-admin", role.Admin)
+	m := s.middlewareFor(admin, "reporting", "reporting-password")
+	_, err := s.repositories.UserRepository.UpdateStatus(s.ctx, admin.ID, userstatus.Disabled)
+	s.r.NoError(err)
+
+	// Act
+	_, code := s.run(m, "reporting", "reporting-password", rbac.UsersRead)
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, code)
+}
@@ -0,0 +1,220 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/jwt"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const AdminUserImpersonateEndpoint = "/api/v1/admin/users/%s/impersonate"
+
+// ImpersonationSuite runs the real managers end to end
+type ImpersonationSuite struct {
+	RouterSuite
+}
+
+func TestImpersonationSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationSuite))
+}
+
+func (s *ImpersonationSuite) createUser(username string, userRole role.Role) *entity.User {
+	email := username + "@example.com"
+	u, err := s.repositories.UserRepository.Insert(s.ctx, &entity.User{
+		Username:      username,
+		Email:         &email,
+		Password:      "not-a-hash",
+		Status:        userstatus.Verified,
+		Role:          userRole,
+		EmailVerified: true,
+	})
+	s.r.NoError(err)
+	return u
+}
+
+func (s *ImpersonationSuite) accessToken(u *entity.User) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	roleStr := string(u.Role)
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&u.ID, &u.Username, u.Email, nil, &roleStr, &u.EmailVerified, &u.PhoneVerified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *ImpersonationSuite) impersonate(token string, userID uuid.UUID) (response.GeneralResponse[response.ImpersonationResponse], int) {
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.ImpersonationResponse]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserImpersonateEndpoint, userID),
+		&token,
+		nil,
+	)
+	s.r.NoError(err)
+	return resp, code
+}
+
+func (s *ImpersonationSuite) TestImpersonate_ActsAsUser() {
+	// Arrange
+	admin := s.createUser("impersonation-admin", role.SuperAdmin)
+	user := s.createUser("impersonation-user", role.User)
+
+	// Act
+	resp, code := s.impersonate(s.accessToken(admin), user.ID)
+
+	// Assert - an access token for the user and nothing to refresh it with
+	s.r.Equal(http.StatusOK, code)
+	s.r.NotEmpty(resp.Data.AccessToken)
+	s.r.Equal(user.ID, resp.Data.User.ID)
+	s.r.LessOrEqual(resp.Data.ExpiresIn, int64(s.resource.Config.JwtConfig.ImpersonationExpiration.Seconds()))
+	s.r.Nil(httputil.GetCookie("refresh_token"))
+
+	claims, err := jwt.NewJwt(s.resource.Config.JwtConfig).ValidateToken(resp.Data.AccessToken)
+	s.r.NoError(err)
+	s.r.Nil(claims.RefreshTokenBase64)
+	s.r.NotNil(claims.Act)
+	s.r.Equal(admin.ID, *claims.Act.UserID)
+
+	// Act - the app as the user sees it
+	token := resp.Data.AccessToken
+	me, code, err := httputil.RequestHTTP[response.GeneralResponse[response.MeResponse]](
+		s.e,
+		http.MethodGet,
+		"/api/v1/auth/me",
+		&token,
+		nil,
+	)
+
+	// Assert - both identities are visible
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(user.ID, me.Data.ID)
+	s.r.NotNil(me.Data.ImpersonatorID)
+	s.r.Equal(admin.ID, *me.Data.ImpersonatorID)
+
+	// Assert - the grant and the request are both in the audit log
+	started := audit.ActionImpersonationStarted
+	events, err := s.repositories.AuditEventRepository.FindMany(s.ctx, repository.AuditEventFilter{Action: &started, SubjectID: &user.ID}, 10)
+	s.r.NoError(err)
+	s.r.Len(events, 1)
+	s.r.Equal(admin.ID, *events[0].ActorID)
+
+	impersonated := audit.ActionImpersonatedRequest
+	events, err = s.repositories.AuditEventRepository.FindMany(s.ctx, repository.AuditEventFilter{Action: &impersonated, SubjectID: &user.ID}, 10)
+	s.r.NoError(err)
+	s.r.Len(events, 1)
+	s.r.Equal(admin.ID, *events[0].ActorID)
+	s.r.Equal("/api/v1/auth/me", events[0].Metadata["path"])
+	s.r.EqualValues(http.StatusOK, events[0].Metadata["status"])
+	s.r.Equal(admin.ID.String(), events[0].Metadata[audit.MetadataImpersonatorID])
+}
+
+func (s *ImpersonationSuite) TestImpersonate_SensitiveEndpointsBlocked() {
+	// Arrange
+	admin := s.createUser("impersonation-blocked-admin", role.SuperAdmin)
+	user := s.createUser("impersonation-blocked-user", role.User)
+	resp, code := s.impersonate(s.accessToken(admin), user.ID)
+	s.r.Equal(http.StatusOK, code)
+	token := resp.Data.AccessToken
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   interface{}
+	}{
+		{name: "password change", method: http.MethodPut, target: "/api/v1/auth/me/password", body: request.ChangePasswordRequest{CurrentPassword: "a", NewPassword: "b"}},
+		{name: "email change", method: http.MethodPut, target: "/api/v1/auth/me/email"},
+		{name: "account deletion", method: http.MethodDelete, target: "/api/v1/auth/me"},
+		{name: "mfa disable", method: http.MethodPost, target: "/api/v1/auth/mfa/disable"},
+		{name: "revoke other sessions", method: http.MethodPost, target: "/api/v1/auth/sessions/revoke-others"},
+		{name: "admin endpoints", method: http.MethodGet, target: AdminUsersEndpoint},
+		{name: "nested impersonation", method: http.MethodPost, target: fmt.Sprintf(AdminUserImpersonateEndpoint, admin.ID)},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, tt.method, tt.target, &token, tt.body)
+
+			// Assert
+			s.r.NoError(err)
+			s.r.Equal(http.StatusForbidden, code)
+		})
+	}
+
+	// Assert - refused requests are audited too
+	impersonated := audit.ActionImpersonatedRequest
+	events, err := s.repositories.AuditEventRepository.FindMany(s.ctx, repository.AuditEventFilter{Action: &impersonated, SubjectID: &user.ID}, 20)
+	s.r.NoError(err)
+	s.r.Len(events, len(tests))
+	for _, e := range events {
+		s.r.EqualValues(http.StatusForbidden, e.Metadata["status"])
+	}
+}
+
+func (s *ImpersonationSuite) TestImpersonate_SuperAdminOnly() {
+	// Arrange - ADMIN is below SUPER_ADMIN even with every other admin permission
+	admin := s.createUser("impersonation-plain-admin", role.Admin)
+	user := s.createUser("impersonation-target", role.User)
+
+	// Act
+	_, code := s.impersonate(s.accessToken(admin), user.ID)
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *ImpersonationSuite) TestImpersonate_Self() {
+	// Arrange
+	admin := s.createUser("impersonation-self", role.SuperAdmin)
+
+	// Act
+	_, code := s.impersonate(s.accessToken(admin), admin.ID)
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *ImpersonationSuite) TestImpersonate_SuspendedUser() {
+	// Arrange
+	admin := s.createUser("impersonation-suspended-admin", role.SuperAdmin)
+	user := s.createUser("impersonation-suspended-user", role.User)
+	_, err := s.repositories.UserRepository.UpdateStatus(s.ctx, user.ID, userstatus.Disabled)
+	s.r.NoError(err)
+
+	// Act
+	_, code := s.impersonate(s.accessToken(admin), user.ID)
+
+	// Assert
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *ImpersonationSuite) TestImpersonate_EndsWithAdminLogout() {
+	// Arrange
+	admin := s.createUser("impersonation-revoked-admin", role.SuperAdmin)
+	user := s.createUser("impersonation-revoked-user", role.User)
+	resp, code := s.impersonate(s.accessToken(admin), user.ID)
+	s.r.Equal(http.StatusOK, code)
+	token := resp.Data.AccessToken
+
+	// Act - every token of the admin is revoked
+	s.r.NoError(denylist.NewRedisDenylist(s.resource.Redis, s.resource.Config.JwtConfig).RevokeUserTokens(s.ctx, admin.ID))
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, "/api/v1/auth/me", &token, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
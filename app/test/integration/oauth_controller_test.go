@@ -0,0 +1,439 @@
+package integration
+
+import (
+	"backend/service-platform/app/pkg/tokenversion"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/database/constant/role"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/jwt"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	OAuthTokenEndpoint      = "/oauth/token"
+	OAuthIntrospectEndpoint = "/oauth/introspect"
+	ServiceClientsEndpoint  = "/api/v1/admin/service-clients"
+	ServiceClientEndpoint   = "/api/v1/admin/service-clients/%s"
+
+	serviceTokenTestEndpoint = "/test/service-token"
+)
+
+type OAuthControllerSuite struct {
+	RouterSuite
+}
+
+func TestOAuthControllerSuite(t *testing.T) {
+	suite.Run(t, new(OAuthControllerSuite))
+}
+
+func (s *OAuthControllerSuite) SetupSuite() {
+	s.RouterSuite.SetupSuite()
+
+	// A route only service principals holding reports:read may call
+	m := middleware.NewMiddleware(s.resource)
+	s.e.GET(serviceTokenTestEndpoint, func(ec echo.Context) error {
+		return ec.JSON(http.StatusOK, response.ToSuccessResponse(middleware.CurrentRole(ec)))
+	}, m.RequireAuth(middleware.AuthMethodServiceToken), m.RequireScope("reports:read"))
+}
+
+// useRealOAuthManager undoes a mock installed by an earlier test
+func (s *OAuthControllerSuite) useRealOAuthManager() {
+	s.managers.OAuthManager = manager.NewOAuthManager(
+		s.resource,
+		jwt.NewJwt(s.resource.Config.JwtConfig),
+		denylist.NewRedisDenylist(s.resource.Redis, s.resource.Config.JwtConfig),
+		tokenversion.NewRedisVersions(s.resource.Redis, s.repositories.UserRepository, s.resource.Config.JwtConfig.AccessExpiration),
+		s.managers.AuditLogger,
+		s.repositories,
+	)
+}
+
+func (s *OAuthControllerSuite) accessToken(userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	userID := uuid.New()
+	username := "admin@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *OAuthControllerSuite) createClient(scopes ...string) *response.CreatedServiceClientResponse {
+	s.useRealOAuthManager()
+	client, err := s.managers.OAuthManager.CreateServiceClient(s.ctx, request.CreateServiceClientRequest{Name: "billing", Scopes: scopes})
+	s.r.NoError(err)
+	return client
+}
+
+// postForm sends an application/x-www-form-urlencoded request, with HTTP Basic credentials when clientID is set
+func (s *OAuthControllerSuite) postForm(target string, form url.Values, clientID string, clientSecret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	if clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *OAuthControllerSuite) issueToken(client *response.CreatedServiceClientResponse, scope string) response.OAuthTokenResponse {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if scope != "" {
+		form.Set("scope", scope)
+	}
+	rec := s.postForm(OAuthTokenEndpoint, form, client.ClientID, client.ClientSecret)
+	s.r.Equal(http.StatusOK, rec.Code, rec.Body.String())
+	var token response.OAuthTokenResponse
+	s.r.NoError(json.Unmarshal(rec.Body.Bytes(), &token))
+	return token
+}
+
+func (s *OAuthControllerSuite) oauthError(rec *httptest.ResponseRecorder) response.OAuthErrorResponse {
+	var res response.OAuthErrorResponse
+	s.r.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func (s *OAuthControllerSuite) introspect(client *response.CreatedServiceClientResponse, token string) response.IntrospectionResponse {
+	rec := s.postForm(OAuthIntrospectEndpoint, url.Values{"token": {token}}, client.ClientID, client.ClientSecret)
+	s.r.Equal(http.StatusOK, rec.Code, rec.Body.String())
+	var res response.IntrospectionResponse
+	s.r.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func (s *OAuthControllerSuite) TestToken_ClientSecretBasic() {
+	// Arrange
+	client := s.createClient("reports:read", "reports:write")
+
+	// Act
+	rec := s.postForm(OAuthTokenEndpoint, url.Values{"grant_type": {"client_credentials"}}, client.ClientID, client.ClientSecret)
+
+	// Assert - every allowed scope, not cacheable
+	s.r.Equal(http.StatusOK, rec.Code)
+	s.r.Equal("no-store", rec.Header().Get(echo.HeaderCacheControl))
+	var token response.OAuthTokenResponse
+	s.r.NoError(json.Unmarshal(rec.Body.Bytes(), &token))
+	s.r.Equal("Bearer", token.TokenType)
+	s.r.Equal("reports:read reports:write", token.Scope)
+	s.r.Positive(token.ExpiresIn)
+	s.r.LessOrEqual(token.ExpiresIn, int64(s.resource.Config.JwtConfig.ServiceTokenExpiration.Seconds()))
+
+	claims, err := jwt.NewJwt(s.resource.Config.JwtConfig).ValidateToken(token.AccessToken)
+	s.r.NoError(err)
+	s.r.Equal(client.ClientID, claims.Subject)
+	s.r.Nil(claims.UserID)
+}
+
+func (s *OAuthControllerSuite) TestToken_ClientSecretPost() {
+	// Arrange
+	client := s.createClient("reports:read")
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {client.ClientID},
+		"client_secret": {client.ClientSecret},
+	}
+
+	// Act
+	rec := s.postForm(OAuthTokenEndpoint, form, "", "")
+
+	// Assert
+	s.r.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *OAuthControllerSuite) TestToken_NarrowedScope() {
+	// Arrange
+	client := s.createClient("reports:read", "reports:write")
+
+	// Act
+	token := s.issueToken(client, "reports:read")
+
+	// Assert
+	s.r.Equal("reports:read", token.Scope)
+}
+
+func (s *OAuthControllerSuite) TestToken_Errors() {
+	// Arrange
+	client := s.createClient("reports:read")
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		clientSecret string
+		wantStatus   int
+		wantError    string
+	}{
+		{name: "wrong secret", form: url.Values{"grant_type": {"client_credentials"}}, clientSecret: "wrong", wantStatus: http.StatusUnauthorized, wantError: "invalid_client"},
+		{name: "scope not allowed", form: url.Values{"grant_type": {"client_credentials"}, "scope": {"reports:write"}}, clientSecret: client.ClientSecret, wantStatus: http.StatusBadRequest, wantError: "invalid_scope"},
+		{name: "unsupported grant", form: url.Values{"grant_type": {"password"}}, clientSecret: client.ClientSecret, wantStatus: http.StatusBadRequest, wantError: "unsupported_grant_type"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			rec := s.postForm(OAuthTokenEndpoint, tt.form, client.ClientID, tt.clientSecret)
+
+			// Assert
+			s.r.Equal(tt.wantStatus, rec.Code)
+			s.r.Equal(tt.wantError, s.oauthError(rec).Error)
+		})
+	}
+}
+
+func (s *OAuthControllerSuite) TestToken_UnknownClient() {
+	// Act
+	rec := s.postForm(OAuthTokenEndpoint, url.Values{"grant_type": {"client_credentials"}}, "svc_unknown", "secret")
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, rec.Code)
+	s.r.Equal("invalid_client", s.oauthError(rec).Error)
+	s.r.NotEmpty(rec.Header().Get(echo.HeaderWWWAuthenticate))
+}
+
+func (s *OAuthControllerSuite) TestServiceToken_AuthenticatesServicePrincipal() {
+	// Arrange
+	client := s.createClient("reports:read")
+	token := s.issueToken(client, "").AccessToken
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[string]](s.e, http.MethodGet, serviceTokenTestEndpoint, &token, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal("SERVICE", resp.Data)
+}
+
+func (s *OAuthControllerSuite) TestServiceToken_MissingScope() {
+	// Arrange
+	client := s.createClient("reports:read", "reports:write")
+	token := s.issueToken(client, "reports:write").AccessToken
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, serviceTokenTestEndpoint, &token, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *OAuthControllerSuite) TestServiceToken_NotAUser() {
+	// Arrange
+	client := s.createClient("reports:read")
+	serviceToken := s.issueToken(client, "").AccessToken
+	userToken := s.accessToken(role.User)
+
+	// Act - a user route refuses the service token and the service route the user token
+	_, meCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, MeEndpoint, &serviceToken, nil)
+	s.r.NoError(err)
+	_, serviceCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, serviceTokenTestEndpoint, &userToken, nil)
+	s.r.NoError(err)
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, meCode)
+	s.r.Equal(http.StatusUnauthorized, serviceCode)
+}
+
+func (s *OAuthControllerSuite) TestServiceToken_RevokedClient() {
+	// Arrange
+	client := s.createClient("reports:read")
+	token := s.issueToken(client, "").AccessToken
+
+	// Act
+	s.r.NoError(s.managers.OAuthManager.RevokeServiceClient(s.ctx, client.ID))
+
+	// Assert - neither the token nor the credentials work anymore
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, serviceTokenTestEndpoint, &token, nil)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+	rec := s.postForm(OAuthTokenEndpoint, url.Values{"grant_type": {"client_credentials"}}, client.ClientID, client.ClientSecret)
+	s.r.Equal(http.StatusUnauthorized, rec.Code)
+}
+
+func (s *OAuthControllerSuite) TestIntrospect_ServiceToken() {
+	// Arrange
+	resourceServer := s.createClient("introspect")
+	client := s.createClient("reports:read")
+	token := s.issueToken(client, "").AccessToken
+
+	// Act
+	res := s.introspect(resourceServer, token)
+
+	// Assert
+	s.r.True(res.Active)
+	s.r.Equal(client.ClientID, res.ClientID)
+	s.r.Equal(client.ClientID, res.Sub)
+	s.r.Equal("reports:read", res.Scope)
+	s.r.Equal("Bearer", res.TokenType)
+	s.r.Positive(res.Exp)
+
+	// Act - the client is revoked
+	s.r.NoError(s.managers.OAuthManager.RevokeServiceClient(s.ctx, client.ID))
+
+	// Assert
+	s.r.False(s.introspect(resourceServer, token).Active)
+}
+
+func (s *OAuthControllerSuite) TestIntrospect_UserToken() {
+	// Arrange
+	resourceServer := s.createClient("introspect")
+	token := s.accessToken(role.User)
+
+	// Act
+	res := s.introspect(resourceServer, token)
+
+	// Assert
+	s.r.True(res.Active)
+	s.r.NotEmpty(res.Sub)
+	s.r.Empty(res.ClientID)
+}
+
+func (s *OAuthControllerSuite) TestIntrospect_InactiveToken() {
+	// Arrange
+	resourceServer := s.createClient("introspect")
+
+	// Act
+	rec := s.postForm(OAuthIntrospectEndpoint, url.Values{"token": {"not-a-token"}}, resourceServer.ClientID, resourceServer.ClientSecret)
+
+	// Assert - nothing but active is disclosed
+	s.r.Equal(http.StatusOK, rec.Code)
+	s.r.JSONEq(`{"active":false}`, rec.Body.String())
+}
+
+func (s *OAuthControllerSuite) TestIntrospect_Unauthenticated() {
+	// Arrange
+	client := s.createClient("reports:read")
+	token := s.issueToken(client, "").AccessToken
+
+	// Act
+	rec := s.postForm(OAuthIntrospectEndpoint, url.Values{"token": {token}}, "", "")
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, rec.Code)
+	s.r.Equal("invalid_client", s.oauthError(rec).Error)
+}
+
+func (s *OAuthControllerSuite) TestCreateServiceClient_Success() {
+	// Arrange
+	s.useRealOAuthManager()
+	token := s.accessToken(role.SuperAdmin)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.CreatedServiceClientResponse]](
+		s.e,
+		http.MethodPost,
+		ServiceClientsEndpoint,
+		&token,
+		request.CreateServiceClientRequest{Name: "reports", Scopes: []string{"reports:read"}},
+	)
+
+	// Assert - the secret is returned once and never stored in clear
+	s.r.NoError(err)
+	s.r.Equal(http.StatusCreated, code)
+	s.r.NotEmpty(resp.Data.ClientSecret)
+	stored, err := s.repositories.ServiceClientRepository.FindByClientID(s.ctx, resp.Data.ClientID)
+	s.r.NoError(err)
+	s.r.NotEqual(resp.Data.ClientSecret, stored.SecretHash)
+	s.r.Equal([]string{"reports:read"}, stored.Scopes)
+}
+
+func (s *OAuthControllerSuite) TestCreateServiceClient_InvalidScope() {
+	// Arrange
+	token := s.accessToken(role.SuperAdmin)
+
+	// Act - scopes are space separated on the wire and cannot contain spaces
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ServiceClientsEndpoint,
+		&token,
+		request.CreateServiceClientRequest{Name: "reports", Scopes: []string{"reports:read reports:write"}},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *OAuthControllerSuite) TestCreateServiceClient_ForbiddenWithoutPermission() {
+	// Arrange - ADMIN does not hold service_clients:manage
+	token := s.accessToken(role.Admin)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ServiceClientsEndpoint,
+		&token,
+		request.CreateServiceClientRequest{Name: "reports", Scopes: []string{"reports:read"}},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *OAuthControllerSuite) TestListServiceClients_Success() {
+	// Arrange
+	m := mocks.NewMockOAuthManager(s.T())
+	s.managers.OAuthManager = m
+	token := s.accessToken(role.SuperAdmin)
+	m.EXPECT().ListServiceClients(mock.Anything).Return([]response.ServiceClientResponse{{ClientID: "svc_reports", Scopes: []string{"reports:read"}}}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[[]response.ServiceClientResponse]](
+		s.e,
+		http.MethodGet,
+		ServiceClientsEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 1)
+	s.r.Equal("svc_reports", resp.Data[0].ClientID)
+}
+
+func (s *OAuthControllerSuite) TestRevokeServiceClient_NotFound() {
+	// Arrange
+	m := mocks.NewMockOAuthManager(s.T())
+	s.managers.OAuthManager = m
+	token := s.accessToken(role.SuperAdmin)
+	id := uuid.New()
+	m.EXPECT().RevokeServiceClient(mock.Anything, id).Return(manager.ErrServiceClientNotFound)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodDelete,
+		fmt.Sprintf(ServiceClientEndpoint, id),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
@@ -0,0 +1,207 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/response"
+	utilcookie "backend/service-platform/app/pkg/util/cookie"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	OidcStartEndpoint = "/api/v1/auth/oidc/%s/start"
+
+	// Matches the mock provider of config-test.yaml
+	mockOidcProvider = "mock"
+	mockOidcAddress  = "127.0.0.1:9400"
+)
+
+// OidcControllerSuite runs the whole authorization code flow against a local mock provider with the real managers
+type OidcControllerSuite struct {
+	RouterSuite
+	provider *httputil.MockOidcServer
+}
+
+func TestOidcControllerSuite(t *testing.T) {
+	suite.Run(t, new(OidcControllerSuite))
+}
+
+func (s *OidcControllerSuite) SetupSuite() {
+	s.RouterSuite.SetupSuite()
+	cfg := s.resource.Config.OidcConfig.Providers[mockOidcProvider]
+	provider, err := httputil.NewMockOidcServerAt(mockOidcAddress, cfg.ClientID, cfg.ClientSecret)
+	s.r.NoError(err)
+	s.provider = provider
+}
+
+func (s *OidcControllerSuite) TearDownSuite() {
+	s.provider.Close()
+	s.RouterSuite.TearDownSuite()
+}
+
+// login starts the flow, lets the mock provider approve it and returns the callback target,
+// the state cookie is kept for the callback like a browser would
+func (s *OidcControllerSuite) login() string {
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf(OidcStartEndpoint, mockOidcProvider), nil))
+	s.r.Equal(http.StatusFound, rec.Code)
+	var stateCookie *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == utilcookie.OidcStateCookieName {
+			stateCookie = c
+		}
+	}
+	s.r.NotNil(stateCookie)
+	s.r.True(stateCookie.HttpOnly)
+	s.r.Equal(http.SameSiteLaxMode, stateCookie.SameSite)
+	httputil.SetCookie(stateCookie.Name, stateCookie.Value, stateCookie.MaxAge)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(rec.Header().Get(echo.HeaderLocation))
+	s.r.NoError(err)
+	defer resp.Body.Close()
+	s.r.Equal(http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get(echo.HeaderLocation))
+	s.r.NoError(err)
+	return callback.RequestURI()
+}
+
+func (s *OidcControllerSuite) TestOidcLogin_CreatesAccount() {
+	// Arrange
+	s.provider.Subject = "subject-create"
+	s.provider.Email = "oidc-create@example.com"
+	s.provider.EmailVerified = true
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](
+		s.e,
+		http.MethodGet,
+		s.login(),
+		nil,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.NotEmpty(resp.Data.AccessToken)
+	s.r.NotNil(httputil.GetCookie("refresh_token"))
+
+	u, err := s.repositories.UserRepository.FindByEmail(s.ctx, "oidc-create@example.com")
+	s.r.NoError(err)
+	s.r.True(u.EmailVerified)
+}
+
+func (s *OidcControllerSuite) TestOidcLogin_ReusesLinkedIdentity() {
+	// Arrange
+	s.provider.Subject = "subject-reuse"
+	s.provider.Email = "oidc-reuse@example.com"
+	s.provider.EmailVerified = true
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](s.e, http.MethodGet, s.login(), nil, nil)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	first, err := s.repositories.UserIdentityRepository.FindByProviderSubject(s.ctx, mockOidcProvider, "subject-reuse")
+	s.r.NoError(err)
+
+	// Act - the provider now reports another email for the same subject
+	s.provider.Email = "oidc-renamed@example.com"
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](s.e, http.MethodGet, s.login(), nil, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	second, err := s.repositories.UserIdentityRepository.FindByProviderSubject(s.ctx, mockOidcProvider, "subject-reuse")
+	s.r.NoError(err)
+	s.r.Equal(first.UserID, second.UserID)
+}
+
+func (s *OidcControllerSuite) TestOidcCallback_ReplayedState() {
+	// Arrange
+	s.provider.Subject = "subject-replay"
+	s.provider.Email = "oidc-replay@example.com"
+	callback := s.login()
+	stateCookie := httputil.GetCookie(utilcookie.OidcStateCookieName)
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, callback, nil, nil)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	// Act - even from the browser that started the login
+	httputil.SetCookie(stateCookie.Name, stateCookie.Value, stateCookie.MaxAge)
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, callback, nil, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *OidcControllerSuite) TestOidcCallback_WithoutStateCookie() {
+	// Arrange - the callback URL reaches a browser that never started the login
+	s.provider.Subject = "subject-unbound"
+	s.provider.Email = "oidc-unbound@example.com"
+	callback := s.login()
+	stateCookie := httputil.GetCookie(utilcookie.OidcStateCookieName)
+	httputil.ClearCookies()
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, callback, nil, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+
+	// Act - another browser's cookie does not match either
+	httputil.SetCookie(stateCookie.Name, "not-the-state-hash", stateCookie.MaxAge)
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, callback, nil, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+
+	// Act - the state was not consumed, the right browser still completes the login
+	httputil.SetCookie(stateCookie.Name, stateCookie.Value, stateCookie.MaxAge)
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, callback, nil, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+}
+
+func (s *OidcControllerSuite) TestOidcCallback_ProviderError() {
+	// Arrange - a state is consumed even when the user denied the consent
+	callback, err := url.Parse(s.login())
+	s.r.NoError(err)
+	query := callback.Query()
+	query.Del("code")
+	query.Set("error", "access_denied")
+	callback.RawQuery = query.Encode()
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, callback.RequestURI(), nil, nil)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *OidcControllerSuite) TestOidcStart_UnknownProvider() {
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		fmt.Sprintf(OidcStartEndpoint, "unknown"),
+		nil,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
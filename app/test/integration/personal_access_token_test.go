@@ -0,0 +1,319 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/rbac"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	PersonalAccessTokensEndpoint = "/api/v1/auth/me/tokens"
+	PersonalAccessTokenEndpoint  = "/api/v1/auth/me/tokens/%s"
+)
+
+type PersonalAccessTokenSuite struct {
+	RouterSuite
+}
+
+func TestPersonalAccessTokenSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenSuite))
+}
+
+func (s *PersonalAccessTokenSuite) createUser(username string, userRole role.Role) *entity.User {
+	email := username + "@example.com"
+	u, err := s.repositories.UserRepository.Insert(s.ctx, &entity.User{
+		Username:      username,
+		Email:         &email,
+		Password:      "not-a-hash",
+		Status:        userstatus.Verified,
+		Role:          userRole,
+		EmailVerified: true,
+	})
+	s.r.NoError(err)
+	return u
+}
+
+func (s *PersonalAccessTokenSuite) accessToken(u *entity.User) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	roleStr := string(u.Role)
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&u.ID, &u.Username, u.Email, nil, &roleStr, &u.EmailVerified, &u.PhoneVerified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+// useRealManager undoes a mock installed by an earlier test
+func (s *PersonalAccessTokenSuite) useRealManager() {
+	authorizer := rbac.NewRedisAuthorizer(s.resource.Redis, s.repositories.RoleRepository, s.resource.Config.RbacConfig.CacheTTL)
+	s.managers.PersonalAccessTokenManager = manager.NewPersonalAccessTokenManager(s.resource, authorizer, s.managers.AuditLogger, s.repositories)
+}
+
+func (s *PersonalAccessTokenSuite) createToken(accessToken string, req request.CreatePersonalAccessTokenRequest) (response.GeneralResponse[response.CreatedPersonalAccessTokenResponse], int) {
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.CreatedPersonalAccessTokenResponse]](
+		s.e,
+		http.MethodPost,
+		PersonalAccessTokensEndpoint,
+		&accessToken,
+		req,
+	)
+	s.r.NoError(err)
+	return resp, code
+}
+
+func (s *PersonalAccessTokenSuite) listUsers(token string) int {
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, AdminUsersEndpoint, &token, nil)
+	s.r.NoError(err)
+	return code
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_Lifecycle() {
+	// Arrange
+	s.useRealManager()
+	admin := s.createUser("pat-lifecycle-admin", role.Admin)
+	accessToken := s.accessToken(admin)
+
+	// Act - create
+	created, code := s.createToken(accessToken, request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}})
+
+	// Assert - the plaintext is handed out once, only its hash is stored
+	s.r.Equal(http.StatusCreated, code)
+	s.r.True(strings.HasPrefix(created.Data.Token, "pat_"))
+	s.r.Equal([]string{rbac.UsersRead}, created.Data.Scopes)
+	stored, err := s.repositories.PersonalAccessTokenRepository.FindByPrefix(s.ctx, created.Data.Prefix)
+	s.r.NoError(err)
+	s.r.NotContains(stored.SecretHash, created.Data.Token)
+	s.r.Nil(stored.LastUsedAt)
+
+	// Act - authenticate with the token
+	code = s.listUsers(created.Data.Token)
+
+	// Assert - accepted as the admin and the use is recorded
+	s.r.Equal(http.StatusOK, code)
+	stored, err = s.repositories.PersonalAccessTokenRepository.FindByPrefix(s.ctx, created.Data.Prefix)
+	s.r.NoError(err)
+	s.r.NotNil(stored.LastUsedAt)
+
+	// Act - list
+	list, code, err := httputil.RequestHTTP[response.GeneralResponse[[]response.PersonalAccessTokenResponse]](
+		s.e,
+		http.MethodGet,
+		PersonalAccessTokensEndpoint,
+		&accessToken,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(list.Data, 1)
+	s.r.Equal(created.Data.ID, list.Data[0].ID)
+	s.r.NotNil(list.Data[0].LastUsedAt)
+	s.r.False(list.Data[0].Revoked)
+
+	// Act - revoke
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodDelete,
+		fmt.Sprintf(PersonalAccessTokenEndpoint, created.Data.ID),
+		&accessToken,
+		nil,
+	)
+
+	// Assert - the token stops working at once and both changes are audited
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(http.StatusUnauthorized, s.listUsers(created.Data.Token))
+
+	for _, action := range []string{audit.ActionPersonalTokenCreated, audit.ActionPersonalTokenRevoked} {
+		events, err := s.repositories.AuditEventRepository.FindMany(s.ctx, repository.AuditEventFilter{Action: &action, SubjectID: &admin.ID}, 10)
+		s.r.NoError(err)
+		s.r.Len(events, 1, action)
+		s.r.Equal(created.Data.Prefix, events[0].Metadata["prefix"])
+	}
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_ScopesNarrowRole() {
+	// Arrange
+	s.useRealManager()
+	admin := s.createUser("pat-scopes-admin", role.Admin)
+	user := s.createUser("pat-scopes-user", role.User)
+	created, code := s.createToken(s.accessToken(admin), request.CreatePersonalAccessTokenRequest{Name: "read only", Scopes: []string{rbac.UsersRead}})
+	s.r.Equal(http.StatusCreated, code)
+	token := created.Data.Token
+
+	// Act - a permission the role holds but the token does not
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserSuspendEndpoint, user.ID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_FollowsCurrentRole() {
+	// Arrange
+	s.useRealManager()
+	admin := s.createUser("pat-demoted-admin", role.Admin)
+	created, code := s.createToken(s.accessToken(admin), request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}})
+	s.r.Equal(http.StatusCreated, code)
+
+	// Act - the owner is demoted after the token was issued
+	s.r.NoError(s.repositories.UserRepository.UpdateRole(s.ctx, admin.ID, role.User))
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, s.listUsers(created.Data.Token))
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_InactiveOwner() {
+	// Arrange
+	s.useRealManager()
+	admin := s.createUser("pat-suspended-admin", role.Admin)
+	created, code := s.createToken(s.accessToken(admin), request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}})
+	s.r.Equal(http.StatusCreated, code)
+
+	// Act
+	_, err := s.repositories.UserRepository.UpdateStatus(s.ctx, admin.ID, userstatus.Disabled)
+	s.r.NoError(err)
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, s.listUsers(created.Data.Token))
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_Expired() {
+	// Arrange - a token that expired after it was issued
+	s.useRealManager()
+	admin := s.createUser("pat-expired-admin", role.Admin)
+	expiresAt := time.Now().Add(time.Hour)
+	created, code := s.createToken(s.accessToken(admin), request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}, ExpiresAt: &expiresAt})
+	s.r.Equal(http.StatusCreated, code)
+	_, err := s.resource.DB.PrimaryDb.ExecContext(s.ctx, "UPDATE personal_access_tokens SET expires_at = now() - interval '1 minute' WHERE id = $1", created.Data.ID)
+	s.r.NoError(err)
+
+	// Act & Assert
+	s.r.Equal(http.StatusUnauthorized, s.listUsers(created.Data.Token))
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_CannotManageTokens() {
+	// Arrange
+	s.useRealManager()
+	admin := s.createUser("pat-self-admin", role.Admin)
+	created, code := s.createToken(s.accessToken(admin), request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}})
+	s.r.Equal(http.StatusCreated, code)
+
+	// Act - a token minting another token
+	_, code = s.createToken(created.Data.Token, request.CreatePersonalAccessTokenRequest{Name: "nested", Scopes: []string{rbac.UsersRead}})
+
+	// Assert
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *PersonalAccessTokenSuite) TestPersonalAccessToken_InvalidToken() {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "unknown prefix", token: "pat_000000000000_secret"},
+		{name: "malformed", token: "pat_garbage"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act & Assert
+			s.r.Equal(http.StatusUnauthorized, s.listUsers(tt.token))
+		})
+	}
+}
+
+func (s *PersonalAccessTokenSuite) TestCreatePersonalAccessToken_ScopeNotHeld() {
+	// Arrange
+	s.useRealManager()
+	user := s.createUser("pat-scope-user", role.User)
+
+	// Act
+	_, code := s.createToken(s.accessToken(user), request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}})
+
+	// Assert
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *PersonalAccessTokenSuite) TestCreatePersonalAccessToken_InvalidData() {
+	// Arrange
+	token := s.accessToken(s.createUser("pat-invalid-user", role.User))
+
+	tests := []struct {
+		name string
+		req  request.CreatePersonalAccessTokenRequest
+	}{
+		{name: "missing name", req: request.CreatePersonalAccessTokenRequest{Scopes: []string{rbac.UsersRead}}},
+		{name: "missing scopes", req: request.CreatePersonalAccessTokenRequest{Name: "ci"}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			_, code := s.createToken(token, tt.req)
+
+			// Assert
+			s.r.Equal(http.StatusBadRequest, code)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenSuite) TestCreatePersonalAccessToken_ExpiresInPast() {
+	// Arrange
+	m := mocks.NewMockPersonalAccessTokenManager(s.T())
+	s.managers.PersonalAccessTokenManager = m
+	token := s.accessToken(s.createUser("pat-past-user", role.User))
+	m.EXPECT().CreateToken(mock.Anything, mock.Anything).Return(nil, manager.ErrPersonalAccessTokenExpiresInPast)
+
+	// Act
+	_, code := s.createToken(token, request.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{rbac.UsersRead}})
+
+	// Assert
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *PersonalAccessTokenSuite) TestRevokePersonalAccessToken_NotFound() {
+	// Arrange
+	m := mocks.NewMockPersonalAccessTokenManager(s.T())
+	s.managers.PersonalAccessTokenManager = m
+	user := s.createUser("pat-missing-user", role.User)
+	token := s.accessToken(user)
+	id := uuid.New()
+	m.EXPECT().RevokeToken(mock.Anything, user.ID, id).Return(manager.ErrPersonalAccessTokenNotFound)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodDelete,
+		fmt.Sprintf(PersonalAccessTokenEndpoint, id),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
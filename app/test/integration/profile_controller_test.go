@@ -0,0 +1,417 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/password"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	ProfilePasswordEndpoint = "/api/v1/auth/me/password"
+	ProfileEmailEndpoint    = "/api/v1/auth/me/email"
+	ProfileUsernameEndpoint = "/api/v1/auth/me/username"
+	ProfilePhoneEndpoint    = "/api/v1/auth/me/phone"
+	ProfileEndpoint         = "/api/v1/auth/me"
+	ProfileExportEndpoint   = "/api/v1/auth/me/export"
+)
+
+type ProfileControllerSuite struct {
+	RouterSuite
+}
+
+func TestProfileControllerSuite(t *testing.T) {
+	suite.Run(t, new(ProfileControllerSuite))
+}
+
+func (s *ProfileControllerSuite) accessToken(userID uuid.UUID) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	username := "user@example.com"
+	roleStr := string(role.User)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *ProfileControllerSuite) TestChangePassword_Success() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	m.EXPECT().ChangePassword(mock.Anything, mock.MatchedBy(func(r request.ChangePasswordRequest) bool {
+		return r.UserID == userID && r.CurrentPassword == "old-password" && r.NewPassword == "new-password"
+	})).Return(nil)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfilePasswordEndpoint,
+		&token,
+		request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+}
+
+func (s *ProfileControllerSuite) TestChangePassword_SamePassword() {
+	// Arrange
+	token := s.accessToken(uuid.New())
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfilePasswordEndpoint,
+		&token,
+		request.ChangePasswordRequest{CurrentPassword: "same-password", NewPassword: "same-password"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *ProfileControllerSuite) TestChangePassword_WrongCurrentPassword() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	token := s.accessToken(uuid.New())
+	m.EXPECT().ChangePassword(mock.Anything, mock.Anything).Return(manager.ErrInvalidCurrentPassword)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfilePasswordEndpoint,
+		&token,
+		request.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal(manager.ErrInvalidCurrentPassword.Error(), resp.Message)
+}
+
+func (s *ProfileControllerSuite) TestChangePassword_PasswordPolicy() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	token := s.accessToken(uuid.New())
+	m.EXPECT().ChangePassword(mock.Anything, mock.Anything).Return(&manager.PasswordPolicyError{Violations: []password.Violation{
+		{Rule: password.RuleRepeatedChars, Message: "must not repeat a character more than 3 times in a row"},
+	}})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfilePasswordEndpoint,
+		&token,
+		request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "aaaaaaaa"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal([]response.ErrorDetail{
+		{Key: password.RuleRepeatedChars, Field: "new_password", Message: "must not repeat a character more than 3 times in a row"},
+	}, resp.ErrorDetails)
+}
+
+func (s *ProfileControllerSuite) TestChangePassword_Unauthenticated() {
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfilePasswordEndpoint,
+		nil,
+		request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *ProfileControllerSuite) TestChangeEmail_Accepted() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	pending := "new@example.com"
+	m.EXPECT().ChangeEmail(mock.Anything, mock.MatchedBy(func(r request.ChangeEmailRequest) bool {
+		return r.UserID == userID && r.Email == pending
+	})).Return(&response.UserResponse{ID: userID, PendingEmail: &pending}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.UserResponse]](
+		s.e,
+		http.MethodPut,
+		ProfileEmailEndpoint,
+		&token,
+		request.ChangeEmailRequest{Email: pending, CurrentPassword: "password123"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusAccepted, code)
+	s.r.Equal(pending, *resp.Data.PendingEmail)
+}
+
+func (s *ProfileControllerSuite) TestChangeEmail_Taken() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	token := s.accessToken(uuid.New())
+	m.EXPECT().ChangeEmail(mock.Anything, mock.Anything).Return(nil, manager.ErrEmailAlreadyExists)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfileEmailEndpoint,
+		&token,
+		request.ChangeEmailRequest{Email: "taken@example.com", CurrentPassword: "password123"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *ProfileControllerSuite) TestChangeUsername_InvalidFormat() {
+	// Arrange
+	token := s.accessToken(uuid.New())
+
+	for _, username := range []string{"ab", "someone@example.com", "has space", "-leading"} {
+		// Act
+		_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+			s.e,
+			http.MethodPut,
+			ProfileUsernameEndpoint,
+			&token,
+			request.ChangeUsernameRequest{Username: username},
+		)
+
+		// Assert
+		s.r.NoError(err)
+		s.r.Equal(http.StatusBadRequest, code, username)
+	}
+}
+
+func (s *ProfileControllerSuite) TestChangeUsername_Taken() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	token := s.accessToken(uuid.New())
+	m.EXPECT().ChangeUsername(mock.Anything, mock.MatchedBy(func(r request.ChangeUsernameRequest) bool {
+		return r.Username == "jane.doe"
+	})).Return(nil, manager.ErrUsernameAlreadyExisted)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfileUsernameEndpoint,
+		&token,
+		request.ChangeUsernameRequest{Username: "jane.doe"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *ProfileControllerSuite) TestSetPhoneNumber_InvalidFormat() {
+	// Arrange
+	token := s.accessToken(uuid.New())
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		ProfilePhoneEndpoint,
+		&token,
+		request.SetPhoneNumberRequest{PhoneNumber: "0912 345 678"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *ProfileControllerSuite) TestSetPhoneNumber_Success() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	phone := "+84912345678"
+	m.EXPECT().SetPhoneNumber(mock.Anything, request.SetPhoneNumberRequest{UserID: userID, PhoneNumber: phone}).
+		Return(&response.UserResponse{ID: userID, PhoneNumber: &phone}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.UserResponse]](
+		s.e,
+		http.MethodPut,
+		ProfilePhoneEndpoint,
+		&token,
+		request.SetPhoneNumberRequest{PhoneNumber: phone},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(phone, *resp.Data.PhoneNumber)
+	s.r.False(resp.Data.PhoneVerified)
+}
+
+func (s *ProfileControllerSuite) TestRemovePhoneNumber_Success() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	m.EXPECT().RemovePhoneNumber(mock.Anything, userID).Return(&response.UserResponse{ID: userID}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.UserResponse]](
+		s.e,
+		http.MethodDelete,
+		ProfilePhoneEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Nil(resp.Data.PhoneNumber)
+}
+
+func (s *ProfileControllerSuite) TestDeleteAccount_Scheduled() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	scheduledAt := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	m.EXPECT().DeleteAccount(mock.Anything, request.DeleteAccountRequest{UserID: userID, CurrentPassword: "password123"}).
+		Return(&response.AccountDeletionResponse{DeletionScheduledAt: scheduledAt}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AccountDeletionResponse]](
+		s.e,
+		http.MethodDelete,
+		ProfileEndpoint,
+		&token,
+		request.DeleteAccountRequest{CurrentPassword: "password123"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusAccepted, code)
+	s.r.True(scheduledAt.Equal(resp.Data.DeletionScheduledAt))
+}
+
+func (s *ProfileControllerSuite) TestDeactivateAccount_WrongCurrentPassword() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	token := s.accessToken(uuid.New())
+	m.EXPECT().DeactivateAccount(mock.Anything, mock.Anything).Return(manager.ErrInvalidCurrentPassword)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		ProfileEndpoint+"/deactivate",
+		&token,
+		request.DeactivateAccountRequest{CurrentPassword: "wrong-password"},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal(manager.ErrInvalidCurrentPassword.Error(), resp.Message)
+}
+
+func (s *ProfileControllerSuite) TestRequestDataExport_Accepted() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	exportID := uuid.New()
+	m.EXPECT().RequestDataExport(mock.Anything, userID).
+		Return(&response.DataExportResponse{ID: exportID, Status: "pending", CreatedAt: time.Now()}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.DataExportResponse]](
+		s.e,
+		http.MethodPost,
+		ProfileExportEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusAccepted, code)
+	s.r.Equal(exportID, resp.Data.ID)
+}
+
+func (s *ProfileControllerSuite) TestDownloadDataExport_NotReady() {
+	// Arrange
+	m := mocks.NewMockProfileManager(s.T())
+	s.managers.ProfileManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID)
+	exportID := uuid.New()
+	m.EXPECT().DataExportPath(mock.Anything, userID, exportID).Return("", manager.ErrDataExportNotReady)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		ProfileExportEndpoint+"/"+exportID.String(),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
@@ -0,0 +1,259 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/rbac"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	RolesEndpoint       = "/api/v1/admin/roles"
+	RoleEndpoint        = "/api/v1/admin/roles/%s"
+	PermissionsEndpoint = "/api/v1/admin/permissions"
+)
+
+type RoleControllerSuite struct {
+	RouterSuite
+}
+
+func TestRoleControllerSuite(t *testing.T) {
+	suite.Run(t, new(RoleControllerSuite))
+}
+
+func (s *RoleControllerSuite) accessToken(userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	userID := uuid.New()
+	username := "admin@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *RoleControllerSuite) TestListRoles_Success() {
+	// Arrange
+	m := mocks.NewMockRoleManager(s.T())
+	s.managers.RoleManager = m
+
+	token := s.accessToken(role.SuperAdmin)
+	parent := "USER"
+	m.EXPECT().ListRoles(mock.Anything).Return([]response.RoleResponse{
+		{Name: "OPERATOR", Parent: &parent, Permissions: []string{rbac.JobsRead}, EffectivePermissions: []string{rbac.JobsRead}, BuiltIn: true},
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[[]response.RoleResponse]](
+		s.e,
+		http.MethodGet,
+		RolesEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 1)
+	s.r.Equal("OPERATOR", resp.Data[0].Name)
+}
+
+func (s *RoleControllerSuite) TestListRoles_ForbiddenWithoutPermission() {
+	// Arrange - ADMIN holds other admin permissions but not roles:manage
+	token := s.accessToken(role.Admin)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		RolesEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *RoleControllerSuite) TestCreateRole_Success() {
+	// Arrange
+	m := mocks.NewMockRoleManager(s.T())
+	s.managers.RoleManager = m
+
+	token := s.accessToken(role.SuperAdmin)
+	parent := "OPERATOR"
+	req := request.CreateRoleRequest{
+		Name:        "SUPPORT",
+		Parent:      &parent,
+		Permissions: []string{rbac.UsersRead},
+	}
+	m.EXPECT().CreateRole(mock.Anything, mock.MatchedBy(func(r request.CreateRoleRequest) bool {
+		return r.Name == "SUPPORT" && r.Parent != nil && *r.Parent == "OPERATOR"
+	})).Return(&response.RoleResponse{
+		Name:                 "SUPPORT",
+		Parent:               &parent,
+		Permissions:          []string{rbac.UsersRead},
+		EffectivePermissions: []string{rbac.JobsRead, rbac.JobsRetry, rbac.UsersRead},
+	}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.RoleResponse]](
+		s.e,
+		http.MethodPost,
+		RolesEndpoint,
+		&token,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusCreated, code)
+	s.r.Equal([]string{rbac.JobsRead, rbac.JobsRetry, rbac.UsersRead}, resp.Data.EffectivePermissions)
+}
+
+func (s *RoleControllerSuite) TestCreateRole_InvalidName() {
+	// Arrange
+	token := s.accessToken(role.SuperAdmin)
+	req := request.CreateRoleRequest{Name: "support agent"}
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		RolesEndpoint,
+		&token,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *RoleControllerSuite) TestCreateRole_UnknownPermission() {
+	// Arrange
+	m := mocks.NewMockRoleManager(s.T())
+	s.managers.RoleManager = m
+
+	token := s.accessToken(role.SuperAdmin)
+	req := request.CreateRoleRequest{Name: "SUPPORT", Permissions: []string{"jobs:delete"}}
+	m.EXPECT().CreateRole(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: jobs:delete", manager.ErrUnknownPermission))
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		RolesEndpoint,
+		&token,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *RoleControllerSuite) TestUpdateRole_BuiltIn() {
+	// Arrange
+	m := mocks.NewMockRoleManager(s.T())
+	s.managers.RoleManager = m
+
+	token := s.accessToken(role.SuperAdmin)
+	m.EXPECT().UpdateRole(mock.Anything, mock.MatchedBy(func(r request.UpdateRoleRequest) bool {
+		return r.Name == "ADMIN"
+	})).Return(nil, manager.ErrRoleBuiltIn)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPatch,
+		fmt.Sprintf(RoleEndpoint, "ADMIN"),
+		&token,
+		request.UpdateRoleRequest{Permissions: []string{rbac.JobsRead}},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *RoleControllerSuite) TestDeleteRole_NotFound() {
+	// Arrange
+	m := mocks.NewMockRoleManager(s.T())
+	s.managers.RoleManager = m
+
+	token := s.accessToken(role.SuperAdmin)
+	m.EXPECT().DeleteRole(mock.Anything, "SUPPORT").Return(manager.ErrRoleNotFound)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodDelete,
+		fmt.Sprintf(RoleEndpoint, "SUPPORT"),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
+
+func (s *RoleControllerSuite) TestListPermissions_Success() {
+	// Arrange
+	m := mocks.NewMockRoleManager(s.T())
+	s.managers.RoleManager = m
+
+	token := s.accessToken(role.SuperAdmin)
+	m.EXPECT().ListPermissions(mock.Anything).Return([]response.PermissionResponse{{Name: rbac.JobsRead}}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[[]response.PermissionResponse]](
+		s.e,
+		http.MethodGet,
+		PermissionsEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(rbac.JobsRead, resp.Data[0].Name)
+}
+
+func (s *RoleControllerSuite) TestCustomRole_ReachesAdminRoutes() {
+	// Arrange - a custom role below ADMIN that only adds users:read
+	parent := string(role.User)
+	_, err := s.repositories.RoleRepository.Insert(s.ctx, &entity.Role{Name: "SUPPORT_READER", Parent: &parent, Permissions: []string{rbac.UsersRead}})
+	s.r.NoError(err)
+	s.r.NoError(rbac.NewRedisAuthorizer(s.resource.Redis, s.repositories.RoleRepository, s.resource.Config.RbacConfig.CacheTTL).Invalidate(s.ctx))
+	token := s.accessToken("SUPPORT_READER")
+
+	// Act
+	_, listCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, AdminUsersEndpoint, &token, nil)
+	s.r.NoError(err)
+	_, rolesCode, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodGet, RolesEndpoint, &token, nil)
+	s.r.NoError(err)
+
+	// Assert - the permission decides, not being an admin
+	s.r.Equal(http.StatusOK, listCode)
+	s.r.Equal(http.StatusForbidden, rolesCode)
+}
@@ -0,0 +1,202 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	SessionsEndpoint            = "/api/v1/auth/sessions"
+	RevokeOtherSessionsEndpoint = "/api/v1/auth/sessions/revoke-others"
+	AdminUserSessionsEndpoint   = "/api/v1/admin/users/%s/sessions"
+)
+
+type SessionControllerSuite struct {
+	RouterSuite
+}
+
+func TestSessionControllerSuite(t *testing.T) {
+	suite.Run(t, new(SessionControllerSuite))
+}
+
+func (s *SessionControllerSuite) accessToken(userID uuid.UUID, userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	username := "test@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *SessionControllerSuite) TestListSessions_Success() {
+	// Arrange
+	m := mocks.NewMockSessionManager(s.T())
+	s.managers.SessionManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID, role.User)
+	sessionID := uuid.New()
+	m.EXPECT().ListSessions(mock.Anything, request.ListSessionsRequest{UserID: userID, RefreshToken: "refresh-token"}).
+		Return([]response.SessionResponse{{ID: sessionID, Current: true}}, nil)
+	httputil.SetCookie("refresh_token", "refresh-token", 3600)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[[]response.SessionResponse]](
+		s.e,
+		http.MethodGet,
+		SessionsEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 1)
+	s.r.Equal(sessionID, resp.Data[0].ID)
+	s.r.True(resp.Data[0].Current)
+}
+
+func (s *SessionControllerSuite) TestListSessions_Unauthenticated() {
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		SessionsEndpoint,
+		nil,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *SessionControllerSuite) TestRevokeSession_NotFound() {
+	// Arrange
+	m := mocks.NewMockSessionManager(s.T())
+	s.managers.SessionManager = m
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	token := s.accessToken(userID, role.User)
+	m.EXPECT().RevokeSession(mock.Anything, request.RevokeSessionRequest{UserID: userID, SessionID: sessionID}).
+		Return(manager.ErrSessionNotFound)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodDelete,
+		SessionsEndpoint+"/"+sessionID.String(),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+	s.r.Equal("session not found", resp.Message)
+}
+
+func (s *SessionControllerSuite) TestRevokeOtherSessions_MissingRefreshToken() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.User)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		RevokeOtherSessionsEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+	s.r.Equal("Missing refresh token", resp.Message)
+}
+
+func (s *SessionControllerSuite) TestRevokeOtherSessions_Success() {
+	// Arrange
+	m := mocks.NewMockSessionManager(s.T())
+	s.managers.SessionManager = m
+
+	userID := uuid.New()
+	token := s.accessToken(userID, role.User)
+	m.EXPECT().RevokeOtherSessions(mock.Anything, request.RevokeOtherSessionsRequest{UserID: userID, RefreshToken: "refresh-token"}).
+		Return(&response.RevokeSessionsResponse{Revoked: 2}, nil)
+	httputil.SetCookie("refresh_token", "refresh-token", 3600)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.RevokeSessionsResponse]](
+		s.e,
+		http.MethodPost,
+		RevokeOtherSessionsEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(2, resp.Data.Revoked)
+}
+
+func (s *SessionControllerSuite) TestAdminListSessions_ForbiddenForUser() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.User)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		fmt.Sprintf(AdminUserSessionsEndpoint, uuid.NewString()),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *SessionControllerSuite) TestAdminRevokeAllSessions_Success() {
+	// Arrange
+	m := mocks.NewMockSessionManager(s.T())
+	s.managers.SessionManager = m
+
+	targetID := uuid.New()
+	token := s.accessToken(uuid.New(), role.Admin)
+	m.EXPECT().RevokeAllSessions(mock.Anything, targetID).Return(&response.RevokeSessionsResponse{Revoked: 3}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.RevokeSessionsResponse]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserSessionsEndpoint, targetID)+"/revoke-all",
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(3, resp.Data.Revoked)
+}
@@ -181,6 +181,7 @@ func (s *RouterSuite) cleanDBAt(timestamp time.Time) error {
 		AND t.table_schema = c.table_schema
 	WHERE t.table_schema = 'public'
 		AND c.column_name = 'created_at'
+		AND t.table_name <> 'audit_events'
 	`)
 	if err != nil {
 		return err
@@ -222,6 +223,7 @@ func (s *RouterSuite) cleanAllTestData() error {
 		AND t.table_schema = c.table_schema
 	WHERE t.table_schema = 'public'
 		AND c.column_name = 'created_at'
+		AND t.table_name <> 'audit_events'
 	`)
 	if err != nil {
 		return err
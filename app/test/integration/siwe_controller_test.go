@@ -0,0 +1,217 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/pkg/ethereum"
+	"backend/service-platform/app/pkg/siwe"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	SiweNonceEndpoint  = "/api/v1/auth/siwe/nonce"
+	SiweVerifyEndpoint = "/api/v1/auth/siwe/verify"
+)
+
+// SiweControllerSuite signs in with wallets against the real managers
+type SiweControllerSuite struct {
+	RouterSuite
+}
+
+func TestSiweControllerSuite(t *testing.T) {
+	suite.Run(t, new(SiweControllerSuite))
+}
+
+func (s *SiweControllerSuite) walletKey(last byte) ([]byte, string) {
+	key := make([]byte, 32)
+	key[31] = last
+	address, err := ethereum.PrivateKeyAddress(key)
+	s.r.NoError(err)
+	return key, address
+}
+
+// signedLogin asks for a nonce and signs a message for the frontend origin of config-test.yaml
+func (s *SiweControllerSuite) signedLogin(key []byte, address string, edit func(m *siwe.Message)) request.SiweLoginRequest {
+	nonce, code, err := httputil.RequestHTTP[response.GeneralResponse[response.SiweNonceResponse]](s.e, http.MethodGet, SiweNonceEndpoint, nil, nil)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	m := &siwe.Message{
+		Domain:    "localhost:3000",
+		Address:   address,
+		Statement: nonce.Data.Statement,
+		URI:       "http://localhost:3000/login",
+		Version:   siwe.Version,
+		ChainID:   nonce.Data.ChainID,
+		Nonce:     nonce.Data.Nonce,
+		IssuedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+	if edit != nil {
+		edit(m)
+	}
+	signature, err := ethereum.SignPersonal([]byte(m.String()), key)
+	s.r.NoError(err)
+	return request.SiweLoginRequest{Message: m.String(), Signature: "0x" + hex.EncodeToString(signature)}
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_CreatesAccount() {
+	// Arrange
+	key, address := s.walletKey(11)
+	body := s.signedLogin(key, address, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.NotEmpty(resp.Data.AccessToken)
+	s.r.NotNil(httputil.GetCookie("refresh_token"))
+
+	wallet, err := s.repositories.UserWalletRepository.FindByAddress(s.ctx, address)
+	s.r.NoError(err)
+	u, err := s.repositories.UserRepository.FindByID(s.ctx, wallet.UserID)
+	s.r.NoError(err)
+	s.r.Equal(address, u.Username)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_ReusesWallet() {
+	// Arrange
+	key, address := s.walletKey(12)
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, s.signedLogin(key, address, nil))
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	first, err := s.repositories.UserWalletRepository.FindByAddress(s.ctx, address)
+	s.r.NoError(err)
+
+	// Act
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[response.AuthResponse]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, s.signedLogin(key, address, nil))
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	second, err := s.repositories.UserWalletRepository.FindByAddress(s.ctx, address)
+	s.r.NoError(err)
+	s.r.Equal(first.UserID, second.UserID)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_ReplayedNonce() {
+	// Arrange
+	key, address := s.walletKey(13)
+	body := s.signedLogin(key, address, nil)
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+
+	// Act
+	_, code, err = httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_ConcurrentReplay() {
+	// Arrange - the same signed message sent several times at once
+	key, address := s.walletKey(15)
+	body, err := json.Marshal(s.signedLogin(key, address, nil))
+	s.r.NoError(err)
+	const attempts = 5
+	codes := make(chan int, attempts)
+
+	// Act - the shared cookie jar of httputil is not safe for concurrent use
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, SiweVerifyEndpoint, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			s.e.ServeHTTP(rec, req)
+			codes <- rec.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	// Assert - the nonce signs in exactly once
+	succeeded := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			succeeded++
+			continue
+		}
+		s.r.Equal(http.StatusUnauthorized, code)
+	}
+	s.r.Equal(1, succeeded)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_UnknownNonce() {
+	// Arrange
+	key, address := s.walletKey(14)
+	body := s.signedLogin(key, address, func(m *siwe.Message) { m.Nonce = "NeverIssuedNonce42" })
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_ForeignDomain() {
+	// Arrange
+	key, address := s.walletKey(15)
+	body := s.signedLogin(key, address, func(m *siwe.Message) {
+		m.Domain = "phishing.example.com"
+		m.URI = "https://phishing.example.com/login"
+	})
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_SignedByAnotherWallet() {
+	// Arrange
+	key, _ := s.walletKey(16)
+	_, victim := s.walletKey(17)
+	body := s.signedLogin(key, victim, nil)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *SiweControllerSuite) TestSiweLogin_MalformedMessage() {
+	// Arrange
+	key, address := s.walletKey(18)
+	body := s.signedLogin(key, address, nil)
+	body.Message = "not a siwe message"
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](s.e, http.MethodPost, SiweVerifyEndpoint, nil, body)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
@@ -0,0 +1,240 @@
+package integration
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	auditactor "backend/service-platform/app/database/constant/audit"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/database/repository"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/audit"
+	"backend/service-platform/app/pkg/password"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const BootstrapEndpoint = "/api/v1/auth/bootstrap"
+
+type SuperAdminControllerSuite struct {
+	RouterSuite
+}
+
+func TestSuperAdminControllerSuite(t *testing.T) {
+	suite.Run(t, new(SuperAdminControllerSuite))
+}
+
+func (s *SuperAdminControllerSuite) bootstrapRequest() request.BootstrapSuperAdminRequest {
+	return request.BootstrapSuperAdminRequest{
+		CreateSuperAdminRequest: request.CreateSuperAdminRequest{
+			Email:    "root@example.com",
+			Password: "correct-horse-battery",
+		},
+		BootstrapToken: "test-bootstrap-token",
+	}
+}
+
+func (s *SuperAdminControllerSuite) TestBootstrap_Success() {
+	// Arrange
+	m := mocks.NewMockSuperAdminManager(s.T())
+	s.managers.SuperAdminManager = m
+
+	userID := uuid.New()
+	m.EXPECT().Bootstrap(mock.Anything, mock.MatchedBy(func(r request.BootstrapSuperAdminRequest) bool {
+		return r.Email == "root@example.com" && r.BootstrapToken == "test-bootstrap-token"
+	})).Return(&response.SuperAdminResponse{UserID: userID, Email: "root@example.com", Outcome: response.SuperAdminCreated}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.SuperAdminResponse]](
+		s.e,
+		http.MethodPost,
+		BootstrapEndpoint,
+		nil,
+		s.bootstrapRequest(),
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusCreated, code)
+	s.r.Equal(userID, resp.Data.UserID)
+	s.r.Equal(response.SuperAdminCreated, resp.Data.Outcome)
+}
+
+func (s *SuperAdminControllerSuite) TestBootstrap_MissingToken() {
+	// Arrange
+	req := s.bootstrapRequest()
+	req.BootstrapToken = ""
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		BootstrapEndpoint,
+		nil,
+		req,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *SuperAdminControllerSuite) TestBootstrap_InvalidToken() {
+	// Arrange
+	m := mocks.NewMockSuperAdminManager(s.T())
+	s.managers.SuperAdminManager = m
+	m.EXPECT().Bootstrap(mock.Anything, mock.Anything).Return(nil, manager.ErrInvalidBootstrapToken)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		BootstrapEndpoint,
+		nil,
+		s.bootstrapRequest(),
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusUnauthorized, code)
+}
+
+func (s *SuperAdminControllerSuite) TestBootstrap_AdminAlreadyExists() {
+	// Arrange
+	m := mocks.NewMockSuperAdminManager(s.T())
+	s.managers.SuperAdminManager = m
+	m.EXPECT().Bootstrap(mock.Anything, mock.Anything).Return(nil, manager.ErrBootstrapUnavailable)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		BootstrapEndpoint,
+		nil,
+		s.bootstrapRequest(),
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
+
+func (s *SuperAdminControllerSuite) TestBootstrap_RateLimited() {
+	// Arrange
+	m := mocks.NewMockSuperAdminManager(s.T())
+	s.managers.SuperAdminManager = m
+	m.EXPECT().Bootstrap(mock.Anything, mock.Anything).Return(nil, &manager.RateLimitError{RetryAfter: time.Minute})
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		BootstrapEndpoint,
+		nil,
+		s.bootstrapRequest(),
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusTooManyRequests, code)
+}
+
+func (s *SuperAdminControllerSuite) TestCreateSuperAdmin_AuditedAsSystem() {
+	// Arrange
+	admins := manager.NewManagers(s.resource, nil, s.repositories).SuperAdminManager
+	email := fmt.Sprintf("cli-%s@example.com", uuid.NewString())
+	req := request.CreateSuperAdminRequest{Email: email, Password: "correct-horse-battery", Source: manager.SuperAdminSourceCLI}
+
+	// Act
+	res, err := admins.CreateSuperAdmin(s.ctx, req)
+
+	// Assert - no principal runs the command, the audit names the CLI
+	s.r.NoError(err)
+	s.r.Equal(response.SuperAdminCreated, res.Outcome)
+	created := audit.ActionSuperAdminCreated
+	events, err := s.repositories.AuditEventRepository.FindMany(s.ctx, repository.AuditEventFilter{Action: &created, SubjectID: &res.UserID}, 10)
+	s.r.NoError(err)
+	s.r.Len(events, 1)
+	s.r.Equal(auditactor.System, events[0].ActorType)
+	s.r.Nil(events[0].ActorID)
+	s.r.Equal(manager.SuperAdminSourceCLI, *events[0].ActorName)
+}
+
+func (s *SuperAdminControllerSuite) TestCreateSuperAdmin_PromotionAudited() {
+	// Arrange
+	admins := manager.NewManagers(s.resource, nil, s.repositories).SuperAdminManager
+	email := fmt.Sprintf("promoted-%s@example.com", uuid.NewString())
+	u, err := s.repositories.UserRepository.Insert(s.ctx, &entity.User{
+		Username:      email,
+		Email:         &email,
+		Password:      "not-a-hash",
+		Status:        userstatus.Verified,
+		Role:          role.User,
+		EmailVerified: true,
+	})
+	s.r.NoError(err)
+
+	// Act
+	res, err := admins.CreateSuperAdmin(s.ctx, request.CreateSuperAdminRequest{Email: email, Source: manager.SuperAdminSourceCLI})
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(response.SuperAdminPromoted, res.Outcome)
+	promoted := audit.ActionSuperAdminPromoted
+	events, err := s.repositories.AuditEventRepository.FindMany(s.ctx, repository.AuditEventFilter{Action: &promoted, SubjectID: &u.ID}, 10)
+	s.r.NoError(err)
+	s.r.Len(events, 1)
+	s.r.Equal(auditactor.System, events[0].ActorType)
+	s.r.Equal(manager.SuperAdminSourceCLI, *events[0].ActorName)
+	s.r.Equal(string(role.User), events[0].Metadata["previous_role"])
+}
+
+func (s *SuperAdminControllerSuite) TestBootstrap_PasswordPolicy() {
+	// Arrange
+	m := mocks.NewMockSuperAdminManager(s.T())
+	s.managers.SuperAdminManager = m
+	m.EXPECT().Bootstrap(mock.Anything, mock.Anything).Return(nil, &manager.PasswordPolicyError{
+		Violations: []password.Violation{{Rule: password.RuleRepeatedChars, Message: "too many repeated characters"}},
+	})
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		BootstrapEndpoint,
+		nil,
+		s.bootstrapRequest(),
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+	s.r.Equal([]response.ErrorDetail{
+		{Key: password.RuleRepeatedChars, Field: "password", Message: "too many repeated characters"},
+	}, resp.ErrorDetails)
+}
+
+func (s *SuperAdminControllerSuite) TestCreateSuperAdmin_PasswordPolicy() {
+	// Arrange
+	admins := manager.NewManagers(s.resource, nil, s.repositories).SuperAdminManager
+	email := fmt.Sprintf("weak-%s@example.com", uuid.NewString())
+
+	// Act
+	_, err := admins.CreateSuperAdmin(s.ctx, request.CreateSuperAdminRequest{Email: email, Password: "aaaaaaaa", Source: manager.SuperAdminSourceCLI})
+
+	// Assert - refused and nothing created
+	s.r.ErrorIs(err, manager.ErrPasswordPolicy)
+	_, err = s.repositories.UserRepository.FindByEmail(s.ctx, email)
+	s.r.ErrorIs(err, sql.ErrNoRows)
+}
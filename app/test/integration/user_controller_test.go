@@ -0,0 +1,261 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/api/client/request"
+	"backend/service-platform/app/api/client/response"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/jwt"
+	mocks "backend/service-platform/app/test/mocks/managers"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const (
+	AdminUsersEndpoint       = "/api/v1/admin/users"
+	AdminUserEndpoint        = "/api/v1/admin/users/%s"
+	AdminUserRoleEndpoint    = "/api/v1/admin/users/%s/role"
+	AdminUserSuspendEndpoint = "/api/v1/admin/users/%s/suspend"
+	AdminUserRestoreEndpoint = "/api/v1/admin/users/%s/restore"
+	AdminUserLogoutEndpoint  = "/api/v1/admin/users/%s/logout"
+)
+
+type UserControllerSuite struct {
+	RouterSuite
+}
+
+func TestUserControllerSuite(t *testing.T) {
+	suite.Run(t, new(UserControllerSuite))
+}
+
+func (s *UserControllerSuite) accessToken(userID uuid.UUID, userRole role.Role) string {
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	username := "admin@example.com"
+	roleStr := string(userRole)
+	verified := true
+	now := time.Now()
+	token, err := j.GenerateAccessToken(&userID, &username, &username, nil, &roleStr, &verified, &verified, &now, nil)
+	s.r.NoError(err)
+	return token.Token
+}
+
+func (s *UserControllerSuite) TestListUsers_Filters() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	m.EXPECT().ListUsers(mock.Anything, mock.MatchedBy(func(r request.ListUsersRequest) bool {
+		return r.Status != nil && *r.Status == userstatus.Disabled &&
+			r.Search != nil && *r.Search == "bob" &&
+			r.CreatedFrom != nil && r.Page == 2 && r.Size == 5
+	})).Return([]response.UserResponse{{ID: uuid.New(), Username: "bob"}}, int64(6), nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.PaginationResponse[response.UserResponse]](
+		s.e,
+		http.MethodGet,
+		AdminUsersEndpoint+"?status=DISABLED&q=bob&created_from=2024-01-01T00:00:00Z&page=2&size=5",
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Len(resp.Data, 1)
+	s.r.Equal(int64(6), resp.Paging.Total)
+	s.r.Equal(2, resp.Paging.NumberOfPages)
+}
+
+func (s *UserControllerSuite) TestListUsers_InvalidStatus() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.Admin)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		AdminUsersEndpoint+"?status=BANNED",
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusBadRequest, code)
+}
+
+func (s *UserControllerSuite) TestListUsers_ForbiddenForUsers() {
+	// Arrange
+	token := s.accessToken(uuid.New(), role.User)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		AdminUsersEndpoint,
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *UserControllerSuite) TestGetUser_NotFound() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	userID := uuid.New()
+	m.EXPECT().GetUser(mock.Anything, userID).Return(nil, manager.ErrUserNotFound)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodGet,
+		fmt.Sprintf(AdminUserEndpoint, userID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusNotFound, code)
+}
+
+func (s *UserControllerSuite) TestUpdateUserRole_InsufficientPrivileges() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	actorID := uuid.New()
+	userID := uuid.New()
+	token := s.accessToken(actorID, role.Admin)
+	m.EXPECT().UpdateUserRole(mock.Anything, mock.MatchedBy(func(r request.UpdateUserRoleRequest) bool {
+		return r.UserID == userID && r.ActorID != nil && *r.ActorID == actorID &&
+			r.ActorRole == string(role.Admin) && r.Role == role.SuperAdmin
+	})).Return(nil, manager.ErrInsufficientPrivileges)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPut,
+		fmt.Sprintf(AdminUserRoleEndpoint, userID),
+		&token,
+		request.UpdateUserRoleRequest{Role: role.SuperAdmin},
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *UserControllerSuite) TestSuspendUser_Success() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	userID := uuid.New()
+	m.EXPECT().SuspendUser(mock.Anything, mock.MatchedBy(func(r request.AdminUserRequest) bool {
+		return r.UserID == userID
+	})).Return(&response.UserResponse{ID: userID, Status: string(userstatus.Disabled)}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.UserResponse]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserSuspendEndpoint, userID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(string(userstatus.Disabled), resp.Data.Status)
+}
+
+func (s *UserControllerSuite) TestSuspendUser_Self() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	actorID := uuid.New()
+	token := s.accessToken(actorID, role.Admin)
+	m.EXPECT().SuspendUser(mock.Anything, mock.Anything).Return(nil, manager.ErrCannotManageSelf)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserSuspendEndpoint, actorID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusForbidden, code)
+}
+
+func (s *UserControllerSuite) TestRestoreUser_Conflict() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	m.EXPECT().RestoreUser(mock.Anything, mock.Anything).Return(nil, manager.ErrUserRestoreConflict)
+
+	// Act
+	_, code, err := httputil.RequestHTTP[response.GeneralResponse[any]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserRestoreEndpoint, uuid.New()),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusConflict, code)
+}
+
+func (s *UserControllerSuite) TestForceLogout_Success() {
+	// Arrange
+	m := mocks.NewMockUserManager(s.T())
+	s.managers.UserManager = m
+
+	token := s.accessToken(uuid.New(), role.Admin)
+	userID := uuid.New()
+	m.EXPECT().ForceLogout(mock.Anything, mock.MatchedBy(func(r request.AdminUserRequest) bool {
+		return r.UserID == userID
+	})).Return(&response.RevokeSessionsResponse{Revoked: 3}, nil)
+
+	// Act
+	resp, code, err := httputil.RequestHTTP[response.GeneralResponse[response.RevokeSessionsResponse]](
+		s.e,
+		http.MethodPost,
+		fmt.Sprintf(AdminUserLogoutEndpoint, userID),
+		&token,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.Equal(3, resp.Data.Revoked)
+}
@@ -0,0 +1,38 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"backend/service-platform/app/pkg/jwt"
+	httputil "backend/service-platform/app/test/util"
+)
+
+const JwksEndpoint = "/.well-known/jwks.json"
+
+type WellKnownControllerSuite struct {
+	RouterSuite
+}
+
+func TestWellKnownControllerSuite(t *testing.T) {
+	suite.Run(t, new(WellKnownControllerSuite))
+}
+
+func (s *WellKnownControllerSuite) TestJwks_DoesNotPublishHMACSecret() {
+	// Act
+	resp, code, err := httputil.RequestHTTP[jwt.JSONWebKeySet](
+		s.e,
+		http.MethodGet,
+		JwksEndpoint,
+		nil,
+		nil,
+	)
+
+	// Assert
+	s.r.NoError(err)
+	s.r.Equal(http.StatusOK, code)
+	s.r.NotNil(resp.Keys)
+	s.r.Empty(resp.Keys)
+}
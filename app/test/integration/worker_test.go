@@ -2,12 +2,19 @@ package integration
 
 import (
 	"backend/service-platform/app/database/constant/job"
+	"backend/service-platform/app/database/constant/role"
+	userstatus "backend/service-platform/app/database/constant/user"
+	"backend/service-platform/app/database/entity"
+	queryutil "backend/service-platform/app/database/repository/query_utils"
 	"backend/service-platform/app/manager"
+	"backend/service-platform/app/pkg/denylist"
+	"backend/service-platform/app/pkg/jwt"
 	"backend/service-platform/app/pkg/worker/handlers"
 	"context"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -186,3 +193,58 @@ func (s *WorkerSuite) TestJobsByStatus() {
 	}
 	s.a.Equal(2, foundJobs, "Should find both created jobs in pending status")
 }
+
+func (s *WorkerSuite) TestPurgeAccountHandler() {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Second)
+	defer cancel()
+
+	email := "purge-handler@example.com"
+	u, err := s.repositories.UserRepository.Insert(ctx, &entity.User{
+		Username:      "purge-handler",
+		Email:         &email,
+		Password:      "not-a-hash",
+		Status:        userstatus.Verified,
+		Role:          role.User,
+		EmailVerified: true,
+	})
+	s.r.NoError(err)
+	_, err = s.repositories.UserRepository.ScheduleDeletion(ctx, u.ID, time.Now().Add(-time.Minute))
+	s.r.NoError(err)
+
+	j := jwt.NewJwt(s.resource.Config.JwtConfig)
+	roleStr := string(u.Role)
+	issuedAt := time.Now()
+	token, err := j.GenerateAccessToken(&u.ID, &u.Username, u.Email, nil, &roleStr, &u.EmailVerified, &u.PhoneVerified, &issuedAt, nil)
+	s.r.NoError(err)
+	claims, err := j.ValidateToken(token.Token)
+	s.r.NoError(err)
+
+	// Only one purge job stays open per account, as the deletion scheduler of every worker queues it
+	newPurgeJob := func() *entity.Job {
+		return &entity.Job{
+			ID:          uuid.New(),
+			Type:        string(job.PurgeAccount),
+			Priority:    job.PriorityLow,
+			Payload:     entity.JobPayload{job.PayloadUserID: u.ID.String()},
+			MaxAttempts: 3,
+			CreatedAt:   time.Now(),
+			Status:      job.Pending,
+		}
+	}
+	purgeJob := newPurgeJob()
+	s.r.NoError(s.repositories.JobRepository.Create(ctx, purgeJob))
+	s.r.True(queryutil.IsUniqueViolation(s.repositories.JobRepository.Create(ctx, newPurgeJob())))
+
+	tokens := denylist.NewRedisDenylist(s.resource.Redis, s.resource.Config.JwtConfig)
+	handler := handlers.NewPurgeAccountHandler(s.repositories, tokens, s.resource.Config.AccountLifecycleConfig, s.resource.Logger)
+	s.r.NoError(handler.Handle(ctx, purgeJob))
+
+	purged, err := s.repositories.UserRepository.FindByIDWithDeleted(ctx, u.ID)
+	s.r.NoError(err)
+	s.r.NotNil(purged.PurgedAt)
+	s.r.Equal(userstatus.Purged, purged.Status)
+	s.r.ErrorIs(tokens.Check(ctx, claims), denylist.ErrTokenRevoked)
+
+	// A retry of the same job finds the account purged and completes
+	s.r.NoError(handler.Handle(ctx, purgeJob))
+}
@@ -8,6 +8,7 @@ import (
 	"backend/service-platform/app/api/client/request"
 	"backend/service-platform/app/api/client/response"
 	"context"
+	"github.com/google/uuid"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -39,27 +40,27 @@ func (_m *MockAuthManager) EXPECT() *MockAuthManager_Expecter {
 	return &MockAuthManager_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function for the type MockAuthManager
-func (_mock *MockAuthManager) Login(ctx context.Context, request1 request.AuthUserRequest) (*response.AuthResponse, error) {
+// CompleteOidcLogin provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) CompleteOidcLogin(ctx context.Context, request1 request.OidcCallbackRequest) (*response.AuthResponse, error) {
 	ret := _mock.Called(ctx, request1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for CompleteOidcLogin")
 	}
 
 	var r0 *response.AuthResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, request.AuthUserRequest) (*response.AuthResponse, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.OidcCallbackRequest) (*response.AuthResponse, error)); ok {
 		return returnFunc(ctx, request1)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, request.AuthUserRequest) *response.AuthResponse); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.OidcCallbackRequest) *response.AuthResponse); ok {
 		r0 = returnFunc(ctx, request1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.AuthResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, request.AuthUserRequest) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.OidcCallbackRequest) error); ok {
 		r1 = returnFunc(ctx, request1)
 	} else {
 		r1 = ret.Error(1)
@@ -67,27 +68,27 @@ func (_mock *MockAuthManager) Login(ctx context.Context, request1 request.AuthUs
 	return r0, r1
 }
 
-// MockAuthManager_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type MockAuthManager_Login_Call struct {
+// MockAuthManager_CompleteOidcLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteOidcLogin'
+type MockAuthManager_CompleteOidcLogin_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// CompleteOidcLogin is a helper method to define mock.On call
 //   - ctx context.Context
-//   - request1 request.AuthUserRequest
-func (_e *MockAuthManager_Expecter) Login(ctx interface{}, request1 interface{}) *MockAuthManager_Login_Call {
-	return &MockAuthManager_Login_Call{Call: _e.mock.On("Login", ctx, request1)}
+//   - request1 request.OidcCallbackRequest
+func (_e *MockAuthManager_Expecter) CompleteOidcLogin(ctx interface{}, request1 interface{}) *MockAuthManager_CompleteOidcLogin_Call {
+	return &MockAuthManager_CompleteOidcLogin_Call{Call: _e.mock.On("CompleteOidcLogin", ctx, request1)}
 }
 
-func (_c *MockAuthManager_Login_Call) Run(run func(ctx context.Context, request1 request.AuthUserRequest)) *MockAuthManager_Login_Call {
+func (_c *MockAuthManager_CompleteOidcLogin_Call) Run(run func(ctx context.Context, request1 request.OidcCallbackRequest)) *MockAuthManager_CompleteOidcLogin_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 request.AuthUserRequest
+		var arg1 request.OidcCallbackRequest
 		if args[1] != nil {
-			arg1 = args[1].(request.AuthUserRequest)
+			arg1 = args[1].(request.OidcCallbackRequest)
 		}
 		run(
 			arg0,
@@ -97,26 +98,156 @@ func (_c *MockAuthManager_Login_Call) Run(run func(ctx context.Context, request1
 	return _c
 }
 
-func (_c *MockAuthManager_Login_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_Login_Call {
+func (_c *MockAuthManager_CompleteOidcLogin_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_CompleteOidcLogin_Call {
 	_c.Call.Return(authResponse, err)
 	return _c
 }
 
-func (_c *MockAuthManager_Login_Call) RunAndReturn(run func(ctx context.Context, request1 request.AuthUserRequest) (*response.AuthResponse, error)) *MockAuthManager_Login_Call {
+func (_c *MockAuthManager_CompleteOidcLogin_Call) RunAndReturn(run func(ctx context.Context, request1 request.OidcCallbackRequest) (*response.AuthResponse, error)) *MockAuthManager_CompleteOidcLogin_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Logout provides a mock function for the type MockAuthManager
-func (_mock *MockAuthManager) Logout(ctx context.Context, request1 request.LogoutRequest) error {
+// ConfirmMfa provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) ConfirmMfa(ctx context.Context, request1 request.MfaConfirmRequest) (*response.MfaConfirmResponse, error) {
 	ret := _mock.Called(ctx, request1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Logout")
+		panic("no return value specified for ConfirmMfa")
+	}
+
+	var r0 *response.MfaConfirmResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaConfirmRequest) (*response.MfaConfirmResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaConfirmRequest) *response.MfaConfirmResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.MfaConfirmResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.MfaConfirmRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_ConfirmMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmMfa'
+type MockAuthManager_ConfirmMfa_Call struct {
+	*mock.Call
+}
+
+// ConfirmMfa is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.MfaConfirmRequest
+func (_e *MockAuthManager_Expecter) ConfirmMfa(ctx interface{}, request1 interface{}) *MockAuthManager_ConfirmMfa_Call {
+	return &MockAuthManager_ConfirmMfa_Call{Call: _e.mock.On("ConfirmMfa", ctx, request1)}
+}
+
+func (_c *MockAuthManager_ConfirmMfa_Call) Run(run func(ctx context.Context, request1 request.MfaConfirmRequest)) *MockAuthManager_ConfirmMfa_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.MfaConfirmRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.MfaConfirmRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_ConfirmMfa_Call) Return(mfaConfirmResponse *response.MfaConfirmResponse, err error) *MockAuthManager_ConfirmMfa_Call {
+	_c.Call.Return(mfaConfirmResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_ConfirmMfa_Call) RunAndReturn(run func(ctx context.Context, request1 request.MfaConfirmRequest) (*response.MfaConfirmResponse, error)) *MockAuthManager_ConfirmMfa_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSiweNonce provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) CreateSiweNonce(ctx context.Context) (*response.SiweNonceResponse, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSiweNonce")
+	}
+
+	var r0 *response.SiweNonceResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*response.SiweNonceResponse, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *response.SiweNonceResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.SiweNonceResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_CreateSiweNonce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSiweNonce'
+type MockAuthManager_CreateSiweNonce_Call struct {
+	*mock.Call
+}
+
+// CreateSiweNonce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuthManager_Expecter) CreateSiweNonce(ctx interface{}) *MockAuthManager_CreateSiweNonce_Call {
+	return &MockAuthManager_CreateSiweNonce_Call{Call: _e.mock.On("CreateSiweNonce", ctx)}
+}
+
+func (_c *MockAuthManager_CreateSiweNonce_Call) Run(run func(ctx context.Context)) *MockAuthManager_CreateSiweNonce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_CreateSiweNonce_Call) Return(siweNonceResponse *response.SiweNonceResponse, err error) *MockAuthManager_CreateSiweNonce_Call {
+	_c.Call.Return(siweNonceResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_CreateSiweNonce_Call) RunAndReturn(run func(ctx context.Context) (*response.SiweNonceResponse, error)) *MockAuthManager_CreateSiweNonce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableMfa provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) DisableMfa(ctx context.Context, request1 request.MfaDisableRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMfa")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, request.LogoutRequest) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaDisableRequest) error); ok {
 		r0 = returnFunc(ctx, request1)
 	} else {
 		r0 = ret.Error(0)
@@ -124,27 +255,27 @@ func (_mock *MockAuthManager) Logout(ctx context.Context, request1 request.Logou
 	return r0
 }
 
-// MockAuthManager_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
-type MockAuthManager_Logout_Call struct {
+// MockAuthManager_DisableMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableMfa'
+type MockAuthManager_DisableMfa_Call struct {
 	*mock.Call
 }
 
-// Logout is a helper method to define mock.On call
+// DisableMfa is a helper method to define mock.On call
 //   - ctx context.Context
-//   - request1 request.LogoutRequest
-func (_e *MockAuthManager_Expecter) Logout(ctx interface{}, request1 interface{}) *MockAuthManager_Logout_Call {
-	return &MockAuthManager_Logout_Call{Call: _e.mock.On("Logout", ctx, request1)}
+//   - request1 request.MfaDisableRequest
+func (_e *MockAuthManager_Expecter) DisableMfa(ctx interface{}, request1 interface{}) *MockAuthManager_DisableMfa_Call {
+	return &MockAuthManager_DisableMfa_Call{Call: _e.mock.On("DisableMfa", ctx, request1)}
 }
 
-func (_c *MockAuthManager_Logout_Call) Run(run func(ctx context.Context, request1 request.LogoutRequest)) *MockAuthManager_Logout_Call {
+func (_c *MockAuthManager_DisableMfa_Call) Run(run func(ctx context.Context, request1 request.MfaDisableRequest)) *MockAuthManager_DisableMfa_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 request.LogoutRequest
+		var arg1 request.MfaDisableRequest
 		if args[1] != nil {
-			arg1 = args[1].(request.LogoutRequest)
+			arg1 = args[1].(request.MfaDisableRequest)
 		}
 		run(
 			arg0,
@@ -154,37 +285,37 @@ func (_c *MockAuthManager_Logout_Call) Run(run func(ctx context.Context, request
 	return _c
 }
 
-func (_c *MockAuthManager_Logout_Call) Return(err error) *MockAuthManager_Logout_Call {
+func (_c *MockAuthManager_DisableMfa_Call) Return(err error) *MockAuthManager_DisableMfa_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAuthManager_Logout_Call) RunAndReturn(run func(ctx context.Context, request1 request.LogoutRequest) error) *MockAuthManager_Logout_Call {
+func (_c *MockAuthManager_DisableMfa_Call) RunAndReturn(run func(ctx context.Context, request1 request.MfaDisableRequest) error) *MockAuthManager_DisableMfa_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RefreshToken provides a mock function for the type MockAuthManager
-func (_mock *MockAuthManager) RefreshToken(ctx context.Context, request1 request.RefreshTokenRequest) (*response.AuthResponse, error) {
+// EnrollMfa provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) EnrollMfa(ctx context.Context, request1 request.MfaEnrollRequest) (*response.MfaEnrollmentResponse, error) {
 	ret := _mock.Called(ctx, request1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RefreshToken")
+		panic("no return value specified for EnrollMfa")
 	}
 
-	var r0 *response.AuthResponse
+	var r0 *response.MfaEnrollmentResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, request.RefreshTokenRequest) (*response.AuthResponse, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaEnrollRequest) (*response.MfaEnrollmentResponse, error)); ok {
 		return returnFunc(ctx, request1)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, request.RefreshTokenRequest) *response.AuthResponse); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaEnrollRequest) *response.MfaEnrollmentResponse); ok {
 		r0 = returnFunc(ctx, request1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*response.AuthResponse)
+			r0 = ret.Get(0).(*response.MfaEnrollmentResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, request.RefreshTokenRequest) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.MfaEnrollRequest) error); ok {
 		r1 = returnFunc(ctx, request1)
 	} else {
 		r1 = ret.Error(1)
@@ -192,27 +323,27 @@ func (_mock *MockAuthManager) RefreshToken(ctx context.Context, request1 request
 	return r0, r1
 }
 
-// MockAuthManager_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
-type MockAuthManager_RefreshToken_Call struct {
+// MockAuthManager_EnrollMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollMfa'
+type MockAuthManager_EnrollMfa_Call struct {
 	*mock.Call
 }
 
-// RefreshToken is a helper method to define mock.On call
+// EnrollMfa is a helper method to define mock.On call
 //   - ctx context.Context
-//   - request1 request.RefreshTokenRequest
-func (_e *MockAuthManager_Expecter) RefreshToken(ctx interface{}, request1 interface{}) *MockAuthManager_RefreshToken_Call {
-	return &MockAuthManager_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, request1)}
+//   - request1 request.MfaEnrollRequest
+func (_e *MockAuthManager_Expecter) EnrollMfa(ctx interface{}, request1 interface{}) *MockAuthManager_EnrollMfa_Call {
+	return &MockAuthManager_EnrollMfa_Call{Call: _e.mock.On("EnrollMfa", ctx, request1)}
 }
 
-func (_c *MockAuthManager_RefreshToken_Call) Run(run func(ctx context.Context, request1 request.RefreshTokenRequest)) *MockAuthManager_RefreshToken_Call {
+func (_c *MockAuthManager_EnrollMfa_Call) Run(run func(ctx context.Context, request1 request.MfaEnrollRequest)) *MockAuthManager_EnrollMfa_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 request.RefreshTokenRequest
+		var arg1 request.MfaEnrollRequest
 		if args[1] != nil {
-			arg1 = args[1].(request.RefreshTokenRequest)
+			arg1 = args[1].(request.MfaEnrollRequest)
 		}
 		run(
 			arg0,
@@ -222,26 +353,26 @@ func (_c *MockAuthManager_RefreshToken_Call) Run(run func(ctx context.Context, r
 	return _c
 }
 
-func (_c *MockAuthManager_RefreshToken_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_RefreshToken_Call {
-	_c.Call.Return(authResponse, err)
+func (_c *MockAuthManager_EnrollMfa_Call) Return(mfaEnrollmentResponse *response.MfaEnrollmentResponse, err error) *MockAuthManager_EnrollMfa_Call {
+	_c.Call.Return(mfaEnrollmentResponse, err)
 	return _c
 }
 
-func (_c *MockAuthManager_RefreshToken_Call) RunAndReturn(run func(ctx context.Context, request1 request.RefreshTokenRequest) (*response.AuthResponse, error)) *MockAuthManager_RefreshToken_Call {
+func (_c *MockAuthManager_EnrollMfa_Call) RunAndReturn(run func(ctx context.Context, request1 request.MfaEnrollRequest) (*response.MfaEnrollmentResponse, error)) *MockAuthManager_EnrollMfa_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function for the type MockAuthManager
-func (_mock *MockAuthManager) Register(ctx context.Context, request1 request.RegisterRequest) error {
+// ForgotPassword provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) ForgotPassword(ctx context.Context, request1 request.ForgotPasswordRequest) error {
 	ret := _mock.Called(ctx, request1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Register")
+		panic("no return value specified for ForgotPassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, request.RegisterRequest) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.ForgotPasswordRequest) error); ok {
 		r0 = returnFunc(ctx, request1)
 	} else {
 		r0 = ret.Error(0)
@@ -249,27 +380,27 @@ func (_mock *MockAuthManager) Register(ctx context.Context, request1 request.Reg
 	return r0
 }
 
-// MockAuthManager_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
-type MockAuthManager_Register_Call struct {
+// MockAuthManager_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type MockAuthManager_ForgotPassword_Call struct {
 	*mock.Call
 }
 
-// Register is a helper method to define mock.On call
+// ForgotPassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - request1 request.RegisterRequest
-func (_e *MockAuthManager_Expecter) Register(ctx interface{}, request1 interface{}) *MockAuthManager_Register_Call {
-	return &MockAuthManager_Register_Call{Call: _e.mock.On("Register", ctx, request1)}
+//   - request1 request.ForgotPasswordRequest
+func (_e *MockAuthManager_Expecter) ForgotPassword(ctx interface{}, request1 interface{}) *MockAuthManager_ForgotPassword_Call {
+	return &MockAuthManager_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, request1)}
 }
 
-func (_c *MockAuthManager_Register_Call) Run(run func(ctx context.Context, request1 request.RegisterRequest)) *MockAuthManager_Register_Call {
+func (_c *MockAuthManager_ForgotPassword_Call) Run(run func(ctx context.Context, request1 request.ForgotPasswordRequest)) *MockAuthManager_ForgotPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 request.RegisterRequest
+		var arg1 request.ForgotPasswordRequest
 		if args[1] != nil {
-			arg1 = args[1].(request.RegisterRequest)
+			arg1 = args[1].(request.ForgotPasswordRequest)
 		}
 		run(
 			arg0,
@@ -279,12 +410,887 @@ func (_c *MockAuthManager_Register_Call) Run(run func(ctx context.Context, reque
 	return _c
 }
 
-func (_c *MockAuthManager_Register_Call) Return(err error) *MockAuthManager_Register_Call {
+func (_c *MockAuthManager_ForgotPassword_Call) Return(err error) *MockAuthManager_ForgotPassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAuthManager_Register_Call) RunAndReturn(run func(ctx context.Context, request1 request.RegisterRequest) error) *MockAuthManager_Register_Call {
+func (_c *MockAuthManager_ForgotPassword_Call) RunAndReturn(run func(ctx context.Context, request1 request.ForgotPasswordRequest) error) *MockAuthManager_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) Login(ctx context.Context, request1 request.AuthUserRequest) (*response.AuthResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *response.AuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.AuthUserRequest) (*response.AuthResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.AuthUserRequest) *response.AuthResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.AuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.AuthUserRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockAuthManager_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.AuthUserRequest
+func (_e *MockAuthManager_Expecter) Login(ctx interface{}, request1 interface{}) *MockAuthManager_Login_Call {
+	return &MockAuthManager_Login_Call{Call: _e.mock.On("Login", ctx, request1)}
+}
+
+func (_c *MockAuthManager_Login_Call) Run(run func(ctx context.Context, request1 request.AuthUserRequest)) *MockAuthManager_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.AuthUserRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.AuthUserRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_Login_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_Login_Call {
+	_c.Call.Return(authResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_Login_Call) RunAndReturn(run func(ctx context.Context, request1 request.AuthUserRequest) (*response.AuthResponse, error)) *MockAuthManager_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) Logout(ctx context.Context, request1 request.LogoutRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.LogoutRequest) error); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuthManager_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.LogoutRequest
+func (_e *MockAuthManager_Expecter) Logout(ctx interface{}, request1 interface{}) *MockAuthManager_Logout_Call {
+	return &MockAuthManager_Logout_Call{Call: _e.mock.On("Logout", ctx, request1)}
+}
+
+func (_c *MockAuthManager_Logout_Call) Run(run func(ctx context.Context, request1 request.LogoutRequest)) *MockAuthManager_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.LogoutRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.LogoutRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_Logout_Call) Return(err error) *MockAuthManager_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_Logout_Call) RunAndReturn(run func(ctx context.Context, request1 request.LogoutRequest) error) *MockAuthManager_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReactivateAccount provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) ReactivateAccount(ctx context.Context, request1 request.AuthUserRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactivateAccount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.AuthUserRequest) error); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_ReactivateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReactivateAccount'
+type MockAuthManager_ReactivateAccount_Call struct {
+	*mock.Call
+}
+
+// ReactivateAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.AuthUserRequest
+func (_e *MockAuthManager_Expecter) ReactivateAccount(ctx interface{}, request1 interface{}) *MockAuthManager_ReactivateAccount_Call {
+	return &MockAuthManager_ReactivateAccount_Call{Call: _e.mock.On("ReactivateAccount", ctx, request1)}
+}
+
+func (_c *MockAuthManager_ReactivateAccount_Call) Run(run func(ctx context.Context, request1 request.AuthUserRequest)) *MockAuthManager_ReactivateAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.AuthUserRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.AuthUserRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_ReactivateAccount_Call) Return(err error) *MockAuthManager_ReactivateAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_ReactivateAccount_Call) RunAndReturn(run func(ctx context.Context, request1 request.AuthUserRequest) error) *MockAuthManager_ReactivateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshToken provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) RefreshToken(ctx context.Context, request1 request.RefreshTokenRequest) (*response.AuthResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 *response.AuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.RefreshTokenRequest) (*response.AuthResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.RefreshTokenRequest) *response.AuthResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.AuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.RefreshTokenRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type MockAuthManager_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.RefreshTokenRequest
+func (_e *MockAuthManager_Expecter) RefreshToken(ctx interface{}, request1 interface{}) *MockAuthManager_RefreshToken_Call {
+	return &MockAuthManager_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, request1)}
+}
+
+func (_c *MockAuthManager_RefreshToken_Call) Run(run func(ctx context.Context, request1 request.RefreshTokenRequest)) *MockAuthManager_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.RefreshTokenRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.RefreshTokenRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_RefreshToken_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_RefreshToken_Call {
+	_c.Call.Return(authResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_RefreshToken_Call) RunAndReturn(run func(ctx context.Context, request1 request.RefreshTokenRequest) (*response.AuthResponse, error)) *MockAuthManager_RefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) Register(ctx context.Context, request1 request.RegisterRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.RegisterRequest) error); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockAuthManager_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.RegisterRequest
+func (_e *MockAuthManager_Expecter) Register(ctx interface{}, request1 interface{}) *MockAuthManager_Register_Call {
+	return &MockAuthManager_Register_Call{Call: _e.mock.On("Register", ctx, request1)}
+}
+
+func (_c *MockAuthManager_Register_Call) Run(run func(ctx context.Context, request1 request.RegisterRequest)) *MockAuthManager_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.RegisterRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.RegisterRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_Register_Call) Return(err error) *MockAuthManager_Register_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_Register_Call) RunAndReturn(run func(ctx context.Context, request1 request.RegisterRequest) error) *MockAuthManager_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerification provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) ResendVerification(ctx context.Context, request1 request.ResendVerificationRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.ResendVerificationRequest) error); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type MockAuthManager_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.ResendVerificationRequest
+func (_e *MockAuthManager_Expecter) ResendVerification(ctx interface{}, request1 interface{}) *MockAuthManager_ResendVerification_Call {
+	return &MockAuthManager_ResendVerification_Call{Call: _e.mock.On("ResendVerification", ctx, request1)}
+}
+
+func (_c *MockAuthManager_ResendVerification_Call) Run(run func(ctx context.Context, request1 request.ResendVerificationRequest)) *MockAuthManager_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.ResendVerificationRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.ResendVerificationRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_ResendVerification_Call) Return(err error) *MockAuthManager_ResendVerification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_ResendVerification_Call) RunAndReturn(run func(ctx context.Context, request1 request.ResendVerificationRequest) error) *MockAuthManager_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) ResetPassword(ctx context.Context, request1 request.ResetPasswordRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.ResetPasswordRequest) error); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockAuthManager_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.ResetPasswordRequest
+func (_e *MockAuthManager_Expecter) ResetPassword(ctx interface{}, request1 interface{}) *MockAuthManager_ResetPassword_Call {
+	return &MockAuthManager_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, request1)}
+}
+
+func (_c *MockAuthManager_ResetPassword_Call) Run(run func(ctx context.Context, request1 request.ResetPasswordRequest)) *MockAuthManager_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.ResetPasswordRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.ResetPasswordRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_ResetPassword_Call) Return(err error) *MockAuthManager_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, request1 request.ResetPasswordRequest) error) *MockAuthManager_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendPhoneOtp provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) SendPhoneOtp(ctx context.Context, request1 request.SendPhoneOtpRequest) (*response.PhoneOtpResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPhoneOtp")
+	}
+
+	var r0 *response.PhoneOtpResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.SendPhoneOtpRequest) (*response.PhoneOtpResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.SendPhoneOtpRequest) *response.PhoneOtpResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.PhoneOtpResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.SendPhoneOtpRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_SendPhoneOtp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPhoneOtp'
+type MockAuthManager_SendPhoneOtp_Call struct {
+	*mock.Call
+}
+
+// SendPhoneOtp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.SendPhoneOtpRequest
+func (_e *MockAuthManager_Expecter) SendPhoneOtp(ctx interface{}, request1 interface{}) *MockAuthManager_SendPhoneOtp_Call {
+	return &MockAuthManager_SendPhoneOtp_Call{Call: _e.mock.On("SendPhoneOtp", ctx, request1)}
+}
+
+func (_c *MockAuthManager_SendPhoneOtp_Call) Run(run func(ctx context.Context, request1 request.SendPhoneOtpRequest)) *MockAuthManager_SendPhoneOtp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.SendPhoneOtpRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.SendPhoneOtpRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_SendPhoneOtp_Call) Return(phoneOtpResponse *response.PhoneOtpResponse, err error) *MockAuthManager_SendPhoneOtp_Call {
+	_c.Call.Return(phoneOtpResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_SendPhoneOtp_Call) RunAndReturn(run func(ctx context.Context, request1 request.SendPhoneOtpRequest) (*response.PhoneOtpResponse, error)) *MockAuthManager_SendPhoneOtp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SiweLogin provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) SiweLogin(ctx context.Context, request1 request.SiweLoginRequest) (*response.AuthResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SiweLogin")
+	}
+
+	var r0 *response.AuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.SiweLoginRequest) (*response.AuthResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.SiweLoginRequest) *response.AuthResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.AuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.SiweLoginRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_SiweLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SiweLogin'
+type MockAuthManager_SiweLogin_Call struct {
+	*mock.Call
+}
+
+// SiweLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.SiweLoginRequest
+func (_e *MockAuthManager_Expecter) SiweLogin(ctx interface{}, request1 interface{}) *MockAuthManager_SiweLogin_Call {
+	return &MockAuthManager_SiweLogin_Call{Call: _e.mock.On("SiweLogin", ctx, request1)}
+}
+
+func (_c *MockAuthManager_SiweLogin_Call) Run(run func(ctx context.Context, request1 request.SiweLoginRequest)) *MockAuthManager_SiweLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.SiweLoginRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.SiweLoginRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_SiweLogin_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_SiweLogin_Call {
+	_c.Call.Return(authResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_SiweLogin_Call) RunAndReturn(run func(ctx context.Context, request1 request.SiweLoginRequest) (*response.AuthResponse, error)) *MockAuthManager_SiweLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartOidcLogin provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) StartOidcLogin(ctx context.Context, request1 request.OidcStartRequest) (*response.OidcStartResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartOidcLogin")
+	}
+
+	var r0 *response.OidcStartResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.OidcStartRequest) (*response.OidcStartResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.OidcStartRequest) *response.OidcStartResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.OidcStartResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.OidcStartRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_StartOidcLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartOidcLogin'
+type MockAuthManager_StartOidcLogin_Call struct {
+	*mock.Call
+}
+
+// StartOidcLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.OidcStartRequest
+func (_e *MockAuthManager_Expecter) StartOidcLogin(ctx interface{}, request1 interface{}) *MockAuthManager_StartOidcLogin_Call {
+	return &MockAuthManager_StartOidcLogin_Call{Call: _e.mock.On("StartOidcLogin", ctx, request1)}
+}
+
+func (_c *MockAuthManager_StartOidcLogin_Call) Run(run func(ctx context.Context, request1 request.OidcStartRequest)) *MockAuthManager_StartOidcLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.OidcStartRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.OidcStartRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_StartOidcLogin_Call) Return(oidcStartResponse *response.OidcStartResponse, err error) *MockAuthManager_StartOidcLogin_Call {
+	_c.Call.Return(oidcStartResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_StartOidcLogin_Call) RunAndReturn(run func(ctx context.Context, request1 request.OidcStartRequest) (*response.OidcStartResponse, error)) *MockAuthManager_StartOidcLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockAccount provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) UnlockAccount(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockAccount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_UnlockAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockAccount'
+type MockAuthManager_UnlockAccount_Call struct {
+	*mock.Call
+}
+
+// UnlockAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockAuthManager_Expecter) UnlockAccount(ctx interface{}, userID interface{}) *MockAuthManager_UnlockAccount_Call {
+	return &MockAuthManager_UnlockAccount_Call{Call: _e.mock.On("UnlockAccount", ctx, userID)}
+}
+
+func (_c *MockAuthManager_UnlockAccount_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockAuthManager_UnlockAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_UnlockAccount_Call) Return(err error) *MockAuthManager_UnlockAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_UnlockAccount_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockAuthManager_UnlockAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) VerifyEmail(ctx context.Context, request1 request.VerifyEmailRequest) error {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.VerifyEmailRequest) error); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthManager_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockAuthManager_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.VerifyEmailRequest
+func (_e *MockAuthManager_Expecter) VerifyEmail(ctx interface{}, request1 interface{}) *MockAuthManager_VerifyEmail_Call {
+	return &MockAuthManager_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, request1)}
+}
+
+func (_c *MockAuthManager_VerifyEmail_Call) Run(run func(ctx context.Context, request1 request.VerifyEmailRequest)) *MockAuthManager_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.VerifyEmailRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.VerifyEmailRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_VerifyEmail_Call) Return(err error) *MockAuthManager_VerifyEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthManager_VerifyEmail_Call) RunAndReturn(run func(ctx context.Context, request1 request.VerifyEmailRequest) error) *MockAuthManager_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyMfa provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) VerifyMfa(ctx context.Context, request1 request.MfaVerifyRequest) (*response.AuthResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMfa")
+	}
+
+	var r0 *response.AuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaVerifyRequest) (*response.AuthResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.MfaVerifyRequest) *response.AuthResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.AuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.MfaVerifyRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_VerifyMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMfa'
+type MockAuthManager_VerifyMfa_Call struct {
+	*mock.Call
+}
+
+// VerifyMfa is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.MfaVerifyRequest
+func (_e *MockAuthManager_Expecter) VerifyMfa(ctx interface{}, request1 interface{}) *MockAuthManager_VerifyMfa_Call {
+	return &MockAuthManager_VerifyMfa_Call{Call: _e.mock.On("VerifyMfa", ctx, request1)}
+}
+
+func (_c *MockAuthManager_VerifyMfa_Call) Run(run func(ctx context.Context, request1 request.MfaVerifyRequest)) *MockAuthManager_VerifyMfa_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.MfaVerifyRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.MfaVerifyRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_VerifyMfa_Call) Return(authResponse *response.AuthResponse, err error) *MockAuthManager_VerifyMfa_Call {
+	_c.Call.Return(authResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_VerifyMfa_Call) RunAndReturn(run func(ctx context.Context, request1 request.MfaVerifyRequest) (*response.AuthResponse, error)) *MockAuthManager_VerifyMfa_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyPhoneOtp provides a mock function for the type MockAuthManager
+func (_mock *MockAuthManager) VerifyPhoneOtp(ctx context.Context, request1 request.VerifyPhoneOtpRequest) (*response.UserResponse, error) {
+	ret := _mock.Called(ctx, request1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPhoneOtp")
+	}
+
+	var r0 *response.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.VerifyPhoneOtpRequest) (*response.UserResponse, error)); ok {
+		return returnFunc(ctx, request1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, request.VerifyPhoneOtpRequest) *response.UserResponse); ok {
+		r0 = returnFunc(ctx, request1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.UserResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, request.VerifyPhoneOtpRequest) error); ok {
+		r1 = returnFunc(ctx, request1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthManager_VerifyPhoneOtp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyPhoneOtp'
+type MockAuthManager_VerifyPhoneOtp_Call struct {
+	*mock.Call
+}
+
+// VerifyPhoneOtp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request1 request.VerifyPhoneOtpRequest
+func (_e *MockAuthManager_Expecter) VerifyPhoneOtp(ctx interface{}, request1 interface{}) *MockAuthManager_VerifyPhoneOtp_Call {
+	return &MockAuthManager_VerifyPhoneOtp_Call{Call: _e.mock.On("VerifyPhoneOtp", ctx, request1)}
+}
+
+func (_c *MockAuthManager_VerifyPhoneOtp_Call) Run(run func(ctx context.Context, request1 request.VerifyPhoneOtpRequest)) *MockAuthManager_VerifyPhoneOtp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 request.VerifyPhoneOtpRequest
+		if args[1] != nil {
+			arg1 = args[1].(request.VerifyPhoneOtpRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthManager_VerifyPhoneOtp_Call) Return(userResponse *response.UserResponse, err error) *MockAuthManager_VerifyPhoneOtp_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockAuthManager_VerifyPhoneOtp_Call) RunAndReturn(run func(ctx context.Context, request1 request.VerifyPhoneOtpRequest) (*response.UserResponse, error)) *MockAuthManager_VerifyPhoneOtp_Call {
 	_c.Call.Return(run)
 	return _c
 }
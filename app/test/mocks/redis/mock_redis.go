@@ -77,6 +77,15 @@ func (m *InMemoryRedis) Get(ctx context.Context, key string, outPtr any) error {
 	return json.Unmarshal(b, outPtr)
 }
 
+func (m *InMemoryRedis) GetDel(ctx context.Context, key string, outPtr any) error {
+	var b []byte
+	if err := m.getBytes(ctx, key, &b); err != nil {
+		return err
+	}
+	_ = m.Delete(ctx, key)
+	return json.Unmarshal(b, outPtr)
+}
+
 func (m *InMemoryRedis) getBytes(_ context.Context, key string, out *[]byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -98,6 +107,13 @@ func (m *InMemoryRedis) Delete(ctx context.Context, key string) error {
 	m.mu.Unlock()
 	return nil
 }
+func (m *InMemoryRedis) DeleteExisting(ctx context.Context, key string) (bool, error) {
+	var b []byte
+	if err := m.getBytes(ctx, key, &b); err != nil {
+		return false, nil
+	}
+	return true, m.Delete(ctx, key)
+}
 func (m *InMemoryRedis) Increment(ctx context.Context, key string, val int64) error { return nil }
 func (m *InMemoryRedis) Decrement(ctx context.Context, key string, val int64) error { return nil }
 func (m *InMemoryRedis) SetExpire(ctx context.Context, key string, ttl time.Duration) error {
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/internal/runtime"
+	redismock "backend/service-platform/app/test/mocks/redis"
+)
+
+type ApiKeyAuthenticationSuite struct {
+	suite.Suite
+	middleware *middleware.Middleware
+	echo       *echo.Echo
+	req        *http.Request
+	rec        *httptest.ResponseRecorder
+	ctx        echo.Context
+	res        runtime.Resource
+}
+
+func TestApiKeyAuthenticationSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyAuthenticationSuite))
+}
+
+func (s *ApiKeyAuthenticationSuite) SetupSuite() {
+	logger, _ := zap.NewDevelopment()
+	s.res = runtime.Resource{
+		Config: config.ApplicationConfig{
+			JwtConfig: config.JwtConfig{SecretKey: "test-secret-key-for-api-key-testing"},
+		},
+		Logger: logger,
+		Redis:  redismock.NewInMemoryRedis(),
+	}
+}
+
+func (s *ApiKeyAuthenticationSuite) SetupTest() {
+	s.echo = echo.New()
+	s.req = httptest.NewRequest(http.MethodGet, "/", nil)
+	s.rec = httptest.NewRecorder()
+	s.ctx = s.echo.NewContext(s.req, s.rec)
+	s.middleware = middleware.NewMiddleware(s.res)
+}
+
+func (s *ApiKeyAuthenticationSuite) next(called *bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		*called = true
+		return nil
+	}
+}
+
+func (s *ApiKeyAuthenticationSuite) TestCanHandle_WithHeader() {
+	// Arrange
+	s.req.Header.Set("X-API-Key", "sk_0123456789ab_secret")
+
+	// Act & Assert
+	s.True(s.middleware.ApiKeyAuthentication.CanHandle(s.ctx))
+}
+
+func (s *ApiKeyAuthenticationSuite) TestCanHandle_WithoutHeader() {
+	// Act & Assert
+	s.False(s.middleware.ApiKeyAuthentication.CanHandle(s.ctx))
+}
+
+func (s *ApiKeyAuthenticationSuite) TestAuthenticate_MalformedKey() {
+	// Arrange - the legacy hardcoded keys are no longer accepted
+	s.req.Header.Set("X-API-Key", "service-key-1")
+
+	// Act
+	result, err := s.middleware.ApiKeyAuthentication.Authenticate(s.ctx)
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+}
+
+func (s *ApiKeyAuthenticationSuite) TestRequireRole_MissingKey() {
+	// Arrange
+	called := false
+
+	// Act
+	err := s.middleware.ApiKeyAuthentication.RequireRole("ADMIN")(s.next(&called))(s.ctx)
+
+	// Assert
+	var httpErr *echo.HTTPError
+	s.ErrorAs(err, &httpErr)
+	s.Equal(http.StatusUnauthorized, httpErr.Code)
+	s.False(called)
+}
+
+func (s *ApiKeyAuthenticationSuite) TestRequireRole_MalformedKey() {
+	// Arrange
+	s.req.Header.Set("X-API-Key", "service-key-1")
+	called := false
+
+	// Act
+	err := s.middleware.ApiKeyAuthentication.RequireRole("SERVICE")(s.next(&called))(s.ctx)
+
+	// Assert
+	var httpErr *echo.HTTPError
+	s.ErrorAs(err, &httpErr)
+	s.Equal(http.StatusUnauthorized, httpErr.Code)
+	s.False(called)
+}
+
+func (s *ApiKeyAuthenticationSuite) TestRequireScope_Granted() {
+	// Arrange
+	s.ctx.Set("auth_method", "api_key")
+	s.ctx.Set("scopes", []string{"jobs:read", "jobs:write"})
+	called := false
+
+	// Act
+	err := s.middleware.RequireScope("jobs:read")(s.next(&called))(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.True(called)
+}
+
+func (s *ApiKeyAuthenticationSuite) TestRequireScope_MissingScope() {
+	// Arrange
+	s.ctx.Set("auth_method", "api_key")
+	s.ctx.Set("scopes", []string{"jobs:read"})
+	called := false
+
+	// Act
+	err := s.middleware.RequireScope("jobs:read", "jobs:write")(s.next(&called))(s.ctx)
+
+	// Assert
+	var httpErr *echo.HTTPError
+	s.ErrorAs(err, &httpErr)
+	s.Equal(http.StatusForbidden, httpErr.Code)
+	s.False(called)
+}
+
+func (s *ApiKeyAuthenticationSuite) TestRequireScope_PrincipalWithoutScopes() {
+	// Arrange
+	s.ctx.Set("auth_method", "jwt")
+	called := false
+
+	// Act
+	err := s.middleware.RequireScope("jobs:read")(s.next(&called))(s.ctx)
+
+	// Assert
+	var httpErr *echo.HTTPError
+	s.ErrorAs(err, &httpErr)
+	s.Equal(http.StatusForbidden, httpErr.Code)
+	s.False(called)
+}
+
+func (s *ApiKeyAuthenticationSuite) TestRequireScope_Unauthenticated() {
+	// Arrange
+	called := false
+
+	// Act
+	err := s.middleware.RequireScope("jobs:read")(s.next(&called))(s.ctx)
+
+	// Assert
+	var httpErr *echo.HTTPError
+	s.ErrorAs(err, &httpErr)
+	s.Equal(http.StatusUnauthorized, httpErr.Code)
+	s.False(called)
+}
@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	gobcrypt "golang.org/x/crypto/bcrypt"
+
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/bcrypt"
+	jwtPkg "backend/service-platform/app/pkg/jwt"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	redismock "backend/service-platform/app/test/mocks/redis"
+)
+
+type CompositeAuthenticationSuite struct {
+	suite.Suite
+	echo       *echo.Echo
+	req        *http.Request
+	rec        *httptest.ResponseRecorder
+	ctx        echo.Context
+	res        runtime.Resource
+	testUserID uuid.UUID
+}
+
+func TestCompositeAuthenticationSuite(t *testing.T) {
+	suite.Run(t, new(CompositeAuthenticationSuite))
+}
+
+func (s *CompositeAuthenticationSuite) SetupSuite() {
+	s.testUserID = uuid.New()
+	logger, _ := zap.NewDevelopment()
+	s.res = runtime.Resource{
+		Config: config.ApplicationConfig{
+			JwtConfig: config.JwtConfig{
+				SecretKey:        "test-secret-key-for-composite-testing",
+				AccessExpiration: time.Hour,
+			},
+		},
+		Logger: logger,
+		Redis:  redismock.NewInMemoryRedis(),
+	}
+	// Token version as the cache holds it, the database is never reached
+	s.Require().NoError(s.res.Redis.Set(context.Background(), rediskey.TokenVersionKey(s.testUserID.String()), 0, time.Hour))
+}
+
+func (s *CompositeAuthenticationSuite) SetupTest() {
+	s.echo = echo.New()
+	s.req = httptest.NewRequest(http.MethodGet, "/", nil)
+	s.rec = httptest.NewRecorder()
+	s.ctx = s.echo.NewContext(s.req, s.rec)
+}
+
+func (s *CompositeAuthenticationSuite) newMiddleware(methods ...string) *middleware.Middleware {
+	res := s.res
+	res.Config.AuthenticationConfig.Methods = methods
+	return middleware.NewMiddleware(res)
+}
+
+func (s *CompositeAuthenticationSuite) accessToken(role string) string {
+	username := "testuser"
+	verified := true
+	now := time.Now()
+	token, err := jwtPkg.NewJwt(s.res.Config.JwtConfig).GenerateAccessToken(&s.testUserID, &username, &username, nil, &role, &verified, &verified, &now, nil)
+	s.Require().NoError(err)
+	return token.Token
+}
+
+func (s *CompositeAuthenticationSuite) run(mw echo.MiddlewareFunc) (bool, error) {
+	called := false
+	err := mw(func(c echo.Context) error {
+		called = true
+		return nil
+	})(s.ctx)
+	return called, err
+}
+
+func (s *CompositeAuthenticationSuite) assertStatus(err error, status int) {
+	s.Require().Error(err)
+	httpErr, ok := err.(*echo.HTTPError)
+	s.Require().True(ok)
+	s.Equal(status, httpErr.Code)
+}
+
+func (s *CompositeAuthenticationSuite) TestMethods_DefaultOrder() {
+	// Act & Assert
+	s.Equal([]string{middleware.AuthMethodJWT, middleware.AuthMethodAPIKey}, s.newMiddleware().Authentication.Methods())
+}
+
+func (s *CompositeAuthenticationSuite) TestMethods_ConfiguredOrder() {
+	// Arrange - unknown and repeated methods are skipped
+	m := s.newMiddleware(middleware.AuthMethodBasic, "unknown", middleware.AuthMethodJWT, middleware.AuthMethodBasic)
+
+	// Act & Assert
+	s.Equal([]string{middleware.AuthMethodBasic, middleware.AuthMethodJWT}, m.Authentication.Methods())
+}
+
+func (s *CompositeAuthenticationSuite) TestOnly_KeepsConfiguredOrder() {
+	// Arrange
+	m := s.newMiddleware(middleware.AuthMethodAPIKey, middleware.AuthMethodJWT)
+
+	// Act
+	chain := m.Authentication.Only(middleware.AuthMethodJWT, middleware.AuthMethodAPIKey, middleware.AuthMethodBasic)
+
+	// Assert - basic_auth is not configured and stays disabled
+	s.Equal([]string{middleware.AuthMethodAPIKey, middleware.AuthMethodJWT}, chain.Methods())
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_Jwt() {
+	// Arrange
+	s.req.Header.Set("Authorization", "Bearer "+s.accessToken("USER"))
+
+	// Act
+	called, err := s.run(s.newMiddleware().RequireAuth())
+
+	// Assert
+	s.NoError(err)
+	s.True(called)
+	s.Equal(middleware.AuthMethodJWT, s.ctx.Get("auth_method"))
+	s.Equal(s.testUserID.String(), s.ctx.Get("user_id"))
+	s.Equal(s.testUserID, s.ctx.Get("user_uuid"))
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_NoCredentials() {
+	// Act
+	called, err := s.run(s.newMiddleware().RequireAuth())
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_MethodNotAcceptedByRoute() {
+	// Arrange
+	s.req.Header.Set("X-API-Key", "sk_0123456789ab_secret")
+
+	// Act
+	called, err := s.run(s.newMiddleware().RequireAuth(middleware.AuthMethodJWT))
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_InvalidCredentialsDoNotFallThrough() {
+	// Arrange - the malformed API key is tried first and the valid token is never looked at
+	s.req.Header.Set("X-API-Key", "service-key-1")
+	s.req.Header.Set("Authorization", "Bearer "+s.accessToken("USER"))
+	m := s.newMiddleware(middleware.AuthMethodAPIKey, middleware.AuthMethodJWT)
+
+	// Act
+	called, err := s.run(m.RequireAuth())
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_BasicNotConfigured() {
+	// Arrange
+	s.req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:admin-password")))
+
+	// Act
+	called, err := s.run(s.newMiddleware().RequireAuth())
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_BasicDisabled() {
+	// Arrange - listed in the chain but not enabled, the former built-in credentials are gone
+	s.req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:admin-password")))
+	m := s.newMiddleware(middleware.AuthMethodJWT, middleware.AuthMethodBasic)
+
+	// Act
+	called, err := s.run(m.RequireAuth())
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAuth_BasicWrongPassword() {
+	// Arrange
+	hasher := bcrypt.NewBcrypt(gobcrypt.MinCost)
+	hash, err := hasher.HashPassword("correct-password")
+	s.Require().NoError(err)
+	res := s.res
+	res.Config.AuthenticationConfig = config.AuthenticationConfig{
+		Methods: []string{middleware.AuthMethodBasic},
+		BasicAuth: config.BasicAuthConfig{
+			Enabled:     true,
+			Credentials: []config.BasicAuthCredential{{Username: "reporting", PasswordHash: hash, UserID: s.testUserID.String()}},
+		},
+	}
+	s.req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("reporting:wrong-password")))
+
+	// Act - refused before the bound user is looked up
+	called, err := s.run(middleware.NewMiddleware(res).RequireAuth())
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireRole_InsufficientRole() {
+	// Arrange
+	s.req.Header.Set("Authorization", "Bearer "+s.accessToken("USER"))
+
+	// Act
+	called, err := s.run(s.newMiddleware().RequireRole("ADMIN"))
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusForbidden)
+}
+
+func (s *CompositeAuthenticationSuite) TestRequireAnyRole_AfterRequireAuth() {
+	// Arrange
+	s.req.Header.Set("Authorization", "Bearer "+s.accessToken("ADMIN"))
+	m := s.newMiddleware()
+
+	// Act
+	called, err := s.run(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return m.RequireAuth()(m.RequireAnyRole("ADMIN", "SUPER_ADMIN")(next))
+	})
+
+	// Assert
+	s.NoError(err)
+	s.True(called)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,7 +16,11 @@ import (
 	"backend/service-platform/app/api/middleware"
 	"backend/service-platform/app/internal/config"
 	"backend/service-platform/app/internal/runtime"
+	"backend/service-platform/app/pkg/denylist"
 	jwtPkg "backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/tokenversion"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	redismock "backend/service-platform/app/test/mocks/redis"
 
 	"go.uber.org/zap"
 )
@@ -50,7 +55,10 @@ func (s *JwtAuthenticationSuite) SetupSuite() {
 			},
 		},
 		Logger: logger,
+		Redis:  redismock.NewInMemoryRedis(),
 	}
+	// Token version as the cache holds it, the database is never reached
+	s.Require().NoError(s.res.Redis.Set(context.Background(), rediskey.TokenVersionKey(s.testUserID.String()), 0, time.Hour))
 
 	s.jwtInstance = jwtPkg.NewJwt(s.res.Config.JwtConfig)
 }
@@ -251,6 +259,97 @@ func (s *JwtAuthenticationSuite) TestAuthenticate_ExpiredToken() {
 	s.Contains(err.Error(), "Invalid credentials")
 }
 
+func (s *JwtAuthenticationSuite) TestAuthenticate_RefreshToken() {
+	// Arrange - a valid refresh token presented as an access token
+	username := "testuser"
+	userRole := "USER"
+	refreshToken, err := s.jwtInstance.GenerateRefreshToken(&s.testUserID, &username, nil, nil, &userRole, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", refreshToken.Token))
+
+	// Act
+	result, err := s.jwtAuth.Authenticate(s.ctx)
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+	s.Contains(err.Error(), "Invalid credentials")
+}
+
+func (s *JwtAuthenticationSuite) TestAuthenticate_DeniedToken() {
+	// Arrange
+	userID := uuid.New()
+	username := "testuser"
+	token, err := s.jwtInstance.GenerateAccessToken(&userID, &username, nil, nil, nil, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	claims, err := s.jwtInstance.ValidateToken(token.Token)
+	s.Require().NoError(err)
+	s.Require().NoError(denylist.NewRedisDenylist(s.res.Redis, s.res.Config.JwtConfig).RevokeToken(s.ctx.Request().Context(), claims))
+	s.req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.Token))
+
+	// Act
+	result, err := s.jwtAuth.Authenticate(s.ctx)
+
+	// Assert
+	s.ErrorIs(err, denylist.ErrTokenRevoked)
+	s.Nil(result)
+}
+
+func (s *JwtAuthenticationSuite) TestAuthenticate_IssuedBeforeUserRevocation() {
+	// Arrange
+	userID := uuid.New()
+	username := "testuser"
+	userRole := "USER"
+	validToken := s.createValidToken(&userID, &username, nil, &userRole)
+	s.Require().NoError(denylist.NewRedisDenylist(s.res.Redis, s.res.Config.JwtConfig).RevokeUserTokens(s.ctx.Request().Context(), userID))
+	s.req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", validToken))
+
+	// Act
+	result, err := s.jwtAuth.Authenticate(s.ctx)
+
+	// Assert
+	s.ErrorIs(err, denylist.ErrTokenRevoked)
+	s.Nil(result)
+}
+
+func (s *JwtAuthenticationSuite) TestAuthenticate_StaleTokenVersion() {
+	// Arrange - the user's role changed after the token was issued
+	userID := uuid.New()
+	username := "testuser"
+	userRole := "USER"
+	version := int64(1)
+	token, err := s.jwtInstance.GenerateAccessToken(&userID, &username, nil, nil, &userRole, nil, nil, nil, &version)
+	s.Require().NoError(err)
+	s.Require().NoError(s.res.Redis.Set(s.ctx.Request().Context(), rediskey.TokenVersionKey(userID.String()), 2, time.Hour))
+	s.req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.Token))
+
+	// Act
+	result, err := s.jwtAuth.Authenticate(s.ctx)
+
+	// Assert
+	s.ErrorIs(err, tokenversion.ErrStaleToken)
+	s.Nil(result)
+}
+
+func (s *JwtAuthenticationSuite) TestAuthenticate_CurrentTokenVersion() {
+	// Arrange
+	userID := uuid.New()
+	username := "testuser"
+	userRole := "USER"
+	version := int64(2)
+	token, err := s.jwtInstance.GenerateAccessToken(&userID, &username, nil, nil, &userRole, nil, nil, nil, &version)
+	s.Require().NoError(err)
+	s.Require().NoError(s.res.Redis.Set(s.ctx.Request().Context(), rediskey.TokenVersionKey(userID.String()), 2, time.Hour))
+	s.req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.Token))
+
+	// Act
+	result, err := s.jwtAuth.Authenticate(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(userID, *result.UserID)
+}
+
 func (s *JwtAuthenticationSuite) TestAuthenticate_NoAuthHeader() {
 	// Arrange - no authorization header
 
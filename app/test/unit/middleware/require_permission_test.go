@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"backend/service-platform/app/api/middleware"
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/internal/runtime"
+	jwtPkg "backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/rbac"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	redismock "backend/service-platform/app/test/mocks/redis"
+)
+
+type RequirePermissionSuite struct {
+	suite.Suite
+	middleware *middleware.Middleware
+	echo       *echo.Echo
+	req        *http.Request
+	rec        *httptest.ResponseRecorder
+	ctx        echo.Context
+	res        runtime.Resource
+}
+
+func TestRequirePermissionSuite(t *testing.T) {
+	suite.Run(t, new(RequirePermissionSuite))
+}
+
+func (s *RequirePermissionSuite) SetupSuite() {
+	logger, _ := zap.NewDevelopment()
+	rds := redismock.NewInMemoryRedis()
+	// Resolved mapping as the authorizer caches it, the database is never reached
+	s.Require().NoError(rds.Set(context.Background(), rediskey.RolePermissionsKey(), map[string][]string{
+		"USER":        {},
+		"OPERATOR":    {rbac.JobsRead, rbac.JobsRetry},
+		"ADMIN":       {rbac.JobsRead, rbac.JobsRetry, rbac.UsersRead, rbac.UsersSuspend},
+		"SUPER_ADMIN": {rbac.JobsRead, rbac.JobsRetry, rbac.RolesManage, rbac.UsersRead, rbac.UsersSuspend},
+	}, time.Hour))
+
+	s.res = runtime.Resource{
+		Config: config.ApplicationConfig{
+			JwtConfig: config.JwtConfig{
+				SecretKey:        "test-secret-key-for-permission-testing",
+				AccessExpiration: time.Hour,
+			},
+			AuthenticationConfig: config.AuthenticationConfig{
+				Methods: []string{middleware.AuthMethodJWT, middleware.AuthMethodAPIKey, middleware.AuthMethodBasic},
+			},
+		},
+		Logger: logger,
+		Redis:  rds,
+	}
+}
+
+func (s *RequirePermissionSuite) SetupTest() {
+	s.echo = echo.New()
+	s.req = httptest.NewRequest(http.MethodGet, "/", nil)
+	s.rec = httptest.NewRecorder()
+	s.ctx = s.echo.NewContext(s.req, s.rec)
+	s.middleware = middleware.NewMiddleware(s.res)
+}
+
+func (s *RequirePermissionSuite) bearer(role string) {
+	userID := uuid.New()
+	username := "testuser"
+	verified := true
+	now := time.Now()
+	token, err := jwtPkg.NewJwt(s.res.Config.JwtConfig).GenerateAccessToken(&userID, &username, &username, nil, &role, &verified, &verified, &now, nil)
+	s.Require().NoError(err)
+	// Token version as the cache holds it, the database is never reached
+	s.Require().NoError(s.res.Redis.Set(context.Background(), rediskey.TokenVersionKey(userID.String()), 0, time.Hour))
+	s.req.Header.Set("Authorization", "Bearer "+token.Token)
+}
+
+// run authenticates with the configured chain, then checks the permissions
+func (s *RequirePermissionSuite) run(permissions ...string) (bool, error) {
+	called := false
+	handler := s.middleware.RequireAuth()(s.middleware.RequirePermission(permissions...)(func(c echo.Context) error {
+		called = true
+		return nil
+	}))
+	return called, handler(s.ctx)
+}
+
+func (s *RequirePermissionSuite) assertStatus(err error, status int) {
+	s.Require().Error(err)
+	httpErr, ok := err.(*echo.HTTPError)
+	s.Require().True(ok)
+	s.Equal(status, httpErr.Code)
+}
+
+func (s *RequirePermissionSuite) TestJwt_InheritedPermission() {
+	// Arrange
+	s.bearer("ADMIN")
+
+	// Act
+	called, err := s.run(rbac.JobsRead, rbac.UsersSuspend)
+
+	// Assert
+	s.NoError(err)
+	s.True(called)
+}
+
+func (s *RequirePermissionSuite) TestJwt_MissingPermission() {
+	// Arrange
+	s.bearer("OPERATOR")
+
+	// Act
+	called, err := s.run(rbac.UsersSuspend)
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusForbidden)
+}
+
+func (s *RequirePermissionSuite) TestJwt_UnknownRole() {
+	// Arrange
+	s.bearer("GHOST")
+
+	// Act
+	called, err := s.run(rbac.JobsRead)
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusForbidden)
+}
+
+func (s *RequirePermissionSuite) TestBasicAuth_NotConfigured() {
+	// Arrange - basic_auth is in the chain but no credentials are configured
+	s.req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:admin-password")))
+
+	// Act
+	called, err := s.run(rbac.UsersRead)
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *RequirePermissionSuite) TestUnauthenticated() {
+	// Act
+	called := false
+	err := s.middleware.RequirePermission(rbac.JobsRead)(func(c echo.Context) error {
+		called = true
+		return nil
+	})(s.ctx)
+
+	// Assert
+	s.False(called)
+	s.assertStatus(err, http.StatusUnauthorized)
+}
+
+func (s *RequirePermissionSuite) TestApiKey_ServiceHoldsItsScopes() {
+	// Arrange - context as set by a service key
+	s.ctx.Set("auth_method", middleware.AuthMethodAPIKey)
+	s.ctx.Set("role", "SERVICE")
+	s.ctx.Set("scopes", []string{rbac.JobsRead})
+	mw := s.middleware.RequirePermission(rbac.JobsRead)
+	denied := s.middleware.RequirePermission(rbac.JobsRetry)
+	next := func(c echo.Context) error { return nil }
+
+	// Act & Assert
+	s.NoError(mw(next)(s.ctx))
+	s.assertStatus(denied(next)(s.ctx), http.StatusForbidden)
+}
+
+func (s *RequirePermissionSuite) TestApiKey_ScopesNarrowTheRole() {
+	// Arrange - context as set by a key owned by an admin
+	s.ctx.Set("auth_method", middleware.AuthMethodAPIKey)
+	s.ctx.Set("role", "ADMIN")
+	s.ctx.Set("scopes", []string{rbac.JobsRead, rbac.RolesManage})
+	next := func(c echo.Context) error { return nil }
+
+	// Act & Assert - in the scopes and held by the role
+	s.NoError(s.middleware.RequirePermission(rbac.JobsRead)(next)(s.ctx))
+	// held by the role, not in the scopes
+	s.assertStatus(s.middleware.RequirePermission(rbac.UsersRead)(next)(s.ctx), http.StatusForbidden)
+	// in the scopes, not held by the role
+	s.assertStatus(s.middleware.RequirePermission(rbac.RolesManage)(next)(s.ctx), http.StatusForbidden)
+}
+
+func (s *RequirePermissionSuite) TestApiKey_WithoutScopesGrantsNothing() {
+	// Arrange - context as set by a key owned by an admin that was stored without scopes
+	s.ctx.Set("auth_method", middleware.AuthMethodAPIKey)
+	s.ctx.Set("role", "ADMIN")
+	next := func(c echo.Context) error { return nil }
+
+	// Act & Assert
+	s.assertStatus(s.middleware.RequirePermission(rbac.JobsRead)(next)(s.ctx), http.StatusForbidden)
+}
+
+func (s *RequirePermissionSuite) TestRequireRole_Hierarchy() {
+	// Arrange
+	s.bearer("SUPER_ADMIN")
+
+	// Act
+	called := false
+	err := s.middleware.RequireRole("ADMIN")(func(c echo.Context) error {
+		called = true
+		return nil
+	})(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.True(called)
+}
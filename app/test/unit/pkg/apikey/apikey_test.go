@@ -0,0 +1,77 @@
+package apikey_test
+
+import (
+	"backend/service-platform/app/pkg/apikey"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	key, err := apikey.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.HasPrefix(key.Plaintext, "sk_"+key.Prefix+"_") {
+		t.Errorf("Plaintext %q does not embed prefix %q", key.Plaintext, key.Prefix)
+	}
+	if key.Hash != apikey.Hash(key.Plaintext) {
+		t.Errorf("Hash = %q, want the hash of the plaintext", key.Hash)
+	}
+
+	other, err := apikey.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if other.Prefix == key.Prefix || other.Plaintext == key.Plaintext {
+		t.Errorf("Generate() returned the same key twice")
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	key, _ := apikey.Generate()
+
+	tests := []struct {
+		name       string
+		key        string
+		wantPrefix string
+		wantOK     bool
+	}{
+		{name: "generated key", key: key.Plaintext, wantPrefix: key.Prefix, wantOK: true},
+		{name: "secret with underscores", key: "sk_0123456789ab_se_cr_et", wantPrefix: "0123456789ab", wantOK: true},
+		{name: "legacy key", key: "service-key-1"},
+		{name: "missing secret", key: "sk_0123456789ab_"},
+		{name: "short prefix", key: "sk_0123_secret"},
+		{name: "empty", key: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := apikey.ParsePrefix(tt.key)
+			if ok != tt.wantOK || prefix != tt.wantPrefix {
+				t.Errorf("ParsePrefix() = (%q, %v), want (%q, %v)", prefix, ok, tt.wantPrefix, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	key, _ := apikey.Generate()
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "matching key", key: key.Plaintext, want: true},
+		{name: "same prefix other secret", key: "sk_" + key.Prefix + "_other", want: false},
+		{name: "empty", key: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := apikey.Verify(tt.key, key.Hash); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
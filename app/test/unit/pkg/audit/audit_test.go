@@ -0,0 +1,360 @@
+package audit_test
+
+import (
+	auditactor "backend/service-platform/app/database/constant/audit"
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/audit"
+	ctxutil "backend/service-platform/app/pkg/util/context"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// memoryStore keeps the chain in memory like the repository does in the database
+type memoryStore struct {
+	events []entity.AuditEvent
+	err    error
+}
+
+func (s *memoryStore) Append(_ context.Context, event *entity.AuditEvent, seal func(previousHash string)) error {
+	if s.err != nil {
+		return s.err
+	}
+	previousHash := ""
+	if len(s.events) > 0 {
+		previousHash = s.events[len(s.events)-1].Hash
+	}
+	seal(previousHash)
+	event.ID = uuid.New()
+	event.Seq = int64(len(s.events) + 1)
+	s.events = append(s.events, *event)
+	return nil
+}
+
+func record(t *testing.T, store *memoryStore, ctx context.Context, events ...audit.Event) {
+	t.Helper()
+	logger := audit.NewChainLogger(store, zap.NewNop())
+	for _, e := range events {
+		logger.Record(ctx, e)
+	}
+	if len(store.events) != len(events) {
+		t.Fatalf("stored %d events, want %d", len(store.events), len(events))
+	}
+}
+
+// roundTrip returns the events as they are read back from the jsonb column
+func roundTrip(t *testing.T, events []entity.AuditEvent) []entity.AuditEvent {
+	t.Helper()
+	res := make([]entity.AuditEvent, len(events))
+	for i, e := range events {
+		value, err := e.Metadata.Value()
+		if err != nil {
+			t.Fatalf("Value() error = %v", err)
+		}
+		var metadata entity.AuditMetadata
+		if err := metadata.Scan(value); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		e.Metadata = metadata
+		res[i] = e
+	}
+	return res
+}
+
+func TestChainLogger_LinksEvents(t *testing.T) {
+	store := &memoryStore{}
+	userID := uuid.New()
+	record(t, store, context.Background(),
+		audit.Event{Action: audit.ActionLoginFailed, ActorID: &userID, SubjectID: &userID, Metadata: map[string]interface{}{"reason": "invalid_password"}},
+		audit.Event{Action: audit.ActionLoginSucceeded, ActorID: &userID, SubjectID: &userID, Metadata: map[string]interface{}{"method": "password"}},
+		audit.Event{Action: audit.ActionLoggedOut, ActorID: &userID, SubjectID: &userID},
+	)
+
+	if store.events[0].PreviousHash != "" {
+		t.Errorf("first PreviousHash = %q, want empty", store.events[0].PreviousHash)
+	}
+	for i := 1; i < len(store.events); i++ {
+		if store.events[i].PreviousHash != store.events[i-1].Hash {
+			t.Errorf("event %d PreviousHash = %q, want %q", i, store.events[i].PreviousHash, store.events[i-1].Hash)
+		}
+	}
+	if i := audit.Verify("", store.events); i != -1 {
+		t.Errorf("Verify() = %d, want -1", i)
+	}
+}
+
+func TestChainLogger_HashSurvivesStorage(t *testing.T) {
+	store := &memoryStore{}
+	record(t, store, context.Background(), audit.Event{
+		Action: audit.ActionUserRoleChanged,
+		Metadata: map[string]interface{}{
+			"revoked_sessions": 3,
+			"scopes":           []string{"users:read"},
+			"nested":           map[string]interface{}{"b": 1.5, "a": true},
+		},
+	})
+
+	if i := audit.Verify("", roundTrip(t, store.events)); i != -1 {
+		t.Errorf("Verify() after storage = %d, want -1", i)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	userID := uuid.New()
+	newChain := func() []entity.AuditEvent {
+		store := &memoryStore{}
+		record(t, store, context.Background(),
+			audit.Event{Action: audit.ActionLoginSucceeded, ActorID: &userID},
+			audit.Event{Action: audit.ActionUserSuspended, SubjectID: &userID, Metadata: map[string]interface{}{"reason": "abuse"}},
+			audit.Event{Action: audit.ActionUserUnsuspended, SubjectID: &userID},
+		)
+		return store.events
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(events []entity.AuditEvent) []entity.AuditEvent
+		want   int
+	}{
+		{
+			name:   "untouched",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent { return events },
+			want:   -1,
+		},
+		{
+			name: "action changed",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent {
+				events[1].Action = audit.ActionUserRestored
+				return events
+			},
+			want: 1,
+		},
+		{
+			name: "metadata changed",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent {
+				events[1].Metadata = entity.AuditMetadata{"reason": "mistake"}
+				return events
+			},
+			want: 1,
+		},
+		{
+			name: "actor removed",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent {
+				events[0].ActorID = nil
+				return events
+			},
+			want: 0,
+		},
+		{
+			name: "timestamp changed",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent {
+				events[2].CreatedAt = events[2].CreatedAt.Add(-time.Hour)
+				return events
+			},
+			want: 2,
+		},
+		{
+			name: "event removed",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent {
+				return append(events[:1], events[2:]...)
+			},
+			want: 1,
+		},
+		{
+			name: "hash recomputed after an edit",
+			tamper: func(events []entity.AuditEvent) []entity.AuditEvent {
+				events[1].Action = audit.ActionUserRestored
+				events[1].Hash = audit.Hash(events[1].PreviousHash, events[1])
+				return events
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := audit.Verify("", tt.tamper(newChain())); got != tt.want {
+				t.Errorf("Verify() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify_ContinuesFromPreviousHash(t *testing.T) {
+	store := &memoryStore{}
+	record(t, store, context.Background(),
+		audit.Event{Action: audit.ActionRoleCreated},
+		audit.Event{Action: audit.ActionRoleUpdated},
+	)
+
+	if i := audit.Verify(store.events[0].Hash, store.events[1:]); i != -1 {
+		t.Errorf("Verify() from the first hash = %d, want -1", i)
+	}
+	if i := audit.Verify("", store.events[1:]); i != 0 {
+		t.Errorf("Verify() without the previous hash = %d, want 0", i)
+	}
+}
+
+func TestHash_Deterministic(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	e := entity.AuditEvent{
+		Action:    audit.ActionLoginSucceeded,
+		ActorType: auditactor.User,
+		Metadata:  entity.AuditMetadata{"b": "2", "a": "1"},
+		CreatedAt: createdAt,
+	}
+	same := e
+	same.Metadata = entity.AuditMetadata{"a": "1", "b": "2"}
+	same.CreatedAt = createdAt.In(time.FixedZone("UTC+2", 2*60*60))
+
+	if audit.Hash("", e) != audit.Hash("", same) {
+		t.Error("Hash() differs for the same event")
+	}
+	if audit.Hash("", e) == audit.Hash("previous", e) {
+		t.Error("Hash() ignores the previous hash")
+	}
+	empty := e
+	empty.Metadata = nil
+	withEmpty := e
+	withEmpty.Metadata = entity.AuditMetadata{}
+	if audit.Hash("", empty) != audit.Hash("", withEmpty) {
+		t.Error("Hash() differs for nil and empty metadata")
+	}
+}
+
+func TestChainLogger_ResolvesActor(t *testing.T) {
+	userID := uuid.New()
+	adminID := uuid.New()
+	serviceUserID := uuid.Nil
+	client := ctxutil.ClientInfo{IPAddress: "203.0.113.7", UserAgent: "curl/8", RequestID: "req-1"}
+
+	tests := []struct {
+		name      string
+		principal *ctxutil.Principal
+		event     audit.Event
+		wantType  auditactor.ActorType
+		wantID    *uuid.UUID
+		wantName  *string
+	}{
+		{
+			name:     "anonymous",
+			event:    audit.Event{Action: audit.ActionLoginFailed},
+			wantType: auditactor.Anonymous,
+		},
+		{
+			name:      "authenticated user",
+			principal: &ctxutil.Principal{UserID: &adminID, Role: "ADMIN", Method: "jwt"},
+			event:     audit.Event{Action: audit.ActionUserSuspended, SubjectID: &userID},
+			wantType:  auditactor.User,
+			wantID:    &adminID,
+		},
+		{
+			name:      "service api key",
+			principal: &ctxutil.Principal{UserID: &serviceUserID, Role: "SERVICE", Method: "api_key", ServiceName: "billing"},
+			event:     audit.Event{Action: audit.ActionUserSuspended, SubjectID: &userID},
+			wantType:  auditactor.Service,
+			wantName:  stringPtr("billing"),
+		},
+		{
+			name:      "explicit actor overrides the principal",
+			principal: &ctxutil.Principal{UserID: &adminID},
+			event:     audit.Event{Action: audit.ActionLoginSucceeded, ActorID: &userID},
+			wantType:  auditactor.User,
+			wantID:    &userID,
+		},
+		{
+			name:     "system process",
+			event:    audit.Event{Action: audit.ActionSuperAdminCreated, System: "cli"},
+			wantType: auditactor.System,
+			wantName: stringPtr("cli"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ctxutil.ClientInfoKey.Set(context.Background(), client)
+			if tt.principal != nil {
+				ctx = ctxutil.PrincipalKey.Set(ctx, *tt.principal)
+			}
+			store := &memoryStore{}
+			record(t, store, ctx, tt.event)
+
+			got := store.events[0]
+			if got.ActorType != tt.wantType {
+				t.Errorf("ActorType = %q, want %q", got.ActorType, tt.wantType)
+			}
+			if !equalPtr(got.ActorID, tt.wantID) {
+				t.Errorf("ActorID = %v, want %v", got.ActorID, tt.wantID)
+			}
+			if !equalPtr(got.ActorName, tt.wantName) {
+				t.Errorf("ActorName = %v, want %v", got.ActorName, tt.wantName)
+			}
+			if !equalPtr(got.SubjectID, tt.event.SubjectID) {
+				t.Errorf("SubjectID = %v, want %v", got.SubjectID, tt.event.SubjectID)
+			}
+			if !equalPtr(got.IPAddress, &client.IPAddress) || !equalPtr(got.UserAgent, &client.UserAgent) || !equalPtr(got.RequestID, &client.RequestID) {
+				t.Errorf("client = %v %v %v, want %+v", got.IPAddress, got.UserAgent, got.RequestID, client)
+			}
+		})
+	}
+}
+
+func TestChainLogger_NamesImpersonator(t *testing.T) {
+	userID := uuid.New()
+	adminID := uuid.New()
+	ctx := ctxutil.PrincipalKey.Set(context.Background(), ctxutil.Principal{UserID: &userID, Role: "USER", Method: "jwt", ImpersonatorID: &adminID})
+	metadata := map[string]interface{}{"reason": "test"}
+
+	store := &memoryStore{}
+	record(t, store, ctx, audit.Event{Action: audit.ActionPasswordChanged, SubjectID: &userID, Metadata: metadata})
+
+	got := store.events[0]
+	if !equalPtr(got.ActorID, &userID) {
+		t.Errorf("ActorID = %v, want %v", got.ActorID, userID)
+	}
+	if got.Metadata[audit.MetadataImpersonatorID] != adminID.String() {
+		t.Errorf("Metadata = %v, want %s = %s", got.Metadata, audit.MetadataImpersonatorID, adminID)
+	}
+	if _, ok := metadata[audit.MetadataImpersonatorID]; ok {
+		t.Error("Record() changed the caller's metadata")
+	}
+}
+
+func TestChainLogger_StoreFailureIsSwallowed(t *testing.T) {
+	store := &memoryStore{err: errors.New("database unavailable")}
+	logger := audit.NewChainLogger(store, zap.NewNop())
+
+	// Must neither panic nor surface the error to the audited action
+	logger.Record(context.Background(), audit.Event{Action: audit.ActionLoginSucceeded})
+
+	if len(store.events) != 0 {
+		t.Errorf("stored %d events, want 0", len(store.events))
+	}
+}
+
+func TestChainLogger_RejectsUnencodableMetadata(t *testing.T) {
+	store := &memoryStore{}
+	logger := audit.NewChainLogger(store, zap.NewNop())
+
+	logger.Record(context.Background(), audit.Event{
+		Action:   audit.ActionLoginSucceeded,
+		Metadata: map[string]interface{}{"callback": func() {}},
+	})
+
+	if len(store.events) != 0 {
+		t.Errorf("stored %d events, want 0", len(store.events))
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,112 @@
+package bcrypt_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/bcrypt"
+	"strings"
+	"testing"
+)
+
+// Cheap parameters, the cost does not matter for the format
+var testArgon2Config = config.Argon2Config{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2id_HashPassword(t *testing.T) {
+	hasher := bcrypt.NewArgon2id(testArgon2Config)
+
+	hash, err := hasher.HashPassword("mySecurePassword123!")
+	if err != nil {
+		t.Fatalf("HashPassword() unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("HashPassword() returned invalid hash format: %q", hash)
+	}
+
+	other, err := hasher.HashPassword("mySecurePassword123!")
+	if err != nil {
+		t.Fatalf("HashPassword() unexpected error: %v", err)
+	}
+	if hash == other {
+		t.Error("HashPassword() returned the same hash twice, the salt must be random")
+	}
+
+	if _, err := hasher.HashPassword(""); err == nil {
+		t.Error("HashPassword() expected error for empty password")
+	}
+}
+
+func TestArgon2id_CheckPassword(t *testing.T) {
+	hasher := bcrypt.NewArgon2id(testArgon2Config)
+	hash, err := hasher.HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		valid    bool
+		wantErr  bool
+	}{
+		{name: "correct password", password: "correct horse battery staple", hash: hash, valid: true},
+		{name: "wrong password", password: "Correct horse battery staple", hash: hash},
+		{
+			// Output of the reference implementation: echo -n password | argon2 somesalt -id -t 2 -m 16 -p 4
+			name:     "reference hash with other parameters",
+			password: "password",
+			hash:     "$argon2id$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo/8GaUw434IimaPqxwCo",
+			valid:    true,
+		},
+		{name: "empty password", password: "", hash: hash, wantErr: true},
+		{name: "empty hash", password: "password", hash: "", wantErr: true},
+		{name: "bcrypt hash", password: "password", hash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", wantErr: true},
+		{name: "unsupported version", password: "password", hash: "$argon2id$v=16$m=1024,t=1,p=1$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo", wantErr: true},
+		{name: "invalid parameters", password: "password", hash: "$argon2id$v=19$m=0,t=1,p=1$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo", wantErr: true},
+		{name: "invalid salt", password: "password", hash: "$argon2id$v=19$m=1024,t=1,p=1$!!$GpZ3sK/oH9p7VIiV56G/64Zo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := hasher.CheckPassword(tt.password, tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if valid != tt.valid {
+				t.Errorf("CheckPassword() = %v, want %v", valid, tt.valid)
+			}
+		})
+	}
+}
+
+func TestArgon2id_NeedsRehash(t *testing.T) {
+	hasher := bcrypt.NewArgon2id(testArgon2Config)
+	hash, err := hasher.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = true for a hash with the configured parameters")
+	}
+
+	stronger := testArgon2Config
+	stronger.Iterations = 2
+	upgraded := bcrypt.NewArgon2id(stronger)
+	if !upgraded.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = false for a hash with fewer iterations than configured")
+	}
+
+	if !hasher.NeedsRehash("$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy") {
+		t.Error("NeedsRehash() = false for a bcrypt hash")
+	}
+}
+
+func TestNewArgon2id_Defaults(t *testing.T) {
+	hasher := bcrypt.NewArgon2id(config.Argon2Config{})
+	hash, err := hasher.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$") {
+		t.Errorf("HashPassword() with default parameters = %q", hash)
+	}
+}
@@ -0,0 +1,78 @@
+package bcrypt_test
+
+import (
+	"backend/service-platform/app/pkg/bcrypt"
+	"errors"
+	"testing"
+)
+
+func TestMultiHasher(t *testing.T) {
+	legacy := bcrypt.NewBcrypt(4)
+	argon := bcrypt.NewArgon2id(testArgon2Config)
+	hasher, err := bcrypt.NewMultiHasher(bcrypt.AlgorithmArgon2id, &legacy, &argon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := hasher.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if algorithm, _ := bcrypt.DetectAlgorithm(hash); algorithm != bcrypt.AlgorithmArgon2id {
+		t.Errorf("HashPassword() algorithm = %q, want argon2id", algorithm)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = true for a current argon2id hash")
+	}
+
+	legacyHash, err := legacy.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, err := hasher.CheckPassword("password", legacyHash); err != nil || !valid {
+		t.Errorf("CheckPassword() of a bcrypt hash = %v, %v, want true", valid, err)
+	}
+	if valid, err := hasher.CheckPassword("other", legacyHash); err != nil || valid {
+		t.Errorf("CheckPassword() of a bcrypt hash with a wrong password = %v, %v, want false", valid, err)
+	}
+	if !hasher.NeedsRehash(legacyHash) {
+		t.Error("NeedsRehash() = false for a bcrypt hash while argon2id is configured")
+	}
+
+	if _, err := hasher.CheckPassword("password", "plaintext"); !errors.Is(err, bcrypt.ErrInvalidHash) {
+		t.Errorf("CheckPassword() of an unknown format error = %v, want ErrInvalidHash", err)
+	}
+}
+
+func TestMultiHasher_BcryptCostUpgrade(t *testing.T) {
+	weak := bcrypt.NewBcrypt(4)
+	current := bcrypt.NewBcrypt(5)
+	argon := bcrypt.NewArgon2id(testArgon2Config)
+	hasher, err := bcrypt.NewMultiHasher(bcrypt.AlgorithmBcrypt, &current, &argon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weakHash, err := weak.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasher.NeedsRehash(weakHash) {
+		t.Error("NeedsRehash() = false for a bcrypt hash of a lower cost")
+	}
+	hash, err := hasher.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = true for a bcrypt hash of the configured cost")
+	}
+}
+
+func TestNewMultiHasher_UnknownAlgorithm(t *testing.T) {
+	legacy := bcrypt.NewBcryptWithDefaultCost()
+	argon := bcrypt.NewArgon2id(testArgon2Config)
+	if _, err := bcrypt.NewMultiHasher("scrypt", &legacy, &argon); err == nil {
+		t.Error("NewMultiHasher() expected error for an unsupported algorithm")
+	}
+}
@@ -0,0 +1,151 @@
+package denylist_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/denylist"
+	jwtpkg "backend/service-platform/app/pkg/jwt"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	redismock "backend/service-platform/app/test/mocks/redis"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func newDenylist() *denylist.RedisDenylist {
+	return denylist.NewRedisDenylist(redismock.NewInMemoryRedis(), config.JwtConfig{
+		AccessExpiration:  time.Hour,
+		RefreshExpiration: 24 * time.Hour,
+	})
+}
+
+func claims(userID uuid.UUID, issuedAt time.Time) *jwtpkg.Claims {
+	return &jwtpkg.Claims{
+		UserID: &userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(time.Hour)),
+		},
+	}
+}
+
+func TestRedisDenylist_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+	d := newDenylist()
+	userID := uuid.New()
+	revoked := claims(userID, time.Now())
+	other := claims(userID, time.Now())
+
+	if err := d.RevokeToken(ctx, revoked); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if err := d.Check(ctx, revoked); !errors.Is(err, denylist.ErrTokenRevoked) {
+		t.Errorf("Check() revoked token error = %v, want %v", err, denylist.ErrTokenRevoked)
+	}
+	if err := d.Check(ctx, other); err != nil {
+		t.Errorf("Check() other token error = %v, want nil", err)
+	}
+}
+
+func TestRedisDenylist_RevokeTokenWithoutJti(t *testing.T) {
+	ctx := context.Background()
+	d := newDenylist()
+	c := claims(uuid.New(), time.Now())
+	c.ID = ""
+
+	if err := d.RevokeToken(ctx, c); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if err := d.Check(ctx, c); err != nil {
+		t.Errorf("Check() error = %v, want nil", err)
+	}
+}
+
+func TestRedisDenylist_RevokeUserTokens(t *testing.T) {
+	ctx := context.Background()
+	d := newDenylist()
+	userID := uuid.New()
+
+	if err := d.RevokeUserTokens(ctx, userID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		claims  *jwtpkg.Claims
+		wantErr error
+	}{
+		{name: "issued before the cutoff", claims: claims(userID, time.Now().Add(-time.Minute)), wantErr: denylist.ErrTokenRevoked},
+		{name: "issued after the cutoff", claims: claims(userID, time.Now().Add(time.Second))},
+		{name: "another user", claims: claims(uuid.New(), time.Now().Add(-time.Minute))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := d.Check(ctx, tt.claims); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedisDenylist_RevokeActorTokens(t *testing.T) {
+	ctx := context.Background()
+	d := newDenylist()
+	adminID := uuid.New()
+	impersonation := claims(uuid.New(), time.Now().Add(-time.Minute))
+	impersonation.Act = &jwtpkg.Actor{UserID: &adminID}
+
+	if err := d.Check(ctx, impersonation); err != nil {
+		t.Fatalf("Check() before revocation error = %v, want nil", err)
+	}
+	if err := d.RevokeUserTokens(ctx, adminID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+	if err := d.Check(ctx, impersonation); !errors.Is(err, denylist.ErrTokenRevoked) {
+		t.Errorf("Check() after revoking the admin error = %v, want %v", err, denylist.ErrTokenRevoked)
+	}
+}
+
+func TestRedisDenylist_RevokeUserTokensWithinTheSecond(t *testing.T) {
+	ctx := context.Background()
+	d := newDenylist()
+	userID := uuid.New()
+
+	before := claims(userID, time.Now())
+	time.Sleep(2 * time.Millisecond)
+	if err := d.RevokeUserTokens(ctx, userID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	after := claims(userID, time.Now())
+
+	if err := d.Check(ctx, before); !errors.Is(err, denylist.ErrTokenRevoked) {
+		t.Errorf("Check() token issued just before error = %v, want %v", err, denylist.ErrTokenRevoked)
+	}
+	if err := d.Check(ctx, after); err != nil {
+		t.Errorf("Check() token issued just after error = %v, want nil", err)
+	}
+}
+
+func TestRedisDenylist_CutoffInWholeSeconds(t *testing.T) {
+	ctx := context.Background()
+	rds := redismock.NewInMemoryRedis()
+	d := denylist.NewRedisDenylist(rds, config.JwtConfig{AccessExpiration: time.Hour})
+	userID := uuid.New()
+	cutoff := time.Now().Truncate(time.Second)
+	if err := rds.Set(ctx, rediskey.TokensValidAfterKey(userID.String()), cutoff.Unix(), time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if err := d.Check(ctx, claims(userID, cutoff)); !errors.Is(err, denylist.ErrTokenRevoked) {
+		t.Errorf("Check() token issued at the cutoff error = %v, want %v", err, denylist.ErrTokenRevoked)
+	}
+	if err := d.Check(ctx, claims(userID, cutoff.Add(time.Millisecond))); err != nil {
+		t.Errorf("Check() token issued after the cutoff error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,56 @@
+package encryption_test
+
+import (
+	"backend/service-platform/app/pkg/encryption"
+	"errors"
+	"testing"
+)
+
+func TestAESEncryptor_RoundTrip(t *testing.T) {
+	e := encryption.NewAESEncryptor("test-secret")
+
+	ciphertext, err := e.Encrypt("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if ciphertext == "JBSWY3DPEHPK3PXP" {
+		t.Errorf("Encrypt() returned the plaintext")
+	}
+
+	plaintext, err := e.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if plaintext != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("Decrypt() = %s, want JBSWY3DPEHPK3PXP", plaintext)
+	}
+}
+
+func TestAESEncryptor_Decrypt(t *testing.T) {
+	ciphertext, _ := encryption.NewAESEncryptor("test-secret").Encrypt("value")
+
+	tests := []struct {
+		name       string
+		secret     string
+		ciphertext string
+	}{
+		{name: "wrong key", secret: "other-secret", ciphertext: ciphertext},
+		{name: "not base64", secret: "test-secret", ciphertext: "%%%"},
+		{name: "too short", secret: "test-secret", ciphertext: "AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := encryption.NewAESEncryptor(tt.secret).Decrypt(tt.ciphertext); err == nil {
+				t.Errorf("Decrypt() expected error")
+			}
+		})
+	}
+}
+
+func TestAESEncryptor_MissingKey(t *testing.T) {
+	_, err := encryption.NewAESEncryptor("").Encrypt("value")
+	if !errors.Is(err, encryption.ErrMissingKey) {
+		t.Errorf("Encrypt() error = %v, want %v", err, encryption.ErrMissingKey)
+	}
+}
@@ -0,0 +1,149 @@
+package ethereum_test
+
+import (
+	"backend/service-platform/app/pkg/ethereum"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func privateKey(last byte) []byte {
+	key := make([]byte, 32)
+	key[31] = last
+	return key
+}
+
+func TestKeccak256(t *testing.T) {
+	got := hex.EncodeToString(ethereum.Keccak256())
+	if want := "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"; got != want {
+		t.Errorf("Keccak256() = %s, want %s", got, want)
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	// Examples from EIP-55
+	for _, want := range []string{
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		got, err := ethereum.ChecksumAddress(strings.ToLower(want))
+		if err != nil || got != want {
+			t.Errorf("ChecksumAddress(%q) = %q, %v, want %q", strings.ToLower(want), got, err, want)
+		}
+		if !ethereum.IsChecksumAddress(want) {
+			t.Errorf("IsChecksumAddress(%q) = false", want)
+		}
+	}
+}
+
+func TestIsChecksumAddress_Rejects(t *testing.T) {
+	for _, address := range []string{
+		"0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed", // one letter with the wrong case
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", // lowercase form of a mixed case address
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+		"0xZaAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	} {
+		if ethereum.IsChecksumAddress(address) {
+			t.Errorf("IsChecksumAddress(%q) = true", address)
+		}
+	}
+	if _, err := ethereum.ChecksumAddress("0x1234"); !errors.Is(err, ethereum.ErrInvalidAddress) {
+		t.Errorf("ChecksumAddress() error = %v, want ErrInvalidAddress", err)
+	}
+}
+
+func TestPrivateKeyAddress(t *testing.T) {
+	// Well known addresses of the private keys 1 and 2
+	for last, want := range map[byte]string{
+		1: "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		2: "0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF",
+	} {
+		got, err := ethereum.PrivateKeyAddress(privateKey(last))
+		if err != nil || got != want {
+			t.Errorf("PrivateKeyAddress(%d) = %q, %v, want %q", last, got, err, want)
+		}
+	}
+}
+
+func TestRecoverPersonal_RoundTrip(t *testing.T) {
+	key := privateKey(42)
+	want, err := ethereum.PrivateKeyAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("example.com wants you to sign in with your Ethereum account")
+
+	for i := 0; i < 5; i++ {
+		signature, err := ethereum.SignPersonal(message, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ethereum.RecoverPersonal(message, signature)
+		if err != nil || got != want {
+			t.Fatalf("RecoverPersonal() = %q, %v, want %q", got, err, want)
+		}
+
+		// Some signers encode v as 0/1
+		signature[64] -= 27
+		if got, err := ethereum.RecoverPersonal(message, signature); err != nil || got != want {
+			t.Fatalf("RecoverPersonal() with v in 0/1 = %q, %v, want %q", got, err, want)
+		}
+	}
+}
+
+func TestRecoverPersonal_OtherMessage(t *testing.T) {
+	key := privateKey(42)
+	signer, _ := ethereum.PrivateKeyAddress(key)
+	signature, err := ethereum.SignPersonal([]byte("original"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ethereum.RecoverPersonal([]byte("tampered"), signature)
+	if err == nil && got == signer {
+		t.Error("RecoverPersonal() recovered the signer of another message")
+	}
+}
+
+func TestRecoverPersonal_InvalidSignature(t *testing.T) {
+	signature, err := ethereum.SignPersonal([]byte("message"), privateKey(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	badV := append([]byte(nil), signature...)
+	badV[64] = 29
+	zeroR := append([]byte(nil), signature...)
+	copy(zeroR[:32], make([]byte, 32))
+
+	for name, sig := range map[string][]byte{
+		"short":  signature[:64],
+		"bad v":  badV,
+		"zero r": zeroR,
+	} {
+		if _, err := ethereum.RecoverPersonal([]byte("message"), sig); !errors.Is(err, ethereum.ErrInvalidSignature) {
+			t.Errorf("%s: RecoverPersonal() error = %v, want ErrInvalidSignature", name, err)
+		}
+	}
+}
+
+func TestDecodeSignature(t *testing.T) {
+	signature, _ := ethereum.SignPersonal([]byte("message"), privateKey(7))
+	encoded := "0x" + hex.EncodeToString(signature)
+
+	decoded, err := ethereum.DecodeSignature(encoded)
+	if err != nil || hex.EncodeToString(decoded) != encoded[2:] {
+		t.Errorf("DecodeSignature() = %x, %v", decoded, err)
+	}
+	if _, err := ethereum.DecodeSignature(encoded[:len(encoded)-2]); !errors.Is(err, ethereum.ErrInvalidSignature) {
+		t.Errorf("DecodeSignature() of a truncated signature error = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -19,6 +19,9 @@ func createTestJwt() *jwtpkg.DefaultJwt {
 		SecretKey:         "test-secret-key-12345",
 		AccessExpiration:  1 * time.Hour,
 		RefreshExpiration: 2 * time.Hour,
+
+		ImpersonationExpiration: 15 * time.Minute,
+		ServiceTokenExpiration:  10 * time.Minute,
 	}
 	return jwtpkg.NewJwt(testConfig).(*jwtpkg.DefaultJwt)
 }
@@ -96,7 +99,7 @@ func TestDefaultJwt_ValidateToken_ValidToken(t *testing.T) {
 	lastLoginAt := time.Now()
 
 	// Generate access token
-	accessToken, err := jwtService.GenerateAccessToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt)
+	accessToken, err := jwtService.GenerateAccessToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt, nil)
 	require.NoError(t, err)
 
 	// Validate the token
@@ -148,7 +151,7 @@ func TestDefaultJwt_GenerateAccessToken(t *testing.T) {
 	phoneVerified := false
 	lastLoginAt := time.Now()
 
-	accessToken, err := jwtService.GenerateAccessToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt)
+	accessToken, err := jwtService.GenerateAccessToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, accessToken)
@@ -171,7 +174,7 @@ func TestDefaultJwt_GenerateAccessToken(t *testing.T) {
 func TestDefaultJwt_GenerateAccessToken_NilValues(t *testing.T) {
 	jwtService := createTestJwt()
 
-	accessToken, err := jwtService.GenerateAccessToken(nil, nil, nil, nil, nil, nil, nil, nil)
+	accessToken, err := jwtService.GenerateAccessToken(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, accessToken)
@@ -202,7 +205,7 @@ func TestDefaultJwt_GenerateRefreshToken(t *testing.T) {
 	phoneVerified := true
 	lastLoginAt := time.Now()
 
-	refreshToken, err := jwtService.GenerateRefreshToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt)
+	refreshToken, err := jwtService.GenerateRefreshToken(&userID, &username, &email, &phoneNumber, &roleStr, &emailVerified, &phoneVerified, &lastLoginAt, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, refreshToken)
@@ -232,6 +235,100 @@ func TestDefaultJwt_GenerateRefreshToken(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDefaultJwt_GenerateImpersonationToken(t *testing.T) {
+	jwtService := createTestJwt()
+
+	userID := uuid.New()
+	username := "testuser"
+	roleStr := "USER"
+	adminID := uuid.New()
+	adminName := "support@example.com"
+
+	accessToken, err := jwtService.GenerateImpersonationToken(&userID, &username, nil, nil, &roleStr, nil, nil, nil, nil, &jwtpkg.Actor{UserID: &adminID, Username: &adminName})
+
+	require.NoError(t, err)
+	// Ensure impersonation tokens use ImpersonationExpiration (15m in createTestJwt)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), accessToken.ExpiredAt, 5*time.Second)
+
+	claims, err := jwtService.ValidateToken(accessToken.Token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, *claims.UserID)
+	assert.Equal(t, roleStr, *claims.Role)
+	assert.Nil(t, claims.RefreshTokenBase64)
+	require.NotNil(t, claims.Act)
+	assert.Equal(t, adminID, *claims.Act.UserID)
+	assert.Equal(t, adminName, *claims.Act.Username)
+}
+
+func TestDefaultJwt_GenerateAccessToken_NoActor(t *testing.T) {
+	jwtService := createTestJwt()
+	userID := uuid.New()
+
+	accessToken, err := jwtService.GenerateAccessToken(&userID, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	claims, err := jwtService.ValidateToken(accessToken.Token)
+	require.NoError(t, err)
+	assert.Nil(t, claims.Act)
+}
+
+func TestDefaultJwt_TokenVersion(t *testing.T) {
+	jwtService := createTestJwt()
+	userID := uuid.New()
+	version := int64(3)
+
+	accessToken, err := jwtService.GenerateAccessToken(&userID, nil, nil, nil, nil, nil, nil, nil, &version)
+	require.NoError(t, err)
+	refreshToken, err := jwtService.GenerateRefreshToken(&userID, nil, nil, nil, nil, nil, nil, nil, &version)
+	require.NoError(t, err)
+	legacyToken, err := jwtService.GenerateAccessToken(&userID, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	for _, token := range []string{accessToken.Token, refreshToken.Token} {
+		claims, err := jwtService.ValidateToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, version, claims.Version())
+	}
+	// Tokens issued before versioning are at version 0
+	claims, err := jwtService.ValidateToken(legacyToken.Token)
+	require.NoError(t, err)
+	assert.Nil(t, claims.TokenVersion)
+	assert.Equal(t, int64(0), claims.Version())
+}
+
+func TestDefaultJwt_GenerateServiceToken(t *testing.T) {
+	jwtService := createTestJwt()
+
+	accessToken, err := jwtService.GenerateServiceToken("svc_billing", []string{"users:read", "jobs:read"})
+
+	require.NoError(t, err)
+	// Ensure service tokens use ServiceTokenExpiration (10m in createTestJwt)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), accessToken.ExpiredAt, 5*time.Second)
+
+	claims, err := jwtService.ValidateToken(accessToken.Token)
+	require.NoError(t, err)
+	assert.True(t, claims.IsServiceToken())
+	assert.Equal(t, "svc_billing", claims.Subject)
+	assert.Equal(t, "svc_billing", *claims.ClientID)
+	assert.Equal(t, "users:read jobs:read", *claims.Scope)
+	assert.Equal(t, []string{"users:read", "jobs:read"}, claims.Scopes())
+	assert.Nil(t, claims.UserID)
+	assert.Nil(t, claims.Role)
+}
+
+func TestIsServiceToken(t *testing.T) {
+	jwtService := createTestJwt()
+	userID := uuid.New()
+	userToken, err := jwtService.GenerateAccessToken(&userID, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	serviceToken, err := jwtService.GenerateServiceToken("svc_billing", nil)
+	require.NoError(t, err)
+
+	assert.True(t, jwtpkg.IsServiceToken(serviceToken.Token))
+	assert.False(t, jwtpkg.IsServiceToken(userToken.Token))
+	assert.False(t, jwtpkg.IsServiceToken("not-a-jwt"))
+}
+
 func TestDefaultJwt_GenerateAccessTokenWithExpiration(t *testing.T) {
 	jwtService := createTestJwt()
 
@@ -0,0 +1,211 @@
+package jwt_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	jwtpkg "backend/service-platform/app/pkg/jwt"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rsaKeyConfig(t *testing.T, id string) (config.JwtKeyConfig, *rsa.PrivateKey) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der := x509.MarshalPKCS1PrivateKey(private)
+	return config.JwtKeyConfig{
+		ID:         id,
+		Algorithm:  jwtpkg.AlgorithmRS256,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})),
+	}, private
+}
+
+func publicOnly(t *testing.T, cfg config.JwtKeyConfig, public any) config.JwtKeyConfig {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	cfg.PrivateKey = ""
+	cfg.PublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	return cfg
+}
+
+func ed25519KeyConfig(t *testing.T, id string) config.JwtKeyConfig {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	return config.JwtKeyConfig{
+		ID:         id,
+		Algorithm:  jwtpkg.AlgorithmEdDSA,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+}
+
+func asymmetricConfig(algorithm, kid string, keys ...config.JwtKeyConfig) config.JwtConfig {
+	return config.JwtConfig{
+		Issuer:            "test-issuer",
+		AccessExpiration:  time.Hour,
+		RefreshExpiration: 2 * time.Hour,
+		Algorithm:         algorithm,
+		SigningKeyID:      kid,
+		Keys:              keys,
+	}
+}
+
+func issue(t *testing.T, j jwtpkg.Jwt) string {
+	userID := uuid.New()
+	username := "user"
+	token, err := j.GenerateAccessToken(&userID, &username, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	return token.Token
+}
+
+func TestKeyring_SignAndVerify(t *testing.T) {
+	rsaKey, _ := rsaKeyConfig(t, "rsa-1")
+	tests := []struct {
+		name      string
+		cfg       config.JwtConfig
+		wantAlg   string
+		wantKeyID string
+	}{
+		{name: "RS256", cfg: asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", rsaKey), wantAlg: "RS256", wantKeyID: "rsa-1"},
+		{name: "EdDSA", cfg: asymmetricConfig(jwtpkg.AlgorithmEdDSA, "ed-1", ed25519KeyConfig(t, "ed-1")), wantAlg: "EdDSA", wantKeyID: "ed-1"},
+		{name: "HS256 with kid", cfg: config.JwtConfig{SecretKey: "secret", AccessExpiration: time.Hour, SigningKeyID: "hs-1"}, wantAlg: "HS256", wantKeyID: "hs-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := jwtpkg.NewJwt(tt.cfg)
+			tokenString := issue(t, j)
+
+			token, err := j.ParseToken(tokenString)
+			require.NoError(t, err)
+			assert.True(t, token.Valid)
+			assert.Equal(t, tt.wantAlg, token.Method.Alg())
+			assert.Equal(t, tt.wantKeyID, token.Header["kid"])
+		})
+	}
+}
+
+func TestKeyring_RotationKeepsOldTokensValid(t *testing.T) {
+	oldKey, oldPrivate := rsaKeyConfig(t, "2024-07")
+	newKey, _ := rsaKeyConfig(t, "2025-01")
+
+	oldToken := issue(t, jwtpkg.NewJwt(asymmetricConfig(jwtpkg.AlgorithmRS256, "2024-07", oldKey)))
+
+	rotated := jwtpkg.NewJwt(asymmetricConfig(jwtpkg.AlgorithmRS256, "2025-01", newKey, publicOnly(t, oldKey, &oldPrivate.PublicKey)))
+	_, err := rotated.ValidateToken(oldToken)
+	assert.NoError(t, err)
+
+	newToken := issue(t, rotated)
+	token, err := rotated.ParseToken(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", token.Header["kid"])
+
+	// Once the old key is dropped its tokens are rejected
+	_, err = jwtpkg.NewJwt(asymmetricConfig(jwtpkg.AlgorithmRS256, "2025-01", newKey)).ValidateToken(oldToken)
+	assert.ErrorIs(t, err, jwtpkg.ErrUnknownKeyID)
+}
+
+func TestKeyring_RejectsAlgorithmSubstitution(t *testing.T) {
+	rsaKey, private := rsaKeyConfig(t, "rsa-1")
+	j := jwtpkg.NewJwt(asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", rsaKey))
+
+	// HMAC token keyed with the public key and pointing at the RSA kid
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwtpkg.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	forged.Header["kid"] = "rsa-1"
+	tokenString, err := forged.SignedString(der)
+	require.NoError(t, err)
+
+	_, err = j.ValidateToken(tokenString)
+	assert.Error(t, err)
+}
+
+func TestKeyring_AsymmetricModeRejectsTokensWithoutKid(t *testing.T) {
+	rsaKey, _ := rsaKeyConfig(t, "rsa-1")
+	// The secret is still configured but no migration is in progress
+	cfg := asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", rsaKey)
+	cfg.SecretKey = "some-secret"
+	j := jwtpkg.NewJwt(cfg)
+
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwtpkg.Claims{})
+	tokenString, err := legacy.SignedString([]byte("some-secret"))
+	require.NoError(t, err)
+
+	_, err = j.ValidateToken(tokenString)
+	assert.ErrorIs(t, err, jwtpkg.ErrUnknownKeyID)
+}
+
+func TestKeyring_AcceptLegacyHS256DuringMigration(t *testing.T) {
+	rsaKey, _ := rsaKeyConfig(t, "rsa-1")
+	legacyToken := issue(t, jwtpkg.NewJwt(config.JwtConfig{SecretKey: "some-secret", AccessExpiration: time.Hour}))
+
+	cfg := asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", rsaKey)
+	cfg.SecretKey = "some-secret"
+	cfg.AcceptLegacyHS256 = true
+	j := jwtpkg.NewJwt(cfg)
+
+	_, err := j.ValidateToken(legacyToken)
+	assert.NoError(t, err)
+
+	// New tokens are signed with the asymmetric key
+	token, err := j.ParseToken(issue(t, j))
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", token.Method.Alg())
+}
+
+func TestKeyring_JWKS(t *testing.T) {
+	rsaKey, _ := rsaKeyConfig(t, "rsa-1")
+	cfg := asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", rsaKey, ed25519KeyConfig(t, "ed-1"))
+	cfg.SecretKey = "never-published"
+
+	set := jwtpkg.NewJwt(cfg).JWKS()
+
+	require.Len(t, set.Keys, 2)
+	assert.Equal(t, "ed-1", set.Keys[0].Kid)
+	assert.Equal(t, "OKP", set.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", set.Keys[0].Crv)
+	assert.NotEmpty(t, set.Keys[0].X)
+	assert.Equal(t, "rsa-1", set.Keys[1].Kid)
+	assert.Equal(t, "RSA", set.Keys[1].Kty)
+	assert.Equal(t, "AQAB", set.Keys[1].E)
+	assert.NotEmpty(t, set.Keys[1].N)
+}
+
+func TestNewKeyring_InvalidConfig(t *testing.T) {
+	rsaKey, private := rsaKeyConfig(t, "rsa-1")
+	tests := []struct {
+		name string
+		cfg  config.JwtConfig
+	}{
+		{name: "HS256 without secret", cfg: config.JwtConfig{}},
+		{name: "unknown algorithm", cfg: config.JwtConfig{SecretKey: "secret", Algorithm: "none"}},
+		{name: "unknown signing kid", cfg: asymmetricConfig(jwtpkg.AlgorithmRS256, "missing", rsaKey)},
+		{name: "signing key without private key", cfg: asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", publicOnly(t, rsaKey, &private.PublicKey))},
+		{name: "signing key of another algorithm", cfg: asymmetricConfig(jwtpkg.AlgorithmEdDSA, "rsa-1", rsaKey)},
+		{name: "legacy HS256 without secret", cfg: func() config.JwtConfig {
+			cfg := asymmetricConfig(jwtpkg.AlgorithmRS256, "rsa-1", rsaKey)
+			cfg.AcceptLegacyHS256 = true
+			return cfg
+		}()},
+		{name: "malformed PEM", cfg: asymmetricConfig(jwtpkg.AlgorithmRS256, "bad", config.JwtKeyConfig{ID: "bad", Algorithm: "RS256", PrivateKey: "garbage"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwtpkg.NewKeyring(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package mailer_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/mailer"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestNewMailer(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+	}{
+		{name: "smtp driver", driver: "smtp"},
+		{name: "file driver", driver: "file"},
+		{name: "empty driver defaults to file", driver: ""},
+		{name: "unknown driver", driver: "pigeon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mailer.NewMailer(config.MailerConfig{Driver: tt.driver}, zap.NewNop())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewMailer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	m := mailer.NewFileMailer(config.MailerConfig{From: "no-reply@example.com", OutboxDir: dir}, zap.NewNop())
+
+	message, err := mailer.VerifyEmailTemplate.Render(map[string]any{
+		"Username":  "alice",
+		"Link":      "https://example.com/verify?token=abc",
+		"ExpiresAt": "tomorrow",
+	}, "alice@example.com")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if err := m.Send(context.Background(), message); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one message in outbox, got %d (%v)", len(files), err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	for _, want := range []string{
+		"From: no-reply@example.com",
+		"To: alice@example.com",
+		"Subject: Verify your email address",
+		"https://example.com/verify?token=abc",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("message does not contain %q", want)
+		}
+	}
+}
+
+func TestFileMailer_SendWithoutRecipients(t *testing.T) {
+	m := mailer.NewFileMailer(config.MailerConfig{OutboxDir: t.TempDir()}, zap.NewNop())
+	if err := m.Send(context.Background(), mailer.Message{Subject: "hello"}); err == nil {
+		t.Error("Send() expected error for message without recipients")
+	}
+}
@@ -0,0 +1,185 @@
+package oidc_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/oidc"
+	httputil "backend/service-platform/app/test/util"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	clientID     = "service-platform"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost:8080/api/v1/auth/oidc/mock/callback"
+)
+
+func newProvider(t *testing.T) (*httputil.MockOidcServer, oidc.Provider) {
+	t.Helper()
+	server := httputil.NewMockOidcServer(clientID, clientSecret)
+	t.Cleanup(server.Close)
+	return server, oidc.NewProvider("mock", server.ProviderConfig(redirectURL), resty.New(), time.Hour)
+}
+
+// authorize follows the authorization URL and returns the code and state of the redirect back to us
+func authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize returned status %d", resp.StatusCode)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect: %v", err)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestCodeChallengeS256(t *testing.T) {
+	// Example from RFC 7636 appendix B
+	got := oidc.CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallengeS256() = %q, want %q", got, want)
+	}
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	server, provider := newProvider(t)
+	ctx := context.Background()
+
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("NewCodeVerifier() error = %v", err)
+	}
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	code, state := authorize(t, authURL)
+	if state != "state-1" {
+		t.Fatalf("state = %q, want state-1", state)
+	}
+
+	token, err := provider.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken() error = %v", err)
+	}
+	if claims.Subject != server.Subject || claims.Email != server.Email || !claims.EmailVerified {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestProvider_ExchangeWithWrongVerifier(t *testing.T) {
+	_, provider := newProvider(t)
+	ctx := context.Background()
+
+	verifier, _ := oidc.NewCodeVerifier()
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	code, _ := authorize(t, authURL)
+
+	other, _ := oidc.NewCodeVerifier()
+	if _, err := provider.Exchange(ctx, code, other); !errors.Is(err, oidc.ErrExchangeFailed) {
+		t.Errorf("Exchange() error = %v, want ErrExchangeFailed", err)
+	}
+}
+
+func TestProvider_VerifyIDTokenRejections(t *testing.T) {
+	server, provider := newProvider(t)
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   server.URL,
+			"sub":   "subject",
+			"aud":   clientID,
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+			"nonce": "nonce",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		nonce  string
+	}{
+		{name: "wrong nonce", mutate: func(jwt.MapClaims) {}, nonce: "other"},
+		{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "someone-else" }, nonce: "nonce"},
+		{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, nonce: "nonce"},
+		{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Hour).Unix() }, nonce: "nonce"},
+		{name: "missing subject", mutate: func(c jwt.MapClaims) { delete(c, "sub") }, nonce: "nonce"},
+		{name: "foreign authorized party", mutate: func(c jwt.MapClaims) {
+			c["aud"] = []string{clientID, "someone-else"}
+			c["azp"] = "someone-else"
+		}, nonce: "nonce"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.mutate(claims)
+			_, err := provider.VerifyIDToken(context.Background(), server.SignIDToken(claims), tt.nonce)
+			if !errors.Is(err, oidc.ErrInvalidIDToken) {
+				t.Errorf("VerifyIDToken() error = %v, want ErrInvalidIDToken", err)
+			}
+		})
+	}
+
+	if _, err := provider.VerifyIDToken(context.Background(), server.SignIDToken(valid()), "nonce"); err != nil {
+		t.Errorf("VerifyIDToken() of a valid token error = %v", err)
+	}
+}
+
+func TestProvider_VerifyIDTokenSignedByAnotherKey(t *testing.T) {
+	server, provider := newProvider(t)
+	other := httputil.NewMockOidcServer(clientID, clientSecret)
+	defer other.Close()
+
+	now := time.Now()
+	token := other.SignIDToken(jwt.MapClaims{
+		"iss":   server.URL,
+		"sub":   "subject",
+		"aud":   clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": "nonce",
+	})
+	if _, err := provider.VerifyIDToken(context.Background(), token, "nonce"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Errorf("VerifyIDToken() error = %v, want ErrInvalidIDToken", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := oidc.NewRegistry(config.OidcConfig{Providers: map[string]config.OidcProviderConfig{
+		"google":   {Issuer: "https://accounts.google.com", ClientID: "id"},
+		"disabled": {Issuer: "https://example.com"},
+	}}, nil)
+
+	if _, err := registry.Provider("Google"); err != nil {
+		t.Errorf("Provider(Google) error = %v", err)
+	}
+	if _, err := registry.Provider("disabled"); !errors.Is(err, oidc.ErrUnknownProvider) {
+		t.Errorf("Provider(disabled) error = %v, want ErrUnknownProvider", err)
+	}
+	if names := registry.Names(); len(names) != 1 || names[0] != "google" {
+		t.Errorf("Names() = %v", names)
+	}
+}
@@ -0,0 +1,44 @@
+package otp_test
+
+import (
+	"backend/service-platform/app/pkg/otp"
+	"regexp"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, digits := range []int{otp.MinDigits, otp.DefaultDigits, otp.MaxDigits} {
+		pattern := regexp.MustCompile(`^[0-9]+$`)
+		for range 50 {
+			code, err := otp.Generate(digits)
+			if err != nil {
+				t.Fatalf("Generate(%d) error = %v", digits, err)
+			}
+			if len(code) != digits || !pattern.MatchString(code) {
+				t.Fatalf("Generate(%d) = %q, want %d digits", digits, code, digits)
+			}
+		}
+	}
+}
+
+func TestGenerate_InvalidLength(t *testing.T) {
+	for _, digits := range []int{0, otp.MinDigits - 1, otp.MaxDigits + 1} {
+		if _, err := otp.Generate(digits); err == nil {
+			t.Errorf("Generate(%d) expected error", digits)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	hash := otp.Hash("+14155550100", "123456")
+
+	if !otp.Equal(hash, otp.Hash("+14155550100", " 123456 ")) {
+		t.Error("expected surrounding spaces to be ignored")
+	}
+	if otp.Equal(hash, otp.Hash("+14155550100", "654321")) {
+		t.Error("expected a different code not to match")
+	}
+	if otp.Equal(hash, otp.Hash("+14155550199", "123456")) {
+		t.Error("expected the code not to match another phone number")
+	}
+}
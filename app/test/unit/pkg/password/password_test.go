@@ -0,0 +1,159 @@
+package password_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/password"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func rules(violations []password.Violation) []string {
+	var out []string
+	for _, v := range violations {
+		out = append(out, v.Rule)
+	}
+	return out
+}
+
+func TestRulePolicy_Check(t *testing.T) {
+	policy, err := password.NewRulePolicy(config.PasswordPolicyConfig{
+		MinLength:          10,
+		MaxLength:          20,
+		RequireUppercase:   true,
+		RequireLowercase:   true,
+		RequireDigit:       true,
+		RequireSymbol:      true,
+		MaxRepeatedChars:   2,
+		ForbidPersonalInfo: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{name: "valid", password: "Correct-Horse-42"},
+		{name: "multibyte characters count once", password: "Pässwörd-Ünïcode-1"},
+		{name: "too short", password: "Ab1!", want: []string{password.RuleMinLength}},
+		{name: "too long", password: "Correct-Horse-Battery-42", want: []string{password.RuleMaxLength}},
+		{name: "no uppercase", password: "correct-horse-42", want: []string{password.RuleUppercase}},
+		{name: "no lowercase", password: "CORRECT-HORSE-42", want: []string{password.RuleLowercase}},
+		{name: "no digit", password: "Correct-Horse-!!", want: []string{password.RuleDigit}},
+		{name: "no symbol", password: "CorrectHorse42", want: []string{password.RuleSymbol}},
+		{name: "repeated characters", password: "Correct-Horse-444", want: []string{password.RuleRepeatedChars}},
+		{name: "email local part", password: "Jane.Doe-2024!", want: []string{password.RulePersonalInfo}},
+		{name: "username", password: "My-JDoe99-Pass", want: []string{password.RulePersonalInfo}},
+		{
+			name:     "every rule",
+			password: "aaa",
+			want: []string{
+				password.RuleMinLength, password.RuleUppercase, password.RuleDigit, password.RuleSymbol, password.RuleRepeatedChars,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := policy.Check(tt.password, "jane.doe@example.com", "jdoe99")
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got := rules(violations); !slices.Equal(got, tt.want) {
+				t.Errorf("Check() rules = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulePolicy_ShortPersonalInfoIgnored(t *testing.T) {
+	policy, err := password.NewRulePolicy(config.PasswordPolicyConfig{ForbidPersonalInfo: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "al" is too short to be refused in a password
+	violations, err := policy.Check("always-valid", "al@example.com", "")
+	if err != nil || len(violations) != 0 {
+		t.Errorf("Check() = %v, %v, want no violation", violations, err)
+	}
+}
+
+func TestRulePolicy_DisabledRules(t *testing.T) {
+	policy, err := password.NewRulePolicy(config.PasswordPolicyConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := policy.Check("a", "a@example.com")
+	if err != nil || len(violations) != 0 {
+		t.Errorf("Check() = %v, %v, want no violation", violations, err)
+	}
+}
+
+// writeRange stores the range file of the password the way the Pwned Passwords downloader does
+func writeRange(t *testing.T, dir, plain string, count string) {
+	t.Helper()
+	sum := sha1.Sum([]byte(plain))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	content := "00000000000000000000000000000000001:3\r\n" + hash[5:] + ":" + count + "\r\n"
+	if err := os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatasetBreachChecker(t *testing.T) {
+	dir := t.TempDir()
+	writeRange(t, dir, "breached-password", "1042")
+	writeRange(t, dir, "padding-entry", "0")
+
+	checker, err := password.NewDatasetBreachChecker(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for plain, want := range map[string]bool{
+		"breached-password": true,
+		"Breached-password": false,
+		"padding-entry":     false,
+		// No range file for its prefix
+		"never-breached": false,
+	} {
+		got, err := checker.IsBreached(plain)
+		if err != nil {
+			t.Fatalf("IsBreached(%q) error = %v", plain, err)
+		}
+		if got != want {
+			t.Errorf("IsBreached(%q) = %v, want %v", plain, got, want)
+		}
+	}
+}
+
+func TestRulePolicy_Breached(t *testing.T) {
+	dir := t.TempDir()
+	writeRange(t, dir, "Correct-Horse-42", "7")
+
+	policy, err := password.NewRulePolicy(config.PasswordPolicyConfig{MinLength: 8, BreachedDatasetDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := policy.Check("Correct-Horse-42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rules(violations); !slices.Equal(got, []string{password.RuleBreached}) {
+		t.Errorf("Check() rules = %v, want breached", got)
+	}
+}
+
+func TestNewRulePolicy_MissingDataset(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	if _, err := password.NewRulePolicy(config.PasswordPolicyConfig{BreachedDatasetDir: missing}); err == nil {
+		t.Error("NewRulePolicy() expected error for a missing dataset directory")
+	}
+}
@@ -0,0 +1,81 @@
+package pat_test
+
+import (
+	"backend/service-platform/app/pkg/pat"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	token, err := pat.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.HasPrefix(token.Plaintext, "pat_"+token.Prefix+"_") {
+		t.Errorf("Plaintext %q does not embed prefix %q", token.Plaintext, token.Prefix)
+	}
+	if !pat.IsToken(token.Plaintext) {
+		t.Errorf("IsToken(%q) = false, want true", token.Plaintext)
+	}
+	if token.Hash != pat.Hash(token.Plaintext) {
+		t.Errorf("Hash = %q, want the hash of the plaintext", token.Hash)
+	}
+
+	other, err := pat.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if other.Prefix == token.Prefix || other.Plaintext == token.Plaintext {
+		t.Errorf("Generate() returned the same token twice")
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	token, _ := pat.Generate()
+
+	tests := []struct {
+		name       string
+		token      string
+		wantPrefix string
+		wantOK     bool
+	}{
+		{name: "generated token", token: token.Plaintext, wantPrefix: token.Prefix, wantOK: true},
+		{name: "secret with underscores", token: "pat_0123456789ab_se_cr_et", wantPrefix: "0123456789ab", wantOK: true},
+		{name: "api key", token: "sk_0123456789ab_secret"},
+		{name: "jwt", token: "eyJhbGciOiJIUzI1NiJ9.e30.sig"},
+		{name: "missing secret", token: "pat_0123456789ab_"},
+		{name: "short prefix", token: "pat_0123_secret"},
+		{name: "empty", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := pat.ParsePrefix(tt.token)
+			if ok != tt.wantOK || prefix != tt.wantPrefix {
+				t.Errorf("ParsePrefix() = (%q, %v), want (%q, %v)", prefix, ok, tt.wantPrefix, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	token, _ := pat.Generate()
+
+	tests := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{name: "matching token", token: token.Plaintext, want: true},
+		{name: "same prefix other secret", token: "pat_" + token.Prefix + "_other", want: false},
+		{name: "empty", token: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pat.Verify(tt.token, token.Hash); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package rbac_test
+
+import (
+	"backend/service-platform/app/database/entity"
+	"backend/service-platform/app/pkg/rbac"
+	redismock "backend/service-platform/app/test/mocks/redis"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeRoleStore struct {
+	roles []entity.Role
+	calls int
+}
+
+func (f *fakeRoleStore) FindAll(context.Context) ([]entity.Role, error) {
+	f.calls++
+	return f.roles, nil
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func builtInRoles() []entity.Role {
+	return []entity.Role{
+		{Name: "USER"},
+		{Name: "OPERATOR", Parent: ptr("USER"), Permissions: []string{rbac.JobsRead, rbac.JobsRetry}},
+		{Name: "ADMIN", Parent: ptr("OPERATOR"), Permissions: []string{rbac.UsersRead, rbac.UsersSuspend}},
+		{Name: "SUPER_ADMIN", Parent: ptr("ADMIN"), Permissions: []string{rbac.RolesManage}},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []entity.Role
+		role  string
+		want  []string
+	}{
+		{
+			name:  "no permissions",
+			roles: builtInRoles(),
+			role:  "USER",
+			want:  []string{},
+		},
+		{
+			name:  "direct permissions",
+			roles: builtInRoles(),
+			role:  "OPERATOR",
+			want:  []string{rbac.JobsRead, rbac.JobsRetry},
+		},
+		{
+			name:  "inherits the whole chain",
+			roles: builtInRoles(),
+			role:  "SUPER_ADMIN",
+			want:  []string{rbac.JobsRead, rbac.JobsRetry, rbac.RolesManage, rbac.UsersRead, rbac.UsersSuspend},
+		},
+		{
+			name:  "custom role below a built-in one",
+			roles: append(builtInRoles(), entity.Role{Name: "SUPPORT", Parent: ptr("OPERATOR"), Permissions: []string{rbac.UsersRead}}),
+			role:  "SUPPORT",
+			want:  []string{rbac.JobsRead, rbac.JobsRetry, rbac.UsersRead},
+		},
+		{
+			name:  "missing parent ends the chain",
+			roles: []entity.Role{{Name: "ORPHAN", Parent: ptr("GONE"), Permissions: []string{rbac.JobsRead}}},
+			role:  "ORPHAN",
+			want:  []string{rbac.JobsRead},
+		},
+		{
+			name: "cycle is walked once",
+			roles: []entity.Role{
+				{Name: "A", Parent: ptr("B"), Permissions: []string{rbac.JobsRead}},
+				{Name: "B", Parent: ptr("A"), Permissions: []string{rbac.JobsRetry}},
+			},
+			role: "A",
+			want: []string{rbac.JobsRead, rbac.JobsRetry},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rbac.Resolve(tt.roles)[tt.role]
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve()[%s] = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	got := rbac.Ancestors(builtInRoles(), "SUPER_ADMIN")
+	want := []string{"ADMIN", "OPERATOR", "USER"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Ancestors() = %v, want %v", got, want)
+	}
+	if got := rbac.Ancestors(builtInRoles(), "USER"); len(got) != 0 {
+		t.Errorf("Ancestors(USER) = %v, want none", got)
+	}
+}
+
+func TestRedisAuthorizer_CachesUntilInvalidated(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeRoleStore{roles: builtInRoles()}
+	authorizer := rbac.NewRedisAuthorizer(redismock.NewInMemoryRedis(), store, time.Minute)
+
+	ok, err := authorizer.HasPermissions(ctx, "ADMIN", rbac.JobsRead, rbac.UsersSuspend)
+	if err != nil || !ok {
+		t.Fatalf("HasPermissions(ADMIN) = %v, %v, want true", ok, err)
+	}
+	ok, err = authorizer.HasPermissions(ctx, "OPERATOR", rbac.UsersSuspend)
+	if err != nil || ok {
+		t.Errorf("HasPermissions(OPERATOR) = %v, %v, want false", ok, err)
+	}
+	if store.calls != 1 {
+		t.Errorf("store loaded %d times, want 1", store.calls)
+	}
+
+	store.roles[1].Permissions = append(store.roles[1].Permissions, rbac.UsersSuspend)
+	if err := authorizer.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	ok, err = authorizer.HasPermissions(ctx, "OPERATOR", rbac.UsersSuspend)
+	if err != nil || !ok {
+		t.Errorf("HasPermissions(OPERATOR) after invalidate = %v, %v, want true", ok, err)
+	}
+	if store.calls != 2 {
+		t.Errorf("store loaded %d times, want 2", store.calls)
+	}
+}
+
+func TestRedisAuthorizer_UnknownRole(t *testing.T) {
+	authorizer := rbac.NewRedisAuthorizer(redismock.NewInMemoryRedis(), &fakeRoleStore{roles: builtInRoles()}, time.Minute)
+
+	permissions, err := authorizer.Permissions(context.Background(), "SERVICE")
+	if err != nil {
+		t.Fatalf("Permissions() error = %v", err)
+	}
+	if len(permissions) != 0 {
+		t.Errorf("Permissions(SERVICE) = %v, want none", permissions)
+	}
+}
@@ -0,0 +1,79 @@
+package serviceclient_test
+
+import (
+	"backend/service-platform/app/pkg/serviceclient"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	creds, err := serviceclient.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.HasPrefix(creds.ClientID, "svc_") {
+		t.Errorf("ClientID %q does not start with svc_", creds.ClientID)
+	}
+	if creds.Secret == "" || strings.Contains(creds.SecretHash, creds.Secret) {
+		t.Errorf("SecretHash must not contain the secret")
+	}
+	if !serviceclient.Verify(creds.Secret, creds.SecretHash) {
+		t.Errorf("Verify() = false for the generated secret")
+	}
+
+	other, err := serviceclient.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if other.ClientID == creds.ClientID || other.Secret == creds.Secret {
+		t.Errorf("Generate() returned the same credentials twice")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	creds, _ := serviceclient.Generate()
+
+	tests := []struct {
+		name   string
+		secret string
+		want   bool
+	}{
+		{name: "valid", secret: creds.Secret, want: true},
+		{name: "wrong secret", secret: creds.Secret + "x", want: false},
+		{name: "empty", secret: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceclient.Verify(tt.secret, creds.SecretHash); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope string
+		want  []string
+	}{
+		{name: "empty", scope: "", want: []string{}},
+		{name: "single", scope: "users:read", want: []string{"users:read"}},
+		{name: "extra whitespace", scope: "  users:read   jobs:read ", want: []string{"users:read", "jobs:read"}},
+		{name: "duplicates", scope: "users:read users:read", want: []string{"users:read"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceclient.ParseScope(tt.scope); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseScope(%q) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatScope(t *testing.T) {
+	if got := serviceclient.FormatScope([]string{"users:read", "jobs:read"}); got != "users:read jobs:read" {
+		t.Errorf("FormatScope() = %q", got)
+	}
+}
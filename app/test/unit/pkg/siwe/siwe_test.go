@@ -0,0 +1,210 @@
+package siwe_test
+
+import (
+	"backend/service-platform/app/pkg/ethereum"
+	"backend/service-platform/app/pkg/siwe"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	walletKey = func() []byte {
+		key := make([]byte, 32)
+		key[31] = 1
+		return key
+	}()
+	now = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+)
+
+const walletAddress = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+
+func newMessage() *siwe.Message {
+	expires := now.Add(10 * time.Minute)
+	return &siwe.Message{
+		Domain:         "app.example.com",
+		Address:        walletAddress,
+		Statement:      "Sign in to Service Platform",
+		URI:            "https://app.example.com/login",
+		Version:        siwe.Version,
+		ChainID:        1,
+		Nonce:          "32891756abcdef",
+		IssuedAt:       now.Add(-time.Minute),
+		ExpirationTime: &expires,
+	}
+}
+
+func options() siwe.VerifyOptions {
+	return siwe.VerifyOptions{
+		AllowedOrigins: []string{"http://localhost:3000", "https://app.example.com"},
+		Origin:         "https://app.example.com",
+		ChainID:        1,
+		Statement:      "Sign in to Service Platform",
+		Now:            now,
+		ClockSkew:      time.Minute,
+	}
+}
+
+func signMessage(t *testing.T, raw string) string {
+	t.Helper()
+	signature, err := ethereum.SignPersonal([]byte(raw), walletKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "0x" + hex.EncodeToString(signature)
+}
+
+func TestParseMessage_SpecExample(t *testing.T) {
+	raw := "service.invalid wants you to sign in with your Ethereum account:\n" +
+		"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2\n" +
+		"\n" +
+		"I accept the ServiceOrg Terms of Service: https://service.invalid/tos\n" +
+		"\n" +
+		"URI: https://service.invalid/login\n" +
+		"Version: 1\n" +
+		"Chain ID: 1\n" +
+		"Nonce: 32891756\n" +
+		"Issued At: 2021-09-30T16:25:24Z\n" +
+		"Resources:\n" +
+		"- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/\n" +
+		"- https://example.com/my-web2-claim.json"
+
+	m, err := siwe.ParseMessage(raw)
+	if err != nil {
+		t.Fatalf("ParseMessage() error = %v", err)
+	}
+	if m.Domain != "service.invalid" || m.Address != "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2" ||
+		m.Statement != "I accept the ServiceOrg Terms of Service: https://service.invalid/tos" ||
+		m.ChainID != 1 || m.Nonce != "32891756" || len(m.Resources) != 2 {
+		t.Errorf("ParseMessage() = %+v", m)
+	}
+	if got := m.String(); got != raw {
+		t.Errorf("String() = %q, want the parsed text", got)
+	}
+}
+
+func TestParseMessage_RoundTrip(t *testing.T) {
+	m := newMessage()
+	m.Statement = ""
+	m.RequestID = "request-1"
+
+	parsed, err := siwe.ParseMessage(m.String())
+	if err != nil {
+		t.Fatalf("ParseMessage() error = %v", err)
+	}
+	if parsed.String() != m.String() || parsed.Statement != "" || parsed.RequestID != "request-1" {
+		t.Errorf("ParseMessage() = %+v, want %+v", parsed, m)
+	}
+}
+
+func TestParseMessage_Malformed(t *testing.T) {
+	valid := newMessage().String()
+	for name, raw := range map[string]string{
+		"empty":               "",
+		"no header":           strings.Replace(valid, " wants you to sign in", " asks you to sign in", 1),
+		"lowercase address":   strings.Replace(valid, walletAddress, strings.ToLower(walletAddress), 1),
+		"missing version":     strings.Replace(valid, "Version: 1\n", "", 1),
+		"unknown version":     strings.Replace(valid, "Version: 1", "Version: 2", 1),
+		"bad chain":           strings.Replace(valid, "Chain ID: 1", "Chain ID: one", 1),
+		"short nonce":         strings.Replace(valid, "Nonce: 32891756abcdef", "Nonce: 1234", 1),
+		"symbols in nonce":    strings.Replace(valid, "Nonce: 32891756abcdef", "Nonce: 32891756-abcdef", 1),
+		"bad issued at":       strings.Replace(valid, "Issued At: ", "Issued At: yesterday ", 1),
+		"trailing content":    valid + "\nExtra: field",
+		"fields out of order": strings.Replace(valid, "Version: 1\nChain ID: 1", "Chain ID: 1\nVersion: 1", 1),
+	} {
+		if _, err := siwe.ParseMessage(raw); !errors.Is(err, siwe.ErrMalformedMessage) {
+			t.Errorf("%s: ParseMessage() error = %v, want ErrMalformedMessage", name, err)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	raw := newMessage().String()
+
+	m, err := siwe.Verify(raw, signMessage(t, raw), options())
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if m.Address != walletAddress {
+		t.Errorf("Verify() address = %s, want %s", m.Address, walletAddress)
+	}
+}
+
+func TestVerify_SignatureOfAnotherAccount(t *testing.T) {
+	m := newMessage()
+	// Claims the address of the private key 2, signed with the key 1
+	m.Address = "0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"
+	raw := m.String()
+
+	if _, err := siwe.Verify(raw, signMessage(t, raw), options()); !errors.Is(err, siwe.ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerify_TamperedMessage(t *testing.T) {
+	raw := newMessage().String()
+	signature := signMessage(t, raw)
+
+	tampered := strings.Replace(raw, "Chain ID: 1", "Chain ID: 10", 1)
+	opts := options()
+	opts.ChainID = 0
+	if _, err := siwe.Verify(tampered, signature, opts); !errors.Is(err, siwe.ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestValidate_Rejections(t *testing.T) {
+	tests := map[string]func(m *siwe.Message, opts *siwe.VerifyOptions){
+		"unknown domain": func(m *siwe.Message, _ *siwe.VerifyOptions) { m.Domain = "evil.example.com" },
+		"uri on another origin": func(m *siwe.Message, _ *siwe.VerifyOptions) {
+			m.URI = "https://evil.example.com/login"
+		},
+		"uri with another scheme": func(m *siwe.Message, _ *siwe.VerifyOptions) {
+			m.URI = "http://app.example.com/login"
+		},
+		"request from another origin": func(_ *siwe.Message, opts *siwe.VerifyOptions) {
+			opts.Origin = "http://localhost:3000"
+		},
+		"other chain":      func(m *siwe.Message, _ *siwe.VerifyOptions) { m.ChainID = 5 },
+		"other statement":  func(m *siwe.Message, _ *siwe.VerifyOptions) { m.Statement = "Give me your tokens" },
+		"issued in future": func(m *siwe.Message, _ *siwe.VerifyOptions) { m.IssuedAt = now.Add(time.Hour) },
+		"expired":          func(_ *siwe.Message, opts *siwe.VerifyOptions) { opts.Now = now.Add(time.Hour) },
+		"not yet valid": func(m *siwe.Message, _ *siwe.VerifyOptions) {
+			notBefore := now.Add(time.Hour)
+			m.NotBefore = &notBefore
+		},
+		"no allowed origin": func(_ *siwe.Message, opts *siwe.VerifyOptions) { opts.AllowedOrigins = nil },
+	}
+	for name, edit := range tests {
+		m, opts := newMessage(), options()
+		edit(m, &opts)
+		if err := m.Validate(opts); !errors.Is(err, siwe.ErrInvalidMessage) {
+			t.Errorf("%s: Validate() error = %v, want ErrInvalidMessage", name, err)
+		}
+	}
+}
+
+func TestValidate_Accepts(t *testing.T) {
+	tests := map[string]func(m *siwe.Message, opts *siwe.VerifyOptions){
+		"any chain":             func(m *siwe.Message, opts *siwe.VerifyOptions) { m.ChainID = 137; opts.ChainID = 0 },
+		"without origin header": func(_ *siwe.Message, opts *siwe.VerifyOptions) { opts.Origin = "" },
+		"domain with port": func(m *siwe.Message, opts *siwe.VerifyOptions) {
+			m.Domain = "localhost:3000"
+			m.URI = "http://localhost:3000"
+			opts.Origin = "http://localhost:3000"
+		},
+		"domain with scheme": func(m *siwe.Message, _ *siwe.VerifyOptions) { m.Domain = "https://app.example.com" },
+		"expiry within skew": func(_ *siwe.Message, opts *siwe.VerifyOptions) {
+			opts.Now = now.Add(10*time.Minute + 30*time.Second)
+		},
+	}
+	for name, edit := range tests {
+		m, opts := newMessage(), options()
+		edit(m, &opts)
+		if err := m.Validate(opts); err != nil {
+			t.Errorf("%s: Validate() error = %v", name, err)
+		}
+	}
+}
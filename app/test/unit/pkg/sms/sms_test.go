@@ -0,0 +1,70 @@
+package sms_test
+
+import (
+	"backend/service-platform/app/internal/config"
+	"backend/service-platform/app/pkg/sms"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestNewSender(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+	}{
+		{name: "log driver", driver: "log"},
+		{name: "file driver", driver: "file"},
+		{name: "empty driver defaults to file", driver: ""},
+		{name: "unknown driver", driver: "pager", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sms.NewSender(config.SmsConfig{Driver: tt.driver}, zap.NewNop())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileSender_Send(t *testing.T) {
+	dir := t.TempDir()
+	s := sms.NewFileSender(config.SmsConfig{OutboxDir: dir}, zap.NewNop())
+
+	if err := s.Send(context.Background(), sms.Message{To: "+14155550100", Body: "Your verification code is 123456"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one message in outbox, got %d (%v)", len(files), err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	for _, want := range []string{"To: +14155550100", "Your verification code is 123456"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("message does not contain %q", want)
+		}
+	}
+}
+
+func TestSend_WithoutRecipient(t *testing.T) {
+	senders := map[string]sms.Sender{
+		"log":  sms.NewLogSender(zap.NewNop()),
+		"file": sms.NewFileSender(config.SmsConfig{OutboxDir: t.TempDir()}, zap.NewNop()),
+	}
+	for name, s := range senders {
+		if err := s.Send(context.Background(), sms.Message{Body: "hello"}); err == nil {
+			t.Errorf("%s: Send() expected error for message without recipient", name)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package tokenversion_test
+
+import (
+	jwtpkg "backend/service-platform/app/pkg/jwt"
+	"backend/service-platform/app/pkg/tokenversion"
+	rediskey "backend/service-platform/app/pkg/util/redis_key"
+	redismock "backend/service-platform/app/test/mocks/redis"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// userStore keeps token versions in memory and counts database reads
+type userStore struct {
+	versions map[uuid.UUID]int64
+	reads    int
+}
+
+func (s *userStore) FindTokenVersion(_ context.Context, userID uuid.UUID) (int64, error) {
+	s.reads++
+	version, ok := s.versions[userID]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return version, nil
+}
+
+func (s *userStore) IncrementTokenVersion(_ context.Context, userID uuid.UUID) (int64, error) {
+	if _, ok := s.versions[userID]; !ok {
+		return 0, sql.ErrNoRows
+	}
+	s.versions[userID]++
+	return s.versions[userID], nil
+}
+
+func newVersions(users *userStore) *tokenversion.RedisVersions {
+	return tokenversion.NewRedisVersions(redismock.NewInMemoryRedis(), users, time.Hour)
+}
+
+func claims(userID uuid.UUID, version *int64) *jwtpkg.Claims {
+	return &jwtpkg.Claims{UserID: &userID, TokenVersion: version}
+}
+
+func TestRedisVersions_CurrentIsCached(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	users := &userStore{versions: map[uuid.UUID]int64{userID: 4}}
+	v := newVersions(users)
+
+	for range 3 {
+		version, err := v.Current(ctx, userID)
+		if err != nil {
+			t.Fatalf("Current() error = %v", err)
+		}
+		if version != 4 {
+			t.Errorf("Current() = %d, want 4", version)
+		}
+	}
+	if users.reads != 1 {
+		t.Errorf("database reads = %d, want 1", users.reads)
+	}
+}
+
+func TestRedisVersions_UnknownUser(t *testing.T) {
+	ctx := context.Background()
+	v := newVersions(&userStore{versions: map[uuid.UUID]int64{}})
+
+	version, err := v.Current(ctx, uuid.New())
+	if err != nil {
+		t.Fatalf("Current() error = %v", err)
+	}
+	if version != 0 {
+		t.Errorf("Current() = %d, want 0", version)
+	}
+}
+
+func TestRedisVersions_BumpMakesTokensStale(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	users := &userStore{versions: map[uuid.UUID]int64{userID: 0}}
+	v := newVersions(users)
+	issued := claims(userID, nil)
+
+	if err := v.Check(ctx, issued); err != nil {
+		t.Fatalf("Check() before bump error = %v, want nil", err)
+	}
+	version, err := v.Bump(ctx, userID)
+	if err != nil {
+		t.Fatalf("Bump() error = %v", err)
+	}
+	if version != 1 {
+		t.Errorf("Bump() = %d, want 1", version)
+	}
+
+	// The cached version moves with the bump
+	if err := v.Check(ctx, issued); !errors.Is(err, tokenversion.ErrStaleToken) {
+		t.Errorf("Check() old token error = %v, want %v", err, tokenversion.ErrStaleToken)
+	}
+	if err := v.Check(ctx, claims(userID, &version)); err != nil {
+		t.Errorf("Check() new token error = %v, want nil", err)
+	}
+}
+
+func TestRedisVersions_BumpUnknownUser(t *testing.T) {
+	ctx := context.Background()
+	v := newVersions(&userStore{versions: map[uuid.UUID]int64{}})
+
+	if _, err := v.Bump(ctx, uuid.New()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Bump() error = %v, want %v", err, sql.ErrNoRows)
+	}
+}
+
+func TestRedisVersions_CheckWithoutUser(t *testing.T) {
+	ctx := context.Background()
+	users := &userStore{versions: map[uuid.UUID]int64{}}
+	v := newVersions(users)
+
+	// Service tokens have no user and no version
+	if err := v.Check(ctx, &jwtpkg.Claims{}); err != nil {
+		t.Errorf("Check() error = %v, want nil", err)
+	}
+	if users.reads != 0 {
+		t.Errorf("database reads = %d, want 0", users.reads)
+	}
+}
+
+func TestRedisVersions_CacheKey(t *testing.T) {
+	ctx := context.Background()
+	rds := redismock.NewInMemoryRedis()
+	userID := uuid.New()
+	users := &userStore{versions: map[uuid.UUID]int64{userID: 0}}
+	v := tokenversion.NewRedisVersions(rds, users, time.Hour)
+
+	if _, err := v.Bump(ctx, userID); err != nil {
+		t.Fatalf("Bump() error = %v", err)
+	}
+	var cached int64
+	if err := rds.Get(ctx, rediskey.TokenVersionKey(userID.String()), &cached); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if cached != 1 {
+		t.Errorf("cached version = %d, want 1", cached)
+	}
+}
@@ -0,0 +1,85 @@
+package totp_test
+
+import (
+	"backend/service-platform/app/pkg/totp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// base32 of the RFC 6238 SHA1 test secret "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "t=59", unix: 59, want: "287082"},
+		{name: "t=1111111109", unix: 1111111109, want: "081804"},
+		{name: "t=1234567890", unix: 1234567890, want: "005924"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := totp.GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateCode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	previous, _ := totp.GenerateCode(rfcSecret, now.Add(-totp.Period*time.Second))
+	stale, _ := totp.GenerateCode(rfcSecret, now.Add(-3*totp.Period*time.Second))
+
+	tests := []struct {
+		name     string
+		code     string
+		wantOK   bool
+		wantStep int64
+	}{
+		{name: "current step", code: "081804", wantOK: true, wantStep: totp.Step(now)},
+		{name: "previous step within skew", code: previous, wantOK: true, wantStep: totp.Step(now) - 1},
+		{name: "outside skew", code: stale},
+		{name: "wrong code", code: "000000"},
+		{name: "wrong length", code: "81804"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := totp.Validate(rfcSecret, tt.code, now, 1)
+			if ok != tt.wantOK {
+				t.Fatalf("Validate() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("Validate() step = %d, want %d", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	if _, err := totp.GenerateCode(secret, time.Now()); err != nil {
+		t.Errorf("GenerateCode() with generated secret error = %v", err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := totp.ProvisioningURI("Service Platform", "user@example.com", rfcSecret)
+	for _, want := range []string{"otpauth://totp/Service%20Platform:user@example.com?", "secret=" + rfcSecret, "issuer=Service+Platform", "digits=6", "period=30"} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("ProvisioningURI() = %s, missing %s", uri, want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package util_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"backend/service-platform/app/internal/config"
+	pkgjwt "backend/service-platform/app/pkg/jwt"
+)
+
+const mockOidcKeyID = "mock-key"
+
+// MockOidcServer is a minimal OpenID Connect provider: discovery, authorize with PKCE, token and JWKS endpoints
+type MockOidcServer struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+	// Identity put in the ID tokens issued after the next authorization
+	Subject       string
+	Email         string
+	EmailVerified bool
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]mockAuthorization
+}
+
+type mockAuthorization struct {
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	subject       string
+	email         string
+	emailVerified bool
+}
+
+func NewMockOidcServer(clientID, clientSecret string) *MockOidcServer {
+	s := newMockOidcServer(clientID, clientSecret)
+	s.Start()
+	return s
+}
+
+// NewMockOidcServerAt listens on a fixed address, so the issuer can be set in the test configuration
+func NewMockOidcServerAt(addr, clientID, clientSecret string) (*MockOidcServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := newMockOidcServer(clientID, clientSecret)
+	_ = s.Listener.Close()
+	s.Listener = listener
+	s.Start()
+	return s, nil
+}
+
+func newMockOidcServer(clientID, clientSecret string) *MockOidcServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	s := &MockOidcServer{
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		Subject:       "mock-subject",
+		Email:         "oidc-user@example.com",
+		EmailVerified: true,
+		key:           key,
+		codes:         make(map[string]mockAuthorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+	s.Server = httptest.NewUnstartedServer(mux)
+	return s
+}
+
+// ProviderConfig returns the configuration pointing at this server, relying on discovery for the endpoints
+func (s *MockOidcServer) ProviderConfig(redirectURL string) config.OidcProviderConfig {
+	return config.OidcProviderConfig{
+		Issuer:       s.URL,
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"openid", "email"},
+		TrustEmail:   true,
+	}
+}
+
+// SignIDToken signs arbitrary claims with the server key, for tokens the regular flow would never issue
+func (s *MockOidcServer) SignIDToken(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = mockOidcKeyID
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+func (s *MockOidcServer) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 s.URL,
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+// authorize approves every request at once and redirects back with a code
+func (s *MockOidcServer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID || query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	s.mu.Lock()
+	s.codes[code] = mockAuthorization{
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		subject:       s.Subject,
+		email:         s.Email,
+		emailVerified: s.EmailVerified,
+	}
+	s.mu.Unlock()
+
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *MockOidcServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("client_id") != s.ClientID || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	auth, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || auth.redirectURI != r.PostForm.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            auth.subject,
+		"aud":            s.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          auth.nonce,
+		"email_verified": auth.emailVerified,
+	}
+	if auth.email != "" {
+		claims["email"] = auth.email
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     s.SignIDToken(claims),
+	})
+}
+
+func (s *MockOidcServer) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, pkgjwt.JSONWebKeySet{Keys: []pkgjwt.JSONWebKey{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: mockOidcKeyID,
+		N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,23 @@
+-- Email verification tokens
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens
+(
+  id          UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  user_id     UUID NOT NULL,
+  email       TEXT NOT NULL,                      -- address the token was issued for
+  token       TEXT NOT NULL UNIQUE,               -- sha256 of the token sent by email
+  expires_at  TIMESTAMPTZ NOT NULL,
+  used_at     TIMESTAMPTZ,                        -- single use
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ,
+  deleted_at  TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_email_verification_tokens_updated_at
+  BEFORE UPDATE
+  ON email_verification_tokens
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_by_user_id
+  ON email_verification_tokens (user_id) WHERE (deleted_at IS NULL);
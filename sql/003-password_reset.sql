@@ -0,0 +1,22 @@
+-- Password reset tokens
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens
+(
+  id            UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  user_id       UUID NOT NULL,
+  token         TEXT NOT NULL UNIQUE,             -- sha256 of the token sent by email
+  expires_at    TIMESTAMPTZ NOT NULL,
+  used_at       TIMESTAMPTZ,                      -- single use
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMPTZ,
+  deleted_at    TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_password_reset_tokens_updated_at
+  BEFORE UPDATE
+  ON password_reset_tokens
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_by_user_id
+  ON password_reset_tokens (user_id) WHERE (deleted_at IS NULL);
@@ -0,0 +1,26 @@
+-- TOTP multi-factor authentication
+
+ALTER TABLE users
+  ADD COLUMN mfa_enabled    BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN mfa_secret     TEXT,                 -- AES-GCM encrypted TOTP secret
+  ADD COLUMN mfa_enabled_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes
+(
+  id          UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  user_id     UUID NOT NULL,
+  code        TEXT NOT NULL,                      -- sha256 of the recovery code
+  used_at     TIMESTAMPTZ,                        -- single use
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ,
+  deleted_at  TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_mfa_recovery_codes_updated_at
+  BEFORE UPDATE
+  ON mfa_recovery_codes
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX unique_idx_mfa_recovery_codes_by_user_id_code
+  ON mfa_recovery_codes (user_id, code) WHERE (deleted_at IS NULL);
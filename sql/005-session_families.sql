@@ -0,0 +1,8 @@
+-- Refresh token families: every rotation links the new session to the one it replaced
+
+ALTER TABLE sessions
+  ADD COLUMN parent_id  UUID,                     -- session rotated into this one
+  ADD COLUMN family_id  UUID,                     -- first session of the chain, NULL on the first session itself
+  ADD COLUMN rotated_at TIMESTAMPTZ;              -- set once the refresh token has been exchanged
+
+CREATE INDEX IF NOT EXISTS idx_sessions_by_family_id ON sessions (family_id) WHERE (deleted_at IS NULL);
@@ -0,0 +1,6 @@
+-- Brute-force protection: consecutive failed logins lock the account with an exponential backoff
+
+ALTER TABLE users
+  ADD COLUMN failed_login_attempts INT NOT NULL DEFAULT 0,  -- consecutive failures since the last success or lockout
+  ADD COLUMN lockout_count         INT NOT NULL DEFAULT 0,  -- lockouts since the last success, drives the backoff
+  ADD COLUMN locked_until          TIMESTAMPTZ;             -- login is refused until this time
@@ -0,0 +1,35 @@
+-- API keys for services and users, only a hash of the secret is stored
+
+CREATE TABLE IF NOT EXISTS api_keys
+(
+  id              UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  name            TEXT NOT NULL,
+  prefix          VARCHAR(32) NOT NULL,              -- public part of the key, used for lookup
+  secret_hash     TEXT NOT NULL,                     -- sha256 of the full key
+  owner_type      VARCHAR(16) NOT NULL,              -- SERVICE or USER
+  service_name    TEXT,                              -- owner when owner_type = SERVICE
+  user_id         UUID,                              -- owner when owner_type = USER
+  scopes          TEXT[] NOT NULL DEFAULT '{}',
+  expires_at      TIMESTAMPTZ,
+  last_used_at    TIMESTAMPTZ,
+  revoked         BOOLEAN NOT NULL DEFAULT FALSE,
+  revoked_at      TIMESTAMPTZ,
+  rotated_from_id UUID,                              -- key replaced by this one
+  created_by      UUID,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at      TIMESTAMPTZ,
+  deleted_at      TIMESTAMPTZ,
+  CONSTRAINT check_api_keys_owner CHECK (
+    (owner_type = 'SERVICE' AND service_name IS NOT NULL AND user_id IS NULL) OR
+    (owner_type = 'USER' AND user_id IS NOT NULL AND service_name IS NULL)
+  )
+);
+
+CREATE TRIGGER trigger_api_keys_updated_at
+  BEFORE UPDATE
+  ON api_keys
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX unique_idx_api_keys_by_prefix ON api_keys (prefix) WHERE (deleted_at IS NULL);
+CREATE INDEX IF NOT EXISTS idx_api_keys_by_user_id ON api_keys (user_id) WHERE (deleted_at IS NULL);
@@ -0,0 +1,58 @@
+-- Permission-based access control: named permissions granted to roles, roles inherit from a parent role
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+  id          UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  name        VARCHAR(128) NOT NULL,                -- resource:action, e.g. jobs:read
+  description TEXT,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ,
+  deleted_at  TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_permissions_updated_at
+  BEFORE UPDATE
+  ON permissions
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX unique_idx_permissions_by_name ON permissions (name) WHERE (deleted_at IS NULL);
+
+CREATE TABLE IF NOT EXISTS roles
+(
+  id          UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  name        VARCHAR(64) NOT NULL,                 -- value of users.role
+  description TEXT,
+  parent      VARCHAR(64),                          -- every permission of the parent is inherited
+  permissions TEXT[] NOT NULL DEFAULT '{}',         -- granted directly to this role
+  built_in    BOOLEAN NOT NULL DEFAULT FALSE,       -- roles known to the code, managed by migrations only
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ,
+  deleted_at  TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_roles_updated_at
+  BEFORE UPDATE
+  ON roles
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX unique_idx_roles_by_name ON roles (name) WHERE (deleted_at IS NULL);
+
+INSERT INTO permissions (name, description)
+VALUES ('jobs:read', 'View background jobs'),
+       ('jobs:retry', 'Retry failed background jobs'),
+       ('users:read', 'View user accounts and their sessions'),
+       ('users:suspend', 'Suspend and restore user accounts'),
+       ('users:unlock', 'Lift login lockouts'),
+       ('sessions:revoke', 'Revoke sessions of any user'),
+       ('api_keys:manage', 'Create, rotate and revoke API keys'),
+       ('roles:manage', 'Manage custom roles and their permissions');
+
+-- SUPER_ADMIN > ADMIN > OPERATOR > USER
+INSERT INTO roles (name, description, parent, permissions, built_in)
+VALUES ('USER', 'Regular user', NULL, '{}', TRUE),
+       ('OPERATOR', 'Operates background processing', 'USER', '{jobs:read,jobs:retry}', TRUE),
+       ('ADMIN', 'Administers users and credentials', 'OPERATOR',
+        '{users:read,users:suspend,users:unlock,sessions:revoke,api_keys:manage}', TRUE),
+       ('SUPER_ADMIN', 'Full system access', 'ADMIN', '{roles:manage}', TRUE);
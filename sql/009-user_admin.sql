@@ -0,0 +1,11 @@
+-- Admin user management: role assignment permission and indexes for listing users
+
+INSERT INTO permissions (name, description)
+VALUES ('users:assign_role', 'Change the role of user accounts');
+
+UPDATE roles
+SET permissions = array_append(permissions, 'users:assign_role')
+WHERE name = 'ADMIN';
+
+CREATE INDEX IF NOT EXISTS idx_users_by_created_at ON users (created_at);
+CREATE INDEX IF NOT EXISTS idx_users_by_status_and_role ON users (status, role);
@@ -0,0 +1,15 @@
+-- Self-service profile changes
+
+-- Uniqueness only applies to live accounts, the column constraints from the initial schema
+-- also blocked addresses and handles of soft-deleted users
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_username_key,
+  DROP CONSTRAINT IF EXISTS users_email_key,
+  DROP CONSTRAINT IF EXISTS users_phone_number_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_idx_users_by_phone_number
+  ON users (phone_number) WHERE (deleted_at IS NULL);
+
+-- New address waiting for verification, it replaces email once the link is followed
+ALTER TABLE users
+  ADD COLUMN pending_email TEXT;
@@ -0,0 +1,24 @@
+-- External identities (OpenID Connect) linked to users
+
+CREATE TABLE IF NOT EXISTS user_identities
+(
+  id            UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  user_id       UUID NOT NULL,
+  provider      VARCHAR(64) NOT NULL,               -- name of the provider in the oidc configuration
+  subject       TEXT NOT NULL,                      -- sub claim, stable for the provider
+  email         TEXT,                               -- email claim when the identity was linked
+  last_login_at TIMESTAMPTZ,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMPTZ,
+  deleted_at    TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_user_identities_updated_at
+  BEFORE UPDATE
+  ON user_identities
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX unique_idx_user_identities_by_provider_subject
+  ON user_identities (provider, subject) WHERE (deleted_at IS NULL);
+CREATE INDEX IF NOT EXISTS idx_user_identities_by_user_id ON user_identities (user_id) WHERE (deleted_at IS NULL);
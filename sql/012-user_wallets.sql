@@ -0,0 +1,23 @@
+-- Ethereum wallets signing in users with Sign-In With Ethereum (EIP-4361)
+
+CREATE TABLE IF NOT EXISTS user_wallets
+(
+  id            UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  user_id       UUID NOT NULL,
+  address       VARCHAR(42) NOT NULL,               -- EIP-55 checksummed address
+  chain_id      BIGINT NOT NULL,                    -- chain of the last signed message
+  last_login_at TIMESTAMPTZ,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMPTZ,
+  deleted_at    TIMESTAMPTZ
+);
+
+CREATE TRIGGER trigger_user_wallets_updated_at
+  BEFORE UPDATE
+  ON user_wallets
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX unique_idx_user_wallets_by_address
+  ON user_wallets (address) WHERE (deleted_at IS NULL);
+CREATE INDEX IF NOT EXISTS idx_user_wallets_by_user_id ON user_wallets (user_id) WHERE (deleted_at IS NULL);
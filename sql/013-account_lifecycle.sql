@@ -0,0 +1,11 @@
+-- Self-service deactivation, deletion with a grace period and personal data exports
+
+-- End of the grace period of a requested deletion, the worker purges the account afterwards
+ALTER TABLE users
+  ADD COLUMN deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_by_deletion_scheduled_at
+  ON users (deletion_scheduled_at) WHERE (deletion_scheduled_at IS NOT NULL AND deleted_at IS NULL);
+
+-- Jobs of a user are part of their data export
+CREATE INDEX IF NOT EXISTS idx_jobs_by_payload_user_id ON jobs ((payload ->> 'user_id'));
@@ -0,0 +1,56 @@
+-- Append-only security audit log, every event carries the hash of the one before it
+
+CREATE TABLE IF NOT EXISTS audit_events
+(
+  id            UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  seq           BIGSERIAL NOT NULL,                   -- position in the hash chain, also the pagination cursor
+  action        VARCHAR(64) NOT NULL,                 -- e.g. login_succeeded, user_role_changed
+  actor_type    VARCHAR(16) NOT NULL,                 -- USER, SERVICE, SYSTEM or ANONYMOUS
+  actor_id      UUID,                                 -- user who performed the action
+  actor_name    TEXT,                                 -- service behind an API key or system process
+  subject_id    UUID,                                 -- user the action was performed on
+  ip_address    TEXT,
+  user_agent    TEXT,
+  request_id    TEXT,                                 -- X-Request-Id of the HTTP request
+  metadata      JSONB NOT NULL DEFAULT '{}',
+  previous_hash VARCHAR(64) NOT NULL,                 -- empty for the first event
+  hash          VARCHAR(64) NOT NULL,                 -- sha256 of the previous hash and this event
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_idx_audit_events_by_seq ON audit_events (seq);
+CREATE INDEX IF NOT EXISTS idx_audit_events_by_actor_id ON audit_events (actor_id, seq) WHERE (actor_id IS NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_audit_events_by_subject_id ON audit_events (subject_id, seq) WHERE (subject_id IS NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_audit_events_by_action ON audit_events (action, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_events_by_created_at ON audit_events (created_at);
+
+CREATE
+OR REPLACE FUNCTION reject_audit_event_change() RETURNS trigger
+  LANGUAGE plpgsql
+AS
+$$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END
+$$;
+
+CREATE OR REPLACE TRIGGER trigger_audit_events_append_only
+  BEFORE UPDATE OR DELETE
+  ON audit_events
+  FOR EACH ROW
+  EXECUTE FUNCTION reject_audit_event_change();
+
+CREATE OR REPLACE TRIGGER trigger_audit_events_no_truncate
+  BEFORE TRUNCATE
+  ON audit_events
+  FOR EACH STATEMENT
+  EXECUTE FUNCTION reject_audit_event_change();
+
+INSERT INTO permissions (name, description)
+SELECT 'audit:read', 'View and verify the security audit log'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'audit:read' AND deleted_at IS NULL);
+
+UPDATE roles
+SET permissions = array_append(permissions, 'audit:read')
+WHERE name = 'SUPER_ADMIN'
+  AND NOT ('audit:read' = ANY (permissions));
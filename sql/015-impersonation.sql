@@ -0,0 +1,10 @@
+-- Super admins can act as a user through a short-lived impersonation token
+
+INSERT INTO permissions (name, description)
+SELECT 'users:impersonate', 'Sign in as another user for support, every request is audited'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'users:impersonate' AND deleted_at IS NULL);
+
+UPDATE roles
+SET permissions = array_append(permissions, 'users:impersonate')
+WHERE name = 'SUPER_ADMIN'
+  AND NOT ('users:impersonate' = ANY (permissions));
@@ -0,0 +1,26 @@
+-- Personal access tokens let users authenticate scripts without their password, only a hash of the token is stored
+
+CREATE TABLE IF NOT EXISTS personal_access_tokens
+(
+  id           UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  user_id      UUID NOT NULL,
+  name         TEXT NOT NULL,
+  prefix       VARCHAR(32) NOT NULL,          -- public part of the token, used for lookup
+  secret_hash  TEXT NOT NULL,                 -- sha256 of the full token
+  scopes       TEXT[] NOT NULL DEFAULT '{}',  -- permissions the token is narrowed to
+  expires_at   TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at   TIMESTAMPTZ,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMPTZ,
+  deleted_at   TIMESTAMPTZ
+);
+
+CREATE OR REPLACE TRIGGER trigger_personal_access_tokens_updated_at
+  BEFORE UPDATE
+  ON personal_access_tokens
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_idx_personal_access_tokens_by_prefix ON personal_access_tokens (prefix) WHERE (deleted_at IS NULL);
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_by_user_id ON personal_access_tokens (user_id) WHERE (deleted_at IS NULL);
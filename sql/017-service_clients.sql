@@ -0,0 +1,33 @@
+-- OAuth2 clients of internal services, they exchange their credentials for short-lived access tokens
+
+CREATE TABLE IF NOT EXISTS service_clients
+(
+  id           UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+  client_id    VARCHAR(64) NOT NULL,          -- public identifier, the sub claim of issued tokens
+  name         TEXT NOT NULL,
+  secret_hash  TEXT NOT NULL,                 -- sha256 of the client secret
+  scopes       TEXT[] NOT NULL DEFAULT '{}',  -- scopes the client may request
+  last_used_at TIMESTAMPTZ,                   -- last token issued
+  revoked_at   TIMESTAMPTZ,
+  created_by   UUID,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMPTZ,
+  deleted_at   TIMESTAMPTZ
+);
+
+CREATE OR REPLACE TRIGGER trigger_service_clients_updated_at
+  BEFORE UPDATE
+  ON service_clients
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_updated_at();
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_idx_service_clients_by_client_id ON service_clients (client_id) WHERE (deleted_at IS NULL);
+
+INSERT INTO permissions (name, description)
+SELECT 'service_clients:manage', 'Register and revoke OAuth2 clients of internal services'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'service_clients:manage' AND deleted_at IS NULL);
+
+UPDATE roles
+SET permissions = array_append(permissions, 'service_clients:manage')
+WHERE name = 'SUPER_ADMIN'
+  AND NOT ('service_clients:manage' = ANY (permissions));
@@ -0,0 +1,4 @@
+-- Access and refresh tokens carry the user's token version, raising it makes every token issued before stale
+
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS token_version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- A key can only be rotated once, the replacement is recorded on the rotated key
+
+ALTER TABLE api_keys
+  ADD COLUMN IF NOT EXISTS rotated_to_id UUID;
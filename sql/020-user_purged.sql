@@ -0,0 +1,22 @@
+-- Purged accounts are told apart from soft-deleted ones, their personal data is gone and they cannot be restored
+
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS purged_at TIMESTAMPTZ;
+
+-- One open purge job per account, the deletion scheduler runs on every worker and on every tick.
+-- Duplicates queued before the index existed are dropped, the oldest one stays
+UPDATE jobs
+SET deleted_at = now()
+WHERE id IN (
+  SELECT id
+  FROM (
+    SELECT id, row_number() OVER (PARTITION BY payload ->> 'user_id' ORDER BY created_at, id) AS n
+    FROM jobs
+    WHERE type = 'purge_account' AND status IN ('pending', 'processing', 'retrying') AND deleted_at IS NULL
+  ) open_purges
+  WHERE n > 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_idx_jobs_open_purge_by_user_id
+  ON jobs ((payload ->> 'user_id'))
+  WHERE (type = 'purge_account' AND status IN ('pending', 'processing', 'retrying') AND deleted_at IS NULL);